@@ -23,13 +23,15 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
 	wormscanNotionalCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
-	sqs_client "github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	health "github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
@@ -45,6 +47,8 @@ func handleExit() {
 	}
 }
 
+// Run starts the analytics service with the configuration from the environment and blocks until
+// a termination signal is received.
 func Run() {
 	defer handleExit()
 	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
@@ -57,6 +61,46 @@ func Run() {
 
 	// build logger
 	logger := logger.New("wormhole-explorer-analytics", logger.WithLevel(config.LogLevel))
+
+	// setup tracing
+	shutdownTracing, err := tracing.Init(rootCtx, "wormscan-analytics", config.Tracing, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	stop := Start(rootCtx, config, logger)
+
+	// Waiting for signal
+	logger.Info("waiting for termination signal or context cancellation...")
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("terminating (root context cancelled)")
+	case signal := <-sigterm:
+		logger.Info("terminating (signal received)", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("cancelling root context...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("flushing traces...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Warn("failed to flush traces", zap.Error(err))
+	}
+
+	logger.Info("terminated successfully")
+}
+
+// Start starts the consumers and the http server of the analytics service with the given
+// configuration. The consumers run until rootCtx is cancelled and the returned function releases
+// the remaining resources.
+func Start(rootCtx context.Context, config *config.Configuration, logger *zap.Logger) (stop func()) {
+
 	logger.Info("starting analytics service...")
 
 	// setup DB connection
@@ -104,7 +148,8 @@ func Run() {
 	// create a metrics instance
 	logger.Info("initializing metrics instance...")
 	metric, err := metric.New(rootCtx, db.Database, influxCli, config.InfluxOrganization, config.InfluxBucketInfinite,
-		config.InfluxBucket30Days, config.InfluxBucket24Hours, notionalCache, metrics, tokenResolver.GetTransferredTokenByVaa, tokenProvider, logger)
+		config.InfluxBucket30Days, config.InfluxBucket24Hours, notionalCache, metrics, tokenResolver.GetTransferredTokenByVaa, tokenProvider,
+		lineage.NewRecorder(db.Database, lineage.StageAnalytics, logger), logger)
 	if err != nil {
 		logger.Fatal("failed to create metrics instance", zap.Error(err))
 	}
@@ -129,63 +174,78 @@ func Run() {
 	server := http.NewServer(logger, config.Port, config.PprofEnabled, vaaController, healthChecks...)
 	server.Start()
 
-	// Waiting for signal
-	logger.Info("waiting for termination signal or context cancellation...")
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("terminating (root context cancelled)")
-	case signal := <-sigterm:
-		logger.Info("terminating (signal received)", zap.String("signal", signal.String()))
-	}
-
-	logger.Info("cancelling root context...")
-	rootCtxCancel()
-
-	logger.Info("closing metrics client...")
-	metric.Close()
+	return func() {
+		logger.Info("closing metrics client...")
+		metric.Close()
 
-	logger.Info("closing HTTP server...")
-	server.Stop()
+		logger.Info("closing HTTP server...")
+		server.Stop()
 
-	logger.Info("closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
-
-	logger.Info("terminated successfully")
+		logger.Info("closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+	}
 }
 
-// Creates a callbacks depending on whether the execution is local (memory queue) or not (SQS queue)
+// Creates a callbacks depending on the configured message broker.
 func newVAAConsumeFunc(appCtx context.Context, config *config.Configuration, logger *zap.Logger) queue.ConsumeFunc {
-	sqsConsumer, err := newSQSConsumer(appCtx, config, config.PipelineSQSUrl)
+	consumer, err := newQueueConsumer(appCtx, config, config.PipelineSQSUrl, config.PipelineTopic)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue consumer", zap.Error(err))
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewVaaConverter(logger), logger)
+	vaaQueue := queue.NewEventQueue(consumer, queue.NewVaaConverter(logger), logger)
 	return vaaQueue.Consume
 }
 
 func newNotificationConsumeFunc(ctx context.Context, cfg *config.Configuration, logger *zap.Logger) queue.ConsumeFunc {
 
-	sqsConsumer, err := newSQSConsumer(ctx, cfg, cfg.NotificationsSQSUrl)
+	consumer, err := newQueueConsumer(ctx, cfg, cfg.NotificationsSQSUrl, cfg.NotificationsTopic)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue consumer", zap.Error(err))
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewNotificationEvent(logger), logger)
+	vaaQueue := queue.NewEventQueue(consumer, queue.NewNotificationEvent(logger), logger)
 	return vaaQueue.Consume
 }
 
-func newSQSConsumer(appCtx context.Context, config *config.Configuration, sqsUrl string) (*sqs_client.Consumer, error) {
-	awsconfig, err := newAwsConfig(appCtx, config)
+// Creates a consumer of the configured message broker. The SQS broker reads the queue sqsUrl
+// and the others the consumer group of the topic.
+func newQueueConsumer(appCtx context.Context, config *config.Configuration, sqsUrl, topic string) (broker.Consumer, error) {
+	settings, err := newBrokerSettings(appCtx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return sqs_client.NewConsumer(awsconfig, sqsUrl,
-		sqs_client.WithMaxMessages(10),
-		sqs_client.WithVisibilityTimeout(120))
+	subscription := broker.Subscription{
+		QueueURL:           sqsUrl,
+		Topic:              topic,
+		Group:              config.ConsumerGroup,
+		DeadLetterQueueURL: config.DLQSQSUrl,
+		DeadLetterTopic:    config.DLQTopic,
+		MaxAttempts:        config.DLQMaxAttempts,
+	}
+	return broker.NewConsumer(appCtx, settings, subscription,
+		broker.WithMaxMessages(10),
+		broker.WithVisibilityTimeout(120*time.Second))
+}
+
+// Creates the message broker settings, the AWS config is only loaded for the SQS broker.
+func newBrokerSettings(appCtx context.Context, config *config.Configuration) (broker.Settings, error) {
+	settings := broker.Settings{
+		Type:               broker.Type(config.QueueBroker),
+		RedisURI:           config.QueueRedisURI,
+		NatsURL:            config.QueueNatsURL,
+		NatsStreamMaxAge:   time.Duration(config.QueueNatsStreamMaxAgeHours) * time.Hour,
+		NatsStreamMaxBytes: config.QueueNatsStreamMaxBytes,
+	}
+	if settings.Type == broker.TypeSQS {
+		awsConfig, err := newAwsConfig(appCtx, config)
+		if err != nil {
+			return settings, err
+		}
+		settings.AwsConfig = awsConfig
+	}
+	return settings, nil
 }
 
 func newAwsConfig(appCtx context.Context, cfg *config.Configuration) (aws.Config, error) {
@@ -226,17 +286,21 @@ func newHealthChecks(
 	db *mongo.Database,
 ) ([]health.Check, error) {
 
+	healthChecks := []health.Check{
+		health.Influx(influxCli),
+		health.Mongo(db),
+	}
+	if config.QueueBroker != string(broker.TypeSQS) {
+		return healthChecks, nil
+	}
+
 	awsConfig, err := newAwsConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
-
-	healthChecks := []health.Check{
+	healthChecks = append(healthChecks,
 		health.SQS(awsConfig, config.PipelineSQSUrl),
-		health.SQS(awsConfig, config.NotificationsSQSUrl),
-		health.Influx(influxCli),
-		health.Mongo(db),
-	}
+		health.SQS(awsConfig, config.NotificationsSQSUrl))
 	return healthChecks, nil
 }
 
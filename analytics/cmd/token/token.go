@@ -77,7 +77,7 @@ func (r *TokenResolver) GetTransferredTokenByVaa(ctx context.Context, vaa *sdk.V
 	}
 
 	// Parse the VAA with standarized properties
-	result, err := r.client.ParseVaaWithStandarizedProperties(vaa)
+	result, err := r.client.ParseVaaWithStandarizedProperties(ctx, vaa)
 	if err != nil {
 		r.logger.Error("Parsing vaa with standarized properties",
 			zap.String("vaaId", vaa.MessageID()),
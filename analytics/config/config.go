@@ -5,6 +5,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 // Configuration represents the application configuration with the default values.
@@ -19,6 +20,8 @@ type Configuration struct {
 	AwsRegion               string `env:"AWS_REGION"`
 	PipelineSQSUrl          string `env:"PIPELINE_SQS_URL"`
 	NotificationsSQSUrl     string `env:"NOTIFICATIONS_SQS_URL"`
+	DLQSQSUrl               string `env:"DLQ_SQS_URL"`
+	DLQMaxAttempts          int    `env:"DLQ_MAX_ATTEMPTS,default=5"`
 	InfluxUrl               string `env:"INFLUX_URL"`
 	InfluxToken             string `env:"INFLUX_TOKEN"`
 	InfluxOrganization      string `env:"INFLUX_ORGANIZATION"`
@@ -34,6 +37,22 @@ type Configuration struct {
 	CacheChannel            string `env:"CACHE_CHANNEL,required"`
 	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
 	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
+	QueueConfiguration
+	Tracing tracing.Config
+}
+
+// QueueConfiguration represents the message broker configuration. The topics are used by all
+// the brokers but SQS, which uses the queue URLs.
+type QueueConfiguration struct {
+	QueueBroker                string `env:"QUEUE_BROKER,default=sqs"`
+	QueueRedisURI              string `env:"QUEUE_REDIS_URI"`
+	QueueNatsURL               string `env:"QUEUE_NATS_URL"`
+	QueueNatsStreamMaxAgeHours int    `env:"QUEUE_NATS_STREAM_MAX_AGE_HOURS,default=168"`
+	QueueNatsStreamMaxBytes    int64  `env:"QUEUE_NATS_STREAM_MAX_BYTES,default=1073741824"`
+	PipelineTopic              string `env:"PIPELINE_TOPIC,default=wormscan.vaas"`
+	NotificationsTopic         string `env:"NOTIFICATIONS_TOPIC,default=wormscan.notifications"`
+	DLQTopic                   string `env:"DLQ_TOPIC,default=wormscan.analytics.dlq"`
+	ConsumerGroup              string `env:"CONSUMER_GROUP,default=analytics"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
@@ -31,7 +31,7 @@ func (c *Consumer) Start(ctx context.Context) {
 			// check id message is expired.
 			if msg.IsExpired() {
 				c.logger.Warn("Message with vaa expired", zap.String("id", event.ID))
-				msg.Failed()
+				msg.Failed(queue.ErrMessageExpired)
 				continue
 			}
 
@@ -39,14 +39,14 @@ func (c *Consumer) Start(ctx context.Context) {
 			vaa, err := sdk.Unmarshal(event.Vaa)
 			if err != nil {
 				c.logger.Error("Invalid vaa", zap.String("id", event.ID), zap.Error(err))
-				msg.Failed()
+				msg.Failed(err)
 				continue
 			}
 
 			// push vaa metrics.
-			err = c.pushMetric(ctx, &metric.Params{TrackID: event.TrackID, Vaa: vaa, VaaIsSigned: event.VaaIsSigned})
+			err = c.pushMetric(msg.Context(), &metric.Params{TrackID: event.TrackID, Vaa: vaa, VaaIsSigned: event.VaaIsSigned})
 			if err != nil {
-				msg.Failed()
+				msg.Failed(err)
 				continue
 			}
 
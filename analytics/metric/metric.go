@@ -15,11 +15,17 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
 	wormscanNotionalCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const tracerName = "github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+
 const (
 	VaaCountMeasurement       = "vaa_count"
 	VaaVolumeMeasurement      = "vaa_volume_v2"
@@ -39,6 +45,7 @@ type Metric struct {
 	metrics                  metrics.Metrics
 	getTransferredTokenByVaa token.GetTransferredTokenByVaa
 	tokenProvider            *domain.TokenProvider
+	lineage                  *lineage.Recorder
 	logger                   *zap.Logger
 }
 
@@ -55,6 +62,7 @@ func New(
 	metrics metrics.Metrics,
 	getTransferredTokenByVaa token.GetTransferredTokenByVaa,
 	tokenProvider *domain.TokenProvider,
+	lineage *lineage.Recorder,
 	logger *zap.Logger,
 ) (*Metric, error) {
 
@@ -75,14 +83,27 @@ func New(
 		metrics:                  metrics,
 		getTransferredTokenByVaa: getTransferredTokenByVaa,
 		tokenProvider:            tokenProvider,
+		lineage:                  lineage,
 	}
 	return &m, nil
 }
 
 // Push implement MetricPushFunc definition.
 func (m *Metric) Push(ctx context.Context, params *Params) error {
+	startedAt := time.Now()
+	reason, err := m.push(ctx, params)
+	if params.Vaa.EmitterChain != sdk.ChainIDPythNet {
+		m.lineage.RecordWithReason(ctx, params.Vaa.MessageID(), params.TrackID, startedAt, reason, err)
+	}
+	return err
+}
+
+// push pushes the metrics of a VAA, it returns the reason the VAA has no notional if it is not a transfer
+// with a known price.
+func (m *Metric) push(ctx context.Context, params *Params) (string, error) {
 
 	var err1, err2, err3, err4 error
+	var reason string
 
 	isVaaSigned := params.VaaIsSigned
 
@@ -101,7 +122,7 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 					zap.String("trackId", params.TrackID),
 					zap.String("vaaId", params.Vaa.MessageID()),
 					zap.Error(err))
-				return err
+				return "", err
 			}
 		}
 
@@ -111,7 +132,7 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 				err3 = m.volumeMeasurement(ctx, params, transferredToken.Clone())
 			}
 
-			err4 = upsertTransferPrices(
+			reason, err4 = upsertTransferPrices(
 				ctx,
 				m.logger,
 				params.Vaa,
@@ -134,12 +155,13 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 				zap.String("trackId", params.TrackID),
 				zap.String("vaaId", params.Vaa.MessageID()),
 			)
+			reason = reasonNotATransfer
 		}
 	}
 
 	//TODO if we had go 1.20, we could just use `errors.Join(err1, err2, err3, ...)` here.
 	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
-		return fmt.Errorf("err1=%w, err2=%w, err3=%w err4=%w", err1, err2, err3, err4)
+		return reason, fmt.Errorf("err1=%w, err2=%w, err3=%w err4=%w", err1, err2, err3, err4)
 	}
 
 	if params.Vaa.EmitterChain != sdk.ChainIDPythNet {
@@ -148,10 +170,20 @@ func (m *Metric) Push(ctx context.Context, params *Params) error {
 			zap.String("vaaId", params.Vaa.MessageID()))
 	}
 
-	return nil
+	return reason, nil
 }
 
 // Close influx client.
+// writePoint writes a point in a bucket in a client span.
+func writePoint(ctx context.Context, writeAPI api.WriteAPIBlocking, point *write.Point) error {
+	ctx, span := tracing.Tracer(tracerName).Start(ctx, "influx.write",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("influx.measurement", point.Name())))
+	err := writeAPI.WritePoint(ctx, point)
+	tracing.EndSpan(span, err)
+	return err
+}
+
 func (m *Metric) Close() {
 
 	const flushTimeout = 5 * time.Second
@@ -180,7 +212,7 @@ func (m *Metric) vaaCountMeasurement(ctx context.Context, p *Params) error {
 	}
 
 	// Write the point to influx
-	err = m.apiBucket30Days.WritePoint(ctx, point)
+	err = writePoint(ctx, m.apiBucket30Days, point)
 	if err != nil {
 		m.logger.Error("Failed to write metric",
 			zap.String("measurement", point.Name()),
@@ -218,7 +250,7 @@ func (m *Metric) vaaCountAllMessagesMeasurement(ctx context.Context, params *Par
 		SetTime(generateUniqueTimestamp(params.Vaa))
 
 	// Write the point to influx
-	err := m.apiBucket24Hours.WritePoint(ctx, point)
+	err := writePoint(ctx, m.apiBucket24Hours, point)
 	if err != nil {
 		m.logger.Error("Failed to write metric",
 			zap.String("measurement", VaaAllMessagesMeasurement),
@@ -263,7 +295,7 @@ func (m *Metric) volumeMeasurement(ctx context.Context, params *Params, token *t
 	}
 
 	// Write the point to influx
-	err = m.apiBucketInfinite.WritePoint(ctx, point)
+	err = writePoint(ctx, m.apiBucketInfinite, point)
 	if err != nil {
 		m.metrics.IncFailedMeasurement(VaaVolumeMeasurement)
 		return err
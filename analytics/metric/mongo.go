@@ -39,6 +39,13 @@ type TransferPriceDoc struct {
 	UpdatedAt time.Time `bson:"updatedAt"`
 }
 
+// Reasons a transfer has no document in the transferPrices collection.
+const (
+	reasonNotATransfer  = "not a token transfer"
+	reasonTokenNotFound = "token metadata not found"
+	reasonPriceNotFound = "token price not found"
+)
+
 func upsertTransferPrices(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -47,16 +54,16 @@ func upsertTransferPrices(
 	tokenPriceFunc func(tokenID string, timestamp time.Time) (decimal.Decimal, error),
 	transferredToken *token.TransferredToken,
 	tokenProvider *domain.TokenProvider,
-) error {
+) (string, error) {
 
 	// Do not generate this metric for PythNet VAAs
 	if vaa.EmitterChain == sdk.ChainIDPythNet {
-		return nil
+		return "", nil
 	}
 
 	// Do not generate this metric if the VAA is not a transfer
 	if transferredToken == nil {
-		return nil
+		return reasonNotATransfer, nil
 	}
 
 	// Get the token metadata
@@ -64,7 +71,7 @@ func upsertTransferPrices(
 	// This is complementary data about the token that is not present in the VAA itself.
 	tokenMeta, ok := tokenProvider.GetTokenByAddress(transferredToken.TokenChain, transferredToken.TokenAddress.String())
 	if !ok {
-		return nil
+		return reasonTokenNotFound, nil
 	}
 
 	// Try to obtain the token notional value from the cache
@@ -77,7 +84,7 @@ func upsertTransferPrices(
 			zap.Any("tokenMetadata", tokenMeta),
 			zap.Error(err),
 		)
-		return nil
+		return reasonPriceNotFound, nil
 	}
 
 	// Compute the amount with decimals
@@ -114,8 +121,8 @@ func upsertTransferPrices(
 		options.Update().SetUpsert(true),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to update transfer price collection: %w", err)
+		return "", fmt.Errorf("failed to update transfer price collection: %w", err)
 	}
 
-	return nil
+	return "", nil
 }
@@ -66,10 +66,10 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 
 		switch notification.Event {
 		case events.SignedVaaType:
-			signedVaa, err := events.GetEventData[events.SignedVaa](&notification)
+			signedVaa, err := events.Decode[events.SignedVaa](&notification)
 			if err != nil {
 				log.Error("Error decoding signedVAA from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
-				return nil, nil
+				return nil, err
 			}
 
 			return &Event{
@@ -79,13 +79,15 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				EmitterAddress: signedVaa.EmitterAddress,
 				Sequence:       strconv.FormatUint(signedVaa.Sequence, 10),
 				Timestamp:      &signedVaa.Timestamp,
+				Vaa:            signedVaa.Vaa,
 				VaaIsSigned:    false,
+				TraceContext:   notification.TraceContext,
 			}, nil
 		case events.LogMessagePublishedMesageType:
-			plm, err := events.GetEventData[events.LogMessagePublished](&notification)
+			plm, err := events.Decode[events.LogMessagePublished](&notification)
 			if err != nil {
 				log.Error("Error decoding publishedLogMessage from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
-				return nil, nil
+				return nil, err
 			}
 
 			vaa, err := events.CreateUnsignedVAA(&plm)
@@ -108,6 +110,7 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				Timestamp:      &plm.BlockTime,
 				Vaa:            vaaBytes,
 				VaaIsSigned:    false,
+				TraceContext:   notification.TraceContext,
 			}, nil
 		}
 		return nil, nil
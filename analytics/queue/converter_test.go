@@ -0,0 +1,31 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events/eventstest"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// TestNotificationEvent_Contract checks the converter against the events shared with the producers.
+func TestNotificationEvent_Contract(t *testing.T) {
+	converter := NewNotificationEvent(zap.NewNop())
+	for _, f := range eventstest.Valid() {
+		event, err := converter(string(f.Body))
+		if assert.NoError(t, err, f.Name) && assert.NotNil(t, event, f.Name) {
+			assert.NotEmpty(t, event.ID, f.Name)
+			assert.NotEmpty(t, event.TrackID, f.Name)
+			assert.NotEmpty(t, event.Sequence, f.Name)
+			// the consumer unmarshals the vaa of both event types.
+			_, err := sdk.Unmarshal(event.Vaa)
+			assert.NoError(t, err, f.Name)
+		}
+	}
+	for _, f := range eventstest.Invalid() {
+		event, err := converter(string(f.Body))
+		assert.Error(t, err, f.Name)
+		assert.Nil(t, event, f.Name)
+	}
+}
@@ -2,14 +2,10 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
-type sqsEvent struct {
-	MessageID string `json:"MessageId"`
-	Message   string `json:"Message"`
-}
-
 // Event represents a event data to be handle.
 type Event struct {
 	TrackID        string
@@ -20,14 +16,23 @@ type Event struct {
 	Vaa            []byte
 	Timestamp      *time.Time
 	VaaIsSigned    bool
+	// TraceContext is the trace context sent in the event when the broker has no message attributes.
+	TraceContext map[string]string
 }
 
+// ErrMessageExpired is the failure of a message received after its visibility timeout expired.
+var ErrMessageExpired = errors.New("message expired")
+
 // ConsumerMessage defition.
 type ConsumerMessage interface {
 	Data() *Event
 	Done()
-	Failed()
+	// Failed releases the message to be retried, or moves it to the dead-letter queue
+	// when it reached the maximum number of attempts.
+	Failed(err error)
 	IsExpired() bool
+	// Context returns the context of the processing of the message, with the span of the message.
+	Context() context.Context
 }
 
 // ConsumeFunc is a function to consume VAAEvent.
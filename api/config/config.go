@@ -10,6 +10,7 @@ import (
 
 	ipfslog "github.com/ipfs/go-log/v2"
 	"github.com/spf13/viper"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 const (
@@ -65,6 +66,7 @@ type AppConfig struct {
 		// Prefix for redis keys
 		Prefix string
 	}
+	Tracing tracing.Config
 }
 
 // GetLogLevel get zapcore.Level define in the configuraion.
@@ -84,6 +86,12 @@ func defaulConfig() *AppConfig {
 		}{
 			MetricExpiration: 10,
 		},
+		Tracing: tracing.Config{
+			Exporter:     tracing.ExporterNone,
+			OTLPEndpoint: "localhost:4318",
+			OTLPInsecure: true,
+			SampleRatio:  1,
+		},
 	}
 }
 
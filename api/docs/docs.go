@@ -72,6 +72,112 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/address/{address}/activity": {
+            "get": {
+                "description": "Returns the portfolio and the activity of an address in the operations where it is the sender or the recipient:\nthe totals sent and received by chain and token, the USD volume over time, the counterparties, the apps used,\nthe inbound transfers that were not redeemed yet and the first and last activity.\nThe address can be given in hex, in the Wormhole format or in the native format of its chain.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "get-address-activity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "chain of a native address, every chain is tried when it is not set",
+                        "name": "chain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "interval of the volume over time: 1d, 1w or 1mo (default 1d)",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-address_AddressActivity"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/gaps": {
+            "get": {
+                "description": "Returns the sequence gaps detected by the chain health monitor, i.e. ranges of sequences\nof an emitter for which no VAA was found.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "find-gaps",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "emitter chain",
+                        "name": "chain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "gap status, supported values: open, closed and all (default is open)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements per page.",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "description": "Sort results in ascending or descending order.",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-array_gaps_SequenceGap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/api/v1/global-tx/{chain_id}/{emitter}/{seq}": {
             "get": {
                 "description": "Find a global transaction by VAA ID\nGlobal transactions is a logical association of two transactions that are related to each other by a unique VAA ID.\nThe first transaction is created on the origin chain when the VAA is emitted.\nThe second transaction is created on the destination chain when the VAA is redeemed.\nIf the response only contains an origin tx the VAA was not redeemed.",
@@ -564,6 +670,67 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/guardian-sets": {
+            "get": {
+                "description": "Get every guardian set, with the name of each guardian and the activation and expiration times of the set.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "get-guardian-sets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-array_guardian_GuardianSetDoc"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/guardians/{guardian_address}/stats": {
+            "get": {
+                "description": "Get the statistics of a guardian: signing participation rate and median observation latency per chain,\nand the node information reported in its last heartbeat.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "get-guardian-stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "guardian address",
+                        "name": "guardian_address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time span, supported values: 1d, 1w and 1mo (default is 1d).",
+                        "name": "timeSpan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/guardian.GuardianStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/api/v1/health": {
             "get": {
                 "description": "Health check",
@@ -592,6 +759,75 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/heartbeats/uptime": {
+            "get": {
+                "description": "Get the uptime of each guardian and, for each chain, whether the height reported by the guardian\nis advancing and how far it is behind the majority of guardians.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "get-heartbeats-uptime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Time span, supported values: 1d, 1w and 1mo (default is 1d).",
+                        "name": "timeSpan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-array_heartbeats_GuardianUptime"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/heartbeats/{guardian_address}/history": {
+            "get": {
+                "description": "Get the heartbeats history of a guardian, downsampled to one heartbeat every 5 minutes (1d),\n30 minutes (1w) or 2 hours (1mo).",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "get-heartbeats-history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "guardian address",
+                        "name": "guardian_address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time span, supported values: 1d, 1w and 1mo (default is 1d).",
+                        "name": "timeSpan",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-array_heartbeats_HeartbeatHistoryDoc"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/api/v1/last-txs": {
             "get": {
                 "description": "Returns the number of transactions by a defined time span and sample rate.",
@@ -882,56 +1118,188 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/ready": {
+        "/api/v1/operations/unredeemed": {
             "get": {
-                "description": "Ready check",
+                "description": "Returns the transfers whose VAA was signed, or is held by the governor, and that were not\nredeemed on the destination chain, with the signed VAA and the contract and method that\nredeem them. The transfers held by the governor can not be redeemed until they are released.",
                 "tags": [
                     "wormholescan"
                 ],
-                "operationId": "ready-check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "ready": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                "operationId": "find-unredeemed-operations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "destination chain",
+                        "name": "toChain",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request"
+                    {
+                        "type": "string",
+                        "description": "recipient address, in hex or in the native format of the destination chain",
+                        "name": "address",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/api/v1/relays/:chain/:emitter/:sequence": {
-            "get": {
-                "description": "Get a specific relay information by chainID, emitter address and sequence.",
-                "tags": [
-                    "wormholescan"
-                ],
-                "operationId": "find-relay-by-vaa-id",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/relays.RelayResponse"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "chain of the token",
+                        "name": "tokenChain",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request"
+                    {
+                        "type": "string",
+                        "description": "token address",
+                        "name": "token",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
+                    {
+                        "type": "string",
+                        "description": "minimum time since the transfer was emitted, e.g. 24h",
+                        "name": "minAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "maximum time since the transfer was emitted, e.g. 720h",
+                        "name": "maxAge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of elements per page.",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ASC",
+                            "DESC"
+                        ],
+                        "type": "string",
+                        "description": "Sort results in ascending or descending order.",
+                        "name": "sortOrder",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-array_operations_UnredeemedTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/processing/{chain_id}/{emitter}/{seq}": {
+            "get": {
+                "description": "Returns the processing of a VAA by each stage of the explorer (fly, pipeline, parser, tx-tracker,\nanalytics and contract-watcher): the outcome, the version of the service and how long it took.\nThe stages that have not processed the VAA are pending.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "find-processing-by-vaa-id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "id of the blockchain",
+                        "name": "chain_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "address of the emitter",
+                        "name": "emitter",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "sequence of the VAA",
+                        "name": "seq",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/processing.VaaProcessing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/ready": {
+            "get": {
+                "description": "Ready check",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "ready-check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "ready": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/api/v1/relays/:chain/:emitter/:sequence": {
+            "get": {
+                "description": "Get a specific relay information by chainID, emitter address and sequence.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "find-relay-by-vaa-id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/relays.RelayResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
         },
         "/api/v1/scorecards": {
             "get": {
@@ -1214,6 +1582,46 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/vaas/batch": {
+            "post": {
+                "description": "Returns all the VAAs that match any of the given VAA IDs or transaction hashes.\nAt most 100 VAA IDs and transaction hashes can be requested.",
+                "tags": [
+                    "wormholescan"
+                ],
+                "operationId": "find-vaas-bulk",
+                "parameters": [
+                    {
+                        "description": "VAA IDs and transaction hashes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/vaa.FindBulkRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include the parsed contents of the VAA, if available",
+                        "name": "parsedPayload",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response-array_vaa_VaaDoc"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/api/v1/vaas/parse": {
             "post": {
                 "description": "Parse a VAA.",
@@ -1799,107 +2207,329 @@ const docTemplate = `{
         }
     },
     "definitions": {
-        "address.AddressOverview": {
+        "address.AddressActivity": {
             "type": "object",
             "properties": {
-                "vaas": {
+                "address": {
+                    "type": "string"
+                },
+                "apps": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/vaa.VaaDoc"
+                        "$ref": "#/definitions/address.AppUsage"
                     }
-                }
-            }
-        },
-        "github_com_wormhole-foundation_wormhole-explorer_api_routes_guardian_guardian.GuardianSet": {
-            "type": "object",
-            "properties": {
-                "addresses": {
+                },
+                "counterparties": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/address.Counterparty"
                     }
                 },
-                "index": {
-                    "type": "integer"
-                }
-            }
-        },
-        "governor.AvailableNotionalItemResponse": {
-            "type": "object",
-            "properties": {
-                "bigTransactionSize": {
+                "firstActivity": {
                     "type": "string"
                 },
-                "chainId": {
-                    "$ref": "#/definitions/vaa.ChainID"
+                "formats": {
+                    "description": "Formats are the formats of the address the operations were searched by.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "notionalLimit": {
+                "lastActivity": {
                     "type": "string"
                 },
-                "remainingAvailableNotional": {
-                    "type": "string"
-                }
-            }
-        },
-        "governor.AvailableNotionalResponse": {
-            "type": "object",
-            "properties": {
-                "entries": {
+                "operations": {
+                    "type": "integer"
+                },
+                "pendingInbound": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/governor.AvailableNotionalItemResponse"
+                        "$ref": "#/definitions/address.PendingTransfer"
+                    }
+                },
+                "totals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/address.TokenTotal"
+                    }
+                },
+                "volume": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/address.VolumeBucket"
                     }
                 }
             }
         },
-        "governor.Emitter": {
+        "address.AddressOverview": {
             "type": "object",
             "properties": {
-                "emitterAddress": {
-                    "type": "string"
-                },
-                "enqueuedVaas": {
+                "vaas": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/governor.EnqueuedVAA"
+                        "$ref": "#/definitions/vaa.VaaDoc"
                     }
-                },
-                "totalEnqueuedVaas": {
-                    "type": "integer"
                 }
             }
         },
-        "governor.EnqueuedVAA": {
+        "address.AppUsage": {
             "type": "object",
             "properties": {
-                "notionalValue": {
-                    "type": "integer"
-                },
-                "releaseTime": {
+                "appId": {
                     "type": "string"
                 },
-                "sequence": {
-                    "type": "string"
+                "operations": {
+                    "type": "integer"
                 },
-                "txHash": {
-                    "type": "string"
+                "usdVolume": {
+                    "type": "number"
                 }
             }
         },
-        "governor.EnqueuedVaa": {
+        "address.Counterparty": {
             "type": "object",
             "properties": {
+                "address": {
+                    "type": "string"
+                },
                 "chainId": {
                     "$ref": "#/definitions/vaa.ChainID"
                 },
-                "emitterAddress": {
-                    "type": "string"
+                "direction": {
+                    "$ref": "#/definitions/address.Direction"
                 },
-                "notionalValue": {
+                "operations": {
                     "type": "integer"
                 },
-                "sequence": {
-                    "type": "string"
+                "usdVolume": {
+                    "type": "number"
+                }
+            }
+        },
+        "address.Direction": {
+            "type": "string",
+            "enum": [
+                "sent",
+                "received"
+            ],
+            "x-enum-varnames": [
+                "DirectionSent",
+                "DirectionReceived"
+            ]
+        },
+        "address.PendingTransfer": {
+            "type": "object",
+            "properties": {
+                "fromAddress": {
+                    "type": "string"
+                },
+                "fromChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lifecycleState": {
+                    "description": "LifecycleState is the state of the operation lifecycle, e.g. signed or governor_held.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "toChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "tokenAddress": {
+                    "type": "string"
+                },
+                "tokenAmount": {
+                    "type": "string"
+                },
+                "tokenChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "usdAmount": {
+                    "type": "string"
+                }
+            }
+        },
+        "address.TokenTotal": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "direction": {
+                    "$ref": "#/definitions/address.Direction"
+                },
+                "operations": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "tokenAddress": {
+                    "type": "string"
+                },
+                "tokenAmount": {
+                    "type": "number"
+                },
+                "tokenChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "usdVolume": {
+                    "type": "number"
+                }
+            }
+        },
+        "address.VolumeBucket": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "operations": {
+                    "type": "integer"
+                },
+                "receivedUsd": {
+                    "type": "number"
+                },
+                "sentUsd": {
+                    "type": "number"
+                }
+            }
+        },
+        "gaps.SequenceGap": {
+            "type": "object",
+            "properties": {
+                "closedAt": {
+                    "type": "string"
+                },
+                "detectedAt": {
+                    "type": "string"
+                },
+                "emitterAddr": {
+                    "type": "string"
+                },
+                "emitterChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "endSequence": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "missing": {
+                    "type": "integer"
+                },
+                "startSequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_wormhole-foundation_wormhole-explorer_api_routes_guardian_guardian.GuardianSet": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "governor.AvailableNotionalItemResponse": {
+            "type": "object",
+            "properties": {
+                "bigTransactionSize": {
+                    "type": "string"
+                },
+                "chainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "notionalLimit": {
+                    "type": "string"
+                },
+                "remainingAvailableNotional": {
+                    "type": "string"
+                }
+            }
+        },
+        "governor.AvailableNotionalResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/governor.AvailableNotionalItemResponse"
+                    }
+                }
+            }
+        },
+        "governor.Emitter": {
+            "type": "object",
+            "properties": {
+                "emitterAddress": {
+                    "type": "string"
+                },
+                "enqueuedVaas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/governor.EnqueuedVAA"
+                    }
+                },
+                "totalEnqueuedVaas": {
+                    "type": "integer"
+                }
+            }
+        },
+        "governor.EnqueuedVAA": {
+            "type": "object",
+            "properties": {
+                "notionalValue": {
+                    "type": "integer"
+                },
+                "releaseTime": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "governor.EnqueuedVaa": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "emitterAddress": {
+                    "type": "string"
+                },
+                "notionalValue": {
+                    "type": "integer"
+                },
+                "sequence": {
+                    "type": "string"
                 },
                 "txHash": {
                     "type": "string"
@@ -2187,33 +2817,283 @@ const docTemplate = `{
                 "nodeName": {
                     "type": "string"
                 },
-                "notionalLimit": {
-                    "type": "integer"
+                "notionalLimit": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "governor.TokenList": {
+            "type": "object",
+            "properties": {
+                "originAddress": {
+                    "type": "string"
+                },
+                "originChainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "guardian.ChainParticipation": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "medianLatencyMs": {
+                    "type": "integer"
+                },
+                "messages": {
+                    "description": "Messages is the number of messages observed by any guardian.",
+                    "type": "integer"
+                },
+                "observations": {
+                    "description": "Observations is the number of messages observed by the guardian.",
+                    "type": "integer"
+                },
+                "participationRate": {
+                    "description": "ParticipationRate is Observations / Messages.",
+                    "type": "number"
+                }
+            }
+        },
+        "guardian.GuardianKey": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "guardian.GuardianSetDoc": {
+            "type": "object",
+            "properties": {
+                "activationTime": {
+                    "description": "ActivationTime is nil when it could not be determined.",
+                    "type": "string"
+                },
+                "expirationTime": {
+                    "description": "ExpirationTime is nil for the current guardian set.",
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "description": "Keys is the list of guardians, in the order used for signature indexes.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/guardian.GuardianKey"
+                    }
+                }
+            }
+        },
+        "guardian.GuardianSetResponse": {
+            "type": "object",
+            "properties": {
+                "guardianSet": {
+                    "$ref": "#/definitions/github_com_wormhole-foundation_wormhole-explorer_api_routes_guardian_guardian.GuardianSet"
+                }
+            }
+        },
+        "guardian.GuardianStats": {
+            "type": "object",
+            "properties": {
+                "chains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/guardian.ChainParticipation"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "guardianAddress": {
+                    "type": "string"
+                },
+                "guardianSetIndexes": {
+                    "description": "GuardianSetIndexes lists the guardian sets the guardian is a member of.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "heartbeat": {
+                    "$ref": "#/definitions/guardian.HeartbeatInfo"
+                },
+                "medianLatencyMs": {
+                    "description": "MedianLatencyMs is the median delay between the VAA timestamp and the indexing of\nthe guardian's observation, computed over the most recent observations of the time span.",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "versionHistory": {
+                    "description": "VersionHistory lists the node versions and features reported by the guardian during the\ntime span, from the oldest to the newest, based on the heartbeats history samples.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/guardian.VersionPeriod"
+                    }
+                }
+            }
+        },
+        "guardian.HeartbeatInfo": {
+            "type": "object",
+            "properties": {
+                "bootTimestamp": {
+                    "type": "string"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "indexedAt": {
+                    "type": "string"
+                },
+                "nodeName": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "guardian.VersionPeriod": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "firstSeen": {
+                    "type": "string"
+                },
+                "lastSeen": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "heartbeats.ChainUptime": {
+            "type": "object",
+            "properties": {
+                "chainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "height": {
+                    "description": "Height is the last height reported by the guardian.",
+                    "type": "integer"
+                },
+                "lag": {
+                    "description": "Lag is the number of blocks the guardian is behind MajorityHeight.",
+                    "type": "integer"
+                },
+                "lagging": {
+                    "description": "Lagging is true when the guardian is behind the majority by at least the number of blocks\nthe majority advanced in the last sample intervals.",
+                    "type": "boolean"
+                },
+                "lastAdvancedAt": {
+                    "description": "LastAdvancedAt is the start of the last sample interval in which the reported height advanced.",
+                    "type": "string"
+                },
+                "majorityHeight": {
+                    "description": "MajorityHeight is the median of the last heights reported by all the guardians.",
+                    "type": "integer"
+                },
+                "stalled": {
+                    "description": "Stalled is true when the reported height has not advanced in the last sample intervals.",
+                    "type": "boolean"
+                },
+                "uptime": {
+                    "description": "Uptime is the fraction of sample intervals in which the reported height advanced.",
+                    "type": "number"
+                }
+            }
+        },
+        "heartbeats.GuardianUptime": {
+            "type": "object",
+            "properties": {
+                "chains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/heartbeats.ChainUptime"
+                    }
+                },
+                "guardianAddress": {
+                    "type": "string"
+                },
+                "nodeName": {
+                    "type": "string"
+                },
+                "uptime": {
+                    "description": "Uptime is the fraction of sample intervals in which the guardian sent a heartbeat.",
+                    "type": "number"
+                }
+            }
+        },
+        "heartbeats.HeartbeatHistoryDoc": {
+            "type": "object",
+            "properties": {
+                "bootTimestamp": {
+                    "type": "integer"
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/heartbeats.HeartbeatNetworkHistory"
+                    }
+                },
+                "nodeName": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
                 },
-                "updatedAt": {
+                "version": {
                     "type": "string"
                 }
             }
         },
-        "governor.TokenList": {
+        "heartbeats.HeartbeatNetworkHistory": {
             "type": "object",
             "properties": {
-                "originAddress": {
+                "contractAddress": {
                     "type": "string"
                 },
-                "originChainId": {
-                    "$ref": "#/definitions/vaa.ChainID"
+                "errorCount": {
+                    "type": "integer"
                 },
-                "price": {
-                    "type": "number"
-                }
-            }
-        },
-        "guardian.GuardianSetResponse": {
-            "type": "object",
-            "properties": {
-                "guardianSet": {
-                    "$ref": "#/definitions/github_com_wormhole-foundation_wormhole-explorer_api_routes_guardian_guardian.GuardianSet"
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "$ref": "#/definitions/vaa.ChainID"
                 }
             }
         },
@@ -2358,6 +3238,128 @@ const docTemplate = `{
                 }
             }
         },
+        "operations.DestinationTx": {
+            "type": "object",
+            "properties": {
+                "blockNumber": {
+                    "type": "string"
+                },
+                "chainId": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "txHash": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.RedeemTarget": {
+            "type": "object",
+            "properties": {
+                "caller": {
+                    "description": "Caller is the only address allowed to redeem the transfer, e.g. the recipient of a transfer with payload.",
+                    "type": "string"
+                },
+                "contract": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
+        "operations.UnredeemedTransfer": {
+            "type": "object",
+            "properties": {
+                "destinationTx": {
+                    "description": "DestinationTx is the failed redeem transaction, if any.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/operations.DestinationTx"
+                        }
+                    ]
+                },
+                "emitterChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "fromAddress": {
+                    "type": "string"
+                },
+                "fromChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "governorHeld": {
+                    "description": "GovernorHeld indicates that the governor delayed the VAA, it can not be redeemed until it is released.",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "redeemTarget": {
+                    "description": "RedeemTarget is nil when the contract that redeems the operation is unknown.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/operations.RedeemTarget"
+                        }
+                    ]
+                },
+                "signedAt": {
+                    "description": "SignedAt is the time the VAA was signed, it is nil while the governor holds it.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "toAddress": {
+                    "type": "string"
+                },
+                "toChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "tokenAddress": {
+                    "type": "string"
+                },
+                "tokenAmount": {
+                    "type": "string"
+                },
+                "tokenChain": {
+                    "$ref": "#/definitions/vaa.ChainID"
+                },
+                "usdAmount": {
+                    "type": "string"
+                },
+                "vaa": {
+                    "description": "Vaa is the signed VAA to submit to the redeem target.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "parser.ParseVaaWithStandarizedPropertiesdResponse": {
             "type": "object",
             "properties": {
@@ -2408,6 +3410,55 @@ const docTemplate = `{
                 }
             }
         },
+        "processing.StageProcessing": {
+            "type": "object",
+            "properties": {
+                "durationMs": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "trackId": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "processing.VaaProcessing": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/processing.StageProcessing"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "relays.DeliveryReponse": {
             "type": "object",
             "properties": {
@@ -2436,6 +3487,9 @@ const docTemplate = `{
                         }
                     }
                 },
+                "gasLimit": {
+                    "type": "string"
+                },
                 "refundAddress": {
                     "type": "string"
                 },
@@ -2486,6 +3540,12 @@ const docTemplate = `{
                 "instructions": {
                     "$ref": "#/definitions/relays.InstructionsResponse"
                 },
+                "redeliveryIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "toTxHash": {
                     "type": "string"
                 }
@@ -2540,6 +3600,17 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Response-address_AddressActivity": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/address.AddressActivity"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.ResponsePagination"
+                }
+            }
+        },
         "response.Response-address_AddressOverview": {
             "type": "object",
             "properties": {
@@ -2551,6 +3622,20 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Response-array_gaps_SequenceGap": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gaps.SequenceGap"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.ResponsePagination"
+                }
+            }
+        },
         "response.Response-array_governor_EnqueuedVaaDetail": {
             "type": "object",
             "properties": {
@@ -2649,6 +3734,62 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Response-array_guardian_GuardianSetDoc": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/guardian.GuardianSetDoc"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.ResponsePagination"
+                }
+            }
+        },
+        "response.Response-array_heartbeats_GuardianUptime": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/heartbeats.GuardianUptime"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.ResponsePagination"
+                }
+            }
+        },
+        "response.Response-array_heartbeats_HeartbeatHistoryDoc": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/heartbeats.HeartbeatHistoryDoc"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.ResponsePagination"
+                }
+            }
+        },
+        "response.Response-array_operations_UnredeemedTransfer": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/operations.UnredeemedTransfer"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.ResponsePagination"
+                }
+            }
+        },
         "response.Response-array_vaa_VaaDoc": {
             "type": "object",
             "properties": {
@@ -3072,6 +4213,25 @@ const docTemplate = `{
                 "ChainIDSepolia"
             ]
         },
+        "vaa.FindBulkRequest": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "description": "IDs of the VAAs, with the format emitter_chain/emitter_address/sequence.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "txHashes": {
+                    "description": "TxHashes are the hashes of the transactions that emitted the VAAs.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "vaa.VaaDoc": {
             "type": "object",
             "properties": {
@@ -0,0 +1,184 @@
+package address
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ActivityInterval is the bucket size of the volume of an address over time.
+type ActivityInterval string
+
+const (
+	ActivityIntervalDay   ActivityInterval = "1d"
+	ActivityIntervalWeek  ActivityInterval = "1w"
+	ActivityIntervalMonth ActivityInterval = "1mo"
+)
+
+// ParseActivityInterval parses an activity interval, the empty string is a day.
+func ParseActivityInterval(s string) (ActivityInterval, error) {
+	switch ActivityInterval(s) {
+	case "":
+		return ActivityIntervalDay, nil
+	case ActivityIntervalDay, ActivityIntervalWeek, ActivityIntervalMonth:
+		return ActivityInterval(s), nil
+	default:
+		return "", fmt.Errorf("invalid activity interval: %s", s)
+	}
+}
+
+// unit returns the $dateTrunc unit of the interval.
+func (i ActivityInterval) unit() string {
+	switch i {
+	case ActivityIntervalWeek:
+		return "week"
+	case ActivityIntervalMonth:
+		return "month"
+	default:
+		return "day"
+	}
+}
+
+// AddressActivity is the portfolio and the activity of an address in the operations where it is
+// the sender or the recipient.
+type AddressActivity struct {
+	Address string `json:"address"`
+	// Formats are the formats of the address the operations were searched by.
+	Formats        []string           `json:"formats"`
+	Operations     int64              `json:"operations"`
+	FirstActivity  *time.Time         `json:"firstActivity"`
+	LastActivity   *time.Time         `json:"lastActivity"`
+	Totals         []*TokenTotal      `json:"totals"`
+	Volume         []*VolumeBucket    `json:"volume"`
+	Counterparties []*Counterparty    `json:"counterparties"`
+	Apps           []*AppUsage        `json:"apps"`
+	PendingInbound []*PendingTransfer `json:"pendingInbound"`
+}
+
+// Direction is the side of an operation the address is on.
+type Direction string
+
+const (
+	DirectionSent     Direction = "sent"
+	DirectionReceived Direction = "received"
+)
+
+// TokenTotal is the amount of a token sent or received by an address on a chain. The chain is the
+// source chain of the sent operations and the target chain of the received ones.
+type TokenTotal struct {
+	Direction    Direction   `bson:"direction" json:"direction"`
+	ChainID      sdk.ChainID `bson:"chainId" json:"chainId"`
+	TokenChain   sdk.ChainID `bson:"tokenChain" json:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress" json:"tokenAddress"`
+	Symbol       string      `bson:"symbol" json:"symbol,omitempty"`
+	Operations   int64       `bson:"operations" json:"operations"`
+	TokenAmount  float64     `bson:"tokenAmount" json:"tokenAmount"`
+	UsdVolume    float64     `bson:"usdVolume" json:"usdVolume"`
+}
+
+// VolumeBucket is the USD volume sent and received by an address in an interval.
+type VolumeBucket struct {
+	From        time.Time `bson:"_id" json:"from"`
+	Operations  int64     `bson:"operations" json:"operations"`
+	SentUsd     float64   `bson:"sentUsd" json:"sentUsd"`
+	ReceivedUsd float64   `bson:"receivedUsd" json:"receivedUsd"`
+}
+
+// Counterparty is an address an address sent to or received from.
+type Counterparty struct {
+	Direction  Direction   `bson:"direction" json:"direction"`
+	ChainID    sdk.ChainID `bson:"chainId" json:"chainId"`
+	Address    string      `bson:"address" json:"address"`
+	Operations int64       `bson:"operations" json:"operations"`
+	UsdVolume  float64     `bson:"usdVolume" json:"usdVolume"`
+}
+
+// AppUsage is the number of operations of an address by app.
+type AppUsage struct {
+	AppID      string  `bson:"_id" json:"appId"`
+	Operations int64   `bson:"operations" json:"operations"`
+	UsdVolume  float64 `bson:"usdVolume" json:"usdVolume"`
+}
+
+// PendingTransfer is an operation to the address that was not redeemed yet.
+type PendingTransfer struct {
+	ID           string      `bson:"_id" json:"id"`
+	Status       string      `bson:"status" json:"status"`
+	FromChain    sdk.ChainID `bson:"fromChain" json:"fromChain"`
+	ToChain      sdk.ChainID `bson:"toChain" json:"toChain"`
+	FromAddress  string      `bson:"fromAddress" json:"fromAddress"`
+	TokenChain   sdk.ChainID `bson:"tokenChain" json:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress" json:"tokenAddress"`
+	Symbol       string      `bson:"symbol" json:"symbol,omitempty"`
+	TokenAmount  string      `bson:"tokenAmount" json:"tokenAmount,omitempty"`
+	UsdAmount    string      `bson:"usdAmount" json:"usdAmount,omitempty"`
+	Timestamp    *time.Time  `bson:"timestamp" json:"timestamp"`
+	// LifecycleState is the state of the operation lifecycle, e.g. signed or governor_held.
+	LifecycleState string `bson:"lifecycleState" json:"lifecycleState,omitempty"`
+}
+
+// StoredFormats returns the formats an address can be stored in by the services: as given, in
+// hex with and without 0x prefix, in the 32 bytes Wormhole format and in the native format of the
+// chain. When the chain is unknown every chain is tried.
+func StoredFormats(address string, chainID *sdk.ChainID) []string {
+	formats := map[string]bool{address: true, strings.ToLower(address): true}
+	addHex := func(h string) {
+		h = strings.ToLower(strings.TrimPrefix(h, "0x"))
+		if !isHex(h) {
+			return
+		}
+		formats[h] = true
+		formats["0x"+h] = true
+		// the 32 bytes Wormhole format of the shorter addresses, e.g. EVM, is left padded with zeros.
+		if len(h) < 64 {
+			formats[strings.Repeat("0", 64-len(h))+h] = true
+		}
+		// the 20 bytes addresses of the zero padded Wormhole format, with and without 0x prefix.
+		if len(h) == 64 && strings.HasPrefix(h, strings.Repeat("0", 24)) {
+			formats[h[24:]] = true
+			formats["0x"+h[24:]] = true
+		}
+	}
+
+	chains := sdk.GetAllNetworkIDs()
+	if chainID != nil {
+		chains = []sdk.ChainID{*chainID}
+	}
+
+	if h := strings.ToLower(strings.TrimPrefix(address, "0x")); isHex(h) {
+		addHex(h)
+		if len(h) == 64 {
+			for _, c := range chains {
+				if native, err := domain.TranslateEmitterAddress(c, h); err == nil {
+					formats[native] = true
+				}
+			}
+		}
+	} else {
+		for _, c := range chains {
+			if h, err := domain.DecodeNativeAddressToHex(c, address); err == nil {
+				addHex(h)
+			}
+		}
+	}
+
+	result := make([]string, 0, len(formats))
+	for f := range formats {
+		result = append(result, f)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func isHex(s string) bool {
+	if s == "" {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,51 @@
+package address
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestAddressFormats_Evm(t *testing.T) {
+	formats := StoredFormats("0xF890982f9310df57d00f659cf4fd87e65aded8d7", nil)
+	assert.Contains(t, formats, "0xF890982f9310df57d00f659cf4fd87e65aded8d7")
+	assert.Contains(t, formats, "0xf890982f9310df57d00f659cf4fd87e65aded8d7")
+	assert.Contains(t, formats, "f890982f9310df57d00f659cf4fd87e65aded8d7")
+	assert.Contains(t, formats, "000000000000000000000000f890982f9310df57d00f659cf4fd87e65aded8d7")
+}
+
+func TestAddressFormats_WormholeFormat(t *testing.T) {
+	chainID := sdk.ChainIDEthereum
+	formats := StoredFormats("000000000000000000000000f890982f9310df57d00f659cf4fd87e65aded8d7", &chainID)
+	assert.Contains(t, formats, "0xf890982f9310df57d00f659cf4fd87e65aded8d7")
+	assert.Contains(t, formats, "f890982f9310df57d00f659cf4fd87e65aded8d7")
+}
+
+func TestAddressFormats_Solana(t *testing.T) {
+	const native = "ENG1wQ7CQKH8ibAJ1hSLmJgL9Ucg6DRDbj752ZAfidLA"
+	const wormhole = "c69a1b1a65dd336bf1df6a77afb501fc25db7fc0938cb08595a9ef473265cb4f"
+
+	chainID := sdk.ChainIDSolana
+	formats := StoredFormats(native, &chainID)
+	assert.Contains(t, formats, native)
+	assert.Contains(t, formats, wormhole)
+	assert.Contains(t, formats, "0x"+wormhole)
+
+	// the native format is found from the Wormhole format without chain.
+	assert.Contains(t, StoredFormats(wormhole, nil), native)
+}
+
+func TestParseActivityInterval(t *testing.T) {
+	interval, err := ParseActivityInterval("")
+	require.NoError(t, err)
+	assert.Equal(t, ActivityIntervalDay, interval)
+
+	interval, err = ParseActivityInterval("1mo")
+	require.NoError(t, err)
+	assert.Equal(t, "month", interval.unit())
+
+	_, err = ParseActivityInterval("1y")
+	assert.Error(t, err)
+}
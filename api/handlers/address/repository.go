@@ -3,9 +3,11 @@ package address
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/common"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
@@ -16,7 +18,8 @@ type Repository struct {
 	logger *zap.Logger
 
 	collections struct {
-		parsedVaa *mongo.Collection
+		parsedVaa  *mongo.Collection
+		operations *mongo.Collection
 	}
 }
 
@@ -24,9 +27,11 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "AddressRepository")),
 		collections: struct {
-			parsedVaa *mongo.Collection
+			parsedVaa  *mongo.Collection
+			operations *mongo.Collection
 		}{
-			parsedVaa: db.Collection("parsedVaa"),
+			parsedVaa:  db.Collection("parsedVaa"),
+			operations: db.Collection("operations"),
 		},
 	}
 }
@@ -124,3 +129,201 @@ func (r *Repository) GetAddressOverview(ctx context.Context, params *GetAddressO
 	}
 	return &AddressOverview{Vaas: vaas}, nil
 }
+
+// GetAddressActivityParams defines the parameters of the activity of an address.
+type GetAddressActivityParams struct {
+	// Formats are the formats of the address, see StoredFormats.
+	Formats  []string
+	Interval ActivityInterval
+	// Limit is the maximum number of counterparties, apps and pending transfers.
+	Limit int64
+}
+
+// addressActivityFacets is the result of the facets of the activity aggregation.
+type addressActivityFacets struct {
+	Summary []struct {
+		Operations    int64      `bson:"operations"`
+		FirstActivity *time.Time `bson:"firstActivity"`
+		LastActivity  *time.Time `bson:"lastActivity"`
+	} `bson:"summary"`
+	SentTotals             []*TokenTotal      `bson:"sentTotals"`
+	ReceivedTotals         []*TokenTotal      `bson:"receivedTotals"`
+	Volume                 []*VolumeBucket    `bson:"volume"`
+	SentCounterparties     []*Counterparty    `bson:"sentCounterparties"`
+	ReceivedCounterparties []*Counterparty    `bson:"receivedCounterparties"`
+	Apps                   []*AppUsage        `bson:"apps"`
+	PendingInbound         []*PendingTransfer `bson:"pendingInbound"`
+}
+
+// GetAddressActivity returns the activity of an address from the operations where it is the sender
+// of the origin transaction or the recipient of the standardized properties.
+func (r *Repository) GetAddressActivity(ctx context.Context, params *GetAddressActivityParams) (*AddressActivity, error) {
+
+	usdValue := bson.M{"$ifNull": bson.A{"$usdValue", 0}}
+	isSent := bson.M{"$in": bson.A{bson.M{"$ifNull": bson.A{"$originTx.from", ""}}, params.Formats}}
+	isReceived := bson.M{"$in": bson.A{bson.M{"$ifNull": bson.A{"$standardizedProperties.toAddress", ""}}, params.Formats}}
+
+	// totals groups the operations of one direction by chain and token.
+	totals := func(direction Direction, match, chain string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{match: true}},
+			bson.M{"$group": bson.D{
+				{Key: "_id", Value: bson.D{
+					{Key: "chainId", Value: chain},
+					{Key: "tokenChain", Value: "$standardizedProperties.tokenChain"},
+					{Key: "tokenAddress", Value: "$standardizedProperties.tokenAddress"},
+				}},
+				{Key: "symbol", Value: bson.M{"$max": "$symbol"}},
+				{Key: "operations", Value: bson.M{"$sum": 1}},
+				{Key: "tokenAmount", Value: bson.M{"$sum": bson.M{"$convert": bson.M{
+					"input": "$tokenAmount", "to": "double", "onError": 0, "onNull": 0,
+				}}}},
+				{Key: "usdVolume", Value: bson.M{"$sum": usdValue}},
+			}},
+			bson.M{"$sort": bson.D{{Key: "usdVolume", Value: -1}, {Key: "operations", Value: -1}}},
+			bson.M{"$project": bson.D{
+				{Key: "_id", Value: 0},
+				{Key: "direction", Value: direction},
+				{Key: "chainId", Value: "$_id.chainId"},
+				{Key: "tokenChain", Value: "$_id.tokenChain"},
+				{Key: "tokenAddress", Value: "$_id.tokenAddress"},
+				{Key: "symbol", Value: 1},
+				{Key: "operations", Value: 1},
+				{Key: "tokenAmount", Value: 1},
+				{Key: "usdVolume", Value: 1},
+			}},
+		}
+	}
+
+	// counterparties groups the operations of one direction by the address on the other side.
+	counterparties := func(direction Direction, match, chain, address string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{match: true}},
+			bson.M{"$group": bson.D{
+				{Key: "_id", Value: bson.D{{Key: "chainId", Value: chain}, {Key: "address", Value: address}}},
+				{Key: "operations", Value: bson.M{"$sum": 1}},
+				{Key: "usdVolume", Value: bson.M{"$sum": usdValue}},
+			}},
+			bson.M{"$match": bson.M{"_id.address": bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$sort": bson.D{{Key: "operations", Value: -1}, {Key: "usdVolume", Value: -1}}},
+			bson.M{"$limit": params.Limit},
+			bson.M{"$project": bson.D{
+				{Key: "_id", Value: 0},
+				{Key: "direction", Value: direction},
+				{Key: "chainId", Value: "$_id.chainId"},
+				{Key: "address", Value: "$_id.address"},
+				{Key: "operations", Value: 1},
+				{Key: "usdVolume", Value: 1},
+			}},
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		// the operations are only listed once their origin transaction is known.
+		{{Key: "$match", Value: bson.D{
+			{Key: "originTx", Value: bson.M{"$exists": true}},
+			{Key: "$or", Value: bson.A{
+				bson.D{{Key: "originTx.from", Value: bson.M{"$in": params.Formats}}},
+				bson.D{{Key: "standardizedProperties.toAddress", Value: bson.M{"$in": params.Formats}}},
+			}},
+		}}},
+		{{Key: "$addFields", Value: bson.D{
+			{Key: "sent", Value: isSent},
+			{Key: "received", Value: isReceived},
+		}}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "summary", Value: bson.A{
+				bson.M{"$group": bson.D{
+					{Key: "_id", Value: nil},
+					{Key: "operations", Value: bson.M{"$sum": 1}},
+					{Key: "firstActivity", Value: bson.M{"$min": "$timestamp"}},
+					{Key: "lastActivity", Value: bson.M{"$max": "$timestamp"}},
+				}},
+			}},
+			{Key: "sentTotals", Value: totals(DirectionSent, "sent", "$standardizedProperties.fromChain")},
+			{Key: "receivedTotals", Value: totals(DirectionReceived, "received", "$standardizedProperties.toChain")},
+			{Key: "volume", Value: bson.A{
+				bson.M{"$match": bson.M{"timestamp": bson.M{"$ne": nil}}},
+				bson.M{"$group": bson.D{
+					{Key: "_id", Value: bson.M{"$dateTrunc": bson.M{"date": "$timestamp", "unit": params.Interval.unit()}}},
+					{Key: "operations", Value: bson.M{"$sum": 1}},
+					{Key: "sentUsd", Value: bson.M{"$sum": bson.M{"$cond": bson.A{"$sent", usdValue, 0}}}},
+					{Key: "receivedUsd", Value: bson.M{"$sum": bson.M{"$cond": bson.A{"$received", usdValue, 0}}}},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			}},
+			{Key: "sentCounterparties", Value: counterparties(DirectionSent, "sent",
+				"$standardizedProperties.toChain", "$standardizedProperties.toAddress")},
+			{Key: "receivedCounterparties", Value: counterparties(DirectionReceived, "received",
+				"$standardizedProperties.fromChain", "$originTx.from")},
+			{Key: "apps", Value: bson.A{
+				bson.M{"$unwind": "$standardizedProperties.appIds"},
+				bson.M{"$group": bson.D{
+					{Key: "_id", Value: "$standardizedProperties.appIds"},
+					{Key: "operations", Value: bson.M{"$sum": 1}},
+					{Key: "usdVolume", Value: bson.M{"$sum": usdValue}},
+				}},
+				bson.M{"$sort": bson.D{{Key: "operations", Value: -1}}},
+				bson.M{"$limit": params.Limit},
+			}},
+			// the transfers to the address that were signed or are in progress and were not redeemed.
+			{Key: "pendingInbound", Value: bson.A{
+				bson.M{"$match": bson.M{"received": true, "status": bson.M{"$in": bson.A{domain.SourceTxStatusInProgress, "pending"}}}},
+				bson.M{"$sort": bson.M{"timestamp": -1}},
+				bson.M{"$limit": params.Limit},
+				bson.M{"$project": bson.D{
+					{Key: "status", Value: 1},
+					{Key: "fromChain", Value: "$standardizedProperties.fromChain"},
+					{Key: "toChain", Value: "$standardizedProperties.toChain"},
+					{Key: "fromAddress", Value: "$originTx.from"},
+					{Key: "tokenChain", Value: "$standardizedProperties.tokenChain"},
+					{Key: "tokenAddress", Value: "$standardizedProperties.tokenAddress"},
+					{Key: "symbol", Value: 1},
+					{Key: "tokenAmount", Value: 1},
+					{Key: "usdAmount", Value: 1},
+					{Key: "timestamp", Value: 1},
+					{Key: "lifecycleState", Value: "$lifecycle.state"},
+				}},
+			}},
+		}}},
+	}
+
+	cur, err := r.collections.operations.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Aggregate command to get address activity",
+			zap.Error(err),
+			zap.Strings("formats", params.Formats),
+			zap.String("requestID", requestID),
+		)
+		return nil, err
+	}
+
+	var facets []addressActivityFacets
+	if err := cur.All(ctx, &facets); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor for address activity",
+			zap.Error(err),
+			zap.Strings("formats", params.Formats),
+			zap.String("requestID", requestID),
+		)
+		return nil, err
+	}
+
+	activity := AddressActivity{Formats: params.Formats}
+	if len(facets) == 0 {
+		return &activity, nil
+	}
+	f := facets[0]
+	if len(f.Summary) > 0 {
+		activity.Operations = f.Summary[0].Operations
+		activity.FirstActivity = f.Summary[0].FirstActivity
+		activity.LastActivity = f.Summary[0].LastActivity
+	}
+	activity.Totals = append(f.SentTotals, f.ReceivedTotals...)
+	activity.Volume = f.Volume
+	activity.Counterparties = append(f.SentCounterparties, f.ReceivedCounterparties...)
+	activity.Apps = f.Apps
+	activity.PendingInbound = f.PendingInbound
+	return &activity, nil
+}
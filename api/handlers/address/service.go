@@ -5,6 +5,7 @@ import (
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -44,3 +45,32 @@ func (s *Service) GetAddressOverview(
 	response.Data = overview
 	return response, nil
 }
+
+// activityLimit is the maximum number of counterparties, apps and pending transfers of an address activity.
+const activityLimit = 50
+
+// GetAddressActivity returns the activity of an address in any of its formats. The chain is the
+// chain of a native address, when it is nil the native formats of every chain are tried.
+func (s *Service) GetAddressActivity(
+	ctx context.Context,
+	address string,
+	chainID *sdk.ChainID,
+	interval ActivityInterval,
+) (*response.Response[*AddressActivity], error) {
+
+	response := &response.Response[*AddressActivity]{}
+
+	p := GetAddressActivityParams{
+		Formats:  StoredFormats(address, chainID),
+		Interval: interval,
+		Limit:    activityLimit,
+	}
+	activity, err := s.repo.GetAddressActivity(ctx, &p)
+	if err != nil {
+		return response, err
+	}
+
+	activity.Address = address
+	response.Data = activity
+	return response, nil
+}
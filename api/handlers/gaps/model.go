@@ -0,0 +1,33 @@
+package gaps
+
+import (
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Gap status constants.
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// SequenceGap is a range of sequences of an emitter for which no VAA was found by the chain health monitor.
+type SequenceGap struct {
+	ID            string      `bson:"_id" json:"id"`
+	EmitterChain  sdk.ChainID `bson:"emitterChain" json:"emitterChain"`
+	EmitterAddr   string      `bson:"emitterAddr" json:"emitterAddr"`
+	StartSequence uint64      `bson:"startSequence" json:"startSequence"`
+	EndSequence   uint64      `bson:"endSequence" json:"endSequence"`
+	Missing       uint64      `bson:"missing" json:"missing"`
+	Status        string      `bson:"status" json:"status"`
+	DetectedAt    time.Time   `bson:"detectedAt" json:"detectedAt"`
+	UpdatedAt     time.Time   `bson:"updatedAt" json:"updatedAt"`
+	ClosedAt      *time.Time  `bson:"closedAt" json:"closedAt,omitempty"`
+}
+
+// GapQuery represents a query for the sequenceGaps mongodb document.
+type GapQuery struct {
+	chainID *sdk.ChainID
+	status  string
+}
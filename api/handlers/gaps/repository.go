@@ -0,0 +1,65 @@
+package gaps
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		sequenceGaps *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{db: db,
+		logger: logger.With(zap.String("module", "GapsRepository")),
+		collections: struct {
+			sequenceGaps *mongo.Collection
+		}{
+			sequenceGaps: db.Collection("sequenceGaps"),
+		},
+	}
+}
+
+// FindGaps get the sequence gaps that match the query, sorted by detection time.
+func (r *Repository) FindGaps(ctx context.Context, q *GapQuery, p *pagination.Pagination) ([]*SequenceGap, error) {
+	filter := bson.D{}
+	if q.chainID != nil {
+		filter = append(filter, bson.E{Key: "emitterChain", Value: *q.chainID})
+	}
+	if q.status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: q.status})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "detectedAt", Value: p.GetSortInt()}, {Key: "_id", Value: 1}}).
+		SetSkip(p.Skip).
+		SetLimit(p.Limit)
+	cur, err := r.collections.sequenceGaps.Find(ctx, filter, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Find command to get sequence gaps",
+			zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	gaps := []*SequenceGap{}
+	if err := cur.All(ctx, &gaps); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*SequenceGap",
+			zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return gaps, nil
+}
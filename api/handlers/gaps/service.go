@@ -0,0 +1,29 @@
+package gaps
+
+import (
+	"context"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo   *Repository
+	logger *zap.Logger
+}
+
+// NewService create a new Service.
+func NewService(repo *Repository, logger *zap.Logger) *Service {
+	return &Service{repo: repo, logger: logger.With(zap.String("module", "GapsService"))}
+}
+
+// FindGaps get the sequence gaps detected by the chain health monitor.
+// When status is empty, gaps of any status are returned.
+func (s *Service) FindGaps(ctx context.Context, chainID *sdk.ChainID, status string, p *pagination.Pagination) ([]*SequenceGap, error) {
+	if p == nil {
+		p = pagination.Default()
+	}
+	return s.repo.FindGaps(ctx, &GapQuery{chainID: chainID, status: status}, p)
+}
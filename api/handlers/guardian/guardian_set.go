@@ -5,13 +5,22 @@ import (
 
 	"github.com/certusone/wormhole/node/pkg/common"
 	eth_common "github.com/ethereum/go-ethereum/common"
-	"github.com/wormhole-foundation/wormhole-explorer/api/internal/config"
+	"github.com/wormhole-foundation/wormhole-explorer/api/config"
 )
 
+// ExpirationPeriod is the time a guardian set remains valid after it has been replaced
+// by a newer one, as enforced by the core contracts.
+const ExpirationPeriod = 24 * time.Hour
+
 // GuardianSet definition.
 type GuardianSet struct {
 	GstByIndex            []common.GuardianSet
 	ExpirationTimeByIndex []time.Time
+	// ActivationTimeByIndex holds the time each guardian set was installed, when it is known
+	// statically. A nil entry means the activation time must be looked up elsewhere.
+	ActivationTimeByIndex []*time.Time
+	// Names maps guardian keys to the name of the operator running the node.
+	Names map[eth_common.Address]string
 }
 
 // Get get guardianset config by enviroment.
@@ -37,6 +46,17 @@ func (gs GuardianSet) GetLatest() common.GuardianSet {
 	return gs.GstByIndex[len(gs.GstByIndex)-1]
 }
 
+// GetName get the name of the guardian that owns the key, or an empty string if it is unknown.
+func (gs GuardianSet) GetName(key eth_common.Address) string {
+	return gs.Names[key]
+}
+
+// activatedAt returns the activation time of a guardian set replaced at the given expiration time.
+func activatedAt(expiration time.Time) *time.Time {
+	t := expiration.Add(-ExpirationPeriod)
+	return &t
+}
+
 func getTestnetGuardianSet() GuardianSet {
 	const tenYears = time.Hour * 24 * 365 * 10
 	gs0TestValidUntil := time.Now().Add(tenYears)
@@ -49,6 +69,8 @@ func getTestnetGuardianSet() GuardianSet {
 	return GuardianSet{
 		GstByIndex:            []common.GuardianSet{gstest0},
 		ExpirationTimeByIndex: []time.Time{gs0TestValidUntil},
+		ActivationTimeByIndex: []*time.Time{nil},
+		Names:                 map[eth_common.Address]string{},
 	}
 }
 
@@ -146,5 +168,32 @@ func getMainnetGuardianSet() GuardianSet {
 	return GuardianSet{
 		GstByIndex:            []common.GuardianSet{gs0, gs1, gs2, gs3},
 		ExpirationTimeByIndex: []time.Time{gs0ValidUntil, gs1ValidUntil, gs2ValidUntil, gs3ValidUntil},
+		// gs0 and gs3 activation times are resolved from the first VAA signed by each set.
+		ActivationTimeByIndex: []*time.Time{nil, activatedAt(gs0ValidUntil), activatedAt(gs1ValidUntil), nil},
+		Names:                 mainnetGuardianNames,
 	}
 }
+
+var mainnetGuardianNames = map[eth_common.Address]string{
+	eth_common.HexToAddress("0x58CC3AE5C097b213cE3c81979e1B9f9570746AA5"): "Certus One",
+	eth_common.HexToAddress("0xfF6CB952589BDE862c25Ef4392132fb9D4A42157"): "Staked",
+	eth_common.HexToAddress("0x114De8460193bdf3A2fCf81f86a09765F4762fD1"): "Figment",
+	eth_common.HexToAddress("0x107A0086b32d7A0977926A205131d8731D39cbEB"): "ChainodeTech",
+	eth_common.HexToAddress("0x8C82B2fd82FaeD2711d59AF0F2499D16e726f6b2"): "Inotel",
+	eth_common.HexToAddress("0x11b39756C042441BE6D8650b69b54EbE715E2343"): "HashQuark",
+	eth_common.HexToAddress("0x54Ce5B4D348fb74B958e8966e2ec3dBd4958a7cd"): "ChainLayer",
+	eth_common.HexToAddress("0xeB5F7389Fa26941519f0863349C223b73a6DDEE7"): "DokiaCapital",
+	eth_common.HexToAddress("0x66B9590e1c41e0B226937bf9217D1d67Fd4E91F5"): "FTX",
+	eth_common.HexToAddress("0x15e7cAF07C4e3DC8e7C469f92C8Cd88FB8005a20"): "xLabs",
+	eth_common.HexToAddress("0x74a3bf913953D695260D88BC1aA25A4eeE363ef0"): "Forbole",
+	eth_common.HexToAddress("0x000aC0076727b35FBea2dAc28fEE5cCB0fEA768e"): "Staking Fund",
+	eth_common.HexToAddress("0xAF45Ced136b9D9e24903464AE889F5C8a723FC14"): "MoonletWallet",
+	eth_common.HexToAddress("0xf93124b7c738843CBB89E864c862c38cddCccF95"): "P2P Validator",
+	eth_common.HexToAddress("0xD2CC37A4dc036a8D232b48f62cDD4731412f4890"): "01node",
+	eth_common.HexToAddress("0xDA798F6896A3331F64b48c12D1D57Fd9cbe70811"): "MCF-V2-MAINNET",
+	eth_common.HexToAddress("0x71AA1BE1D36CaFE3867910F99C09e347899C19C3"): "Everstake",
+	eth_common.HexToAddress("0x8192b6E7387CCd768277c17DAb1b7a5027c0b3Cf"): "Chorus One",
+	eth_common.HexToAddress("0x178e21ad2E77AE06711549CFBB1f9c7a9d8096e8"): "syncnode",
+	eth_common.HexToAddress("0x5E1487F35515d02A92753504a8D75471b9f49EdB"): "Triton",
+	eth_common.HexToAddress("0x6FbEBc898F403E4773E95feB15E80C9A99c8348d"): "Staking Facilities",
+}
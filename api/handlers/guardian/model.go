@@ -0,0 +1,95 @@
+package guardian
+
+import (
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// GuardianSetDoc represent a guardian set with its guardians and validity period.
+type GuardianSetDoc struct {
+	Index uint32 `json:"index"`
+	// Keys is the list of guardians, in the order used for signature indexes.
+	Keys []GuardianKey `json:"keys"`
+	// ActivationTime is nil when it could not be determined.
+	ActivationTime *time.Time `json:"activationTime"`
+	// ExpirationTime is nil for the current guardian set.
+	ExpirationTime *time.Time `json:"expirationTime"`
+}
+
+// GuardianKey represent a guardian member of a guardian set.
+type GuardianKey struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+// GuardianStats represent the statistics of a guardian over a time span.
+type GuardianStats struct {
+	GuardianAddress string `json:"guardianAddress"`
+	Name            string `json:"name"`
+	// GuardianSetIndexes lists the guardian sets the guardian is a member of.
+	GuardianSetIndexes []uint32             `json:"guardianSetIndexes"`
+	From               time.Time            `json:"from"`
+	To                 time.Time            `json:"to"`
+	Chains             []ChainParticipation `json:"chains"`
+	// MedianLatencyMs is the median delay between the VAA timestamp and the indexing of
+	// the guardian's observation, computed over the most recent observations of the time span.
+	MedianLatencyMs *int64         `json:"medianLatencyMs"`
+	Heartbeat       *HeartbeatInfo `json:"heartbeat"`
+	// VersionHistory lists the node versions and features reported by the guardian during the
+	// time span, from the oldest to the newest, based on the heartbeats history samples.
+	VersionHistory []VersionPeriod `json:"versionHistory"`
+}
+
+// ChainParticipation represent the signing participation of a guardian on a chain.
+type ChainParticipation struct {
+	ChainID vaa.ChainID `json:"chainId"`
+	// Messages is the number of messages observed by any guardian.
+	Messages int64 `json:"messages"`
+	// Observations is the number of messages observed by the guardian.
+	Observations int64 `json:"observations"`
+	// ParticipationRate is Observations / Messages.
+	ParticipationRate float64 `json:"participationRate"`
+	MedianLatencyMs   *int64  `json:"medianLatencyMs"`
+}
+
+// HeartbeatInfo represent the last heartbeat sent by a guardian.
+type HeartbeatInfo struct {
+	NodeName      string     `json:"nodeName"`
+	Version       string     `json:"version"`
+	Features      []string   `json:"features"`
+	BootTimestamp time.Time  `json:"bootTimestamp"`
+	Timestamp     time.Time  `json:"timestamp"`
+	IndexedAt     *time.Time `json:"indexedAt"`
+}
+
+// VersionPeriod represent the time a guardian reported a node version and set of features.
+type VersionPeriod struct {
+	Version   string    `bson:"version" json:"version"`
+	Features  []string  `bson:"features" json:"features"`
+	FirstSeen time.Time `bson:"firstSeen" json:"firstSeen"`
+	LastSeen  time.Time `bson:"lastSeen" json:"lastSeen"`
+}
+
+// heartbeatDoc is the subset of the heartbeats document used by the guardian statistics.
+type heartbeatDoc struct {
+	BootTimestamp int64      `bson:"boottimestamp"`
+	Features      []string   `bson:"features"`
+	IndexedAt     *time.Time `bson:"indexedAt"`
+	NodeName      string     `bson:"nodename"`
+	Timestamp     int64      `bson:"timestamp"`
+	Version       string     `bson:"version"`
+}
+
+// chainCount is the result of counting documents grouped by chain.
+type chainCount struct {
+	ChainID vaa.ChainID `bson:"_id"`
+	Count   int64       `bson:"count"`
+}
+
+// observationLatency is the indexing time of an observation along with the timestamp of its VAA.
+type observationLatency struct {
+	ChainID      vaa.ChainID `bson:"emitterChain"`
+	IndexedAt    time.Time   `bson:"indexedAt"`
+	VaaTimestamp *time.Time  `bson:"vaaTimestamp"`
+}
@@ -0,0 +1,207 @@
+package guardian
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		vaas              *mongo.Collection
+		observations      *mongo.Collection
+		heartbeats        *mongo.Collection
+		heartbeatsHistory *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{db: db,
+		logger: logger.With(zap.String("module", "GuardianRepository")),
+		collections: struct {
+			vaas              *mongo.Collection
+			observations      *mongo.Collection
+			heartbeats        *mongo.Collection
+			heartbeatsHistory *mongo.Collection
+		}{
+			vaas:              db.Collection("vaas"),
+			observations:      db.Collection("observations"),
+			heartbeats:        db.Collection("heartbeats"),
+			heartbeatsHistory: db.Collection("heartbeatsHistory"),
+		},
+	}
+}
+
+// FindFirstVaaTimestamp get the timestamp of the oldest VAA signed by a guardian set.
+func (r *Repository) FindFirstVaaTimestamp(ctx context.Context, guardianSetIndex uint32) (*time.Time, error) {
+	var doc struct {
+		Timestamp *time.Time `bson:"timestamp"`
+	}
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "timestamp", Value: 1}}).
+		SetProjection(bson.D{{Key: "timestamp", Value: 1}})
+	err := r.collections.vaas.FindOne(ctx, bson.M{"guardianSetIndex": guardianSetIndex}, opts).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute FindOne command to get first vaa of guardian set",
+			zap.Error(err), zap.Uint32("guardianSetIndex", guardianSetIndex), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return doc.Timestamp, nil
+}
+
+// CountMessagesByChain count the distinct messages observed by any guardian since the given time, grouped by chain.
+func (r *Repository) CountMessagesByChain(ctx context.Context, from time.Time) ([]chainCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "indexedAt", Value: bson.D{{Key: "$gte", Value: from}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "chain", Value: "$emitterChain"}, {Key: "messageId", Value: "$messageId"}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.chain"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	return r.countByChain(ctx, pipeline, "")
+}
+
+// CountObservationsByChain count the observations sent by a guardian since the given time, grouped by chain.
+func (r *Repository) CountObservationsByChain(ctx context.Context, guardianAddr string, from time.Time) ([]chainCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "guardianAddr", Value: guardianAddr},
+			{Key: "indexedAt", Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$emitterChain"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	return r.countByChain(ctx, pipeline, guardianAddr)
+}
+
+func (r *Repository) countByChain(ctx context.Context, pipeline mongo.Pipeline, guardianAddr string) ([]chainCount, error) {
+	cur, err := r.collections.observations.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Aggregate command to count observations by chain",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	var counts []chainCount
+	if err := cur.All(ctx, &counts); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []chainCount",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return counts, nil
+}
+
+// FindObservationLatencies get the most recent observations of a guardian since the given time,
+// joined with the timestamp of the VAA they belong to.
+func (r *Repository) FindObservationLatencies(ctx context.Context, guardianAddr string, from time.Time, limit int64) ([]observationLatency, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "guardianAddr", Value: guardianAddr},
+			{Key: "indexedAt", Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "indexedAt", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "vaas"},
+			{Key: "localField", Value: "messageId"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "vaas"},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "emitterChain", Value: 1},
+			{Key: "indexedAt", Value: 1},
+			{Key: "vaaTimestamp", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$vaas.timestamp", 0}}}},
+		}}},
+	}
+	cur, err := r.collections.observations.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Aggregate command to get observation latencies",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	var latencies []observationLatency
+	if err := cur.All(ctx, &latencies); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []observationLatency",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return latencies, nil
+}
+
+// FindHeartbeat get the last heartbeat of a guardian.
+func (r *Repository) FindHeartbeat(ctx context.Context, guardianAddr string) (*heartbeatDoc, error) {
+	var hb heartbeatDoc
+	err := r.collections.heartbeats.FindOne(ctx, bson.M{"_id": guardianAddr}).Decode(&hb)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute FindOne command to get heartbeat",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return &hb, nil
+}
+
+// FindVersionHistory get the node versions and features reported by a guardian since the given time,
+// with the first and last heartbeat sample of each one, from the oldest to the newest.
+func (r *Repository) FindVersionHistory(ctx context.Context, guardianAddr string, from time.Time) ([]VersionPeriod, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "guardianAddr", Value: guardianAddr},
+			{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "version", Value: "$version"}, {Key: "features", Value: "$features"}}},
+			{Key: "firstSeen", Value: bson.D{{Key: "$min", Value: "$timestamp"}}},
+			{Key: "lastSeen", Value: bson.D{{Key: "$max", Value: "$timestamp"}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "version", Value: "$_id.version"},
+			{Key: "features", Value: "$_id.features"},
+			{Key: "firstSeen", Value: 1},
+			{Key: "lastSeen", Value: 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "firstSeen", Value: 1}}}},
+	}
+	cur, err := r.collections.heartbeatsHistory.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Aggregate command to get version history",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	history := make([]VersionPeriod, 0)
+	if err := cur.All(ctx, &history); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []VersionPeriod",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return history, nil
+}
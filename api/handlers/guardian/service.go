@@ -0,0 +1,211 @@
+package guardian
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/stats"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// maxLatencySamples is the maximum number of observations used to compute latency medians.
+const maxLatencySamples = 1000
+
+// Service definition.
+type Service struct {
+	repo   *Repository
+	gs     GuardianSet
+	logger *zap.Logger
+
+	// activationTimes caches the activation times resolved from the database,
+	// which never change once a guardian set has signed its first VAA.
+	mu              sync.Mutex
+	activationTimes map[uint32]time.Time
+}
+
+// NewService create a new Service.
+func NewService(repo *Repository, p2pNetwork string, logger *zap.Logger) *Service {
+	return &Service{
+		repo:            repo,
+		gs:              GetByEnv(p2pNetwork),
+		logger:          logger.With(zap.String("module", "GuardianService")),
+		activationTimes: make(map[uint32]time.Time),
+	}
+}
+
+// GetGuardianSets get every guardian set, from the oldest to the current one.
+func (s *Service) GetGuardianSets(ctx context.Context) ([]*GuardianSetDoc, error) {
+	activations := make([]*time.Time, len(s.gs.GstByIndex))
+	for i := range s.gs.GstByIndex {
+		t, err := s.getActivationTime(ctx, uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		activations[i] = t
+	}
+
+	sets := make([]*GuardianSetDoc, 0, len(s.gs.GstByIndex))
+	for i, set := range s.gs.GstByIndex {
+		keys := make([]GuardianKey, 0, len(set.Keys))
+		for _, k := range set.Keys {
+			keys = append(keys, GuardianKey{Address: k.Hex(), Name: s.gs.GetName(k)})
+		}
+		sets = append(sets, &GuardianSetDoc{
+			Index:          set.Index,
+			Keys:           keys,
+			ActivationTime: activations[i],
+			ExpirationTime: expirationTime(activations, i),
+		})
+	}
+	return sets, nil
+}
+
+// getActivationTime get the activation time of a guardian set, either from the static
+// configuration or from the first VAA signed by the set.
+func (s *Service) getActivationTime(ctx context.Context, index uint32) (*time.Time, error) {
+	if t := s.gs.ActivationTimeByIndex[index]; t != nil {
+		return t, nil
+	}
+
+	s.mu.Lock()
+	t, ok := s.activationTimes[index]
+	s.mu.Unlock()
+	if ok {
+		return &t, nil
+	}
+
+	first, err := s.repo.FindFirstVaaTimestamp(ctx, index)
+	if errors.Is(err, errs.ErrNotFound) || (err == nil && first == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.activationTimes[index] = *first
+	s.mu.Unlock()
+	return first, nil
+}
+
+// expirationTime returns the expiration time of the guardian set at position i, which is
+// ExpirationPeriod after the next guardian set was activated.
+func expirationTime(activations []*time.Time, i int) *time.Time {
+	if i+1 >= len(activations) || activations[i+1] == nil {
+		return nil
+	}
+	t := activations[i+1].Add(ExpirationPeriod)
+	return &t
+}
+
+// GetGuardianStats get the statistics of a guardian since the given time.
+func (s *Service) GetGuardianStats(ctx context.Context, guardian eth_common.Address, from time.Time) (*GuardianStats, error) {
+	var indexes []uint32
+	for _, set := range s.gs.GstByIndex {
+		if _, ok := set.KeyIndex(guardian); ok {
+			indexes = append(indexes, set.Index)
+		}
+	}
+	if len(indexes) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	addr := guardian.Hex()
+	messages, err := s.repo.CountMessagesByChain(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	observations, err := s.repo.CountObservationsByChain(ctx, addr, from)
+	if err != nil {
+		return nil, err
+	}
+	latencies, err := s.repo.FindObservationLatencies(ctx, addr, from, maxLatencySamples)
+	if err != nil {
+		return nil, err
+	}
+
+	var heartbeat *HeartbeatInfo
+	hb, err := s.repo.FindHeartbeat(ctx, addr)
+	switch {
+	case err == nil:
+		heartbeat = &HeartbeatInfo{
+			NodeName:      hb.NodeName,
+			Version:       hb.Version,
+			Features:      hb.Features,
+			BootTimestamp: time.Unix(0, hb.BootTimestamp).UTC(),
+			Timestamp:     time.Unix(0, hb.Timestamp).UTC(),
+			IndexedAt:     hb.IndexedAt,
+		}
+	case !errors.Is(err, errs.ErrNotFound):
+		return nil, err
+	}
+	history, err := s.repo.FindVersionHistory(ctx, addr, from)
+	if err != nil {
+		return nil, err
+	}
+
+	chains, median := participation(messages, observations, latencies)
+	return &GuardianStats{
+		GuardianAddress:    addr,
+		Name:               s.gs.GetName(guardian),
+		GuardianSetIndexes: indexes,
+		From:               from,
+		To:                 time.Now(),
+		Chains:             chains,
+		MedianLatencyMs:    median,
+		Heartbeat:          heartbeat,
+		VersionHistory:     history,
+	}, nil
+}
+
+// participation merges the message and observation counts per chain and computes the median
+// observation latency per chain and overall.
+func participation(messages, observations []chainCount, latencies []observationLatency) ([]ChainParticipation, *int64) {
+	byChain := make(map[vaa.ChainID]*ChainParticipation)
+	for _, m := range messages {
+		byChain[m.ChainID] = &ChainParticipation{ChainID: m.ChainID, Messages: m.Count}
+	}
+	for _, o := range observations {
+		p, ok := byChain[o.ChainID]
+		if !ok {
+			p = &ChainParticipation{ChainID: o.ChainID}
+			byChain[o.ChainID] = p
+		}
+		p.Observations = o.Count
+	}
+
+	all := make([]int64, 0, len(latencies))
+	latenciesByChain := make(map[vaa.ChainID][]int64)
+	for _, l := range latencies {
+		if l.VaaTimestamp == nil {
+			continue
+		}
+		ms := l.IndexedAt.Sub(*l.VaaTimestamp).Milliseconds()
+		all = append(all, ms)
+		latenciesByChain[l.ChainID] = append(latenciesByChain[l.ChainID], ms)
+	}
+
+	chains := make([]ChainParticipation, 0, len(byChain))
+	for chainID, p := range byChain {
+		if p.Messages > 0 {
+			p.ParticipationRate = float64(p.Observations) / float64(p.Messages)
+		}
+		if m, ok := stats.Median(latenciesByChain[chainID]); ok {
+			p.MedianLatencyMs = &m
+		}
+		chains = append(chains, *p)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].ChainID < chains[j].ChainID })
+
+	var overall *int64
+	if m, ok := stats.Median(all); ok {
+		overall = &m
+	}
+	return chains, overall
+}
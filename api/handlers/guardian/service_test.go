@@ -0,0 +1,53 @@
+package guardian
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestExpirationTime(t *testing.T) {
+	t1 := time.Date(2022, 4, 20, 18, 35, 3, 0, time.UTC)
+	activations := []*time.Time{nil, &t1, nil}
+
+	assert.Equal(t, t1.Add(ExpirationPeriod), *expirationTime(activations, 0))
+	assert.Nil(t, expirationTime(activations, 1))
+	assert.Nil(t, expirationTime(activations, 2))
+}
+
+func TestMainnetActivationTimes(t *testing.T) {
+	gs := getMainnetGuardianSet()
+	for i := 1; i < 3; i++ {
+		assert.Equal(t, gs.ExpirationTimeByIndex[i-1], *expirationTime(gs.ActivationTimeByIndex, i-1))
+	}
+	for _, set := range gs.GstByIndex {
+		for _, k := range set.Keys {
+			assert.NotEmpty(t, gs.GetName(k), k.Hex())
+		}
+	}
+}
+
+func TestParticipation(t *testing.T) {
+	vaaTime := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	messages := []chainCount{{ChainID: vaa.ChainIDEthereum, Count: 10}, {ChainID: vaa.ChainIDSolana, Count: 4}}
+	observations := []chainCount{{ChainID: vaa.ChainIDSolana, Count: 2}, {ChainID: vaa.ChainIDEthereum, Count: 10}}
+	latencies := []observationLatency{
+		{ChainID: vaa.ChainIDSolana, IndexedAt: vaaTime.Add(2 * time.Second), VaaTimestamp: &vaaTime},
+		{ChainID: vaa.ChainIDEthereum, IndexedAt: vaaTime.Add(10 * time.Second), VaaTimestamp: &vaaTime},
+		{ChainID: vaa.ChainIDEthereum, IndexedAt: vaaTime.Add(20 * time.Second), VaaTimestamp: &vaaTime},
+		{ChainID: vaa.ChainIDEthereum, IndexedAt: vaaTime},
+	}
+
+	chains, overall := participation(messages, observations, latencies)
+
+	assert.Len(t, chains, 2)
+	assert.Equal(t, vaa.ChainIDSolana, chains[0].ChainID)
+	assert.Equal(t, 0.5, chains[0].ParticipationRate)
+	assert.Equal(t, int64(2000), *chains[0].MedianLatencyMs)
+	assert.Equal(t, vaa.ChainIDEthereum, chains[1].ChainID)
+	assert.Equal(t, 1.0, chains[1].ParticipationRate)
+	assert.Equal(t, int64(15000), *chains[1].MedianLatencyMs)
+	assert.Equal(t, int64(10000), *overall)
+}
@@ -1,6 +1,10 @@
 package heartbeats
 
-import "time"
+import (
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
 
 // HeartbeatDoc represent an heartbeat document.
 type HeartbeatDoc struct {
@@ -24,3 +28,65 @@ type HeartbeatNetwork struct {
 	ContractAddress string `bson:"contractaddress" json:"contractAddress"`
 	ErrorCount      int64  `bson:"errorcount" json:"errorCount"`
 }
+
+// HeartbeatHistoryDoc represent a sample of the heartbeats of a guardian.
+type HeartbeatHistoryDoc struct {
+	Timestamp     time.Time                 `bson:"_id" json:"timestamp"`
+	NodeName      string                    `bson:"nodeName" json:"nodeName"`
+	Version       string                    `bson:"version" json:"version"`
+	Features      []string                  `bson:"features" json:"features"`
+	BootTimestamp int64                     `bson:"bootTimestamp" json:"bootTimestamp"`
+	Networks      []HeartbeatNetworkHistory `bson:"networks" json:"networks"`
+}
+
+// HeartbeatNetworkHistory definition.
+type HeartbeatNetworkHistory struct {
+	ID              vaa.ChainID `bson:"id" json:"id"`
+	Height          int64       `bson:"height" json:"height"`
+	ContractAddress string      `bson:"contractAddress" json:"contractAddress"`
+	ErrorCount      int64       `bson:"errorCount" json:"errorCount"`
+}
+
+// GuardianUptime represent the uptime of a guardian over a time span.
+type GuardianUptime struct {
+	GuardianAddress string `json:"guardianAddress"`
+	NodeName        string `json:"nodeName"`
+	// Uptime is the fraction of sample intervals in which the guardian sent a heartbeat.
+	Uptime float64       `json:"uptime"`
+	Chains []ChainUptime `json:"chains"`
+}
+
+// ChainUptime represent the state of the connection of a guardian to a chain.
+type ChainUptime struct {
+	ChainID vaa.ChainID `json:"chainId"`
+	// Height is the last height reported by the guardian.
+	Height int64 `json:"height"`
+	// MajorityHeight is the median of the last heights reported by all the guardians.
+	MajorityHeight int64 `json:"majorityHeight"`
+	// Lag is the number of blocks the guardian is behind MajorityHeight.
+	Lag int64 `json:"lag"`
+	// Uptime is the fraction of sample intervals in which the reported height advanced.
+	Uptime float64 `json:"uptime"`
+	// LastAdvancedAt is the start of the last sample interval in which the reported height advanced.
+	LastAdvancedAt *time.Time `json:"lastAdvancedAt"`
+	// Stalled is true when the reported height has not advanced in the last sample intervals.
+	Stalled bool `json:"stalled"`
+	// Lagging is true when the guardian is behind the majority by at least the number of blocks
+	// the majority advanced in the last sample intervals.
+	Lagging bool `json:"lagging"`
+}
+
+// guardianBucket is the number of heartbeats of a guardian in a sample interval.
+type guardianBucket struct {
+	GuardianAddr string    `bson:"guardianAddr"`
+	NodeName     string    `bson:"nodeName"`
+	Bucket       time.Time `bson:"bucket"`
+}
+
+// chainHeightBucket is the maximum height of a chain reported by a guardian in a sample interval.
+type chainHeightBucket struct {
+	GuardianAddr string      `bson:"guardianAddr"`
+	ChainID      vaa.ChainID `bson:"chainId"`
+	Bucket       time.Time   `bson:"bucket"`
+	Height       int64       `bson:"height"`
+}
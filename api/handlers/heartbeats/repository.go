@@ -3,6 +3,7 @@ package heartbeats
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -15,15 +16,22 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		heartbeats *mongo.Collection
+		heartbeats        *mongo.Collection
+		heartbeatsHistory *mongo.Collection
 	}
 }
 
 // NewRepository create a new Repository.
 func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
-		logger:      logger.With(zap.String("module", "HeartbeatsRepository")),
-		collections: struct{ heartbeats *mongo.Collection }{heartbeats: db.Collection("heartbeats")},
+		logger: logger.With(zap.String("module", "HeartbeatsRepository")),
+		collections: struct {
+			heartbeats        *mongo.Collection
+			heartbeatsHistory *mongo.Collection
+		}{
+			heartbeats:        db.Collection("heartbeats"),
+			heartbeatsHistory: db.Collection("heartbeatsHistory"),
+		},
 	}
 }
 
@@ -47,3 +55,118 @@ func (r *Repository) FindByIDs(ctx context.Context, ids []string) ([]*HeartbeatD
 	}
 	return heartbeats, err
 }
+
+// dateTrunc returns a expression that truncates the timestamp of a heartbeat sample to the sample interval.
+func dateTrunc(interval time.Duration) bson.D {
+	return bson.D{{Key: "$dateTrunc", Value: bson.D{
+		{Key: "date", Value: "$timestamp"},
+		{Key: "unit", Value: "minute"},
+		{Key: "binSize", Value: int64(interval.Minutes())},
+	}}}
+}
+
+// FindHistory get the heartbeats of a guardian since the given time, keeping the last heartbeat of each interval.
+func (r *Repository) FindHistory(ctx context.Context, guardianAddr string, from time.Time, interval time.Duration) ([]*HeartbeatHistoryDoc, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "guardianAddr", Value: guardianAddr},
+			{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: dateTrunc(interval)},
+			{Key: "nodeName", Value: bson.D{{Key: "$last", Value: "$nodeName"}}},
+			{Key: "version", Value: bson.D{{Key: "$last", Value: "$version"}}},
+			{Key: "features", Value: bson.D{{Key: "$last", Value: "$features"}}},
+			{Key: "bootTimestamp", Value: bson.D{{Key: "$last", Value: "$bootTimestamp"}}},
+			{Key: "networks", Value: bson.D{{Key: "$last", Value: "$networks"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	cur, err := r.collections.heartbeatsHistory.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Aggregate command to get heartbeats history",
+			zap.Error(err), zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	history := make([]*HeartbeatHistoryDoc, 0)
+	err = cur.All(ctx, &history)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding cursor to []*HeartbeatHistoryDoc", zap.Error(err),
+			zap.String("guardianAddr", guardianAddr), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return history, nil
+}
+
+// findGuardianBuckets get the sample intervals since the given time in which each guardian sent a heartbeat.
+func (r *Repository) findGuardianBuckets(ctx context.Context, from time.Time, interval time.Duration) ([]guardianBucket, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: from}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "guardianAddr", Value: "$guardianAddr"}, {Key: "bucket", Value: dateTrunc(interval)}}},
+			{Key: "nodeName", Value: bson.D{{Key: "$last", Value: "$nodeName"}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "guardianAddr", Value: "$_id.guardianAddr"},
+			{Key: "bucket", Value: "$_id.bucket"},
+			{Key: "nodeName", Value: 1},
+		}}},
+	}
+	var buckets []guardianBucket
+	if err := r.aggregateHistory(ctx, pipeline, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// findChainHeightBuckets get the maximum height of each chain reported by each guardian in every sample interval
+// since the given time.
+func (r *Repository) findChainHeightBuckets(ctx context.Context, from time.Time, interval time.Duration) ([]chainHeightBucket, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: from}}}}}},
+		{{Key: "$unwind", Value: "$networks"}},
+		// chains without a height (e.g. not connected to the guardian) are not tracked.
+		{{Key: "$match", Value: bson.D{{Key: "networks.height", Value: bson.D{{Key: "$gt", Value: 0}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "guardianAddr", Value: "$guardianAddr"},
+				{Key: "chainId", Value: "$networks.id"},
+				{Key: "bucket", Value: dateTrunc(interval)},
+			}},
+			{Key: "height", Value: bson.D{{Key: "$max", Value: "$networks.height"}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "guardianAddr", Value: "$_id.guardianAddr"},
+			{Key: "chainId", Value: "$_id.chainId"},
+			{Key: "bucket", Value: "$_id.bucket"},
+			{Key: "height", Value: 1},
+		}}},
+	}
+	var buckets []chainHeightBucket
+	if err := r.aggregateHistory(ctx, pipeline, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (r *Repository) aggregateHistory(ctx context.Context, pipeline mongo.Pipeline, results interface{}) error {
+	cur, err := r.collections.heartbeatsHistory.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute Aggregate command to get heartbeats history",
+			zap.Error(err), zap.String("requestID", requestID))
+		return errors.WithStack(err)
+	}
+	if err := cur.All(ctx, results); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed decoding heartbeats history cursor", zap.Error(err), zap.String("requestID", requestID))
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -2,10 +2,18 @@ package heartbeats
 
 import (
 	"context"
+	"sort"
+	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/stats"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+// stallIntervals is the number of sample intervals without height changes after which
+// a chain is considered stalled for a guardian.
+const stallIntervals = 2
+
 // Service definition.
 type Service struct {
 	repo   *Repository
@@ -21,3 +29,148 @@ func NewService(dao *Repository, logger *zap.Logger) *Service {
 func (s *Service) GetHeartbeatsByIds(ctx context.Context, heartbeatsIDs []string) ([]*HeartbeatDoc, error) {
 	return s.repo.FindByIDs(ctx, heartbeatsIDs)
 }
+
+// GetHistory get the heartbeats history of a guardian for a time span (1d, 1w or 1mo).
+// The history is downsampled to one heartbeat per sample interval.
+func (s *Service) GetHistory(ctx context.Context, guardianAddr string, timeSpan string) ([]*HeartbeatHistoryDoc, error) {
+	span, interval := sampling(timeSpan)
+	return s.repo.FindHistory(ctx, guardianAddr, time.Now().Add(-span), interval)
+}
+
+// GetUptime get the uptime of every guardian and of their connections to each chain for a time span (1d, 1w or 1mo).
+func (s *Service) GetUptime(ctx context.Context, timeSpan string) ([]*GuardianUptime, error) {
+	span, interval := sampling(timeSpan)
+	to := time.Now()
+	from := to.Add(-span)
+
+	guardians, err := s.repo.findGuardianBuckets(ctx, from, interval)
+	if err != nil {
+		return nil, err
+	}
+	heights, err := s.repo.findChainHeightBuckets(ctx, from, interval)
+	if err != nil {
+		return nil, err
+	}
+	return computeUptime(span, interval, guardians, heights), nil
+}
+
+// sampling returns the duration of a time span and the sample interval used to downsample it.
+func sampling(timeSpan string) (time.Duration, time.Duration) {
+	switch timeSpan {
+	case "1w":
+		return 7 * 24 * time.Hour, 30 * time.Minute
+	case "1mo":
+		return 30 * 24 * time.Hour, 2 * time.Hour
+	default:
+		return 24 * time.Hour, 5 * time.Minute
+	}
+}
+
+type chainKey struct {
+	guardianAddr string
+	chainID      vaa.ChainID
+}
+
+// computeUptime computes the uptime of the guardians from the sample intervals in which they sent
+// heartbeats and the heights they reported in each interval.
+func computeUptime(span, interval time.Duration, guardians []guardianBucket, heights []chainHeightBucket) []*GuardianUptime {
+	expected := float64(span / interval)
+
+	// guardian uptime.
+	byGuardian := make(map[string]*GuardianUptime)
+	counts := make(map[string]int)
+	for _, g := range guardians {
+		u, ok := byGuardian[g.GuardianAddr]
+		if !ok {
+			u = &GuardianUptime{GuardianAddress: g.GuardianAddr, Chains: make([]ChainUptime, 0)}
+			byGuardian[g.GuardianAddr] = u
+		}
+		if g.NodeName != "" {
+			u.NodeName = g.NodeName
+		}
+		counts[g.GuardianAddr]++
+	}
+	for addr, u := range byGuardian {
+		u.Uptime = ratio(float64(counts[addr]), expected)
+	}
+
+	// group the heights by guardian and chain, sorted by interval.
+	series := make(map[chainKey][]chainHeightBucket)
+	var latest time.Time
+	for _, h := range heights {
+		k := chainKey{guardianAddr: h.GuardianAddr, chainID: h.ChainID}
+		series[k] = append(series[k], h)
+		if h.Bucket.After(latest) {
+			latest = h.Bucket
+		}
+	}
+	windowStart := latest.Add(-stallIntervals * interval)
+
+	lastHeights := make(map[vaa.ChainID][]int64)
+	advances := make(map[vaa.ChainID][]int64)
+	chains := make(map[chainKey]*ChainUptime)
+	for k, rows := range series {
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Bucket.Before(rows[j].Bucket) })
+
+		c := &ChainUptime{ChainID: k.chainID, Height: rows[len(rows)-1].Height}
+		var advanced int
+		var windowHeight *int64
+		for i, r := range rows {
+			if i > 0 && r.Height > rows[i-1].Height {
+				advanced++
+				bucket := r.Bucket
+				c.LastAdvancedAt = &bucket
+			}
+			if !r.Bucket.After(windowStart) {
+				height := r.Height
+				windowHeight = &height
+			}
+		}
+		c.Uptime = ratio(float64(advanced), expected-1)
+		// only series that started before the stall window can be considered stalled.
+		if windowHeight != nil {
+			c.Stalled = c.LastAdvancedAt == nil || !c.LastAdvancedAt.After(windowStart)
+			advances[k.chainID] = append(advances[k.chainID], c.Height-*windowHeight)
+		}
+		lastHeights[k.chainID] = append(lastHeights[k.chainID], c.Height)
+		chains[k] = c
+	}
+
+	// compare each guardian with the majority.
+	for k, c := range chains {
+		c.MajorityHeight, _ = stats.Median(lastHeights[k.chainID])
+		if c.MajorityHeight > c.Height {
+			c.Lag = c.MajorityHeight - c.Height
+		}
+		advance, _ := stats.Median(advances[k.chainID])
+		c.Lagging = c.Lag > 0 && c.Lag >= advance
+
+		u, ok := byGuardian[k.guardianAddr]
+		if !ok {
+			u = &GuardianUptime{GuardianAddress: k.guardianAddr, Chains: make([]ChainUptime, 0)}
+			byGuardian[k.guardianAddr] = u
+		}
+		u.Chains = append(u.Chains, *c)
+	}
+
+	result := make([]*GuardianUptime, 0, len(byGuardian))
+	for _, u := range byGuardian {
+		sort.Slice(u.Chains, func(i, j int) bool { return u.Chains[i].ChainID < u.Chains[j].ChainID })
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].NodeName != result[j].NodeName {
+			return result[i].NodeName < result[j].NodeName
+		}
+		return result[i].GuardianAddress < result[j].GuardianAddress
+	})
+	return result
+}
+
+// ratio returns n / total, capped to 1.
+func ratio(n, total float64) float64 {
+	if total <= 0 || n >= total {
+		return 1
+	}
+	return n / total
+}
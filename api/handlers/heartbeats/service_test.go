@@ -0,0 +1,64 @@
+package heartbeats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestComputeUptime(t *testing.T) {
+	const interval = 5 * time.Minute
+	start := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	bucket := func(i int) time.Time { return start.Add(time.Duration(i) * interval) }
+
+	var guardians []guardianBucket
+	var heights []chainHeightBucket
+	for i := 0; i < 6; i++ {
+		// guardian A is healthy.
+		guardians = append(guardians, guardianBucket{GuardianAddr: "A", NodeName: "a", Bucket: bucket(i)})
+		heights = append(heights, chainHeightBucket{GuardianAddr: "A", ChainID: vaa.ChainIDEthereum, Bucket: bucket(i), Height: int64(100 + 10*i)})
+		// guardian B stops advancing after the second interval.
+		guardians = append(guardians, guardianBucket{GuardianAddr: "B", NodeName: "b", Bucket: bucket(i)})
+		heights = append(heights, chainHeightBucket{GuardianAddr: "B", ChainID: vaa.ChainIDEthereum, Bucket: bucket(i), Height: int64(100 + 10*stalledAt(i, 2))})
+		// guardian C only sent half of the heartbeats.
+		if i%2 == 0 {
+			guardians = append(guardians, guardianBucket{GuardianAddr: "C", NodeName: "c", Bucket: bucket(i)})
+			heights = append(heights, chainHeightBucket{GuardianAddr: "C", ChainID: vaa.ChainIDEthereum, Bucket: bucket(i), Height: int64(100 + 10*i)})
+		}
+	}
+
+	uptimes := computeUptime(6*interval, interval, guardians, heights)
+
+	assert.Len(t, uptimes, 3)
+	a, b, c := uptimes[0], uptimes[1], uptimes[2]
+
+	assert.Equal(t, 1.0, a.Uptime)
+	assert.Len(t, a.Chains, 1)
+	assert.Equal(t, int64(150), a.Chains[0].Height)
+	assert.Equal(t, int64(140), a.Chains[0].MajorityHeight)
+	assert.Equal(t, 1.0, a.Chains[0].Uptime)
+	assert.False(t, a.Chains[0].Stalled)
+	assert.False(t, a.Chains[0].Lagging)
+
+	assert.Equal(t, 1.0, b.Uptime)
+	assert.Equal(t, int64(120), b.Chains[0].Height)
+	assert.Equal(t, int64(20), b.Chains[0].Lag)
+	assert.Equal(t, bucket(2), *b.Chains[0].LastAdvancedAt)
+	assert.True(t, b.Chains[0].Stalled)
+	assert.True(t, b.Chains[0].Lagging)
+
+	assert.Equal(t, 0.5, c.Uptime)
+	assert.Equal(t, int64(140), c.Chains[0].Height)
+	assert.Equal(t, int64(0), c.Chains[0].Lag)
+	assert.False(t, c.Chains[0].Stalled)
+	assert.False(t, c.Chains[0].Lagging)
+}
+
+func stalledAt(i, stall int) int {
+	if i < stall {
+		return i
+	}
+	return stall
+}
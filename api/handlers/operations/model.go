@@ -10,6 +10,8 @@ import (
 type OperationDto struct {
 	ID                     string                  `bson:"_id"`
 	TxHash                 string                  `bson:"txHash"`
+	Status                 string                  `bson:"status"`
+	Timestamp              *time.Time              `bson:"timestamp"`
 	Symbol                 string                  `bson:"symbol"`
 	UsdAmount              string                  `bson:"usdAmount"`
 	TokenAmount            string                  `bson:"tokenAmount"`
@@ -18,6 +20,21 @@ type OperationDto struct {
 	DestinationTx          *DestinationTx          `bson:"destinationTx" json:"destinationTx"`
 	Payload                map[string]any          `bson:"payload"`
 	StandardizedProperties *StandardizedProperties `bson:"standardizedProperties"`
+	Lifecycle              *Lifecycle              `bson:"lifecycle"`
+}
+
+// Lifecycle represents the states an operation went through, it is computed by the projector.
+type Lifecycle struct {
+	State       string                `bson:"state" json:"state"`
+	StartedAt   *time.Time            `bson:"startedAt" json:"startedAt"`
+	EnteredAt   *time.Time            `bson:"enteredAt" json:"enteredAt"`
+	Transitions []LifecycleTransition `bson:"transitions" json:"transitions"`
+}
+
+// LifecycleTransition represents the time an operation entered a state of its lifecycle.
+type LifecycleTransition struct {
+	State string     `bson:"state" json:"state"`
+	At    *time.Time `bson:"at" json:"at"`
 }
 
 // StandardizedProperties represents the standardized properties of a operation.
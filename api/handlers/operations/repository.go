@@ -2,15 +2,18 @@ package operations
 
 import (
 	"context"
-	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -19,9 +22,7 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		vaas               *mongo.Collection
-		parsedVaa          *mongo.Collection
-		globalTransactions *mongo.Collection
+		operations *mongo.Collection
 	}
 }
 
@@ -30,13 +31,9 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "OperationRepository")),
 		collections: struct {
-			vaas               *mongo.Collection
-			parsedVaa          *mongo.Collection
-			globalTransactions *mongo.Collection
+			operations *mongo.Collection
 		}{
-			vaas:               db.Collection("vaas"),
-			parsedVaa:          db.Collection("parsedVaa"),
-			globalTransactions: db.Collection("globalTransactions"),
+			operations: db.Collection("operations"),
 		},
 	}
 }
@@ -44,70 +41,27 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 // FindById returns the operations for the given chainID/emitter/seq.
 func (r *Repository) FindById(ctx context.Context, id string) (*OperationDto, error) {
 
-	var pipeline mongo.Pipeline
-
-	// filter vaas by id
-	pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "_id", Value: id}}}})
-
-	// lookup vaas
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "vaas"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "vaas"}}}})
-
-	// lookup globalTransactions
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "globalTransactions"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}})
-
-	// lookup transferPrices
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "transferPrices"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "transferPrices"}}}})
-
-	// lookup parsedVaa
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "parsedVaa"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "parsedVaa"}}}})
-
-	// add fields
-	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.D{
-		{Key: "payload", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$parsedVaa.parsedPayload", 0}}}},
-		{Key: "vaa", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$vaas", 0}}}},
-		{Key: "standardizedProperties", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$parsedVaa.standardizedProperties", 0}}}},
-		{Key: "symbol", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.symbol", 0}}}},
-		{Key: "usdAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.usdAmount", 0}}}},
-		{Key: "tokenAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.tokenAmount", 0}}}},
-	}}})
-
-	// unset
-	pipeline = append(pipeline, bson.D{{Key: "$unset", Value: bson.A{"transferPrices", "parsedVaa"}}})
-
-	// Execute the aggregation pipeline
-	cur, err := r.collections.globalTransactions.Aggregate(ctx, pipeline)
-	if err != nil {
-		r.logger.Error("failed execute aggregation pipeline", zap.Error(err))
-		return nil, err
+	// the operation is only listed once its origin transaction is known.
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "originTx", Value: bson.M{"$exists": true}},
 	}
 
-	// Read results from cursor
-	var operations []*OperationDto
-	err = cur.All(ctx, &operations)
+	var operation OperationDto
+	err := r.collections.operations.FindOne(ctx, filter).Decode(&operation)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.ErrNotFound
+	}
 	if err != nil {
-		r.logger.Error("failed to decode cursor", zap.Error(err))
+		r.logger.Error("failed to find operation", zap.String("id", id), zap.Error(err))
 		return nil, err
 	}
 
-	// Check if there is only one operation
-	if len(operations) > 1 {
-		r.logger.Error("invalid number of operations", zap.Int("count", len(operations)))
-		return nil, fmt.Errorf("invalid number of operations")
-	}
-
-	if len(operations) == 0 {
-		return nil, errors.ErrNotFound
-	}
-
-	return operations[0], nil
-}
-
-type mongoID struct {
-	Id string `bson:"_id"`
+	return &operation, nil
 }
 
-// findOperationsIdByAddressOrTxHash returns all operations filtered by address or txHash.
-func findOperationsIdByAddressOrTxHash(ctx context.Context, db *mongo.Database, q string, pagination *pagination.Pagination) ([]string, error) {
+// filterOperationsByAddressOrTxHash returns the filter of the operations that match an address or txHash.
+func filterOperationsByAddressOrTxHash(q string) bson.D {
 	qHexa := strings.ToLower(q)
 	if !utils.StartsWith0x(q) {
 		qHexa = "0x" + strings.ToLower(qHexa)
@@ -122,44 +76,13 @@ func findOperationsIdByAddressOrTxHash(ctx context.Context, db *mongo.Database,
 		qHigherWith0X = "0x" + strings.ToUpper(qHigher)
 	}
 
-	matchGlobalTransactions := bson.D{{Key: "$match", Value: bson.D{{Key: "$or", Value: bson.A{
-		bson.D{{Key: "originTx.from", Value: bson.M{"$eq": qHexa}}},
-		bson.D{{Key: "originTx.from", Value: bson.M{"$eq": q}}},
-		bson.D{{Key: "originTx.nativeTxHash", Value: bson.M{"$eq": qHexa}}},
-		bson.D{{Key: "originTx.nativeTxHash", Value: bson.M{"$eq": q}}},
-		bson.D{{Key: "originTx.attribute.value.originTxHash", Value: bson.M{"$eq": qHexa}}},
-		bson.D{{Key: "originTx.attribute.value.originTxHash", Value: bson.M{"$eq": q}}},
-		bson.D{{Key: "destinationTx.txHash", Value: bson.M{"$eq": q}}},
-		bson.D{{Key: "destinationTx.txHash", Value: bson.M{"$eq": qLower}}},
-		bson.D{{Key: "destinationTx.txHash", Value: bson.M{"$eq": qHigher}}},
-		bson.D{{Key: "destinationTx.txHash", Value: bson.M{"$eq": qLowerWith0X}}},
-		bson.D{{Key: "destinationTx.txHash", Value: bson.M{"$eq": qHigherWith0X}}},
-	}}}}}
-
-	matchParsedVaa := bson.D{{Key: "$match", Value: bson.D{{Key: "$or", Value: bson.A{
-		bson.D{{Key: "standardizedProperties.toAddress", Value: bson.M{"$eq": qHexa}}},
-		bson.D{{Key: "standardizedProperties.toAddress", Value: bson.M{"$eq": q}}},
-	}}}}}
-
-	globalTransactionFilter := bson.D{{Key: "$unionWith", Value: bson.D{{Key: "coll", Value: "globalTransactions"}, {Key: "pipeline", Value: bson.A{matchGlobalTransactions}}}}}
-	parserFilter := bson.D{{Key: "$unionWith", Value: bson.D{{Key: "coll", Value: "parsedVaa"}, {Key: "pipeline", Value: bson.A{matchParsedVaa}}}}}
-	group := bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$_id"}}}}
-	pipeline := []bson.D{globalTransactionFilter, parserFilter, group}
-
-	cur, err := db.Collection("_operationsTemporal").Aggregate(ctx, pipeline)
-	if err != nil {
-		return nil, err
-	}
-	var documents []mongoID
-	err = cur.All(ctx, &documents)
-	if err != nil {
-		return nil, err
-	}
-	var ids []string
-	for _, doc := range documents {
-		ids = append(ids, doc.Id)
-	}
-	return ids, nil
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "originTx.from", Value: bson.M{"$in": bson.A{qHexa, q}}}},
+		bson.D{{Key: "originTx.nativeTxHash", Value: bson.M{"$in": bson.A{qHexa, q}}}},
+		bson.D{{Key: "originTx.attribute.value.originTxHash", Value: bson.M{"$in": bson.A{qHexa, q}}}},
+		bson.D{{Key: "destinationTx.txHash", Value: bson.M{"$in": bson.A{q, qLower, qHigher, qLowerWith0X, qHigherWith0X}}}},
+		bson.D{{Key: "standardizedProperties.toAddress", Value: bson.M{"$in": bson.A{qHexa, q}}}},
+	}}}
 }
 
 // QueryFilterIsVaaID checks if q is a vaaID.
@@ -175,76 +98,104 @@ func QueryFilterIsVaaID(ctx context.Context, q string) []string {
 // FindAll returns all operations filtered by q.
 func (r *Repository) FindAll(ctx context.Context, q string, pagination *pagination.Pagination) ([]*OperationDto, error) {
 
-	var pipeline mongo.Pipeline
+	// the operations are only listed once their origin transaction is known.
+	filter := bson.D{{Key: "originTx", Value: bson.M{"$exists": true}}}
 
-	// get all ids by that match q
+	// filter by vaaID, address or txHash
 	if q != "" {
-		var ids []string
-		// find all ids that match q (vaaID)
-		ids = QueryFilterIsVaaID(ctx, q)
-		if len(ids) == 0 {
-			// find all ids that match q (address or txHash)
-			var err error
-			ids, err = findOperationsIdByAddressOrTxHash(ctx, r.db, q, pagination)
-			if err != nil {
-				return nil, err
-			}
-
-			if len(ids) == 0 {
-				return []*OperationDto{}, nil
-			}
+		if ids := QueryFilterIsVaaID(ctx, q); len(ids) > 0 {
+			filter = append(filter, bson.E{Key: "_id", Value: bson.M{"$in": ids}})
+		} else {
+			filter = append(filter, filterOperationsByAddressOrTxHash(q)...)
 		}
-		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}}})
 	}
 
-	// sort
-	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{bson.E{Key: "originTx.timestamp", Value: pagination.GetSortInt()}}}})
-
-	// Skip initial results
-	pipeline = append(pipeline, bson.D{{Key: "$skip", Value: pagination.Skip}})
+	opts := options.Find().
+		SetSort(bson.D{{Key: "originTx.timestamp", Value: pagination.GetSortInt()}}).
+		SetSkip(pagination.Skip).
+		SetLimit(pagination.Limit)
 
-	// Limit size of results
-	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: pagination.Limit}})
-
-	// lookup vaas
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "vaas"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "vaas"}}}})
+	cur, err := r.collections.operations.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find operations", zap.Error(err))
+		return nil, err
+	}
 
-	// lookup globalTransactions
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "globalTransactions"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}})
+	// Read results from cursor
+	operations := []*OperationDto{}
+	err = cur.All(ctx, &operations)
+	if err != nil {
+		r.logger.Error("failed to decode cursor", zap.Error(err))
+		return nil, err
+	}
 
-	// lookup transferPrices
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "transferPrices"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "transferPrices"}}}})
+	return operations, nil
+}
 
-	// lookup parsedVaa
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "parsedVaa"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "parsedVaa"}}}})
+// UnredeemedQuery is the filter of the unredeemed transfers, the unset fields do not filter.
+type UnredeemedQuery struct {
+	ToChain *sdk.ChainID
+	// ToAddress and TokenAddress are the formats of the addresses, see address.StoredFormats.
+	ToAddress    []string
+	TokenChain   *sdk.ChainID
+	TokenAddress []string
+	// The transfers emitted between MaxAge and MinAge ago are returned.
+	MinAge time.Duration
+	MaxAge time.Duration
+}
 
-	// add fields
-	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.D{
-		{Key: "payload", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$parsedVaa.parsedPayload", 0}}}},
-		{Key: "vaa", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$vaas", 0}}}},
-		{Key: "standardizedProperties", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$parsedVaa.standardizedProperties", 0}}}},
-		{Key: "symbol", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.symbol", 0}}}},
-		{Key: "usdAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.usdAmount", 0}}}},
-		{Key: "tokenAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.tokenAmount", 0}}}},
-	}}})
+// FindUnredeemed returns the transfers that were signed, or are held by the governor, and were not
+// redeemed on the destination chain, sorted by the time they were emitted.
+func (r *Repository) FindUnredeemed(ctx context.Context, q *UnredeemedQuery, p *pagination.Pagination) ([]*OperationDto, error) {
+	filter := bson.D{
+		// the toChain of the VAAs held by the governor is unset until the VAA is signed and parsed.
+		{Key: "$or", Value: bson.A{
+			bson.D{
+				{Key: "status", Value: bson.M{"$in": bson.A{"pending", "failed"}}},
+				{Key: "standardizedProperties.toChain", Value: bson.M{"$gt": sdk.ChainIDUnset}},
+			},
+			bson.D{{Key: "status", Value: domain.SourceTxStatusInProgress}, {Key: "lifecycle.state", Value: lifecycleStateGovernorHeld}},
+		}},
+	}
+	if q.ToChain != nil {
+		filter = append(filter, bson.E{Key: "standardizedProperties.toChain", Value: *q.ToChain})
+	}
+	if len(q.ToAddress) > 0 {
+		filter = append(filter, bson.E{Key: "standardizedProperties.toAddress", Value: bson.M{"$in": q.ToAddress}})
+	}
+	if q.TokenChain != nil {
+		filter = append(filter, bson.E{Key: "standardizedProperties.tokenChain", Value: *q.TokenChain})
+	}
+	if len(q.TokenAddress) > 0 {
+		filter = append(filter, bson.E{Key: "standardizedProperties.tokenAddress", Value: bson.M{"$in": q.TokenAddress}})
+	}
+	timestamp := bson.M{}
+	now := time.Now()
+	if q.MinAge > 0 {
+		timestamp["$lte"] = now.Add(-q.MinAge)
+	}
+	if q.MaxAge > 0 {
+		timestamp["$gte"] = now.Add(-q.MaxAge)
+	}
+	if len(timestamp) > 0 {
+		filter = append(filter, bson.E{Key: "timestamp", Value: timestamp})
+	}
 
-	// unset
-	pipeline = append(pipeline, bson.D{{Key: "$unset", Value: bson.A{"transferPrices", "parsedVaa"}}})
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: p.GetSortInt()}, {Key: "_id", Value: 1}}).
+		SetSkip(p.Skip).
+		SetLimit(p.Limit)
 
-	// Execute the aggregation pipeline
-	cur, err := r.collections.globalTransactions.Aggregate(ctx, pipeline)
+	cur, err := r.collections.operations.Find(ctx, filter, opts)
 	if err != nil {
-		r.logger.Error("failed execute aggregation pipeline", zap.Error(err))
+		r.logger.Error("failed to find unredeemed operations", zap.Error(err))
 		return nil, err
 	}
 
-	// Read results from cursor
-	var operations []*OperationDto
-	err = cur.All(ctx, &operations)
-	if err != nil {
+	operations := []*OperationDto{}
+	if err := cur.All(ctx, &operations); err != nil {
 		r.logger.Error("failed to decode cursor", zap.Error(err))
 		return nil, err
 	}
-
 	return operations, nil
 }
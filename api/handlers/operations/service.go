@@ -3,6 +3,8 @@ package operations
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/api/types"
@@ -11,13 +13,21 @@ import (
 )
 
 type Service struct {
-	repo   *Repository
-	logger *zap.Logger
+	repo       *Repository
+	p2pNetwork string
+	// contracts are nil when the p2p network has no contract-watcher configuration.
+	contracts *redeemContracts
+	logger    *zap.Logger
 }
 
 // NewService create a new Service.
-func NewService(repo *Repository, logger *zap.Logger) *Service {
-	return &Service{repo: repo, logger: logger.With(zap.String("module", "OperationService"))}
+func NewService(repo *Repository, p2pNetwork string, logger *zap.Logger) *Service {
+	logger = logger.With(zap.String("module", "OperationService"))
+	contracts, err := newRedeemContracts(p2pNetwork)
+	if err != nil {
+		logger.Warn("the redeem targets of the unredeemed transfers are disabled", zap.Error(err))
+	}
+	return &Service{repo: repo, p2pNetwork: p2pNetwork, contracts: contracts, logger: logger}
 }
 
 // FindById returns the operations for the given chainID/emitter/seq.
@@ -39,3 +49,59 @@ func (s *Service) FindAll(ctx context.Context, q string, pagination *pagination.
 	}
 	return operations, nil
 }
+
+// FindUnredeemed returns the transfers that were not redeemed on the destination chain with the
+// signed VAA and the contract that redeems them.
+func (s *Service) FindUnredeemed(ctx context.Context, q *UnredeemedQuery, p *pagination.Pagination) ([]*UnredeemedTransfer, error) {
+	if p == nil {
+		p = pagination.Default()
+	}
+	operations, err := s.repo.FindUnredeemed(ctx, q, p)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*UnredeemedTransfer, 0, len(operations))
+	for _, op := range operations {
+		transfers = append(transfers, s.toUnredeemedTransfer(op))
+	}
+	return transfers, nil
+}
+
+func (s *Service) toUnredeemedTransfer(op *OperationDto) *UnredeemedTransfer {
+	// the operation ID is chainID/emitter/sequence.
+	parts := strings.Split(op.ID, "/")
+	var emitterChain vaa.ChainID
+	var emitterAddr string
+	if len(parts) == 3 {
+		if chain, err := strconv.ParseUint(parts[0], 10, 16); err == nil {
+			emitterChain = vaa.ChainID(chain)
+		}
+		emitterAddr = parts[1]
+	}
+
+	t := &UnredeemedTransfer{
+		ID:            op.ID,
+		Status:        op.Status,
+		EmitterChain:  emitterChain,
+		Symbol:        op.Symbol,
+		TokenAmount:   op.TokenAmount,
+		UsdAmount:     op.UsdAmount,
+		Timestamp:     op.Timestamp,
+		DestinationTx: op.DestinationTx,
+		GovernorHeld:  op.Lifecycle != nil && op.Lifecycle.State == lifecycleStateGovernorHeld,
+		RedeemTarget:  findRedeemTarget(s.p2pNetwork, s.contracts, emitterChain, emitterAddr, op.Payload, op.StandardizedProperties),
+	}
+	if p := op.StandardizedProperties; p != nil {
+		t.FromChain, t.FromAddress = p.FromChain, p.FromAddress
+		t.ToChain, t.ToAddress = p.ToChain, p.ToAddress
+		t.TokenChain, t.TokenAddress = p.TokenChain, p.TokenAddress
+	}
+	if op.SourceTx != nil && op.SourceTx.From != "" {
+		t.FromAddress = op.SourceTx.From
+	}
+	if op.Vaa != nil {
+		t.Vaa = op.Vaa.Vaa
+		t.SignedAt = op.Vaa.IndexedAt
+	}
+	return t
+}
@@ -0,0 +1,237 @@
+package operations
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	watchers "github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole/sdk"
+	sdkvaa "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Payload types of the token bridge messages.
+const (
+	payloadTypeTransfer            = 1
+	payloadTypeTransferWithPayload = 3
+)
+
+// lifecycleStateGovernorHeld is the lifecycle state of the operations delayed by the governor.
+const lifecycleStateGovernorHeld = "governor_held"
+
+// UnredeemedTransfer is a transfer whose VAA is signed, or held by the governor, and that was not
+// redeemed on the destination chain.
+type UnredeemedTransfer struct {
+	ID           string         `json:"id"`
+	Status       string         `json:"status"`
+	EmitterChain sdkvaa.ChainID `json:"emitterChain"`
+	FromChain    sdkvaa.ChainID `json:"fromChain"`
+	FromAddress  string         `json:"fromAddress"`
+	ToChain      sdkvaa.ChainID `json:"toChain"`
+	ToAddress    string         `json:"toAddress"`
+	TokenChain   sdkvaa.ChainID `json:"tokenChain"`
+	TokenAddress string         `json:"tokenAddress"`
+	Symbol       string         `json:"symbol,omitempty"`
+	TokenAmount  string         `json:"tokenAmount,omitempty"`
+	UsdAmount    string         `json:"usdAmount,omitempty"`
+	Timestamp    *time.Time     `json:"timestamp"`
+	// SignedAt is the time the VAA was signed, it is nil while the governor holds it.
+	SignedAt *time.Time `json:"signedAt,omitempty"`
+	// Vaa is the signed VAA to submit to the redeem target.
+	Vaa []byte `json:"vaa,omitempty"`
+	// GovernorHeld indicates that the governor delayed the VAA, it can not be redeemed until it is released.
+	GovernorHeld bool `json:"governorHeld"`
+	// DestinationTx is the failed redeem transaction, if any.
+	DestinationTx *DestinationTx `json:"destinationTx,omitempty"`
+	// RedeemTarget is nil when the contract that redeems the operation is unknown.
+	RedeemTarget *RedeemTarget `json:"redeemTarget,omitempty"`
+}
+
+// RedeemTarget is the contract and the method that complete a transfer on its destination chain.
+type RedeemTarget struct {
+	Contract string `json:"contract"`
+	Method   string `json:"method"`
+	// Caller is the only address allowed to redeem the transfer, e.g. the recipient of a transfer with payload.
+	Caller string `json:"caller,omitempty"`
+}
+
+// redeemMethods are the methods of a token bridge that redeem the transfers.
+type redeemMethods struct {
+	transfer            string
+	transferWithPayload string
+}
+
+var (
+	evmMethods      = redeemMethods{"completeTransfer", "completeTransferWithPayload"}
+	cosmwasmMethods = redeemMethods{"submit_vaa", "complete_transfer_with_payload"}
+	suiMethods      = redeemMethods{"complete_transfer::authorize_transfer", "complete_transfer_with_payload::authorize_transfer"}
+	aptosMethods    = redeemMethods{"complete_transfer::submit_vaa_and_register_entry", "complete_transfer_with_payload::submit_vaa"}
+	nearMethods     = redeemMethods{"submit_vaa", "submit_vaa"}
+	algorandMethods = redeemMethods{"completeVAA", "completeVAA"}
+)
+
+// tokenBridge is the token bridge contract of a chain.
+type tokenBridge struct {
+	contract string
+	methods  redeemMethods
+}
+
+// providerMethods are the redeem methods of the token bridges by contract-watcher provider.
+var providerMethods = map[string]redeemMethods{
+	watchers.ProviderEvm:      evmMethods,
+	watchers.ProviderAnkr:     evmMethods,
+	watchers.ProviderSolana:   {},
+	watchers.ProviderTerra:    cosmwasmMethods,
+	watchers.ProviderCosmwasm: cosmwasmMethods,
+	watchers.ProviderSui:      suiMethods,
+	watchers.ProviderAptos:    aptosMethods,
+	watchers.ProviderNear:     nearMethods,
+	watchers.ProviderAlgorand: algorandMethods,
+}
+
+// evmTransferMethod is the name of the token bridge redeem method in the EVM watchers.
+const evmTransferMethod = "completeTransfer"
+
+// redeemContracts are the contracts that redeem the operations in a p2p network, the same contracts the
+// contract-watcher watches for redeems.
+type redeemContracts struct {
+	tokenBridges map[sdkvaa.ChainID]tokenBridge
+	// evmChains are the EVM chains, where the generic relayer is deployed.
+	evmChains map[sdkvaa.ChainID]bool
+}
+
+// newRedeemContracts loads the redeem contracts of the p2p network from the contract-watcher configuration.
+func newRedeemContracts(p2pNetwork string) (*redeemContracts, error) {
+	cfg, err := watchers.LoadWatchers("", p2pNetwork)
+	if err != nil {
+		return nil, err
+	}
+	contracts := &redeemContracts{
+		tokenBridges: make(map[sdkvaa.ChainID]tokenBridge),
+		evmChains:    make(map[sdkvaa.ChainID]bool),
+	}
+	for i := range cfg.Watchers {
+		w := &cfg.Watchers[i]
+		chainID, err := w.ChainID()
+		if err != nil {
+			return nil, err
+		}
+		if w.IsEvm() {
+			contracts.evmChains[chainID] = true
+		}
+		methods, ok := providerMethods[w.Provider]
+		if !ok {
+			continue
+		}
+		if contract := tokenBridgeContract(w); contract != "" {
+			contracts.tokenBridges[chainID] = tokenBridge{contract: contract, methods: methods}
+		}
+	}
+	return contracts, nil
+}
+
+// tokenBridgeContract returns the token bridge contract of a watcher, empty when it does not watch it. The
+// EVM watchers watch other contracts too, the token bridge is the one with the completeTransfer method.
+func tokenBridgeContract(w *watchers.WatcherSpec) string {
+	if w.IsEvm() {
+		for _, c := range w.Contracts {
+			for _, m := range c.Methods {
+				if m.Name == evmTransferMethod {
+					return c.Address
+				}
+			}
+		}
+		return ""
+	}
+	if w.Address != "" {
+		return w.Address
+	}
+	if len(w.Contracts) > 0 {
+		return w.Contracts[0].Address
+	}
+	return ""
+}
+
+// tokenBridgeEmitters are the emitters of the token bridge messages by p2p network.
+var tokenBridgeEmitters = map[string]map[sdkvaa.ChainID][]byte{
+	domain.P2pMainNet: sdk.KnownTokenbridgeEmitters,
+	domain.P2pTestNet: sdk.KnownTestnetTokenbridgeEmitters,
+}
+
+// findRedeemTarget returns the contract and the method that redeem an operation on its destination
+// chain, nil when the operation is not a token bridge transfer or a generic relayer delivery of a
+// known chain.
+func findRedeemTarget(p2pNetwork string, contracts *redeemContracts, emitterChain sdkvaa.ChainID, emitterAddr string,
+	payload map[string]any, p *StandardizedProperties) *RedeemTarget {
+	if p == nil || contracts == nil {
+		return nil
+	}
+	emitter, err := hex.DecodeString(strings.TrimPrefix(emitterAddr, "0x"))
+	if err != nil {
+		return nil
+	}
+
+	var address sdkvaa.Address
+	copy(address[:], emitter)
+	if domain.IsWormholeRelayerEmitter(p2pNetwork, address) {
+		// the generic relayer is only deployed on the EVM chains.
+		relayer, ok := domain.GetWormholeRelayerAddress(p2pNetwork)
+		if !ok || !contracts.evmChains[p.ToChain] {
+			return nil
+		}
+		return &RedeemTarget{Contract: relayer, Method: "deliver"}
+	}
+
+	bridge, ok := contracts.tokenBridges[p.ToChain]
+	if !ok {
+		return nil
+	}
+	known, ok := tokenBridgeEmitters[p2pNetwork][emitterChain]
+	if !ok || !bytes.Equal(known, emitter) {
+		return nil
+	}
+
+	switch payloadType(payload) {
+	case payloadTypeTransfer:
+		return &RedeemTarget{Contract: bridge.contract, Method: bridge.method(p.TokenChain, false)}
+	case payloadTypeTransferWithPayload:
+		return &RedeemTarget{Contract: bridge.contract, Method: bridge.method(p.TokenChain, true), Caller: p.ToAddress}
+	default:
+		return nil
+	}
+}
+
+// method returns the redeem method of a transfer of a token. The Solana token bridge has different
+// instructions for the native and the wrapped tokens.
+func (b tokenBridge) method(tokenChain sdkvaa.ChainID, withPayload bool) string {
+	if b.methods == (redeemMethods{}) {
+		method := "complete_wrapped"
+		if tokenChain == sdkvaa.ChainIDSolana {
+			method = "complete_native"
+		}
+		if withPayload {
+			method += "_with_payload"
+		}
+		return method
+	}
+	if withPayload {
+		return b.methods.transferWithPayload
+	}
+	return b.methods.transfer
+}
+
+// payloadType returns the payload type of a parsed payload, zero when it is not set.
+func payloadType(payload map[string]any) int {
+	switch v := payload["payloadType"].(type) {
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,69 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const ethereumTokenBridgeEmitter = "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585"
+
+func mainnetContracts(t *testing.T) *redeemContracts {
+	contracts, err := newRedeemContracts(domain.P2pMainNet)
+	assert.NoError(t, err)
+	return contracts
+}
+
+func TestNewRedeemContracts(t *testing.T) {
+	contracts := mainnetContracts(t)
+	bridges := contracts.tokenBridges
+	assert.Equal(t, tokenBridge{"0x3ee18B2214AFF97000D974cf647E7C347E8fa585", evmMethods}, bridges[sdk.ChainIDEthereum])
+	assert.Equal(t, tokenBridge{"0x8d2de8d2f73F1F4cAB472AC9A881C9b123C79627", evmMethods}, bridges[sdk.ChainIDBase])
+	assert.Equal(t, tokenBridge{"contract.portalbridge.near", nearMethods}, bridges[sdk.ChainIDNear])
+	assert.Equal(t, tokenBridge{"sei1smzlm9t79kur392nu9egl8p8je9j92q4gzguewj56a05kyxxra0qy0nuf3", cosmwasmMethods}, bridges[sdk.ChainIDSei])
+	// the contract-watcher does not watch a token bridge on arbitrum.
+	_, ok := bridges[sdk.ChainIDArbitrum]
+	assert.False(t, ok)
+	assert.True(t, contracts.evmChains[sdk.ChainIDArbitrum])
+	assert.False(t, contracts.evmChains[sdk.ChainIDSolana])
+}
+
+func TestFindRedeemTarget_Transfer(t *testing.T) {
+	p := &StandardizedProperties{ToChain: sdk.ChainIDPolygon, TokenChain: sdk.ChainIDEthereum}
+	target := findRedeemTarget(domain.P2pMainNet, mainnetContracts(t), sdk.ChainIDEthereum, ethereumTokenBridgeEmitter,
+		map[string]any{"payloadType": int32(1)}, p)
+	assert.Equal(t, &RedeemTarget{Contract: "0x5a58505a96D1dbf8dF91cB21B54419FC36e93fdE", Method: "completeTransfer"}, target)
+}
+
+func TestFindRedeemTarget_TransferWithPayload(t *testing.T) {
+	p := &StandardizedProperties{ToChain: sdk.ChainIDSolana, ToAddress: "recipient", TokenChain: sdk.ChainIDSolana}
+	target := findRedeemTarget(domain.P2pMainNet, mainnetContracts(t), sdk.ChainIDEthereum, ethereumTokenBridgeEmitter,
+		map[string]any{"payloadType": int64(3)}, p)
+	assert.Equal(t, &RedeemTarget{
+		Contract: "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb",
+		Method:   "complete_native_with_payload",
+		Caller:   "recipient",
+	}, target)
+}
+
+func TestFindRedeemTarget_WormholeRelayer(t *testing.T) {
+	p := &StandardizedProperties{ToChain: sdk.ChainIDArbitrum}
+	emitter := "00000000000000000000000027428dd2d3dd32a4d7f7c497eaaa23130d894911"
+	target := findRedeemTarget(domain.P2pMainNet, mainnetContracts(t), sdk.ChainIDEthereum, emitter, nil, p)
+	assert.Equal(t, &RedeemTarget{Contract: "0x27428dd2d3dd32a4d7f7c497eaaa23130d894911", Method: "deliver"}, target)
+
+	p.ToChain = sdk.ChainIDSolana
+	assert.Nil(t, findRedeemTarget(domain.P2pMainNet, mainnetContracts(t), sdk.ChainIDEthereum, emitter, nil, p))
+}
+
+func TestFindRedeemTarget_Unknown(t *testing.T) {
+	p := &StandardizedProperties{ToChain: sdk.ChainIDPolygon}
+	// attestations are not transfers.
+	assert.Nil(t, findRedeemTarget(domain.P2pMainNet, mainnetContracts(t), sdk.ChainIDEthereum, ethereumTokenBridgeEmitter,
+		map[string]any{"payloadType": int32(2)}, p))
+	// messages of other emitters.
+	assert.Nil(t, findRedeemTarget(domain.P2pMainNet, mainnetContracts(t), sdk.ChainIDEthereum,
+		"0000000000000000000000000000000000000000000000000000000000000001", map[string]any{"payloadType": int32(1)}, p))
+}
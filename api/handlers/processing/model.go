@@ -0,0 +1,55 @@
+package processing
+
+import (
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+)
+
+// OutcomePending is the outcome of a stage that has not recorded the processing of the VAA.
+const OutcomePending = "pending"
+
+// VaaProcessing is the processing of a VAA by each stage, in the order the VAA passes through them.
+type VaaProcessing struct {
+	ID        string             `json:"id"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Stages    []*StageProcessing `json:"stages"`
+}
+
+// StageProcessing is the last processing of a VAA by a stage.
+type StageProcessing struct {
+	Stage      string     `json:"stage"`
+	Outcome    string     `json:"outcome"`
+	Version    string     `json:"version,omitempty"`
+	TrackID    string     `json:"trackId,omitempty"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	DurationMs *int64     `json:"durationMs,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+}
+
+// newVaaProcessing returns the processing of the lineage document, the stages without entry are pending.
+func newVaaProcessing(doc *lineage.Document) *VaaProcessing {
+	stages := make([]*StageProcessing, 0, len(lineage.Stages))
+	for _, stage := range lineage.Stages {
+		entry, ok := doc.Stages[stage]
+		if !ok || entry == nil {
+			stages = append(stages, &StageProcessing{Stage: string(stage), Outcome: OutcomePending})
+			continue
+		}
+		startedAt, finishedAt, durationMs := entry.StartedAt, entry.FinishedAt, entry.DurationMs
+		stages = append(stages, &StageProcessing{
+			Stage:      string(stage),
+			Outcome:    string(entry.Outcome),
+			Version:    entry.Version,
+			TrackID:    entry.TrackID,
+			StartedAt:  &startedAt,
+			FinishedAt: &finishedAt,
+			DurationMs: &durationMs,
+			Error:      entry.Error,
+			Reason:     entry.Reason,
+		})
+	}
+	return &VaaProcessing{ID: doc.ID, UpdatedAt: doc.UpdatedAt, Stages: stages}
+}
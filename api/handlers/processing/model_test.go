@@ -0,0 +1,54 @@
+package processing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+)
+
+func TestNewVaaProcessing(t *testing.T) {
+	startedAt := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	doc := &lineage.Document{
+		ID: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1",
+		Stages: map[lineage.Stage]*lineage.Entry{
+			lineage.StageParser: {
+				Version:    "v1",
+				StartedAt:  startedAt,
+				FinishedAt: startedAt.Add(250 * time.Millisecond),
+				DurationMs: 250,
+				Outcome:    lineage.OutcomeSucceeded,
+			},
+			lineage.StageFly: {
+				Version:    "v2",
+				TrackID:    "fly-1",
+				StartedAt:  startedAt,
+				FinishedAt: startedAt,
+				Outcome:    lineage.OutcomeSucceeded,
+			},
+			lineage.StageTxTracker: {
+				Version: "v3",
+				Outcome: lineage.OutcomeFailed,
+				Error:   "rpc timeout",
+			},
+		},
+		UpdatedAt: startedAt,
+	}
+
+	result := newVaaProcessing(doc)
+
+	assert.Equal(t, doc.ID, result.ID)
+	assert.Len(t, result.Stages, len(lineage.Stages))
+	// the stages are in processing order, the stages without entry are pending.
+	outcomes := make([]string, 0, len(result.Stages))
+	for i, stage := range result.Stages {
+		assert.Equal(t, string(lineage.Stages[i]), stage.Stage)
+		outcomes = append(outcomes, stage.Outcome)
+	}
+	assert.Equal(t, []string{"succeeded", "pending", "succeeded", "failed", "pending", "pending"}, outcomes)
+	assert.Equal(t, "fly-1", result.Stages[0].TrackID)
+	assert.Nil(t, result.Stages[1].StartedAt)
+	assert.Equal(t, int64(250), *result.Stages[2].DurationMs)
+	assert.Equal(t, "rpc timeout", result.Stages[3].Error)
+}
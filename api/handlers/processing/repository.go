@@ -0,0 +1,50 @@
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		vaaProcessing *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{db: db,
+		logger: logger.With(zap.String("module", "ProcessingRepository")),
+		collections: struct {
+			vaaProcessing *mongo.Collection
+		}{
+			vaaProcessing: db.Collection(lineage.Collection),
+		},
+	}
+}
+
+// FindByID get the processing lineage of a VAA.
+func (r *Repository) FindByID(ctx context.Context, id string) (*lineage.Document, error) {
+	var doc lineage.Document
+	err := r.collections.vaaProcessing.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errs.ErrNotFound
+		}
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute FindOne command to get vaa processing",
+			zap.Error(err), zap.String("id", id), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return &doc, nil
+}
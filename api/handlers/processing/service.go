@@ -0,0 +1,31 @@
+package processing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/types"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Service definition.
+type Service struct {
+	repo   *Repository
+	logger *zap.Logger
+}
+
+// NewService create a new Service.
+func NewService(repo *Repository, logger *zap.Logger) *Service {
+	return &Service{repo: repo, logger: logger.With(zap.String("module", "ProcessingService"))}
+}
+
+// FindByVAA get the processing of a VAA by chainID, emitter address and sequence.
+func (s *Service) FindByVAA(ctx context.Context, chainID sdk.ChainID, emitterAddr *types.Address, seq string) (*VaaProcessing, error) {
+	id := fmt.Sprintf("%d/%s/%s", chainID, emitterAddr.Hex(), seq)
+	doc, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newVaaProcessing(doc), nil
+}
@@ -85,6 +85,7 @@ type RelayDoc struct {
 				RefundChainID          int    `bson:"refundChainId"`
 				RefundDeliveryProvider string `bson:"refundDeliveryProvider"`
 				TargetChainID          int    `bson:"targetChainId"`
+				GasLimit               string `bson:"gasLimit"`
 			} `bson:"instructions"`
 			DeliveryRecord struct {
 				MaxRefund                   string   `bson:"maxRefund"`
@@ -161,6 +162,7 @@ type RelayDoc struct {
 		EmitterChain   int        `bson:"emitterChain"`
 		EmitterAddress string     `bson:"emitterAddress"`
 		FailedAt       *time.Time `bson:"failedAt"`
+		RedeliveryIDs  []string   `bson:"redeliveryIds"`
 	} `bson:"data"`
 	Event  string `bson:"event"`
 	Origin string `bson:"origin"`
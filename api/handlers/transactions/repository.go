@@ -12,11 +12,11 @@ import (
 	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
-	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/common"
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/tvl"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -126,6 +126,7 @@ from(bucket: "%s")
 
 type repositoryCollections struct {
 	vaas               *mongo.Collection
+	operations         *mongo.Collection
 	parsedVaa          *mongo.Collection
 	globalTransactions *mongo.Collection
 }
@@ -162,6 +163,7 @@ func NewRepository(
 		db:                      db,
 		collections: repositoryCollections{
 			vaas:               db.Collection("vaas"),
+			operations:         db.Collection("operations"),
 			parsedVaa:          db.Collection("parsedVaa"),
 			globalTransactions: db.Collection("globalTransactions"),
 		},
@@ -768,6 +770,18 @@ type FindTransactionsInput struct {
 	pagination *pagination.Pagination
 }
 
+// globalTransactionsStage rebuilds the `globalTransactions` array of the transactions from the
+// origin and destination transactions stored in the `operations` collection.
+var globalTransactionsStage = bson.D{
+	{"$addFields", bson.D{
+		{"globalTransactions", bson.M{"$cond": bson.A{
+			bson.M{"$ifNull": bson.A{"$originTx", false}},
+			bson.A{bson.M{"_id": "$_id", "originTx": "$originTx", "destinationTx": "$destinationTx"}},
+			bson.A{},
+		}}},
+	}},
+}
+
 // FindTransactions returns transactions matching a specified search criteria.
 func (r *Repository) FindTransactions(
 	ctx context.Context,
@@ -777,6 +791,13 @@ func (r *Repository) FindTransactions(
 	// Build the aggregation pipeline
 	var pipeline mongo.Pipeline
 	{
+		// Filter by ID
+		if input.id != "" {
+			pipeline = append(pipeline, bson.D{
+				{"$match", bson.D{{"_id", input.id}}},
+			})
+		}
+
 		// Specify sorting criteria
 		if input.sort {
 			pipeline = append(pipeline, bson.D{
@@ -787,70 +808,6 @@ func (r *Repository) FindTransactions(
 			})
 		}
 
-		// Filter by ID
-		if input.id != "" {
-			pipeline = append(pipeline, bson.D{
-				{"$match", bson.D{{"_id", input.id}}},
-			})
-		}
-
-		// left outer join on the `transferPrices` collection
-		pipeline = append(pipeline, bson.D{
-			{"$lookup", bson.D{
-				{"from", "transferPrices"},
-				{"localField", "_id"},
-				{"foreignField", "_id"},
-				{"as", "transferPrices"},
-			}},
-		})
-
-		// left outer join on the `vaaIdTxHash` collection
-		pipeline = append(pipeline, bson.D{
-			{"$lookup", bson.D{
-				{"from", "vaaIdTxHash"},
-				{"localField", "_id"},
-				{"foreignField", "_id"},
-				{"as", "vaaIdTxHash"},
-			}},
-		})
-
-		// left outer join on the `parsedVaa` collection
-		pipeline = append(pipeline, bson.D{
-			{"$lookup", bson.D{
-				{"from", "parsedVaa"},
-				{"localField", "_id"},
-				{"foreignField", "_id"},
-				{"as", "parsedVaa"},
-			}},
-		})
-
-		// left outer join on the `globalTransactions` collection
-		pipeline = append(pipeline, bson.D{
-			{"$lookup", bson.D{
-				{"from", "globalTransactions"},
-				{"localField", "_id"},
-				{"foreignField", "_id"},
-				{"as", "globalTransactions"},
-			}},
-		})
-
-		// add nested fields
-		pipeline = append(pipeline, bson.D{
-			{"$addFields", bson.D{
-				{"txHash", bson.M{"$arrayElemAt": []interface{}{"$vaaIdTxHash.txHash", 0}}},
-				{"payload", bson.M{"$arrayElemAt": []interface{}{"$parsedVaa.parsedPayload", 0}}},
-				{"standardizedProperties", bson.M{"$arrayElemAt": []interface{}{"$parsedVaa.standardizedProperties", 0}}},
-				{"symbol", bson.M{"$arrayElemAt": []interface{}{"$transferPrices.symbol", 0}}},
-				{"usdAmount", bson.M{"$arrayElemAt": []interface{}{"$transferPrices.usdAmount", 0}}},
-				{"tokenAmount", bson.M{"$arrayElemAt": []interface{}{"$transferPrices.tokenAmount", 0}}},
-			}},
-		})
-
-		// Unset unused fields
-		pipeline = append(pipeline, bson.D{
-			{"$unset", []interface{}{"transferPrices", "vaaTxIdHash", "parsedVaa"}},
-		})
-
 		// Skip initial results
 		if input.pagination != nil {
 			pipeline = append(pipeline, bson.D{
@@ -864,10 +821,12 @@ func (r *Repository) FindTransactions(
 				{"$limit", input.pagination.Limit},
 			})
 		}
+
+		pipeline = append(pipeline, globalTransactionsStage)
 	}
 
 	// Execute the aggregation pipeline
-	cur, err := r.collections.vaas.Aggregate(ctx, pipeline)
+	cur, err := r.collections.operations.Aggregate(ctx, pipeline)
 	if err != nil {
 		r.logger.Error("failed execute aggregation pipeline", zap.Error(err))
 		return nil, err
@@ -893,28 +852,21 @@ func (r *Repository) ListTransactionsByAddress(
 	pagination *pagination.Pagination,
 ) ([]TransactionDto, error) {
 
-	ids, err := common.FindVaasIdsByFromAddressOrToAddress(ctx, r.db, address)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(ids) == 0 {
-		return []TransactionDto{}, nil
+	addressHexa := strings.ToLower(address)
+	if !utils.StartsWith0x(address) {
+		addressHexa = "0x" + strings.ToLower(addressHexa)
 	}
 
 	var pipeline mongo.Pipeline
 
-	// filter by ids
-	pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}}})
-
-	// inner join on the `parsedVaa` collection
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{
-		{Key: "from", Value: "parsedVaa"},
-		{Key: "localField", Value: "_id"},
-		{Key: "foreignField", Value: "_id"},
-		{Key: "as", Value: "parsedVaa"},
+	// filter by the origin or destination address, only the parsed VAAs are listed.
+	pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "originTx.from", Value: bson.M{"$in": bson.A{addressHexa, address}}}},
+			bson.D{{Key: "standardizedProperties.toAddress", Value: bson.M{"$in": bson.A{addressHexa, address}}}},
+		}},
+		{Key: "payload", Value: bson.M{"$exists": true}},
 	}}})
-	pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "parsedVaa", Value: bson.D{{Key: "$ne", Value: []any{}}}}}}})
 
 	// sort by timestamp
 	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{bson.E{Key: "timestamp", Value: pagination.GetSortInt()}}}})
@@ -925,44 +877,10 @@ func (r *Repository) ListTransactionsByAddress(
 	// Limit size of results
 	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: pagination.Limit}})
 
-	// left outer join on the `transferPrices` collection
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{
-		{Key: "from", Value: "transferPrices"},
-		{Key: "localField", Value: "_id"},
-		{Key: "foreignField", Value: "_id"},
-		{Key: "as", Value: "transferPrices"},
-	}}})
-
-	// left outer join on the `vaaIdTxHash` collection
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{
-		{Key: "from", Value: "vaaIdTxHash"},
-		{Key: "localField", Value: "_id"},
-		{Key: "foreignField", Value: "_id"},
-		{Key: "as", Value: "vaaIdTxHash"},
-	}}})
-
-	// left outer join on the `globalTransactions` collection
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{
-		{Key: "from", Value: "globalTransactions"},
-		{Key: "localField", Value: "_id"},
-		{Key: "foreignField", Value: "_id"},
-		{Key: "as", Value: "globalTransactions"},
-	}}})
-
-	// add nested fields
-	pipeline = append(pipeline, bson.D{
-		{Key: "$addFields", Value: bson.D{
-			{Key: "txHash", Value: bson.M{"$arrayElemAt": []interface{}{"$vaaIdTxHash.txHash", 0}}},
-			{Key: "payload", Value: bson.M{"$arrayElemAt": []interface{}{"$parsedVaa.parsedPayload", 0}}},
-			{Key: "standardizedProperties", Value: bson.M{"$arrayElemAt": []interface{}{"$parsedVaa.standardizedProperties", 0}}},
-			{Key: "symbol", Value: bson.M{"$arrayElemAt": []interface{}{"$transferPrices.symbol", 0}}},
-			{Key: "usdAmount", Value: bson.M{"$arrayElemAt": []interface{}{"$transferPrices.usdAmount", 0}}},
-			{Key: "tokenAmount", Value: bson.M{"$arrayElemAt": []interface{}{"$transferPrices.tokenAmount", 0}}},
-		}},
-	})
+	pipeline = append(pipeline, globalTransactionsStage)
 
 	// Execute the aggregation pipeline
-	cur, err := r.collections.vaas.Aggregate(ctx, pipeline)
+	cur, err := r.collections.operations.Aggregate(ctx, pipeline)
 	if err != nil {
 		r.logger.Error("failed execute aggregation pipeline", zap.Error(err))
 		return nil, err
@@ -120,6 +120,69 @@ func (r *Repository) FindVaasByTxHashWorkaround(
 	return r.FindVaas(ctx, &q)
 }
 
+// FindVaasByIDsOrTxHashes searches the database for the VAAs that match any of the given VAA IDs or transaction hashes.
+//
+// All the VAAs are fetched with a single `$in` query over the `vaas` collection.
+// Like in `FindVaasByTxHashWorkaround`, the transaction hashes are first looked up in the `globalTransactions`
+// collection, because the hashes stored in the `vaas` collection are not the real ones for Aptos and Solana.
+func (r *Repository) FindVaasByIDsOrTxHashes(
+	ctx context.Context,
+	query *VaaQuery,
+) ([]*VaaDoc, error) {
+
+	q := *query // making a copy to avoid modifying the struct passed by the caller
+
+	if len(q.txHashes) > 0 {
+
+		// match the transaction hashes with and without the 0x prefix
+		var txHashes []string
+		for _, txHash := range q.txHashes {
+			txHashes = append(txHashes, txHash, "0x"+txHash)
+		}
+
+		// Find the IDs of the globalTransactions that match the given TxHashes
+		cur, err := r.collections.globalTransactions.Find(
+			ctx,
+			bson.D{
+				{"$or", bson.A{
+					bson.D{{"originTx.nativeTxHash", bson.M{"$in": txHashes}}},
+					bson.D{{"originTx.attribute.value.originTxHash", bson.M{"$in": txHashes}}},
+				}},
+			},
+			options.Find().SetProjection(bson.D{{"_id", 1}}),
+		)
+		if err != nil {
+			requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+			r.logger.Error("failed to find globalTransactions by TxHashes",
+				zap.Error(err),
+				zap.String("requestID", requestID),
+			)
+			return nil, errors.WithStack(err)
+		}
+
+		var globalTxs []struct {
+			ID string `bson:"_id"`
+		}
+		err = cur.All(ctx, &globalTxs)
+		if err != nil {
+			requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+			r.logger.Error("failed to decode cursor to globalTransactions IDs",
+				zap.Error(err),
+				zap.String("requestID", requestID),
+			)
+			return nil, errors.WithStack(err)
+		}
+
+		ids := append([]string{}, q.ids...)
+		for i := range globalTxs {
+			ids = append(ids, globalTxs[i].ID)
+		}
+		q.ids = ids
+	}
+
+	return r.FindVaas(ctx, &q)
+}
+
 // FindVaasByEmitterAndToChain searches the database for VAAs that match a given emitter chain, address and toChain.
 func (r *Repository) FindVaasByEmitterAndToChain(
 	ctx context.Context,
@@ -213,12 +276,14 @@ func (r *Repository) FindVaas(
 			{"$sort", bson.D{q.getSortPredicate()}},
 		})
 
-		// filter by VAA ids (potentially more than one)
-		if len(q.ids) > 0 {
+		// filter by VAA ids and transaction hashes (potentially more than one)
+		if len(q.ids) > 0 || len(q.txHashes) > 0 {
 			var array bson.A
-			for _, id := range q.ids {
-				predicate := bson.D{bson.E{"_id", id}}
-				array = append(array, predicate)
+			if len(q.ids) > 0 {
+				array = append(array, bson.D{{"_id", bson.M{"$in": q.ids}}})
+			}
+			if len(q.txHashes) > 0 {
+				array = append(array, bson.D{{"txHash", bson.M{"$in": q.txHashes}}})
 			}
 			pipeline = append(pipeline, bson.D{
 				{"$match", bson.D{{"$or", array}}},
@@ -410,6 +475,7 @@ type VaaQuery struct {
 	emitter              string
 	sequence             string
 	txHash               string
+	txHashes             []string
 	appId                string
 	includeParsedPayload bool
 }
@@ -455,6 +521,14 @@ func (q *VaaQuery) SetTxHash(txHash string) *VaaQuery {
 	return q
 }
 
+// SetTxHashes set the txHashes field of the VaaQuery struct.
+//
+// The VAAs that match any of the given IDs or transaction hashes are returned.
+func (q *VaaQuery) SetTxHashes(txHashes []string) *VaaQuery {
+	q.txHashes = txHashes
+	return q
+}
+
 func (q *VaaQuery) SetAppId(appId string) *VaaQuery {
 	q.appId = appId
 	return q
@@ -16,6 +16,13 @@ import (
 	"go.uber.org/zap"
 )
 
+// MaxBulkSize is the maximum number of VAA IDs plus transaction hashes accepted by a bulk request.
+const MaxBulkSize = 100
+
+// maxBulkResults is the maximum number of VAAs returned by a bulk request.
+// A transaction can emit more than one VAA, so it is greater than MaxBulkSize.
+const maxBulkResults = 1000
+
 // Service definition.
 type Service struct {
 	repo         *Repository
@@ -202,6 +209,50 @@ func (s *Service) findById(
 	return docs[0], nil
 }
 
+// FindBulkParams passes input data to the function `FindBulk`.
+type FindBulkParams struct {
+	IDs                  []*types.VaaID
+	TxHashes             []*types.TxHash
+	IncludeParsedPayload bool
+}
+
+// FindBulk returns all the VAAs that match any of the given VAA IDs or transaction hashes.
+func (s *Service) FindBulk(
+	ctx context.Context,
+	params *FindBulkParams,
+) (*response.Response[[]*VaaDoc], error) {
+
+	if len(params.IDs) == 0 && len(params.TxHashes) == 0 {
+		return &response.Response[[]*VaaDoc]{Data: make([]*VaaDoc, 0)}, nil
+	}
+	if len(params.IDs)+len(params.TxHashes) > MaxBulkSize {
+		return nil, errs.ErrMalformedQuery
+	}
+
+	ids := make([]string, 0, len(params.IDs))
+	for _, id := range params.IDs {
+		ids = append(ids, id.String())
+	}
+	txHashes := make([]string, 0, len(params.TxHashes))
+	for _, txHash := range params.TxHashes {
+		txHashes = append(txHashes, txHash.String())
+	}
+
+	query := Query().
+		SetIDs(ids).
+		SetTxHashes(txHashes).
+		SetPagination(pagination.Default().SetLimit(maxBulkResults)).
+		IncludeParsedPayload(params.IncludeParsedPayload)
+
+	vaas, err := s.repo.FindVaasByIDsOrTxHashes(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := response.Response[[]*VaaDoc]{Data: vaas}
+	return &res, nil
+}
+
 // GetVaaCount get a list a list of vaa count grouped by chainID.
 func (s *Service) GetVaaCount(ctx context.Context) (*response.Response[[]*VaaStats], error) {
 	q := Query()
@@ -260,7 +311,7 @@ func (s *Service) ParseVaa(ctx context.Context, vaaByte []byte) (vaaPayloadParse
 	}
 
 	// call vaa payload parser api
-	parsedVaa, err := s.parseVaaFunc(vaa)
+	parsedVaa, err := s.parseVaaFunc(ctx, vaa)
 	if err != nil {
 		if errors.Is(err, vaaPayloadParser.ErrNotFound) {
 			return vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{}, errs.ErrNotFound
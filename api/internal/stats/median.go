@@ -0,0 +1,21 @@
+// Package stats contains the statistics helpers shared by the handlers.
+package stats
+
+import "sort"
+
+// Median returns the median of the values, or false if there are none.
+// For an even number of values it is the mean of the two middle values.
+func Median(values []int64) (int64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	m := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		m = (sorted[len(sorted)/2-1] + m) / 2
+	}
+	return m, true
+}
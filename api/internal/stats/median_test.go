@@ -0,0 +1,20 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedian(t *testing.T) {
+	_, ok := Median(nil)
+	assert.False(t, ok)
+
+	m, ok := Median([]int64{5, 1, 3})
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), m)
+
+	m, ok = Median([]int64{8, 2, 6, 1})
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), m)
+}
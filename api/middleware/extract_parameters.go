@@ -10,6 +10,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	"github.com/wormhole-foundation/wormhole-explorer/api/types"
@@ -60,6 +61,56 @@ func ExtractToChain(c *fiber.Ctx, l *zap.Logger) (*sdk.ChainID, error) {
 	return &result, nil
 }
 
+// ExtractTokenChain obtains the "tokenChain" query parameter from the request.
+//
+// When the parameter is not present, the function returns: a nil ChainID and a nil error.
+func ExtractTokenChain(c *fiber.Ctx, l *zap.Logger) (*sdk.ChainID, error) {
+
+	param := c.Query("tokenChain")
+	if param == "" {
+		return nil, nil
+	}
+
+	chain, err := strconv.ParseInt(param, 10, 16)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", c.Locals("requestid"))
+		l.Error("failed to parse tokenChain parameter",
+			zap.Error(err),
+			zap.String("requestID", requestID),
+		)
+
+		return nil, response.NewInvalidParamError(c, "INVALID TOKEN_CHAIN VALUE", errors.WithStack(err))
+	}
+
+	result := sdk.ChainID(chain)
+	return &result, nil
+}
+
+// ExtractChainFromQueryParams obtains the "chain" query parameter from the request.
+//
+// When the parameter is not present, the function returns: a nil ChainID and a nil error.
+func ExtractChainFromQueryParams(c *fiber.Ctx, l *zap.Logger) (*sdk.ChainID, error) {
+
+	param := c.Query("chain")
+	if param == "" {
+		return nil, nil
+	}
+
+	chain, err := strconv.ParseInt(param, 10, 16)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", c.Locals("requestid"))
+		l.Error("failed to parse chain parameter",
+			zap.Error(err),
+			zap.String("requestID", requestID),
+		)
+
+		return nil, response.NewInvalidParamError(c, "INVALID CHAIN VALUE", errors.WithStack(err))
+	}
+
+	result := sdk.ChainID(chain)
+	return &result, nil
+}
+
 // ExtractEmitterAddr parses the emitter address from the request path.
 //
 // When the parameter `chainIdHint` is not nil, this function will attempt to parse the
@@ -329,6 +380,21 @@ func ExtractTime(c *fiber.Ctx, queryParam string) (*time.Time, error) {
 	return &t, nil
 }
 
+// ExtractDuration parses a duration query parameter, e.g. 24h or 30m.
+//
+// When the parameter is not present, the function returns zero.
+func ExtractDuration(c *fiber.Ctx, queryParam string) (time.Duration, error) {
+	param := c.Query(queryParam)
+	if param == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(param)
+	if err != nil || d < 0 {
+		return 0, response.NewInvalidQueryParamError(c, fmt.Sprintf("INVALID <%s> QUERY PARAMETER", queryParam), nil)
+	}
+	return d, nil
+}
+
 func ExtractApps(ctx *fiber.Ctx) ([]string, error) {
 	apps := ctx.Query("apps")
 	if apps == "" {
@@ -351,6 +417,15 @@ func ExtractIsNotional(ctx *fiber.Ctx) (bool, error) {
 	return false, response.NewInvalidQueryParamError(ctx, "INVALID <by> QUERY PARAMETER", nil)
 }
 
+// ExtractAddressActivityInterval parses the `interval` parameter of the address activity endpoint.
+func ExtractAddressActivityInterval(ctx *fiber.Ctx) (address.ActivityInterval, error) {
+	interval, err := address.ParseActivityInterval(ctx.Query("interval"))
+	if err != nil {
+		return "", response.NewInvalidQueryParamError(ctx, "INVALID <interval> QUERY PARAMETER", nil)
+	}
+	return interval, nil
+}
+
 func ExtractChainActivityTimeSpan(ctx *fiber.Ctx) (transactions.ChainActivityTimeSpan, error) {
 	s := ctx.Query("timeSpan", string(transactions.ChainActivityTs7Days))
 	timeSpan, err := transactions.ParseChainActivityTimeSpan(s)
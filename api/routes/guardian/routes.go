@@ -2,10 +2,10 @@ package guardian
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/config"
 	govsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
 	heartbeatssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
 	vaasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
-	"github.com/wormhole-foundation/wormhole-explorer/api/internal/config"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/guardian/governor"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/guardian/guardian"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/guardian/heartbeats"
@@ -55,3 +55,43 @@ func (c *Controller) FindById(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(response)
 }
+
+// GetActivity godoc
+// @Description Returns the portfolio and the activity of an address in the operations where it is the sender or the recipient:
+// @Description the totals sent and received by chain and token, the USD volume over time, the counterparties, the apps used,
+// @Description the inbound transfers that were not redeemed yet and the first and last activity.
+// @Description The address can be given in hex, in the Wormhole format or in the native format of its chain.
+// @Tags wormholescan
+// @ID get-address-activity
+// @Param address path string true "address"
+// @Param chain query integer false "chain of a native address, every chain is tried when it is not set"
+// @Param interval query string false "interval of the volume over time: 1d, 1w or 1mo (default 1d)"
+// @Success 200 {object} response.Response[address.AddressActivity]
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/address/{address}/activity [get]
+func (c *Controller) GetActivity(ctx *fiber.Ctx) error {
+
+	address := middleware.ExtractAddressFromPath(ctx, c.logger)
+
+	chainID, err := middleware.ExtractChainFromQueryParams(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	interval, err := middleware.ExtractAddressActivityInterval(ctx)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.srv.GetAddressActivity(ctx.Context(), address, chainID, interval)
+	if err != nil {
+		return err
+	}
+	if response.Data.Operations == 0 {
+		return errors.ErrNotFound
+	}
+
+	return ctx.JSON(response)
+}
@@ -0,0 +1,77 @@
+package gaps
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/gaps"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *gaps.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *gaps.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "GapsController")),
+	}
+}
+
+// FindGaps godoc
+// @Description Returns the sequence gaps detected by the chain health monitor, i.e. ranges of sequences
+// @Description of an emitter for which no VAA was found.
+// @Tags wormholescan
+// @ID find-gaps
+// @Param chain query integer false "emitter chain"
+// @Param status query string false "gap status, supported values: open, closed and all (default is open)"
+// @Param page query integer false "Page number."
+// @Param pageSize query integer false "Number of elements per page."
+// @Param sortOrder query string false "Sort results in ascending or descending order." Enums(ASC, DESC)
+// @Success 200 {object} response.Response[[]gaps.SequenceGap]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/gaps [get]
+func (c *Controller) FindGaps(ctx *fiber.Ctx) error {
+	p, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+
+	var chainID *sdk.ChainID
+	if param := ctx.Query("chain"); param != "" {
+		chain, err := strconv.ParseUint(param, 10, 16)
+		if err != nil {
+			requestID := fmt.Sprintf("%v", ctx.Locals("requestid"))
+			c.logger.Error("failed to parse chain parameter",
+				zap.Error(err), zap.String("requestID", requestID))
+			return response.NewInvalidParamError(ctx, "INVALID CHAIN VALUE", errors.WithStack(err))
+		}
+		result := sdk.ChainID(chain)
+		chainID = &result
+	}
+
+	var status string
+	switch param := ctx.Query("status", gaps.StatusOpen); param {
+	case gaps.StatusOpen, gaps.StatusClosed:
+		status = param
+	case "all":
+	default:
+		return response.NewInvalidParamError(ctx, "INVALID STATUS VALUE", nil)
+	}
+
+	result, err := c.srv.FindGaps(ctx.Context(), chainID, status, p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Response[[]*gaps.SequenceGap]{Data: result})
+}
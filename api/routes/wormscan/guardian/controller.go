@@ -0,0 +1,82 @@
+package guardian
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *guardian.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *guardian.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "GuardianController")),
+	}
+}
+
+// GetGuardianSets godoc
+// @Description Get every guardian set, with the name of each guardian and the activation and expiration times of the set.
+// @Tags wormholescan
+// @ID get-guardian-sets
+// @Success 200 {object} response.Response[[]guardian.GuardianSetDoc]
+// @Failure 500
+// @Router /api/v1/guardian-sets [get]
+func (c *Controller) GetGuardianSets(ctx *fiber.Ctx) error {
+	sets, err := c.srv.GetGuardianSets(ctx.Context())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Response[[]*guardian.GuardianSetDoc]{Data: sets})
+}
+
+// GetGuardianStats godoc
+// @Description Get the statistics of a guardian: signing participation rate and median observation latency per chain,
+// @Description and the node information reported in its last heartbeat.
+// @Tags wormholescan
+// @ID get-guardian-stats
+// @Param guardian_address path string true "guardian address"
+// @Param timeSpan query string false "Time span, supported values: 1d, 1w and 1mo (default is 1d)."
+// @Success 200 {object} guardian.GuardianStats
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/guardians/{guardian_address}/stats [get]
+func (c *Controller) GetGuardianStats(ctx *fiber.Ctx) error {
+	addr, err := middleware.ExtractGuardianAddress(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	timeSpan, err := middleware.ExtractTimeSpan(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	from := time.Now().Add(-timeSpanDuration(timeSpan))
+	stats, err := c.srv.GetGuardianStats(ctx.Context(), common.HexToAddress(addr.ShortHex()), from)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(stats)
+}
+
+func timeSpanDuration(timeSpan string) time.Duration {
+	switch timeSpan {
+	case "1w":
+		return 7 * 24 * time.Hour
+	case "1mo":
+		return 30 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
@@ -0,0 +1,76 @@
+package heartbeats
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *heartbeats.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *heartbeats.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "HeartbeatsController")),
+	}
+}
+
+// GetHistory godoc
+// @Description Get the heartbeats history of a guardian, downsampled to one heartbeat every 5 minutes (1d),
+// @Description 30 minutes (1w) or 2 hours (1mo).
+// @Tags wormholescan
+// @ID get-heartbeats-history
+// @Param guardian_address path string true "guardian address"
+// @Param timeSpan query string false "Time span, supported values: 1d, 1w and 1mo (default is 1d)."
+// @Success 200 {object} response.Response[[]heartbeats.HeartbeatHistoryDoc]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/heartbeats/{guardian_address}/history [get]
+func (c *Controller) GetHistory(ctx *fiber.Ctx) error {
+	addr, err := middleware.ExtractGuardianAddress(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	timeSpan, err := middleware.ExtractTimeSpan(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	// heartbeats are stored with the checksummed guardian address.
+	guardianAddr := common.HexToAddress(addr.ShortHex()).Hex()
+	history, err := c.srv.GetHistory(ctx.Context(), guardianAddr, timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Response[[]*heartbeats.HeartbeatHistoryDoc]{Data: history})
+}
+
+// GetUptime godoc
+// @Description Get the uptime of each guardian and, for each chain, whether the height reported by the guardian
+// @Description is advancing and how far it is behind the majority of guardians.
+// @Tags wormholescan
+// @ID get-heartbeats-uptime
+// @Param timeSpan query string false "Time span, supported values: 1d, 1w and 1mo (default is 1d)."
+// @Success 200 {object} response.Response[[]heartbeats.GuardianUptime]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/heartbeats/uptime [get]
+func (c *Controller) GetUptime(ctx *fiber.Ctx) error {
+	timeSpan, err := middleware.ExtractTimeSpan(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	uptime, err := c.srv.GetUptime(ctx.Context(), timeSpan)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Response[[]*heartbeats.GuardianUptime]{Data: uptime})
+}
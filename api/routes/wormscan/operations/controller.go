@@ -4,8 +4,10 @@ import (
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	"go.uber.org/zap"
 )
 
@@ -86,3 +88,64 @@ func (c *Controller) FindById(ctx *fiber.Ctx) error {
 	}
 	return ctx.JSON(response)
 }
+
+// FindUnredeemed godoc
+// @Description Returns the transfers whose VAA was signed, or is held by the governor, and that were not
+// @Description redeemed on the destination chain, with the signed VAA and the contract and method that
+// @Description redeem them. The transfers held by the governor can not be redeemed until they are released.
+// @Tags wormholescan
+// @ID find-unredeemed-operations
+// @Param toChain query integer false "destination chain"
+// @Param address query string false "recipient address, in hex or in the native format of the destination chain"
+// @Param tokenChain query integer false "chain of the token"
+// @Param token query string false "token address"
+// @Param minAge query string false "minimum time since the transfer was emitted, e.g. 24h"
+// @Param maxAge query string false "maximum time since the transfer was emitted, e.g. 720h"
+// @Param page query integer false "Page number."
+// @Param pageSize query integer false "Number of elements per page."
+// @Param sortOrder query string false "Sort results in ascending or descending order." Enums(ASC, DESC)
+// @Success 200 {object} response.Response[[]operations.UnredeemedTransfer]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/operations/unredeemed [get]
+func (c *Controller) FindUnredeemed(ctx *fiber.Ctx) error {
+	p, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+	toChain, err := middleware.ExtractToChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	tokenChain, err := middleware.ExtractTokenChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	minAge, err := middleware.ExtractDuration(ctx, "minAge")
+	if err != nil {
+		return err
+	}
+	maxAge, err := middleware.ExtractDuration(ctx, "maxAge")
+	if err != nil {
+		return err
+	}
+
+	q := &operations.UnredeemedQuery{
+		ToChain:    toChain,
+		TokenChain: tokenChain,
+		MinAge:     minAge,
+		MaxAge:     maxAge,
+	}
+	if recipient := middleware.ExtractAddressFromQueryParams(ctx, c.logger); recipient != "" {
+		q.ToAddress = address.StoredFormats(recipient, toChain)
+	}
+	if token := ctx.Query("token"); token != "" {
+		q.TokenAddress = address.StoredFormats(token, tokenChain)
+	}
+
+	transfers, err := c.srv.FindUnredeemed(ctx.Context(), q, p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Response[[]*operations.UnredeemedTransfer]{Data: transfers})
+}
@@ -25,6 +25,8 @@ type OperationResponse struct {
 	SourceChain    *SourceChain   `json:"sourceChain,omitempty"`
 	TargetChain    *TargetChain   `json:"targetChain,omitempty"`
 	Data           map[string]any `json:"data,omitempty"`
+	// Lifecycle is the current state of the operation and the transitions to it.
+	Lifecycle *operations.Lifecycle `json:"lifecycle,omitempty"`
 }
 
 // EmitterAddress definition.
@@ -127,6 +129,7 @@ func toOperationResponse(operation *operations.OperationDto, log *zap.Logger) (*
 		Data:        getAdditionalData(operation),
 		SourceChain: sourceChain,
 		TargetChain: targetChain,
+		Lifecycle:   operation.Lifecycle,
 	}
 
 	return &r, nil
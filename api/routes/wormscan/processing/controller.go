@@ -0,0 +1,50 @@
+package processing
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/processing"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *processing.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *processing.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "ProcessingController")),
+	}
+}
+
+// FindByVAA godoc
+// @Description Returns the processing of a VAA by each stage of the explorer (fly, pipeline, parser, tx-tracker,
+// @Description analytics and contract-watcher): the outcome, the version of the service and how long it took.
+// @Description The stages that have not processed the VAA are pending.
+// @Tags wormholescan
+// @ID find-processing-by-vaa-id
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param seq path integer true "sequence of the VAA"
+// @Success 200 {object} processing.VaaProcessing
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/processing/{chain_id}/{emitter}/{seq} [get]
+func (c *Controller) FindByVAA(ctx *fiber.Ctx) error {
+	chainID, addr, seq, err := middleware.ExtractVAAParams(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	result, err := c.srv.FindByVAA(ctx.Context(), chainID, addr, strconv.FormatUint(seq, 10))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
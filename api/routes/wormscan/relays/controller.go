@@ -86,7 +86,9 @@ func (c *Controller) makeResponse(doc *relays.RelayDoc) *RelayResponse {
 				RefundChainID:          doc.Data.Metadata.Instructions.RefundChainID,
 				RefundDeliveryProvider: doc.Data.Metadata.Instructions.RefundDeliveryProvider,
 				TargetChainID:          doc.Data.Metadata.Instructions.TargetChainID,
+				GasLimit:               doc.Data.Metadata.Instructions.GasLimit,
 			},
+			RedeliveryIDs: doc.Data.RedeliveryIDs,
 		}
 	}
 	return &RelayResponse{
@@ -111,10 +113,11 @@ type RelayResponse struct {
 }
 
 type RelayDataResponse struct {
-	FromTxHash   string               `json:"fromTxHash"`
-	ToTxHash     *string              `json:"toTxHash"`
-	Instructions InstructionsResponse `json:"instructions"`
-	Delivery     DeliveryReponse      `json:"delivery"`
+	FromTxHash    string               `json:"fromTxHash"`
+	ToTxHash      *string              `json:"toTxHash"`
+	Instructions  InstructionsResponse `json:"instructions"`
+	Delivery      DeliveryReponse      `json:"delivery"`
+	RedeliveryIDs []string             `json:"redeliveryIds,omitempty"`
 }
 
 type DeliveryReponse struct {
@@ -149,4 +152,5 @@ type InstructionsResponse struct {
 	RefundChainID          int    `json:"refundChainId"`
 	RefundDeliveryProvider string `json:"refundDeliveryProvider"`
 	TargetChainID          int    `json:"targetChainId"`
+	GasLimit               string `json:"gasLimit,omitempty"`
 }
@@ -7,18 +7,26 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cache"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	addrsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	gapssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/gaps"
 	govsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
+	guardiansvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/guardian"
+	heartbeatssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
 	infrasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/infrastructure"
 	obssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/observations"
 	opsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
+	processingsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/processing"
 	relayssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
 	trxsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
 	vaasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/address"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/gaps"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/governor"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/heartbeats"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/infrastructure"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/observations"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/processing"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/relays"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/transactions"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/vaa"
@@ -47,6 +55,10 @@ func RegisterRoutes(
 	transactionsService *trxsvc.Service,
 	relaysService *relayssvc.Service,
 	operationsService *opsvc.Service,
+	guardianService *guardiansvc.Service,
+	heartbeatsService *heartbeatssvc.Service,
+	gapsService *gapssvc.Service,
+	processingService *processingsvc.Service,
 ) {
 
 	// Set up controllers
@@ -58,6 +70,10 @@ func RegisterRoutes(
 	transactionCtrl := transactions.NewController(transactionsService, rootLogger)
 	relaysCtrl := relays.NewController(relaysService, rootLogger)
 	opsCtrl := operations.NewController(operationsService, rootLogger)
+	guardianCtrl := guardian.NewController(guardianService, rootLogger)
+	heartbeatsCtrl := heartbeats.NewController(heartbeatsService, rootLogger)
+	gapsCtrl := gaps.NewController(gapsService, rootLogger)
+	processingCtrl := processing.NewController(processingService, rootLogger)
 
 	// Set up route handlers
 	api := app.Group("/api/v1")
@@ -70,6 +86,7 @@ func RegisterRoutes(
 
 	// accounts resource
 	api.Get("/address/:id", addressCtrl.FindById)
+	api.Get("/address/:id/activity", addressCtrl.GetActivity)
 
 	// analytics, transactions, custom endpoints
 	api.Get("/global-tx/:chain/:emitter/:sequence", transactionCtrl.FindGlobalTransactionByID)
@@ -85,6 +102,7 @@ func RegisterRoutes(
 	// operations resource
 	operations := api.Group("/operations")
 	operations.Get("/", opsCtrl.FindAll)
+	operations.Get("/unredeemed", opsCtrl.FindUnredeemed)
 	operations.Get("/:chain/:emitter/:sequence", opsCtrl.FindById)
 
 	// vaas resource
@@ -96,6 +114,7 @@ func RegisterRoutes(
 	vaas.Get("/:chain/:emitter", vaaCtrl.FindByEmitter)
 	vaas.Get("/:chain/:emitter/:sequence", vaaCtrl.FindById)
 	vaas.Post("/parse", vaaCtrl.ParseVaa)
+	vaas.Post("/batch", vaaCtrl.FindBulk)
 
 	// oservations resource
 	observations := api.Group("/observations")
@@ -129,6 +148,23 @@ func RegisterRoutes(
 	enqueueVaas.Get("/", governorCtrl.GetEnqueuedVaas)
 	enqueueVaas.Get("/:chain", governorCtrl.GetEnqueuedVaasByChainID)
 
+	// guardians resources
+	api.Get("/guardian-sets", guardianCtrl.GetGuardianSets)
+	guardians := api.Group("/guardians")
+	guardians.Get("/:guardian_address/stats", guardianCtrl.GetGuardianStats)
+
+	// heartbeats resources
+	heartbeats := api.Group("/heartbeats")
+	heartbeats.Get("/uptime", heartbeatsCtrl.GetUptime)
+	heartbeats.Get("/:guardian_address/history", heartbeatsCtrl.GetHistory)
+
+	// sequence gaps resources
+	api.Get("/gaps", gapsCtrl.FindGaps)
+
 	relays := api.Group("/relays")
 	relays.Get("/:chain/:emitter/:sequence", relaysCtrl.FindOne)
+
+	// vaa processing lineage
+	processing := api.Group("/processing")
+	processing.Get("/:chain/:emitter/:sequence", processingCtrl.FindByVAA)
 }
@@ -3,6 +3,7 @@ package vaa
 
 import (
 	"encoding/base64"
+	"fmt"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,6 +12,8 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	_ "github.com/wormhole-foundation/wormhole-explorer/api/response" // required by swaggo
+	"github.com/wormhole-foundation/wormhole-explorer/api/types"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -193,6 +196,84 @@ func (c *Controller) FindById(ctx *fiber.Ctx) error {
 	return ctx.JSON(vaa)
 }
 
+// FindBulkRequest is the body of the FindBulk request.
+type FindBulkRequest struct {
+	// IDs of the VAAs, with the format emitter_chain/emitter_address/sequence.
+	IDs []string `json:"ids"`
+	// TxHashes are the hashes of the transactions that emitted the VAAs.
+	TxHashes []string `json:"txHashes"`
+}
+
+// FindBulk godoc
+// @Description Returns all the VAAs that match any of the given VAA IDs or transaction hashes.
+// @Description At most 100 VAA IDs and transaction hashes can be requested.
+// @Tags wormholescan
+// @ID find-vaas-bulk
+// @Param request body FindBulkRequest true "VAA IDs and transaction hashes"
+// @Param parsedPayload query bool false "include the parsed contents of the VAA, if available"
+// @Success 200 {object} response.Response[[]vaa.VaaDoc]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/vaas/batch [post]
+func (c *Controller) FindBulk(ctx *fiber.Ctx) error {
+
+	var body FindBulkRequest
+	err := ctx.BodyParser(&body)
+	if err != nil {
+		return response.NewRequestBodyError(ctx,
+			"invalid vaa batch request, unable to parse",
+			errors.WithStack(err))
+	}
+
+	if len(body.IDs) == 0 && len(body.TxHashes) == 0 {
+		return response.NewRequestBodyError(ctx,
+			"invalid vaa batch request, ids and txHashes are empty",
+			nil)
+	}
+	if len(body.IDs)+len(body.TxHashes) > vaa.MaxBulkSize {
+		return response.NewRequestBodyError(ctx,
+			fmt.Sprintf("invalid vaa batch request, at most %d ids and txHashes can be requested", vaa.MaxBulkSize),
+			nil)
+	}
+
+	includeParsedPayload, err := middleware.ExtractParsedPayload(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	p := vaa.FindBulkParams{IncludeParsedPayload: includeParsedPayload}
+	for _, value := range body.IDs {
+		id, err := types.ParseVaaID(value)
+		if err != nil {
+			return response.NewRequestBodyError(ctx,
+				fmt.Sprintf("invalid vaa batch request, malformed id %s", value),
+				errors.WithStack(err))
+		}
+		// PythNet VAAs are not stored with the other VAAs.
+		if id.EmitterChain == sdk.ChainIDPythNet {
+			return response.NewRequestBodyError(ctx,
+				"invalid vaa batch request, not supported for PythNet",
+				nil)
+		}
+		p.IDs = append(p.IDs, id)
+	}
+	for _, value := range body.TxHashes {
+		txHash, err := types.ParseTxHash(value)
+		if err != nil {
+			return response.NewRequestBodyError(ctx,
+				fmt.Sprintf("invalid vaa batch request, malformed txHash %s", value),
+				errors.WithStack(err))
+		}
+		p.TxHashes = append(p.TxHashes, txHash)
+	}
+
+	vaas, err := c.srv.FindBulk(ctx.Context(), &p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(vaas)
+}
+
 // GetVaaCount godoc
 // @Description Returns the total number of VAAs emitted for each blockchain.
 // @Tags wormholescan
@@ -14,6 +14,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
 	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	explorerv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/proto/explorer/v1"
 	"github.com/wormhole-foundation/wormhole-explorer/api/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -24,6 +25,7 @@ import (
 // Handler rpc handler.
 type Handler struct {
 	publicrpcv1.UnimplementedPublicRPCServiceServer
+	explorerv1.UnimplementedExplorerRPCServiceServer
 	gs     guardian.GuardianSet
 	vaaSrv *vaaservice.Service
 	hbSrv  *heartbeats.Service
@@ -87,9 +89,60 @@ func (h *Handler) GetSignedVAA(ctx context.Context, request *publicrpcv1.GetSign
 	}, nil
 }
 
-// GetSignedBatchVAA get signed batch VAA.
+// GetSignedBatchVAA is not implemented: a signed batch VAA carries the guardian signatures of the batch,
+// and the guardians never sign batches, so there is nothing to look up. The VAAs of a transaction are
+// returned one by one by GetSignedVAA and StreamSignedVAAs.
 func (h *Handler) GetSignedBatchVAA(ctx context.Context, _ *publicrpcv1.GetSignedBatchVAARequest) (*publicrpcv1.GetSignedBatchVAAResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not yet implemented")
+	return nil, status.Error(codes.Unimplemented, "batch VAAs are not signed by the guardians, use GetSignedVAA")
+}
+
+// StreamSignedVAAs streams all the VAAs found for a set of VAA IDs and transaction hashes.
+func (h *Handler) StreamSignedVAAs(request *explorerv1.StreamSignedVAAsRequest, stream explorerv1.ExplorerRPCService_StreamSignedVAAsServer) error {
+	if len(request.VaaIds) == 0 && len(request.TxHashes) == 0 {
+		return status.Error(codes.InvalidArgument, "no VAA IDs or transaction hashes specified")
+	}
+	if len(request.VaaIds)+len(request.TxHashes) > vaaservice.MaxBulkSize {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d VAA IDs and transaction hashes can be requested", vaaservice.MaxBulkSize))
+	}
+
+	// parse VAA IDs and transaction hashes.
+	params := vaaservice.FindBulkParams{}
+	for _, value := range request.VaaIds {
+		id, err := types.ParseVaaID(value)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		// PythNet VAAs are not stored in the database.
+		if id.EmitterChain == vaa.ChainIDPythNet {
+			return status.Error(codes.InvalidArgument, "not supported for PythNet")
+		}
+		params.IDs = append(params.IDs, id)
+	}
+	for _, value := range request.TxHashes {
+		txHash, err := types.ParseTxHash(value)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to parse transaction hash %s: %v", value, err))
+		}
+		params.TxHashes = append(params.TxHashes, txHash)
+	}
+
+	// get VAAs by IDs and transaction hashes.
+	vaas, err := h.vaaSrv.FindBulk(stream.Context(), &params)
+	if err != nil {
+		h.logger.Error("failed to fetch VAAs", zap.Error(err), zap.Any("request", request))
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	for _, v := range vaas.Data {
+		signedVAA := explorerv1.SignedVAA{Id: v.ID, VaaBytes: v.Vaa}
+		if v.TxHash != nil {
+			signedVAA.TxHash = *v.TxHash
+		}
+		if err := stream.Send(&signedVAA); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // GetLastHeartbeats get last heartbeats.
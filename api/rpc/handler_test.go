@@ -0,0 +1,176 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/stretchr/testify/assert"
+	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	explorerv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/proto/explorer/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testEmitterAddress = "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585"
+	testTxHash         = "3b3b7b5f1ef8c67afd8dd1e6a6bb1d9e0b2c2a0b1b4f9c1c6ff4bdbcbb0d2f73"
+)
+
+// newTestHandler returns a handler whose VAA service reads from the mocked database of mt.
+func newTestHandler(mt *mtest.T) *Handler {
+	getCache := func(_ context.Context, _ string) (string, error) {
+		return "", cache.ErrNotFound
+	}
+	repo := vaaservice.NewRepository(mt.DB, zap.NewNop())
+	srv := vaaservice.NewService(repo, getCache, nil, zap.NewNop())
+	return &Handler{vaaSrv: srv, logger: zap.NewNop()}
+}
+
+// vaaDoc returns a stored VAA document.
+func vaaDoc(sequence string, vaaBytes []byte) bson.D {
+	return bson.D{
+		{Key: "_id", Value: "2/" + testEmitterAddress + "/" + sequence},
+		{Key: "emitterChain", Value: 2},
+		{Key: "emitterAddr", Value: testEmitterAddress},
+		{Key: "sequence", Value: sequence},
+		{Key: "vaas", Value: vaaBytes},
+		{Key: "txHash", Value: testTxHash},
+	}
+}
+
+// fakeSignedVAAsStream is a server stream that records the sent VAAs.
+type fakeSignedVAAsStream struct {
+	grpc.ServerStream
+	sent []*explorerv1.SignedVAA
+}
+
+func (s *fakeSignedVAAsStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeSignedVAAsStream) Send(v *explorerv1.SignedVAA) error {
+	s.sent = append(s.sent, v)
+	return nil
+}
+
+func TestStreamSignedVAAs_InvalidArguments(t *testing.T) {
+	h := &Handler{logger: zap.NewNop()}
+
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1"
+	}
+
+	tcs := []struct {
+		name    string
+		request *explorerv1.StreamSignedVAAsRequest
+	}{
+		{name: "empty request", request: &explorerv1.StreamSignedVAAsRequest{}},
+		{name: "too many ids", request: &explorerv1.StreamSignedVAAsRequest{VaaIds: tooMany}},
+		{name: "malformed id", request: &explorerv1.StreamSignedVAAsRequest{VaaIds: []string{"2/0x3ee18b"}}},
+		{name: "pythnet id", request: &explorerv1.StreamSignedVAAsRequest{
+			VaaIds: []string{"26/f8cd23c2ab91237730770bbea08d61005cdda0984348f3f6eecb559638c0bba0/1"},
+		}},
+		{name: "malformed tx hash", request: &explorerv1.StreamSignedVAAsRequest{TxHashes: []string{"0x1234"}}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &fakeSignedVAAsStream{}
+			err := h.StreamSignedVAAs(tc.request, stream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Empty(t, stream.sent)
+		})
+	}
+}
+
+func TestStreamSignedVAAs(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("vaa ids and tx hashes", func(mt *mtest.T) {
+		// the transaction hash is resolved in globalTransactions before the VAAs are fetched.
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.globalTransactions", mtest.FirstBatch,
+				bson.D{{Key: "_id", Value: "2/" + testEmitterAddress + "/2"}}),
+			mtest.CreateCursorResponse(0, "test.vaas", mtest.FirstBatch,
+				vaaDoc("1", []byte{1, 2, 3}),
+				vaaDoc("2", []byte{4, 5, 6})),
+		)
+
+		stream := &fakeSignedVAAsStream{}
+		err := newTestHandler(mt).StreamSignedVAAs(&explorerv1.StreamSignedVAAsRequest{
+			VaaIds:   []string{"2/" + testEmitterAddress + "/1"},
+			TxHashes: []string{testTxHash},
+		}, stream)
+		assert.NoError(t, err)
+		assert.Len(t, stream.sent, 2)
+		assert.Equal(t, "2/"+testEmitterAddress+"/1", stream.sent[0].Id)
+		assert.Equal(t, []byte{1, 2, 3}, stream.sent[0].VaaBytes)
+		assert.Equal(t, testTxHash, stream.sent[0].TxHash)
+		assert.Equal(t, "2/"+testEmitterAddress+"/2", stream.sent[1].Id)
+		assert.Equal(t, []byte{4, 5, 6}, stream.sent[1].VaaBytes)
+	})
+
+	mt.Run("not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.vaas", mtest.FirstBatch))
+
+		stream := &fakeSignedVAAsStream{}
+		err := newTestHandler(mt).StreamSignedVAAs(&explorerv1.StreamSignedVAAsRequest{
+			VaaIds: []string{"2/" + testEmitterAddress + "/1"},
+		}, stream)
+		assert.NoError(t, err)
+		assert.Empty(t, stream.sent)
+	})
+}
+
+func TestGetSignedVAA(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.vaas", mtest.FirstBatch, vaaDoc("1", []byte{1, 2, 3})))
+
+		response, err := newTestHandler(mt).GetSignedVAA(context.Background(), &publicrpcv1.GetSignedVAARequest{
+			MessageId: &publicrpcv1.MessageID{
+				EmitterChain:   publicrpcv1.ChainID_CHAIN_ID_ETHEREUM,
+				EmitterAddress: testEmitterAddress,
+				Sequence:       1,
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, response.VaaBytes)
+	})
+
+	mt.Run("not found", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.vaas", mtest.FirstBatch))
+
+		_, err := newTestHandler(mt).GetSignedVAA(context.Background(), &publicrpcv1.GetSignedVAARequest{
+			MessageId: &publicrpcv1.MessageID{
+				EmitterChain:   publicrpcv1.ChainID_CHAIN_ID_ETHEREUM,
+				EmitterAddress: testEmitterAddress,
+				Sequence:       1,
+			},
+		})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGetSignedBatchVAA(t *testing.T) {
+	h := &Handler{logger: zap.NewNop()}
+
+	// the guardians do not sign batches, the method is unimplemented for every request.
+	_, err := h.GetSignedBatchVAA(context.Background(), &publicrpcv1.GetSignedBatchVAARequest{
+		BatchId: &publicrpcv1.BatchID{EmitterChain: publicrpcv1.ChainID_CHAIN_ID_ETHEREUM, TxId: make([]byte, 32), Nonce: 1},
+	})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	_, err = h.GetSignedBatchVAA(context.Background(), &publicrpcv1.GetSignedBatchVAARequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1-devel
+// 	protoc        (unknown)
+// source: explorer/v1/explorer.proto
+
+package explorerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreamSignedVAAsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// IDs of the VAAs, with the format emitter_chain/emitter_address/sequence.
+	VaaIds []string `protobuf:"bytes,1,rep,name=vaa_ids,json=vaaIds,proto3" json:"vaa_ids,omitempty"`
+	// Hashes of the transactions that emitted the VAAs.
+	TxHashes []string `protobuf:"bytes,2,rep,name=tx_hashes,json=txHashes,proto3" json:"tx_hashes,omitempty"`
+}
+
+func (x *StreamSignedVAAsRequest) Reset() {
+	*x = StreamSignedVAAsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explorer_v1_explorer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamSignedVAAsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSignedVAAsRequest) ProtoMessage() {}
+
+func (x *StreamSignedVAAsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explorer_v1_explorer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSignedVAAsRequest.ProtoReflect.Descriptor instead.
+func (*StreamSignedVAAsRequest) Descriptor() ([]byte, []int) {
+	return file_explorer_v1_explorer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamSignedVAAsRequest) GetVaaIds() []string {
+	if x != nil {
+		return x.VaaIds
+	}
+	return nil
+}
+
+func (x *StreamSignedVAAsRequest) GetTxHashes() []string {
+	if x != nil {
+		return x.TxHashes
+	}
+	return nil
+}
+
+type SignedVAA struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID of the VAA, with the format emitter_chain/emitter_address/sequence.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Signed VAA bytes.
+	VaaBytes []byte `protobuf:"bytes,2,opt,name=vaa_bytes,json=vaaBytes,proto3" json:"vaa_bytes,omitempty"`
+	// Hash of the transaction that emitted the VAA.
+	TxHash string `protobuf:"bytes,3,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+}
+
+func (x *SignedVAA) Reset() {
+	*x = SignedVAA{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explorer_v1_explorer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedVAA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedVAA) ProtoMessage() {}
+
+func (x *SignedVAA) ProtoReflect() protoreflect.Message {
+	mi := &file_explorer_v1_explorer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedVAA.ProtoReflect.Descriptor instead.
+func (*SignedVAA) Descriptor() ([]byte, []int) {
+	return file_explorer_v1_explorer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignedVAA) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SignedVAA) GetVaaBytes() []byte {
+	if x != nil {
+		return x.VaaBytes
+	}
+	return nil
+}
+
+func (x *SignedVAA) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+var File_explorer_v1_explorer_proto protoreflect.FileDescriptor
+
+var file_explorer_v1_explorer_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x4f, 0x0a, 0x17, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x61, 0x61, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x61, 0x49, 0x64, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x09, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x76, 0x61, 0x61, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x76, 0x61, 0x61, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x78, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x32, 0x68, 0x0a,
+	0x12, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x52, 0x0a, 0x10, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x12, 0x24, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x56, 0x41, 0x41, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x56, 0x41, 0x41, 0x30, 0x01, 0x42, 0x57, 0x5a, 0x55, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x77, 0x6f, 0x72, 0x6d, 0x68, 0x6f, 0x6c, 0x65, 0x2d, 0x66,
+	0x6f, 0x75, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x77, 0x6f, 0x72, 0x6d, 0x68, 0x6f,
+	0x6c, 0x65, 0x2d, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_explorer_v1_explorer_proto_rawDescOnce sync.Once
+	file_explorer_v1_explorer_proto_rawDescData = file_explorer_v1_explorer_proto_rawDesc
+)
+
+func file_explorer_v1_explorer_proto_rawDescGZIP() []byte {
+	file_explorer_v1_explorer_proto_rawDescOnce.Do(func() {
+		file_explorer_v1_explorer_proto_rawDescData = protoimpl.X.CompressGZIP(file_explorer_v1_explorer_proto_rawDescData)
+	})
+	return file_explorer_v1_explorer_proto_rawDescData
+}
+
+var file_explorer_v1_explorer_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_explorer_v1_explorer_proto_goTypes = []interface{}{
+	(*StreamSignedVAAsRequest)(nil), // 0: explorer.v1.StreamSignedVAAsRequest
+	(*SignedVAA)(nil),               // 1: explorer.v1.SignedVAA
+}
+var file_explorer_v1_explorer_proto_depIdxs = []int32{
+	0, // 0: explorer.v1.ExplorerRPCService.StreamSignedVAAs:input_type -> explorer.v1.StreamSignedVAAsRequest
+	1, // 1: explorer.v1.ExplorerRPCService.StreamSignedVAAs:output_type -> explorer.v1.SignedVAA
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_explorer_v1_explorer_proto_init() }
+func file_explorer_v1_explorer_proto_init() {
+	if File_explorer_v1_explorer_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_explorer_v1_explorer_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamSignedVAAsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explorer_v1_explorer_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedVAA); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_explorer_v1_explorer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_explorer_v1_explorer_proto_goTypes,
+		DependencyIndexes: file_explorer_v1_explorer_proto_depIdxs,
+		MessageInfos:      file_explorer_v1_explorer_proto_msgTypes,
+	}.Build()
+	File_explorer_v1_explorer_proto = out.File
+	file_explorer_v1_explorer_proto_rawDesc = nil
+	file_explorer_v1_explorer_proto_goTypes = nil
+	file_explorer_v1_explorer_proto_depIdxs = nil
+}
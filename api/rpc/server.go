@@ -3,6 +3,7 @@ package rpc
 import (
 	"github.com/certusone/wormhole/node/pkg/common"
 	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	explorerv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/proto/explorer/v1"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -15,5 +16,6 @@ type Server struct {
 func NewServer(h *Handler, logger *zap.Logger) *grpc.Server {
 	grpcServer := common.NewInstrumentedGRPCServer(logger, common.GrpcLogDetailMinimal)
 	publicrpcv1.RegisterPublicRPCServiceServer(grpcServer, h)
+	explorerv1.RegisterExplorerRPCServiceServer(grpcServer, h)
 	return grpcServer
 }
@@ -0,0 +1,284 @@
+// Package server wires the wormscan API repositories, services and routes into an HTTP server.
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ansrivas/fiberprometheus/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/adaptor/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/config"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/gaps"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
+	guardiansvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/infrastructure"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/observations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/processing"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/tvl"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan"
+	rpcApi "github.com/wormhole-foundation/wormhole-explorer/api/rpc"
+	wormscanCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Option represents a server option function.
+type Option func(*Server)
+
+// WithMiddleware registers a middleware that runs before the route handlers, e.g. a rate limiter.
+func WithMiddleware(handler fiber.Handler) Option {
+	return func(s *Server) {
+		s.middlewares = append(s.middlewares, handler)
+	}
+}
+
+// WithRoute registers an additional GET route.
+func WithRoute(path string, handler fiber.Handler) Option {
+	return func(s *Server) {
+		s.routes = append(s.routes, route{path: path, handler: handler})
+	}
+}
+
+type route struct {
+	path    string
+	handler fiber.Handler
+}
+
+// Server represents the wormscan API server.
+type Server struct {
+	app         *fiber.App
+	db          *dbutil.Session
+	cache       wormscanCache.Cache
+	port        int
+	middlewares []fiber.Handler
+	routes      []route
+	logger      *zap.Logger
+}
+
+// New connects to the database and the cache and creates a server with all the API routes.
+func New(ctx context.Context, cfg *config.AppConfig, rootLogger *zap.Logger, opts ...Option) (*Server, error) {
+	s := &Server{port: cfg.PORT, logger: rootLogger}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Setup DB
+	rootLogger.Info("connecting to MongoDB")
+	db, err := dbutil.Connect(ctx, rootLogger, cfg.DB.URL, cfg.DB.Name, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+	s.db = db
+
+	// Get cache get function
+	rootLogger.Info("initializing cache")
+	cache, err := NewCache(ctx, cfg, rootLogger)
+	if err != nil {
+		db.DisconnectWithTimeout(10 * time.Second)
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	s.cache = cache
+
+	// cfg.Cache.Expiration
+	rootLogger.Info("initializing TVL cache")
+	tvl := tvl.NewTVL(cfg.P2pNetwork, cache, cfg.Cache.TvlKey, cfg.Cache.TvlExpiration, rootLogger)
+
+	//InfluxDB client
+	rootLogger.Info("initializing InfluxDB client")
+	influxCli := newInfluxClient(cfg.Influx.URL, cfg.Influx.Token)
+
+	//VaaPayloadParser client
+	vaaParserFunc, err := NewVaaParserFunc(cfg, rootLogger)
+	if err != nil {
+		s.Stop()
+		return nil, fmt.Errorf("failed to initialize VAA parser: %w", err)
+	}
+
+	// Set up repositories
+	rootLogger.Info("initializing repositories")
+	addressRepo := address.NewRepository(db.Database, rootLogger)
+	vaaRepo := vaa.NewRepository(db.Database, rootLogger)
+	obsRepo := observations.NewRepository(db.Database, rootLogger)
+	governorRepo := governor.NewRepository(db.Database, rootLogger)
+	infrastructureRepo := infrastructure.NewRepository(db.Database, rootLogger)
+	heartbeatsRepo := heartbeats.NewRepository(db.Database, rootLogger)
+	transactionsRepo := transactions.NewRepository(
+		tvl,
+		influxCli,
+		cfg.Influx.Organization,
+		cfg.Influx.Bucket24Hours,
+		cfg.Influx.Bucket30Days,
+		cfg.Influx.BucketInfinite,
+		db.Database,
+		rootLogger,
+	)
+	relaysRepo := relays.NewRepository(db.Database, rootLogger)
+	operationsRepo := operations.NewRepository(db.Database, rootLogger)
+	guardianRepo := guardiansvc.NewRepository(db.Database, rootLogger)
+	gapsRepo := gaps.NewRepository(db.Database, rootLogger)
+	processingRepo := processing.NewRepository(db.Database, rootLogger)
+
+	// create token provider
+	tokenProvider := domain.NewTokenProvider(cfg.P2pNetwork)
+
+	// Set up services
+	rootLogger.Info("initializing services")
+	addressService := address.NewService(addressRepo, rootLogger)
+	vaaService := vaa.NewService(vaaRepo, cache.Get, vaaParserFunc, rootLogger)
+	obsService := observations.NewService(obsRepo, rootLogger)
+	governorService := governor.NewService(governorRepo, rootLogger)
+	infrastructureService := infrastructure.NewService(infrastructureRepo, rootLogger)
+	heartbeatsService := heartbeats.NewService(heartbeatsRepo, rootLogger)
+	transactionsService := transactions.NewService(transactionsRepo, cache, time.Duration(cfg.Cache.MetricExpiration)*time.Second, tokenProvider, rootLogger)
+	relaysService := relays.NewService(relaysRepo, rootLogger)
+	operationsService := operations.NewService(operationsRepo, cfg.P2pNetwork, rootLogger)
+	guardianService := guardiansvc.NewService(guardianRepo, cfg.P2pNetwork, rootLogger)
+	gapsService := gaps.NewService(gapsRepo, rootLogger)
+	processingService := processing.NewService(processingRepo, rootLogger)
+
+	// Set up a custom error handler
+	response.SetEnableStackTrace(*cfg)
+	app := fiber.New(fiber.Config{
+		ErrorHandler:          middleware.ErrorHandler,
+		DisableStartupMessage: true,
+	})
+	s.app = app
+
+	// Configure middleware
+	labels := map[string]string{"service": "wormscan-api", "environment": cfg.Environment}
+	prometheus := fiberprometheus.NewWithLabels(labels, "http", "")
+	prometheus.RegisterAt(app, "/metrics")
+	app.Use(prometheus.Middleware)
+
+	app.Use(requestid.New())
+	app.Use(tracing.NewFiberMiddleware())
+	app.Use(logger.New(logger.Config{
+		Format: "level=info timestamp=${time} method=${method} path=${path} latency=${latency} status${status} request_id=${locals:requestid}\n",
+		Next: func(c *fiber.Ctx) bool {
+			path := c.Path()
+			if path == "/api/v1/health" || path == "/api/v1/ready" {
+				return true
+			}
+			return false
+		},
+	}))
+	if cfg.PprofEnabled {
+		app.Use(pprof.New())
+	}
+	app.Use(cors.New())
+	for _, handler := range s.middlewares {
+		app.Use(handler)
+	}
+
+	// Set up route handlers
+	for _, r := range s.routes {
+		app.Get(r.path, r.handler)
+	}
+	wormscan.RegisterRoutes(app, rootLogger, addressService, vaaService, obsService, governorService, infrastructureService, transactionsService, relaysService, operationsService, guardianService, heartbeatsService, gapsService, processingService)
+	guardian.RegisterRoutes(cfg, app, rootLogger, vaaService, governorService, heartbeatsService)
+
+	// Set up gRPC handlers
+	handler := rpcApi.NewHandler(vaaService, heartbeatsService, governorService, rootLogger, cfg.P2pNetwork)
+	grpcServer := rpcApi.NewServer(handler, rootLogger)
+	grpcWebServer := grpcweb.WrapServer(grpcServer)
+	app.Use(
+		adaptor.HTTPMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if grpcWebServer.IsGrpcWebRequest(r) {
+					grpcWebServer.ServeHTTP(w, r)
+				} else {
+					next.ServeHTTP(w, r)
+				}
+			})
+		}))
+
+	return s, nil
+}
+
+// Start starts the HTTP server in a separate goroutine.
+func (s *Server) Start() {
+	s.logger.Info("starting HTTP server in a separate goroutine")
+	go func() {
+		if err := s.app.Listen(":" + strconv.Itoa(s.port)); err != nil {
+			panic("failed to start HTTP server: " + err.Error())
+		}
+	}()
+}
+
+// Stop shuts down the HTTP server and closes the cache and the database connection.
+func (s *Server) Stop() {
+	if s.app != nil {
+		s.logger.Info("shutting down server...")
+		s.app.Shutdown()
+	}
+
+	s.logger.Info("closing cache...")
+	s.cache.Close()
+
+	s.logger.Info("closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
+}
+
+// NewCache get a CacheGetFunc to get a value by a Key from cache and a CacheReadable to get a value by a Key from notional local cache.
+func NewCache(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (wormscanCache.Cache, error) {
+
+	// if run mode is development with cache is disabled, return a dummy cache client and a dummy notional cache client.
+	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.Cache.Enabled {
+		dummyCacheClient := wormscanCache.NewDummyCacheClient()
+		return dummyCacheClient, nil
+	}
+
+	// if we are not in development mode, use a distributed cache and for notional a pubsub to sync local cache.
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Cache.URL})
+
+	// get cache client
+	cacheClient, err := wormscanCache.NewCacheClient(redisClient, cfg.Cache.Enabled, cfg.Cache.Prefix, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache client: %w", err)
+	}
+
+	return cacheClient, nil
+}
+
+func newInfluxClient(url, token string) influxdb2.Client {
+	return influxdb2.NewClient(url, token)
+}
+
+// NewVaaParserFunc returns a function to parse VAA payload.
+func NewVaaParserFunc(cfg *config.AppConfig, logger *zap.Logger) (vaaPayloadParser.ParseVaaFunc, error) {
+	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.VaaPayloadParser.Enabled {
+		return func(_ context.Context, vaa *sdk.VAA) (*vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse, error) {
+			return &vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{}, nil
+		}, nil
+	}
+	vaaPayloadParserClient, err := vaaPayloadParser.NewParserVAAAPIClient(cfg.VaaPayloadParser.Timeout,
+		cfg.VaaPayloadParser.URL, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize VAA parser client: %w", err)
+	}
+	return vaaPayloadParserClient.ParseVaaWithStandarizedProperties, nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// VaaID identifies a VAA by emitter chain, emitter address and sequence.
+type VaaID struct {
+	EmitterChain   vaa.ChainID
+	EmitterAddress *Address
+	Sequence       uint64
+}
+
+// ParseVaaID parses a VAA ID with the format `emitter_chain/emitter_address/sequence`.
+//
+// The emitter address is the hex-encoded 32-byte address, optionally prefixed with "0x".
+func ParseVaaID(value string) (*VaaID, error) {
+
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid VAA ID: %s", value)
+	}
+
+	chain, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emitter chain in VAA ID %s: %w", value, err)
+	}
+
+	address, err := StringToAddress(parts[1], false /*acceptSolanaFormat*/)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emitter address in VAA ID %s: %w", value, err)
+	}
+
+	sequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sequence in VAA ID %s: %w", value, err)
+	}
+
+	return &VaaID{
+		EmitterChain:   vaa.ChainID(chain),
+		EmitterAddress: address,
+		Sequence:       sequence,
+	}, nil
+}
+
+// String returns the VAA ID in the format used as primary key by the `vaas` collection.
+func (id *VaaID) String() string {
+	return fmt.Sprintf("%d/%s/%d", id.EmitterChain, id.EmitterAddress.Hex(), id.Sequence)
+}
@@ -0,0 +1,55 @@
+package types
+
+import "testing"
+
+// TestParseVaaID tests the ParseVaaID function.
+func TestParseVaaID(t *testing.T) {
+
+	tcs := []struct {
+		input  string
+		output string
+	}{
+		{
+			input:  "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/42",
+			output: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/42",
+		},
+		{
+			// Hex prefix and uppercase digits
+			input:  "2/0x0000000000000000000000003EE18B2214AFF97000D974CF647E7C347E8FA585/42",
+			output: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/42",
+		},
+		{
+			// Missing sequence
+			input: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585",
+		},
+		{
+			// Invalid chain
+			input: "chain/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/42",
+		},
+		{
+			// Invalid emitter address
+			input: "2/3ee18b2214aff97000d974cf647e7c347e8fa585zz/42",
+		},
+		{
+			// Invalid sequence
+			input: "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/-1",
+		},
+	}
+
+	for i := range tcs {
+		tc := tcs[i]
+
+		id, err := ParseVaaID(tc.input)
+		if tc.output == "" && err == nil {
+			t.Fatalf("expected ParseVaaID(%s) to fail", tc.input)
+		}
+		if tc.output != "" {
+			if err != nil {
+				t.Fatalf("failed to parse VAA ID %s: %v", tc.input, err)
+			}
+			if id.String() != tc.output {
+				t.Fatalf("expected %s, but got %s", tc.output, id.String())
+			}
+		}
+	}
+}
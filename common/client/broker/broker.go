@@ -0,0 +1,136 @@
+// Package broker defines a message broker abstraction used by the explorer services to
+// publish and consume events, with implementations for AWS SQS/SNS, Redis Streams,
+// NATS JetStream and an in-process broker.
+package broker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Type identifies a broker implementation.
+type Type string
+
+// Broker types.
+const (
+	TypeSQS    Type = "sqs"
+	TypeRedis  Type = "redis"
+	TypeNATS   Type = "nats"
+	TypeMemory Type = "memory"
+)
+
+// ErrUnknownType is returned when the broker type is not supported.
+var ErrUnknownType = errors.New("unknown broker type")
+
+// Message represents a message received from a broker.
+type Message interface {
+	// ID returns the broker identifier of the message.
+	ID() string
+	// Body returns the payload of the message.
+	Body() []byte
+	// Attempts returns the number of times the message was delivered, including this one.
+	Attempts() int
+	// Attributes returns the metadata sent with the message, e.g. the trace context.
+	Attributes() map[string]string
+	// Done acknowledges the message so it is not delivered again.
+	Done(ctx context.Context) error
+	// Failed releases the message to be delivered again, or moves it to the dead-letter
+	// destination when it reached the maximum number of attempts. It returns whether the
+	// message was dead-lettered.
+	Failed(ctx context.Context, failure Failure) (bool, error)
+}
+
+// Consumer receives messages from a queue or a consumer group of a topic.
+type Consumer interface {
+	// Receive waits for the next batch of messages.
+	Receive(ctx context.Context) ([]Message, error)
+	// VisibilityTimeout returns how long a received message is reserved for this consumer.
+	VisibilityTimeout() time.Duration
+	// Close releases the consumer resources.
+	Close() error
+}
+
+// OutgoingMessage represents a message to publish.
+type OutgoingMessage struct {
+	// GroupID orders the messages of the same group, when the broker supports it.
+	GroupID string
+	// DeduplicationID identifies the message to avoid duplicates, when the broker supports it.
+	DeduplicationID string
+	Body            []byte
+	// Attributes are metadata sent with the message, e.g. the trace context. They are sent as
+	// message attributes in SQS/SNS, fields in redis and headers in NATS.
+	Attributes map[string]string
+}
+
+// Producer publishes messages to a queue or topic.
+type Producer interface {
+	Publish(ctx context.Context, msg *OutgoingMessage) error
+	Close() error
+}
+
+// Failure describes why a message could not be processed.
+type Failure struct {
+	VaaID   string
+	ChainID uint16
+	Err     error
+}
+
+// ConsumerOption represents a consumer option function.
+type ConsumerOption func(*consumerOptions)
+
+type consumerOptions struct {
+	maxMessages       int
+	visibilityTimeout time.Duration
+	waitTime          time.Duration
+	deadLetter        string
+	maxAttempts       int
+}
+
+func newConsumerOptions(opts ...ConsumerOption) consumerOptions {
+	o := consumerOptions{
+		maxMessages:       10,
+		visibilityTimeout: 60 * time.Second,
+		waitTime:          20 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithMaxMessages allows to specify the maximum number of messages returned by Receive.
+func WithMaxMessages(v int) ConsumerOption {
+	return func(o *consumerOptions) {
+		o.maxMessages = v
+	}
+}
+
+// WithVisibilityTimeout allows to specify how long a received message is reserved before
+// it is delivered again.
+func WithVisibilityTimeout(v time.Duration) ConsumerOption {
+	return func(o *consumerOptions) {
+		o.visibilityTimeout = v
+	}
+}
+
+// WithWaitTime allows to specify how long Receive waits for messages.
+func WithWaitTime(v time.Duration) ConsumerOption {
+	return func(o *consumerOptions) {
+		o.waitTime = v
+	}
+}
+
+// WithDeadLetter allows to specify where the messages that failed maxAttempts times are
+// moved. The destination is a queue URL for SQS and a topic name for the other brokers.
+func WithDeadLetter(destination string, maxAttempts int) ConsumerOption {
+	return func(o *consumerOptions) {
+		o.deadLetter = destination
+		o.maxAttempts = maxAttempts
+	}
+}
+
+// exhausted reports whether a message delivered attempts times must be dead-lettered.
+func (o consumerOptions) exhausted(attempts int) bool {
+	return o.deadLetter != "" && o.maxAttempts > 0 && attempts >= o.maxAttempts
+}
@@ -0,0 +1,27 @@
+package broker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+)
+
+// deadLetterHeaders returns the metadata added to a dead-lettered message. The names are the
+// ones of the SQS dead-letter queue attributes so the failures look the same in every broker.
+func deadLetterHeaders(source string, attempts int, failure Failure) map[string]string {
+	headers := map[string]string{
+		sqs.AttributeAttempts:    strconv.Itoa(attempts),
+		sqs.AttributeErrorClass:  sqs.ErrorClass(failure.Err),
+		sqs.AttributeSourceQueue: source,
+		sqs.AttributeFailedAt:    time.Now().UTC().Format(time.RFC3339),
+	}
+	if failure.Err != nil {
+		headers[sqs.AttributeErrorMessage] = failure.Err.Error()
+	}
+	if failure.VaaID != "" {
+		headers[sqs.AttributeVaaID] = failure.VaaID
+		headers[sqs.AttributeChainID] = strconv.Itoa(int(failure.ChainID))
+	}
+	return headers
+}
@@ -0,0 +1,159 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-redis/redis/v8"
+	"github.com/nats-io/nats.go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+)
+
+// Settings represents the connection settings of a broker.
+type Settings struct {
+	Type Type
+	// AwsConfig is the AWS configuration used by the SQS broker.
+	AwsConfig aws.Config
+	// RedisURI is the address of the redis server used by the redis broker.
+	RedisURI string
+	// NatsURL is the URL of the NATS server used by the NATS broker.
+	NatsURL string
+	// NatsStreamMaxAge is the maximum age of the messages of the streams created by the NATS broker.
+	// DefaultNATSStreamMaxAge is used when it is zero.
+	NatsStreamMaxAge time.Duration
+	// NatsStreamMaxBytes is the maximum size of the streams created by the NATS broker.
+	// DefaultNATSStreamMaxBytes is used when it is zero.
+	NatsStreamMaxBytes int64
+	// Memory is the in-process broker used by the memory broker. The default broker of the
+	// process is used when it is nil.
+	Memory *MemoryBroker
+}
+
+// Subscription identifies the messages read by a consumer.
+type Subscription struct {
+	// QueueURL is the SQS queue, used by the SQS broker.
+	QueueURL string
+	// Topic is the redis stream, NATS subject or in-process topic, used by the other brokers.
+	Topic string
+	// Group is the consumer group of the topic. Every group receives all the messages.
+	Group string
+	// DeadLetterQueueURL is the SQS dead-letter queue, used by the SQS broker.
+	DeadLetterQueueURL string
+	// DeadLetterTopic is the dead-letter topic, used by the other brokers.
+	DeadLetterTopic string
+	// MaxAttempts is the number of failed attempts after which a message is dead-lettered.
+	MaxAttempts int
+}
+
+// Destination identifies where a producer publishes the messages.
+type Destination struct {
+	// TopicARN is the SNS topic used by the SQS broker.
+	TopicARN string
+	// QueueURL is the SQS queue used by the SQS broker when TopicARN is empty.
+	QueueURL string
+	// Topic is the redis stream, NATS subject or in-process topic, used by the other brokers.
+	Topic string
+}
+
+// NewConsumer creates a Consumer for the configured broker.
+func NewConsumer(ctx context.Context, settings Settings, sub Subscription, opts ...ConsumerOption) (Consumer, error) {
+	switch settings.Type {
+	case TypeSQS:
+		o := newConsumerOptions(opts...)
+		consumer, err := sqs.NewConsumer(settings.AwsConfig, sub.QueueURL,
+			sqs.WithMaxMessages(int32(o.maxMessages)),
+			sqs.WithVisibilityTimeout(int32(o.visibilityTimeout.Seconds())),
+			sqs.WithWaitTimeSeconds(int32(o.waitTime.Seconds())),
+			sqs.WithDeadLetterQueue(sub.DeadLetterQueueURL, sub.MaxAttempts))
+		if err != nil {
+			return nil, err
+		}
+		return NewSQSConsumer(consumer), nil
+	case TypeRedis:
+		client := redis.NewClient(&redis.Options{Addr: settings.RedisURI})
+		opts = append(opts, WithDeadLetter(sub.DeadLetterTopic, sub.MaxAttempts))
+		consumer, err := NewRedisConsumer(ctx, client, sub.Topic, sub.Group, consumerName(sub.Group), opts...)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		return consumer, nil
+	case TypeNATS:
+		conn, err := nats.Connect(settings.NatsURL)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithDeadLetter(sub.DeadLetterTopic, sub.MaxAttempts))
+		consumer, err := NewNATSConsumer(conn, sub.Topic, sub.Group, natsStreamLimits(settings), opts...)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return consumer, nil
+	case TypeMemory:
+		opts = append(opts, WithDeadLetter(sub.DeadLetterTopic, sub.MaxAttempts))
+		return memoryBroker(settings).Consumer(sub.Topic, sub.Group, opts...), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, settings.Type)
+	}
+}
+
+// NewProducer creates a Producer for the configured broker.
+func NewProducer(settings Settings, dest Destination) (Producer, error) {
+	switch settings.Type {
+	case TypeSQS:
+		if dest.TopicARN != "" {
+			return NewSNSProducer(settings.AwsConfig, dest.TopicARN), nil
+		}
+		return NewSQSProducer(settings.AwsConfig, dest.QueueURL), nil
+	case TypeRedis:
+		client := redis.NewClient(&redis.Options{Addr: settings.RedisURI})
+		return NewRedisProducer(client, dest.Topic, 0), nil
+	case TypeNATS:
+		conn, err := nats.Connect(settings.NatsURL)
+		if err != nil {
+			return nil, err
+		}
+		producer, err := NewNATSProducer(conn, dest.Topic, natsStreamLimits(settings))
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return producer, nil
+	case TypeMemory:
+		return memoryBroker(settings).Producer(dest.Topic), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, settings.Type)
+	}
+}
+
+func natsStreamLimits(settings Settings) NATSStreamLimits {
+	limits := NATSStreamLimits{MaxAge: settings.NatsStreamMaxAge, MaxBytes: settings.NatsStreamMaxBytes}
+	if limits.MaxAge == 0 {
+		limits.MaxAge = DefaultNATSStreamMaxAge
+	}
+	if limits.MaxBytes == 0 {
+		limits.MaxBytes = DefaultNATSStreamMaxBytes
+	}
+	return limits
+}
+
+func memoryBroker(settings Settings) *MemoryBroker {
+	if settings.Memory != nil {
+		return settings.Memory
+	}
+	return DefaultMemoryBroker()
+}
+
+// consumerName returns a name that identifies this process within a consumer group.
+func consumerName(group string) string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return group + "-" + hostname + "-" + strconv.Itoa(os.Getpid())
+}
@@ -0,0 +1,151 @@
+//go:build integration
+
+package broker
+
+// The integration tests run against a redis and a NATS server:
+//
+//	docker run -d -p 6379:6379 redis:7
+//	docker run -d -p 4222:4222 nats:2 -js
+//	REDIS_URI=localhost:6379 NATS_URL=nats://localhost:4222 go test -tags integration ./client/broker/...
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+)
+
+const (
+	integrationVisibilityTimeout = time.Second
+	integrationWaitTime          = 2 * time.Second
+)
+
+func TestRedisBroker_Integration(t *testing.T) {
+	uri := os.Getenv("REDIS_URI")
+	if uri == "" {
+		t.Skip("REDIS_URI is not set")
+	}
+	ctx := context.Background()
+	topic := fmt.Sprintf("wormscan.test.%d", time.Now().UnixNano())
+	settings := Settings{Type: TypeRedis, RedisURI: uri}
+
+	producer, err := NewProducer(settings, Destination{Topic: topic})
+	require.NoError(t, err)
+	defer producer.Close()
+	parser := newIntegrationConsumer(t, settings, topic, "parser")
+	analytics := newIntegrationConsumer(t, settings, topic, "analytics")
+
+	testBrokerIntegration(t, producer, parser, analytics)
+
+	client := redis.NewClient(&redis.Options{Addr: uri})
+	defer client.Close()
+	dead, err := client.XRange(ctx, topic+".dlq", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "vaa-1", dead[0].Values[redisFieldBody])
+	assert.Equal(t, "2", dead[0].Values[sqs.AttributeAttempts])
+	assert.Equal(t, "2/0001/1", dead[0].Values[sqs.AttributeVaaID])
+	assert.Equal(t, topic+"/parser", dead[0].Values[sqs.AttributeSourceQueue])
+}
+
+func TestNATSBroker_Integration(t *testing.T) {
+	url := os.Getenv("NATS_URL")
+	if url == "" {
+		t.Skip("NATS_URL is not set")
+	}
+	topic := fmt.Sprintf("wormscan.test.%d", time.Now().UnixNano())
+	settings := Settings{Type: TypeNATS, NatsURL: url, NatsStreamMaxAge: time.Hour, NatsStreamMaxBytes: 1 << 20}
+
+	producer, err := NewProducer(settings, Destination{Topic: topic})
+	require.NoError(t, err)
+	defer producer.Close()
+	parser := newIntegrationConsumer(t, settings, topic, "parser")
+	analytics := newIntegrationConsumer(t, settings, topic, "analytics")
+
+	testBrokerIntegration(t, producer, parser, analytics)
+
+	// the streams are created with the configured limits.
+	conn, err := nats.Connect(url)
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := conn.JetStream()
+	require.NoError(t, err)
+	for _, subject := range []string{topic, topic + ".dlq"} {
+		info, err := js.StreamInfo(streamName(subject))
+		require.NoError(t, err)
+		assert.Equal(t, time.Hour, info.Config.MaxAge)
+		assert.Equal(t, int64(1<<20), info.Config.MaxBytes)
+	}
+
+	// the dead-letter stream keeps the failed message with the failure headers.
+	dead, err := NewNATSConsumer(conn, topic+".dlq", "inspect", natsStreamLimits(settings), WithWaitTime(integrationWaitTime))
+	require.NoError(t, err)
+	messages, err := dead.Receive(context.Background())
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []byte("vaa-1"), messages[0].Body())
+	assert.Equal(t, "2", messages[0].Attributes()[sqs.AttributeAttempts])
+	assert.Equal(t, "2/0001/1", messages[0].Attributes()[sqs.AttributeVaaID])
+	assert.Equal(t, topic+"/parser", messages[0].Attributes()[sqs.AttributeSourceQueue])
+}
+
+func newIntegrationConsumer(t *testing.T, settings Settings, topic, group string) Consumer {
+	sub := Subscription{Topic: topic, Group: group, DeadLetterTopic: topic + ".dlq", MaxAttempts: 2}
+	consumer, err := NewConsumer(context.Background(), settings, sub,
+		WithWaitTime(integrationWaitTime),
+		WithVisibilityTimeout(integrationVisibilityTimeout))
+	require.NoError(t, err)
+	t.Cleanup(func() { consumer.Close() })
+	return consumer
+}
+
+// testBrokerIntegration checks that every group receives the messages, that a failed message is
+// delivered again after the visibility timeout and that it is dead-lettered after the last attempt.
+func testBrokerIntegration(t *testing.T, producer Producer, parser, analytics Consumer) {
+	ctx := context.Background()
+	require.NoError(t, producer.Publish(ctx, &OutgoingMessage{
+		DeduplicationID: "2/0001/1",
+		Body:            []byte("vaa-1"),
+		Attributes:      map[string]string{"traceparent": "00-1-2-01"},
+	}))
+
+	messages := receive(t, analytics)
+	assert.Equal(t, []byte("vaa-1"), messages[0].Body())
+	assert.Equal(t, "00-1-2-01", messages[0].Attributes()["traceparent"])
+	require.NoError(t, messages[0].Done(ctx))
+
+	failure := Failure{VaaID: "2/0001/1", ChainID: 2, Err: errors.New("boom")}
+	messages = receive(t, parser)
+	assert.Equal(t, 1, messages[0].Attempts())
+	moved, err := messages[0].Failed(ctx, failure)
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	time.Sleep(integrationVisibilityTimeout)
+	messages = receive(t, parser)
+	assert.Equal(t, []byte("vaa-1"), messages[0].Body())
+	assert.Equal(t, 2, messages[0].Attempts())
+	moved, err = messages[0].Failed(ctx, failure)
+	require.NoError(t, err)
+	assert.True(t, moved)
+
+	time.Sleep(integrationVisibilityTimeout)
+	messages, err = parser.Receive(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func receive(t *testing.T, consumer Consumer) []Message {
+	messages, err := consumer.Receive(context.Background())
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	return messages
+}
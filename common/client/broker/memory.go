@@ -0,0 +1,266 @@
+package broker
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// deadLetterGroup is the group that retains the messages of a dead-letter topic of the
+// in-process broker.
+const deadLetterGroup = "dead-letter"
+
+var defaultMemoryBroker = NewMemoryBroker()
+
+// DefaultMemoryBroker returns the in-process broker shared by all the components of the process.
+func DefaultMemoryBroker() *MemoryBroker {
+	return defaultMemoryBroker
+}
+
+// MemoryBroker is an in-process broker with the same semantics as the other brokers: a topic
+// delivers every message to each of its groups, and a message is delivered again when it is
+// not acknowledged within the visibility timeout.
+//
+// A group only receives the messages published after its first consumer was created.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	topics map[string]map[string]*memoryQueue
+}
+
+// NewMemoryBroker creates an in-process broker.
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{topics: make(map[string]map[string]*memoryQueue)}
+}
+
+// Consumer creates a Consumer for a group of a topic.
+func (b *MemoryBroker) Consumer(topic, group string, opts ...ConsumerOption) *MemoryConsumer {
+	o := newConsumerOptions(opts...)
+	if o.deadLetter != "" {
+		b.queue(o.deadLetter, deadLetterGroup)
+	}
+	return &MemoryConsumer{
+		broker: b,
+		source: topic + "/" + group,
+		queue:  b.queue(topic, group),
+		opts:   o,
+	}
+}
+
+// Producer creates a Producer for a topic.
+func (b *MemoryBroker) Producer(topic string) *MemoryProducer {
+	return &MemoryProducer{broker: b, topic: topic}
+}
+
+// queue returns the queue of a group of a topic, creating it if it does not exist.
+func (b *MemoryBroker) queue(topic, group string) *memoryQueue {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	groups, ok := b.topics[topic]
+	if !ok {
+		groups = make(map[string]*memoryQueue)
+		b.topics[topic] = groups
+	}
+	q, ok := groups[group]
+	if !ok {
+		q = newMemoryQueue()
+		groups[group] = q
+	}
+	return q
+}
+
+// publish adds a message to every group of a topic.
+func (b *MemoryBroker) publish(topic string, body []byte, headers map[string]string) {
+	b.mu.Lock()
+	queues := make([]*memoryQueue, 0, len(b.topics[topic]))
+	for _, q := range b.topics[topic] {
+		queues = append(queues, q)
+	}
+	b.mu.Unlock()
+
+	for _, q := range queues {
+		q.push(body, headers)
+	}
+}
+
+// DeadLetters returns the messages moved to a dead-letter topic.
+func (b *MemoryBroker) DeadLetters(topic string) []DeadLetter {
+	q := b.queue(topic, deadLetterGroup)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	result := make([]DeadLetter, 0, len(q.ready))
+	for _, e := range q.ready {
+		result = append(result, DeadLetter{Body: e.body, Headers: e.headers})
+	}
+	return result
+}
+
+// DeadLetter is a message moved to a dead-letter topic of the in-process broker.
+type DeadLetter struct {
+	Body    []byte
+	Headers map[string]string
+}
+
+type memoryEntry struct {
+	id       uint64
+	body     []byte
+	headers  map[string]string
+	attempts int
+	deadline time.Time
+}
+
+type memoryQueue struct {
+	mu       sync.Mutex
+	nextID   uint64
+	ready    []*memoryEntry
+	inflight map[uint64]*memoryEntry
+	notify   chan struct{}
+}
+
+func newMemoryQueue() *memoryQueue {
+	return &memoryQueue{
+		inflight: make(map[uint64]*memoryEntry),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+func (q *memoryQueue) push(body []byte, headers map[string]string) {
+	q.mu.Lock()
+	q.nextID++
+	q.ready = append(q.ready, &memoryEntry{id: q.nextID, body: body, headers: headers})
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// take reserves up to max messages until now plus the visibility timeout. The reserved
+// messages whose visibility timeout expired are taken first.
+func (q *memoryQueue) take(max int, visibility time.Duration) []*memoryEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	var entries []*memoryEntry
+	for _, e := range q.inflight {
+		if len(entries) == max {
+			break
+		}
+		if e.deadline.Before(now) {
+			entries = append(entries, e)
+		}
+	}
+	for len(entries) < max && len(q.ready) > 0 {
+		e := q.ready[0]
+		q.ready = q.ready[1:]
+		q.inflight[e.id] = e
+		entries = append(entries, e)
+	}
+	for _, e := range entries {
+		e.attempts++
+		e.deadline = now.Add(visibility)
+	}
+	return entries
+}
+
+func (q *memoryQueue) remove(id uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.inflight, id)
+}
+
+// MemoryConsumer is a Consumer of a group of a topic of the in-process broker.
+type MemoryConsumer struct {
+	broker *MemoryBroker
+	source string
+	queue  *memoryQueue
+	opts   consumerOptions
+}
+
+// Receive waits for the next batch of messages of the group.
+func (c *MemoryConsumer) Receive(ctx context.Context) ([]Message, error) {
+	timer := time.NewTimer(c.opts.waitTime)
+	defer timer.Stop()
+
+	for {
+		if entries := c.queue.take(c.opts.maxMessages, c.opts.visibilityTimeout); len(entries) > 0 {
+			messages := make([]Message, 0, len(entries))
+			for _, e := range entries {
+				messages = append(messages, &memoryMessage{consumer: c, entry: e, attempts: e.attempts})
+			}
+			return messages, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case <-c.queue.notify:
+		}
+	}
+}
+
+// VisibilityTimeout returns how long a received message is reserved for this consumer.
+func (c *MemoryConsumer) VisibilityTimeout() time.Duration {
+	return c.opts.visibilityTimeout
+}
+
+// Close does nothing, the messages stay in the broker.
+func (c *MemoryConsumer) Close() error {
+	return nil
+}
+
+type memoryMessage struct {
+	consumer *MemoryConsumer
+	entry    *memoryEntry
+	attempts int
+}
+
+func (m *memoryMessage) ID() string {
+	return m.consumer.source + "/" + strconv.FormatUint(m.entry.id, 10)
+}
+
+func (m *memoryMessage) Body() []byte {
+	return m.entry.body
+}
+
+func (m *memoryMessage) Attempts() int {
+	return m.attempts
+}
+
+func (m *memoryMessage) Attributes() map[string]string {
+	return m.entry.headers
+}
+
+func (m *memoryMessage) Done(_ context.Context) error {
+	m.consumer.queue.remove(m.entry.id)
+	return nil
+}
+
+func (m *memoryMessage) Failed(ctx context.Context, failure Failure) (bool, error) {
+	c := m.consumer
+	if !c.opts.exhausted(m.attempts) {
+		return false, nil
+	}
+	c.broker.publish(c.opts.deadLetter, m.entry.body, deadLetterHeaders(c.source, m.attempts, failure))
+	return true, m.Done(ctx)
+}
+
+// MemoryProducer is a Producer for a topic of the in-process broker.
+type MemoryProducer struct {
+	broker *MemoryBroker
+	topic  string
+}
+
+// Publish adds the message to every group of the topic.
+func (p *MemoryProducer) Publish(_ context.Context, msg *OutgoingMessage) error {
+	p.broker.publish(p.topic, msg.Body, msg.Attributes)
+	return nil
+}
+
+// Close does nothing, the messages stay in the broker.
+func (p *MemoryProducer) Close() error {
+	return nil
+}
@@ -0,0 +1,108 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+)
+
+func TestMemoryBroker_DeliversToEveryGroup(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBroker()
+	parser := b.Consumer("vaas", "parser", WithWaitTime(10*time.Millisecond))
+	analytics := b.Consumer("vaas", "analytics", WithWaitTime(10*time.Millisecond))
+
+	require.NoError(t, b.Producer("vaas").Publish(ctx, &OutgoingMessage{
+		Body:       []byte("vaa-1"),
+		Attributes: map[string]string{"traceparent": "00-1-2-01"},
+	}))
+
+	for _, c := range []*MemoryConsumer{parser, analytics} {
+		messages, err := c.Receive(ctx)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, []byte("vaa-1"), messages[0].Body())
+		assert.Equal(t, 1, messages[0].Attempts())
+		assert.Equal(t, map[string]string{"traceparent": "00-1-2-01"}, messages[0].Attributes())
+		require.NoError(t, messages[0].Done(ctx))
+	}
+
+	messages, err := parser.Receive(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestMemoryBroker_RedeliversAfterVisibilityTimeout(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBroker()
+	c := b.Consumer("vaas", "parser",
+		WithWaitTime(50*time.Millisecond),
+		WithVisibilityTimeout(10*time.Millisecond))
+
+	require.NoError(t, b.Producer("vaas").Publish(ctx, &OutgoingMessage{Body: []byte("vaa-1")}))
+
+	messages, err := c.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	time.Sleep(20 * time.Millisecond)
+	messages, err = c.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, 2, messages[0].Attempts())
+}
+
+func TestMemoryBroker_FailedMovesToDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	b := NewMemoryBroker()
+	c := b.Consumer("vaas", "parser",
+		WithWaitTime(10*time.Millisecond),
+		WithVisibilityTimeout(time.Nanosecond),
+		WithDeadLetter("vaas.dlq", 2))
+
+	require.NoError(t, b.Producer("vaas").Publish(ctx, &OutgoingMessage{Body: []byte("vaa-1")}))
+	failure := Failure{VaaID: "2/0001/1", ChainID: 2, Err: errors.New("boom")}
+
+	messages, err := c.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	moved, err := messages[0].Failed(ctx, failure)
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	time.Sleep(time.Millisecond)
+	messages, err = c.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	moved, err = messages[0].Failed(ctx, failure)
+	require.NoError(t, err)
+	assert.True(t, moved)
+
+	messages, err = c.Receive(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	dead := b.DeadLetters("vaas.dlq")
+	require.Len(t, dead, 1)
+	assert.Equal(t, []byte("vaa-1"), dead[0].Body)
+	assert.Equal(t, "2", dead[0].Headers[sqs.AttributeAttempts])
+	assert.Equal(t, "boom", dead[0].Headers[sqs.AttributeErrorClass])
+	assert.Equal(t, "vaas/parser", dead[0].Headers[sqs.AttributeSourceQueue])
+	assert.Equal(t, "2/0001/1", dead[0].Headers[sqs.AttributeVaaID])
+}
+
+func TestSQSMessage_BodyUnwrapsSNSNotification(t *testing.T) {
+	notification := `{"Type":"Notification","MessageId":"1","Message":"{\"id\":\"2/0001/1\"}"}`
+	m := &sqsMessage{}
+	m.message.Body = &notification
+	assert.Equal(t, `{"id":"2/0001/1"}`, string(m.Body()))
+
+	raw := "AQAAAAA="
+	m.message.Body = &raw
+	assert.Equal(t, raw, string(m.Body()))
+}
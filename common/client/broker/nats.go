@@ -0,0 +1,232 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Default retention of the streams created by the NATS broker.
+const (
+	DefaultNATSStreamMaxAge         = 7 * 24 * time.Hour
+	DefaultNATSStreamMaxBytes int64 = 1 << 30
+)
+
+// NATSStreamLimits bounds the messages retained by a stream. The oldest messages are discarded
+// when a limit is reached, even if a durable consumer did not read them.
+type NATSStreamLimits struct {
+	MaxAge   time.Duration
+	MaxBytes int64
+}
+
+// NATSConsumer is a Consumer backed by a durable pull consumer of a NATS JetStream stream.
+//
+// Each service reads the subject with its own durable consumer, so a subject behaves like a
+// topic with one queue per durable consumer. A message that is not acknowledged within the
+// visibility timeout is delivered again.
+type NATSConsumer struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	sub     *nats.Subscription
+	subject string
+	durable string
+	opts    consumerOptions
+}
+
+// NewNATSConsumer creates a Consumer for the durable consumer of a subject. The streams of the
+// subject and of the dead-letter subject are created with the given limits if they do not exist.
+// Close closes the NATS connection.
+func NewNATSConsumer(conn *nats.Conn, subject, durable string, limits NATSStreamLimits, opts ...ConsumerOption) (*NATSConsumer, error) {
+	o := newConsumerOptions(opts...)
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStream(js, subject, limits); err != nil {
+		return nil, err
+	}
+	if o.deadLetter != "" {
+		if err := ensureStream(js, o.deadLetter, limits); err != nil {
+			return nil, err
+		}
+	}
+	sub, err := js.PullSubscribe(subject, durable,
+		nats.BindStream(streamName(subject)),
+		nats.AckWait(o.visibilityTimeout),
+		nats.ManualAck())
+	if err != nil {
+		return nil, err
+	}
+	return &NATSConsumer{
+		conn:    conn,
+		js:      js,
+		sub:     sub,
+		subject: subject,
+		durable: durable,
+		opts:    o,
+	}, nil
+}
+
+// Receive waits for the next batch of messages of the durable consumer.
+func (c *NATSConsumer) Receive(ctx context.Context) ([]Message, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, c.opts.waitTime)
+	defer cancel()
+
+	msgs, err := c.sub.Fetch(c.opts.maxMessages, nats.Context(fetchCtx))
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, 0, len(msgs))
+	for _, msg := range msgs {
+		attempts := 1
+		if meta, err := msg.Metadata(); err == nil {
+			attempts = int(meta.NumDelivered)
+		}
+		messages = append(messages, &natsMessage{consumer: c, msg: msg, attempts: attempts})
+	}
+	return messages, nil
+}
+
+// VisibilityTimeout returns how long a received message is reserved for this consumer.
+func (c *NATSConsumer) VisibilityTimeout() time.Duration {
+	return c.opts.visibilityTimeout
+}
+
+// Close closes the NATS connection.
+func (c *NATSConsumer) Close() error {
+	c.conn.Close()
+	return nil
+}
+
+type natsMessage struct {
+	consumer *NATSConsumer
+	msg      *nats.Msg
+	attempts int
+}
+
+func (m *natsMessage) ID() string {
+	if meta, err := m.msg.Metadata(); err == nil {
+		return meta.Stream + "/" + m.msg.Subject + "/" + strconv.FormatUint(meta.Sequence.Stream, 10)
+	}
+	return m.msg.Header.Get(nats.MsgIdHdr)
+}
+
+func (m *natsMessage) Body() []byte {
+	return m.msg.Data
+}
+
+func (m *natsMessage) Attempts() int {
+	return m.attempts
+}
+
+// Attributes returns the headers of the message, the names are lowercase.
+func (m *natsMessage) Attributes() map[string]string {
+	attributes := make(map[string]string, len(m.msg.Header))
+	for k := range m.msg.Header {
+		attributes[strings.ToLower(k)] = m.msg.Header.Get(k)
+	}
+	return attributes
+}
+
+func (m *natsMessage) Done(ctx context.Context) error {
+	return m.msg.Ack(nats.Context(ctx))
+}
+
+func (m *natsMessage) Failed(ctx context.Context, failure Failure) (bool, error) {
+	c := m.consumer
+	if !c.opts.exhausted(m.attempts) {
+		return false, nil
+	}
+
+	dead := nats.NewMsg(c.opts.deadLetter)
+	dead.Data = m.msg.Data
+	for k, v := range deadLetterHeaders(c.subject+"/"+c.durable, m.attempts, failure) {
+		dead.Header.Set(k, v)
+	}
+	if _, err := c.js.PublishMsg(dead, nats.Context(ctx)); err != nil {
+		return false, err
+	}
+	return true, m.msg.Term(nats.Context(ctx))
+}
+
+// NATSProducer is a Producer that publishes messages to a NATS JetStream subject.
+type NATSProducer struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	subject string
+}
+
+// NewNATSProducer creates a Producer for a subject. The stream of the subject is created with the
+// given limits if it does not exist. Close closes the NATS connection.
+func NewNATSProducer(conn *nats.Conn, subject string, limits NATSStreamLimits) (*NATSProducer, error) {
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureStream(js, subject, limits); err != nil {
+		return nil, err
+	}
+	return &NATSProducer{conn: conn, js: js, subject: subject}, nil
+}
+
+// Publish sends a message to the subject. The deduplication id is used as the JetStream
+// message id, so duplicates are discarded within the stream deduplication window.
+func (p *NATSProducer) Publish(ctx context.Context, msg *OutgoingMessage) error {
+	opts := []nats.PubOpt{nats.Context(ctx)}
+	if msg.DeduplicationID != "" {
+		opts = append(opts, nats.MsgId(msg.DeduplicationID))
+	}
+	m := nats.NewMsg(p.subject)
+	m.Data = msg.Body
+	for k, v := range msg.Attributes {
+		m.Header.Set(k, v)
+	}
+	_, err := p.js.PublishMsg(m, opts...)
+	return err
+}
+
+// Close closes the NATS connection.
+func (p *NATSProducer) Close() error {
+	p.conn.Close()
+	return nil
+}
+
+// ensureStream creates the stream of a subject if it does not exist, or updates its limits if
+// they changed.
+func ensureStream(js nats.JetStreamContext, subject string, limits NATSStreamLimits) error {
+	name := streamName(subject)
+	info, err := js.StreamInfo(name)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = js.AddStream(&nats.StreamConfig{
+			Name:     name,
+			Subjects: []string{subject},
+			MaxAge:   limits.MaxAge,
+			MaxBytes: limits.MaxBytes,
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if info.Config.MaxAge != limits.MaxAge || info.Config.MaxBytes != limits.MaxBytes {
+		cfg := info.Config
+		cfg.MaxAge = limits.MaxAge
+		cfg.MaxBytes = limits.MaxBytes
+		_, err = js.UpdateStream(&cfg)
+	}
+	return err
+}
+
+// streamName returns the name of the stream of a subject. Stream names can not contain the
+// characters used as subject separators and wildcards.
+func streamName(subject string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(subject)
+}
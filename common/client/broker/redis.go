@@ -0,0 +1,260 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Fields of the entries of a redis stream.
+const (
+	redisFieldBody            = "body"
+	redisFieldGroupID         = "groupId"
+	redisFieldDeduplicationID = "deduplicationId"
+	// redisAttributePrefix prefixes the fields of the attributes of a message.
+	redisAttributePrefix = "attr:"
+)
+
+// Minimum redis server version required by the consumer, the first with XAUTOCLAIM.
+const (
+	redisMinMajorVersion = 6
+	redisMinMinorVersion = 2
+)
+
+// RedisConsumer is a Consumer backed by a consumer group of a redis stream.
+//
+// Each service reads the stream with its own consumer group, so a stream behaves like a
+// topic with one queue per group. A message that is not acknowledged within the visibility
+// timeout is claimed again by the next Receive with XAUTOCLAIM, so redis 6.2 or later is required.
+type RedisConsumer struct {
+	client *redis.Client
+	stream string
+	group  string
+	name   string
+	opts   consumerOptions
+}
+
+// NewRedisConsumer creates a Consumer for the consumer group of a redis stream. The stream and
+// the group are created if they do not exist. It fails when the redis server is older than 6.2.
+// Close closes the redis client.
+func NewRedisConsumer(ctx context.Context, client *redis.Client, stream, group, name string, opts ...ConsumerOption) (*RedisConsumer, error) {
+	info, err := client.Info(ctx, "server").Result()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRedisVersion(info); err != nil {
+		return nil, err
+	}
+	err = client.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+	return &RedisConsumer{
+		client: client,
+		stream: stream,
+		group:  group,
+		name:   name,
+		opts:   newConsumerOptions(opts...),
+	}, nil
+}
+
+// Receive claims the messages whose visibility timeout expired or, if there are none, waits
+// for new messages of the stream.
+func (c *RedisConsumer) Receive(ctx context.Context) ([]Message, error) {
+	claimed, _, err := c.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   c.stream,
+		Group:    c.group,
+		Consumer: c.name,
+		MinIdle:  c.opts.visibilityTimeout,
+		Start:    "0-0",
+		Count:    int64(c.opts.maxMessages),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(claimed) > 0 {
+		return c.claimedMessages(ctx, claimed)
+	}
+
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.group,
+		Consumer: c.name,
+		Streams:  []string{c.stream, ">"},
+		Count:    int64(c.opts.maxMessages),
+		Block:    c.opts.waitTime,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []Message
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			messages = append(messages, c.newMessage(msg, 1))
+		}
+	}
+	return messages, nil
+}
+
+// checkRedisVersion returns an error when the redis_version of the server section of the
+// INFO command is older than the minimum version required by the consumer.
+func checkRedisVersion(info string) error {
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "redis_version:") {
+			continue
+		}
+		version := strings.TrimPrefix(line, "redis_version:")
+		parts := strings.SplitN(version, ".", 3)
+		major, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) < 2 {
+			return fmt.Errorf("invalid redis version %s", version)
+		}
+		minor, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid redis version %s", version)
+		}
+		if major < redisMinMajorVersion || (major == redisMinMajorVersion && minor < redisMinMinorVersion) {
+			return fmt.Errorf("redis version %s is not supported, the broker requires %d.%d or later",
+				version, redisMinMajorVersion, redisMinMinorVersion)
+		}
+		return nil
+	}
+	return errors.New("redis version not found")
+}
+
+// claimedMessages returns the claimed messages with their delivery count.
+func (c *RedisConsumer) claimedMessages(ctx context.Context, claimed []redis.XMessage) ([]Message, error) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   c.stream,
+		Group:    c.group,
+		Consumer: c.name,
+		Start:    claimed[0].ID,
+		End:      claimed[len(claimed)-1].ID,
+		Count:    int64(len(claimed)),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	attempts := make(map[string]int, len(pending))
+	for _, p := range pending {
+		attempts[p.ID] = int(p.RetryCount)
+	}
+
+	messages := make([]Message, 0, len(claimed))
+	for _, msg := range claimed {
+		messages = append(messages, c.newMessage(msg, attempts[msg.ID]))
+	}
+	return messages, nil
+}
+
+func (c *RedisConsumer) newMessage(msg redis.XMessage, attempts int) *redisMessage {
+	body, _ := msg.Values[redisFieldBody].(string)
+	attributes := make(map[string]string)
+	for k, v := range msg.Values {
+		if name := strings.TrimPrefix(k, redisAttributePrefix); name != k {
+			attributes[name], _ = v.(string)
+		}
+	}
+	return &redisMessage{consumer: c, id: msg.ID, body: []byte(body), attributes: attributes, attempts: attempts}
+}
+
+// VisibilityTimeout returns how long a received message is reserved for this consumer.
+func (c *RedisConsumer) VisibilityTimeout() time.Duration {
+	return c.opts.visibilityTimeout
+}
+
+// Close closes the redis client.
+func (c *RedisConsumer) Close() error {
+	return c.client.Close()
+}
+
+type redisMessage struct {
+	consumer   *RedisConsumer
+	id         string
+	body       []byte
+	attributes map[string]string
+	attempts   int
+}
+
+func (m *redisMessage) ID() string {
+	return m.id
+}
+
+func (m *redisMessage) Body() []byte {
+	return m.body
+}
+
+func (m *redisMessage) Attempts() int {
+	return m.attempts
+}
+
+func (m *redisMessage) Attributes() map[string]string {
+	return m.attributes
+}
+
+func (m *redisMessage) Done(ctx context.Context) error {
+	return m.consumer.client.XAck(ctx, m.consumer.stream, m.consumer.group, m.id).Err()
+}
+
+func (m *redisMessage) Failed(ctx context.Context, failure Failure) (bool, error) {
+	c := m.consumer
+	if !c.opts.exhausted(m.attempts) {
+		return false, nil
+	}
+
+	values := map[string]interface{}{redisFieldBody: string(m.body)}
+	for k, v := range deadLetterHeaders(c.stream+"/"+c.group, m.attempts, failure) {
+		values[k] = v
+	}
+	err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: c.opts.deadLetter, Values: values}).Err()
+	if err != nil {
+		return false, err
+	}
+	return true, m.Done(ctx)
+}
+
+// RedisProducer is a Producer that appends messages to a redis stream.
+type RedisProducer struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisProducer creates a Producer for a redis stream. The stream is trimmed to
+// approximately maxLen entries, or not trimmed when maxLen is zero. Close closes the
+// redis client.
+func NewRedisProducer(client *redis.Client, stream string, maxLen int64) *RedisProducer {
+	return &RedisProducer{client: client, stream: stream, maxLen: maxLen}
+}
+
+// Publish appends a message to the redis stream.
+func (p *RedisProducer) Publish(ctx context.Context, msg *OutgoingMessage) error {
+	values := map[string]interface{}{
+		redisFieldBody:            string(msg.Body),
+		redisFieldGroupID:         msg.GroupID,
+		redisFieldDeduplicationID: msg.DeduplicationID,
+	}
+	for k, v := range msg.Attributes {
+		values[redisAttributePrefix+k] = v
+	}
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: p.maxLen > 0,
+		Values: values,
+	}).Err()
+}
+
+// Close closes the redis client.
+func (p *RedisProducer) Close() error {
+	return p.client.Close()
+}
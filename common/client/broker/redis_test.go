@@ -0,0 +1,19 @@
+package broker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRedisVersion(t *testing.T) {
+	info := "# Server\r\nredis_version:%s\r\nredis_mode:standalone\r\n"
+
+	assert.NoError(t, checkRedisVersion(fmt.Sprintf(info, "6.2.0")))
+	assert.NoError(t, checkRedisVersion(fmt.Sprintf(info, "7.0.11")))
+	assert.Error(t, checkRedisVersion(fmt.Sprintf(info, "6.0.16")))
+	assert.Error(t, checkRedisVersion(fmt.Sprintf(info, "5.0.7")))
+	assert.Error(t, checkRedisVersion(fmt.Sprintf(info, "unknown")))
+	assert.Error(t, checkRedisVersion("# Server\r\nredis_mode:standalone\r\n"))
+}
@@ -0,0 +1,210 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	aws_sns "github.com/aws/aws-sdk-go-v2/service/sns"
+	aws_sns_types "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	aws_sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	aws_sqs_types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+)
+
+// SQSConsumer is a Consumer backed by an SQS queue.
+type SQSConsumer struct {
+	consumer *sqs.Consumer
+}
+
+// NewSQSConsumer creates a Consumer from an SQS consumer. The dead-letter queue is the one
+// configured in the SQS consumer.
+func NewSQSConsumer(consumer *sqs.Consumer) *SQSConsumer {
+	return &SQSConsumer{consumer: consumer}
+}
+
+// Receive retrieves messages from SQS. The body of the messages delivered by an SNS
+// subscription is unwrapped from the SNS notification.
+func (c *SQSConsumer) Receive(ctx context.Context) ([]Message, error) {
+	messages, err := c.consumer.GetMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Message, 0, len(messages))
+	for _, msg := range messages {
+		result = append(result, &sqsMessage{consumer: c.consumer, message: msg})
+	}
+	return result, nil
+}
+
+// VisibilityTimeout returns the visibility timeout of the SQS consumer.
+func (c *SQSConsumer) VisibilityTimeout() time.Duration {
+	return c.consumer.GetVisibilityTimeout()
+}
+
+// Close does nothing, the SQS client does not hold resources.
+func (c *SQSConsumer) Close() error {
+	return nil
+}
+
+// snsNotification is the envelope of the messages delivered by an SNS subscription.
+type snsNotification struct {
+	Type              string `json:"Type"`
+	Message           string `json:"Message"`
+	MessageAttributes map[string]struct {
+		Type  string `json:"Type"`
+		Value string `json:"Value"`
+	} `json:"MessageAttributes"`
+}
+
+// stringAttributeType is the data type of the message attributes sent by the producers.
+const stringAttributeType = "String"
+
+type sqsMessage struct {
+	consumer *sqs.Consumer
+	message  aws_sqs_types.Message
+}
+
+func (m *sqsMessage) ID() string {
+	return aws.ToString(m.message.MessageId)
+}
+
+func (m *sqsMessage) Body() []byte {
+	if notification, ok := m.notification(); ok {
+		return []byte(notification.Message)
+	}
+	return []byte(aws.ToString(m.message.Body))
+}
+
+// Attributes returns the string attributes of the message, or the ones of the SNS notification
+// when the message was delivered by an SNS subscription.
+func (m *sqsMessage) Attributes() map[string]string {
+	attributes := make(map[string]string)
+	if notification, ok := m.notification(); ok {
+		for k, v := range notification.MessageAttributes {
+			if v.Type == stringAttributeType {
+				attributes[k] = v.Value
+			}
+		}
+		return attributes
+	}
+	for k, v := range m.message.MessageAttributes {
+		if aws.ToString(v.DataType) == stringAttributeType {
+			attributes[k] = aws.ToString(v.StringValue)
+		}
+	}
+	return attributes
+}
+
+// notification returns the SNS notification of a message delivered by an SNS subscription.
+func (m *sqsMessage) notification() (*snsNotification, bool) {
+	body := aws.ToString(m.message.Body)
+	var notification snsNotification
+	if strings.HasPrefix(body, "{") && json.Unmarshal([]byte(body), &notification) == nil &&
+		notification.Type == "Notification" {
+		return &notification, true
+	}
+	return nil, false
+}
+
+func (m *sqsMessage) Attempts() int {
+	return sqs.Attempts(m.message)
+}
+
+func (m *sqsMessage) Done(ctx context.Context) error {
+	return m.consumer.DeleteMessage(ctx, m.message.ReceiptHandle)
+}
+
+func (m *sqsMessage) Failed(ctx context.Context, failure Failure) (bool, error) {
+	return m.consumer.Failed(ctx, m.message, sqs.Failure(failure))
+}
+
+// SQSProducer is a Producer that sends messages to an SQS queue.
+type SQSProducer struct {
+	api *aws_sqs.Client
+	url string
+}
+
+// NewSQSProducer creates a Producer for an SQS queue.
+func NewSQSProducer(awsConfig aws.Config, url string) *SQSProducer {
+	return &SQSProducer{api: aws_sqs.NewFromConfig(awsConfig), url: url}
+}
+
+// Publish sends a message to the SQS queue.
+func (p *SQSProducer) Publish(ctx context.Context, msg *OutgoingMessage) error {
+	input := &aws_sqs.SendMessageInput{
+		QueueUrl:          aws.String(p.url),
+		MessageBody:       aws.String(string(msg.Body)),
+		MessageAttributes: sqsAttributes(msg.Attributes),
+	}
+	if strings.HasSuffix(p.url, ".fifo") {
+		input.MessageGroupId = aws.String(msg.GroupID)
+		input.MessageDeduplicationId = aws.String(msg.DeduplicationID)
+	}
+	_, err := p.api.SendMessage(ctx, input)
+	return err
+}
+
+// Close does nothing, the SQS client does not hold resources.
+func (p *SQSProducer) Close() error {
+	return nil
+}
+
+// SNSProducer is a Producer that publishes messages to an SNS topic.
+type SNSProducer struct {
+	api *aws_sns.Client
+	arn string
+}
+
+// NewSNSProducer creates a Producer for an SNS topic.
+func NewSNSProducer(awsConfig aws.Config, arn string) *SNSProducer {
+	return &SNSProducer{api: aws_sns.NewFromConfig(awsConfig), arn: arn}
+}
+
+// Publish sends a message to the SNS topic.
+func (p *SNSProducer) Publish(ctx context.Context, msg *OutgoingMessage) error {
+	_, err := p.api.Publish(ctx,
+		&aws_sns.PublishInput{
+			MessageGroupId:         aws.String(msg.GroupID),
+			MessageDeduplicationId: aws.String(msg.DeduplicationID),
+			Message:                aws.String(string(msg.Body)),
+			MessageAttributes:      snsAttributes(msg.Attributes),
+			TopicArn:               aws.String(p.arn),
+		})
+	return err
+}
+
+// Close does nothing, the SNS client does not hold resources.
+func (p *SNSProducer) Close() error {
+	return nil
+}
+
+// sqsAttributes returns the message attributes of an SQS message, the empty values are not allowed.
+func sqsAttributes(attributes map[string]string) map[string]aws_sqs_types.MessageAttributeValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	result := make(map[string]aws_sqs_types.MessageAttributeValue, len(attributes))
+	for k, v := range attributes {
+		if v != "" {
+			result[k] = aws_sqs_types.MessageAttributeValue{DataType: aws.String(stringAttributeType), StringValue: aws.String(v)}
+		}
+	}
+	return result
+}
+
+// snsAttributes returns the message attributes of an SNS message, the empty values are not allowed.
+func snsAttributes(attributes map[string]string) map[string]aws_sns_types.MessageAttributeValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	result := make(map[string]aws_sns_types.MessageAttributeValue, len(attributes))
+	for k, v := range attributes {
+		if v != "" {
+			result[k] = aws_sns_types.MessageAttributeValue{DataType: aws.String(stringAttributeType), StringValue: aws.String(v)}
+		}
+	}
+	return result
+}
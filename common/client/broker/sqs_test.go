@@ -0,0 +1,39 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	aws_sqs_types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqsMessage_Attributes(t *testing.T) {
+	msg := &sqsMessage{message: aws_sqs_types.Message{
+		Body: aws.String(`{"foo":"bar"}`),
+		MessageAttributes: map[string]aws_sqs_types.MessageAttributeValue{
+			"traceparent": {DataType: aws.String("String"), StringValue: aws.String("00-1-2-01")},
+			"payload":     {DataType: aws.String("Binary"), BinaryValue: []byte("data")},
+		},
+	}}
+
+	assert.Equal(t, []byte(`{"foo":"bar"}`), msg.Body())
+	assert.Equal(t, map[string]string{"traceparent": "00-1-2-01"}, msg.Attributes())
+}
+
+func TestSqsMessage_AttributesFromSnsNotification(t *testing.T) {
+	msg := &sqsMessage{message: aws_sqs_types.Message{
+		Body: aws.String(`{"Type":"Notification","Message":"{\"id\":\"2/a/1\"}",` +
+			`"MessageAttributes":{"traceparent":{"Type":"String","Value":"00-1-2-01"}}}`),
+	}}
+
+	assert.Equal(t, []byte(`{"id":"2/a/1"}`), msg.Body())
+	assert.Equal(t, map[string]string{"traceparent": "00-1-2-01"}, msg.Attributes())
+}
+
+func TestSqsAttributes(t *testing.T) {
+	assert.Nil(t, sqsAttributes(nil))
+	attributes := sqsAttributes(map[string]string{"traceparent": "00-1-2-01", "tracestate": ""})
+	assert.Len(t, attributes, 1)
+	assert.Equal(t, "00-1-2-01", aws.ToString(attributes["traceparent"].StringValue))
+}
@@ -12,13 +12,24 @@ import (
 // ConsumerOption represents a consumer option function.
 type ConsumerOption func(*Consumer)
 
+// API is the subset of the SQS client operations used by this package.
+type API interface {
+	ReceiveMessage(ctx context.Context, params *aws_sqs.ReceiveMessageInput, optFns ...func(*aws_sqs.Options)) (*aws_sqs.ReceiveMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *aws_sqs.DeleteMessageInput, optFns ...func(*aws_sqs.Options)) (*aws_sqs.DeleteMessageOutput, error)
+	SendMessage(ctx context.Context, params *aws_sqs.SendMessageInput, optFns ...func(*aws_sqs.Options)) (*aws_sqs.SendMessageOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *aws_sqs.ChangeMessageVisibilityInput, optFns ...func(*aws_sqs.Options)) (*aws_sqs.ChangeMessageVisibilityOutput, error)
+	GetQueueAttributes(ctx context.Context, params *aws_sqs.GetQueueAttributesInput, optFns ...func(*aws_sqs.Options)) (*aws_sqs.GetQueueAttributesOutput, error)
+}
+
 // Consumer represents SQS consumer.
 type Consumer struct {
-	api               *aws_sqs.Client
+	api               API
 	url               string
 	maxMessages       int32
 	visibilityTimeout int32
 	waitTimeSeconds   int32
+	dlqURL            string
+	maxAttempts       int
 }
 
 // New instances of a Consumer to consume SQS messages.
@@ -59,6 +70,15 @@ func WithWaitTimeSeconds(v int32) ConsumerOption {
 	}
 }
 
+// WithDeadLetterQueue allows to specify a dead-letter queue where the messages that failed
+// maxAttempts times are moved.
+func WithDeadLetterQueue(url string, maxAttempts int) ConsumerOption {
+	return func(c *Consumer) {
+		c.dlqURL = url
+		c.maxAttempts = maxAttempts
+	}
+}
+
 // GetMessages retrieves messages from SQS.
 func (c *Consumer) GetMessages(ctx context.Context) ([]aws_sqs_types.Message, error) {
 	params := &aws_sqs.ReceiveMessageInput{
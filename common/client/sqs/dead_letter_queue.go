@@ -0,0 +1,225 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	aws_sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	aws_sqs_types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// scanVisibilityTimeout is the time the messages of a dead-letter queue are hidden while they are scanned.
+const scanVisibilityTimeout = 60
+
+// DeadLetterQueue allows to inspect, re-drive and purge the messages of a dead-letter queue.
+type DeadLetterQueue struct {
+	api API
+	url string
+}
+
+// DeadLetterMessage is a message of a dead-letter queue.
+type DeadLetterMessage struct {
+	MessageID     string     `json:"messageId"`
+	ReceiptHandle string     `json:"-"`
+	VaaID         string     `json:"vaaId,omitempty"`
+	ChainID       *uint16    `json:"chainId,omitempty"`
+	Attempts      int        `json:"attempts"`
+	ErrorClass    string     `json:"errorClass,omitempty"`
+	ErrorMessage  string     `json:"errorMessage,omitempty"`
+	SourceQueue   string     `json:"sourceQueue,omitempty"`
+	FailedAt      *time.Time `json:"failedAt,omitempty"`
+	Body          string     `json:"body,omitempty"`
+
+	message aws_sqs_types.Message
+}
+
+// DeadLetterFilter selects messages of a dead-letter queue. Empty fields match any message.
+type DeadLetterFilter struct {
+	MessageID  string
+	VaaID      string
+	ChainID    *uint16
+	ErrorClass string
+}
+
+// Match returns true when the message matches all the fields of the filter.
+func (f *DeadLetterFilter) Match(m *DeadLetterMessage) bool {
+	if f == nil {
+		return true
+	}
+	if f.MessageID != "" && f.MessageID != m.MessageID {
+		return false
+	}
+	if f.VaaID != "" && f.VaaID != m.VaaID {
+		return false
+	}
+	if f.ChainID != nil && (m.ChainID == nil || *f.ChainID != *m.ChainID) {
+		return false
+	}
+	if f.ErrorClass != "" && f.ErrorClass != m.ErrorClass {
+		return false
+	}
+	return true
+}
+
+// NewDeadLetterQueue creates a DeadLetterQueue for the queue url.
+func NewDeadLetterQueue(awsConfig aws.Config, url string) *DeadLetterQueue {
+	return &DeadLetterQueue{api: aws_sqs.NewFromConfig(awsConfig), url: url}
+}
+
+// List returns up to limit messages that match the filter. A limit of zero returns all the messages.
+func (q *DeadLetterQueue) List(ctx context.Context, filter *DeadLetterFilter, limit int) ([]*DeadLetterMessage, error) {
+	var messages []*DeadLetterMessage
+	err := q.scan(ctx, filter, limit, func(m *DeadLetterMessage) (bool, error) {
+		messages = append(messages, m)
+		return false, nil
+	})
+	return messages, err
+}
+
+// Redrive sends the messages that match the filter to the target queue and removes them from the
+// dead-letter queue. When target is empty, each message is sent back to the queue it failed in.
+// It returns the number of messages re-driven.
+func (q *DeadLetterQueue) Redrive(ctx context.Context, filter *DeadLetterFilter, limit int, target string) (int, error) {
+	var count int
+	err := q.scan(ctx, filter, limit, func(m *DeadLetterMessage) (bool, error) {
+		url := target
+		if url == "" {
+			url = m.SourceQueue
+		}
+		if url == "" {
+			return false, errors.New("unknown source queue of message " + m.MessageID)
+		}
+		// the failure attributes are removed so that the message gets a new set of attempts.
+		attributes := make(map[string]aws_sqs_types.MessageAttributeValue, len(m.message.MessageAttributes))
+		for k, v := range m.message.MessageAttributes {
+			attributes[k] = v
+		}
+		for _, name := range []string{AttributeAttempts, AttributeErrorClass, AttributeErrorMessage,
+			AttributeSourceQueue, AttributeVaaID, AttributeChainID, AttributeFailedAt} {
+			delete(attributes, name)
+		}
+		if err := sendMessage(ctx, q.api, url, m.message, attributes); err != nil {
+			return false, err
+		}
+		if err := q.delete(ctx, m.ReceiptHandle); err != nil {
+			return false, err
+		}
+		count++
+		return true, nil
+	})
+	return count, err
+}
+
+// Purge removes the messages that match the filter from the dead-letter queue.
+// It returns the number of messages removed.
+func (q *DeadLetterQueue) Purge(ctx context.Context, filter *DeadLetterFilter, limit int) (int, error) {
+	var count int
+	err := q.scan(ctx, filter, limit, func(m *DeadLetterMessage) (bool, error) {
+		if err := q.delete(ctx, m.ReceiptHandle); err != nil {
+			return false, err
+		}
+		count++
+		return true, nil
+	})
+	return count, err
+}
+
+// scan receives all the messages of the queue, calls fn for up to limit messages that match the filter
+// and makes the messages not removed by fn visible again.
+func (q *DeadLetterQueue) scan(ctx context.Context, filter *DeadLetterFilter, limit int,
+	fn func(*DeadLetterMessage) (bool, error)) error {
+
+	var received []string
+	defer func() {
+		for _, handle := range received {
+			_, _ = q.api.ChangeMessageVisibility(ctx, &aws_sqs.ChangeMessageVisibilityInput{
+				QueueUrl:          aws.String(q.url),
+				ReceiptHandle:     aws.String(handle),
+				VisibilityTimeout: 0,
+			})
+		}
+	}()
+
+	var matched int
+	seen := make(map[string]bool)
+	for limit == 0 || matched < limit {
+		res, err := q.api.ReceiveMessage(ctx, &aws_sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(q.url),
+			MaxNumberOfMessages: 10,
+			AttributeNames: []aws_sqs_types.QueueAttributeName{
+				aws_sqs_types.QueueAttributeNameAll,
+			},
+			MessageAttributeNames: []string{
+				string(aws_sqs_types.QueueAttributeNameAll),
+			},
+			VisibilityTimeout: scanVisibilityTimeout,
+			WaitTimeSeconds:   1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(res.Messages) == 0 {
+			return nil
+		}
+		for _, msg := range res.Messages {
+			m := newDeadLetterMessage(msg)
+			if seen[m.MessageID] {
+				continue
+			}
+			seen[m.MessageID] = true
+			if limit != 0 && matched >= limit || !filter.Match(m) {
+				received = append(received, m.ReceiptHandle)
+				continue
+			}
+			matched++
+			removed, err := fn(m)
+			if !removed {
+				received = append(received, m.ReceiptHandle)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (q *DeadLetterQueue) delete(ctx context.Context, receiptHandle string) error {
+	_, err := q.api.DeleteMessage(ctx, &aws_sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(q.url),
+		ReceiptHandle: aws.String(receiptHandle),
+	})
+	return err
+}
+
+func newDeadLetterMessage(msg aws_sqs_types.Message) *DeadLetterMessage {
+	m := &DeadLetterMessage{
+		MessageID:     aws.ToString(msg.MessageId),
+		ReceiptHandle: aws.ToString(msg.ReceiptHandle),
+		Body:          aws.ToString(msg.Body),
+		VaaID:         attribute(msg, AttributeVaaID),
+		ErrorClass:    attribute(msg, AttributeErrorClass),
+		ErrorMessage:  attribute(msg, AttributeErrorMessage),
+		SourceQueue:   attribute(msg, AttributeSourceQueue),
+		message:       msg,
+	}
+	m.Attempts, _ = strconv.Atoi(attribute(msg, AttributeAttempts))
+	if chainID, err := strconv.ParseUint(attribute(msg, AttributeChainID), 10, 16); err == nil {
+		c := uint16(chainID)
+		m.ChainID = &c
+	}
+	if failedAt, err := time.Parse(time.RFC3339, attribute(msg, AttributeFailedAt)); err == nil {
+		m.FailedAt = &failedAt
+	}
+	return m
+}
+
+func attribute(msg aws_sqs_types.Message, name string) string {
+	if v, ok := msg.MessageAttributes[name]; ok {
+		return aws.ToString(v.StringValue)
+	}
+	return ""
+}
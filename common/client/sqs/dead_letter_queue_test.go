@@ -0,0 +1,211 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	aws_sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	aws_sqs_types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSQS is an in-memory stand-in of SQS with per queue visibility and receive counts.
+type fakeSQS struct {
+	mu     sync.Mutex
+	nextID int
+	queues map[string][]*fakeMessage
+}
+
+type fakeMessage struct {
+	msg      aws_sqs_types.Message
+	receives int
+	hidden   bool
+}
+
+func newFakeSQS() *fakeSQS {
+	return &fakeSQS{queues: make(map[string][]*fakeMessage)}
+}
+
+func (f *fakeSQS) ReceiveMessage(_ context.Context, params *aws_sqs.ReceiveMessageInput, _ ...func(*aws_sqs.Options)) (*aws_sqs.ReceiveMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var messages []aws_sqs_types.Message
+	for _, m := range f.queues[*params.QueueUrl] {
+		if m.hidden || len(messages) == int(params.MaxNumberOfMessages) {
+			continue
+		}
+		m.receives++
+		m.hidden = params.VisibilityTimeout > 0
+		msg := m.msg
+		msg.ReceiptHandle = aws.String(fmt.Sprintf("%s-%d", *m.msg.MessageId, m.receives))
+		msg.Attributes = map[string]string{
+			string(aws_sqs_types.MessageSystemAttributeNameApproximateReceiveCount): strconv.Itoa(m.receives),
+		}
+		messages = append(messages, msg)
+	}
+	return &aws_sqs.ReceiveMessageOutput{Messages: messages}, nil
+}
+
+func (f *fakeSQS) find(url, handle string) (int, error) {
+	for i, m := range f.queues[url] {
+		if fmt.Sprintf("%s-%d", *m.msg.MessageId, m.receives) == handle {
+			return i, nil
+		}
+	}
+	return 0, errors.New("invalid receipt handle")
+}
+
+func (f *fakeSQS) DeleteMessage(_ context.Context, params *aws_sqs.DeleteMessageInput, _ ...func(*aws_sqs.Options)) (*aws_sqs.DeleteMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i, err := f.find(*params.QueueUrl, *params.ReceiptHandle)
+	if err != nil {
+		return nil, err
+	}
+	q := f.queues[*params.QueueUrl]
+	f.queues[*params.QueueUrl] = append(q[:i], q[i+1:]...)
+	return &aws_sqs.DeleteMessageOutput{}, nil
+}
+
+func (f *fakeSQS) SendMessage(_ context.Context, params *aws_sqs.SendMessageInput, _ ...func(*aws_sqs.Options)) (*aws_sqs.SendMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	id := aws.String(strconv.Itoa(f.nextID))
+	f.queues[*params.QueueUrl] = append(f.queues[*params.QueueUrl], &fakeMessage{msg: aws_sqs_types.Message{
+		MessageId:         id,
+		Body:              params.MessageBody,
+		MessageAttributes: params.MessageAttributes,
+	}})
+	return &aws_sqs.SendMessageOutput{MessageId: id}, nil
+}
+
+func (f *fakeSQS) ChangeMessageVisibility(_ context.Context, params *aws_sqs.ChangeMessageVisibilityInput, _ ...func(*aws_sqs.Options)) (*aws_sqs.ChangeMessageVisibilityOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i, err := f.find(*params.QueueUrl, *params.ReceiptHandle)
+	if err != nil {
+		return nil, err
+	}
+	f.queues[*params.QueueUrl][i].hidden = params.VisibilityTimeout > 0
+	return &aws_sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func (f *fakeSQS) GetQueueAttributes(_ context.Context, _ *aws_sqs.GetQueueAttributesInput, _ ...func(*aws_sqs.Options)) (*aws_sqs.GetQueueAttributesOutput, error) {
+	return &aws_sqs.GetQueueAttributesOutput{}, nil
+}
+
+// release makes all the messages of a queue visible, as when the visibility timeout expires.
+func (f *fakeSQS) release(url string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, m := range f.queues[url] {
+		m.hidden = false
+	}
+}
+
+type classifiedError struct{}
+
+func (classifiedError) Error() string      { return "rpc unavailable" }
+func (classifiedError) ErrorClass() string { return "rpc" }
+
+func TestErrorClass(t *testing.T) {
+	base := errors.New("chain not supported")
+	assert.Equal(t, "chain not supported", ErrorClass(fmt.Errorf("processing 2/abc/1: %w", base)))
+	assert.Equal(t, "rpc", ErrorClass(fmt.Errorf("fetching tx: %w", classifiedError{})))
+	assert.Equal(t, "unknown", ErrorClass(nil))
+}
+
+func TestErrorClass_StripsVariableParts(t *testing.T) {
+	assert.Equal(t, "vaa * not found",
+		ErrorClass(fmt.Errorf("vaa %s not found", "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1234")))
+	assert.Equal(t, "transaction * failed with status *",
+		ErrorClass(fmt.Errorf("processing: %w", fmt.Errorf("transaction %s failed with status %d", "0x8f3c4b1e9a7d", 500))))
+	assert.Equal(t, "account * not found",
+		ErrorClass(errors.New("account wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb not found")))
+	assert.Equal(t, "dial tcp *:*: connection refused", ErrorClass(errors.New("dial tcp 10.0.0.1:443: connection refused")))
+}
+
+func TestConsumer_FailedMovesToDeadLetterQueue(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeSQS()
+	consumer := &Consumer{api: fake, url: "queue", maxMessages: 10, visibilityTimeout: 60, dlqURL: "dlq", maxAttempts: 3}
+
+	_, err := fake.SendMessage(ctx, &aws_sqs.SendMessageInput{QueueUrl: aws.String("queue"), MessageBody: aws.String("poison")})
+	require.NoError(t, err)
+
+	failure := Failure{VaaID: "2/abc/1", ChainID: 2, Err: fmt.Errorf("parse: %w", errors.New("invalid payload"))}
+	for attempt := 1; attempt <= 3; attempt++ {
+		messages, err := consumer.GetMessages(ctx)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		moved, err := consumer.Failed(ctx, messages[0], failure)
+		require.NoError(t, err)
+		assert.Equal(t, attempt == 3, moved)
+		fake.release("queue")
+	}
+	assert.Empty(t, fake.queues["queue"])
+
+	dlq := &DeadLetterQueue{api: fake, url: "dlq"}
+	messages, err := dlq.List(ctx, &DeadLetterFilter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	m := messages[0]
+	assert.Equal(t, "poison", m.Body)
+	assert.Equal(t, 3, m.Attempts)
+	assert.Equal(t, "invalid payload", m.ErrorClass)
+	assert.Equal(t, "parse: invalid payload", m.ErrorMessage)
+	assert.Equal(t, "queue", m.SourceQueue)
+	assert.Equal(t, "2/abc/1", m.VaaID)
+	require.NotNil(t, m.ChainID)
+	assert.Equal(t, uint16(2), *m.ChainID)
+	assert.NotNil(t, m.FailedAt)
+}
+
+func TestDeadLetterQueue_RedriveAndPurge(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeSQS()
+	for i, class := range []string{"timeout", "timeout", "invalid payload"} {
+		attributes := map[string]aws_sqs_types.MessageAttributeValue{}
+		setAttribute(attributes, AttributeErrorClass, class)
+		setAttribute(attributes, AttributeSourceQueue, "queue")
+		setAttribute(attributes, AttributeVaaID, fmt.Sprintf("2/abc/%d", i))
+		setAttribute(attributes, AttributeChainID, "2")
+		setAttribute(attributes, "trace", "keep")
+		_, err := fake.SendMessage(ctx, &aws_sqs.SendMessageInput{QueueUrl: aws.String("dlq"),
+			MessageBody: aws.String(fmt.Sprintf("body-%d", i)), MessageAttributes: attributes})
+		require.NoError(t, err)
+	}
+	dlq := &DeadLetterQueue{api: fake, url: "dlq"}
+
+	chainID := uint16(2)
+	messages, err := dlq.List(ctx, &DeadLetterFilter{ChainID: &chainID, ErrorClass: "timeout"}, 0)
+	require.NoError(t, err)
+	assert.Len(t, messages, 2)
+
+	// listing leaves the messages visible.
+	messages, err = dlq.List(ctx, &DeadLetterFilter{VaaID: "2/abc/2"}, 0)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "body-2", messages[0].Body)
+
+	count, err := dlq.Redrive(ctx, &DeadLetterFilter{ErrorClass: "timeout"}, 1, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, fake.queues["queue"], 1)
+	redriven := fake.queues["queue"][0].msg
+	assert.Equal(t, "body-0", *redriven.Body)
+	assert.Contains(t, redriven.MessageAttributes, "trace")
+	assert.NotContains(t, redriven.MessageAttributes, AttributeErrorClass)
+
+	count, err = dlq.Purge(ctx, &DeadLetterFilter{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Empty(t, fake.queues["dlq"])
+}
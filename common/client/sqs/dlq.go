@@ -0,0 +1,128 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	aws_sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	aws_sqs_types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// Message attributes added to the messages moved to a dead-letter queue.
+const (
+	AttributeAttempts     = "wormscan-attempts"
+	AttributeErrorClass   = "wormscan-error-class"
+	AttributeErrorMessage = "wormscan-error-message"
+	AttributeSourceQueue  = "wormscan-source-queue"
+	AttributeVaaID        = "wormscan-vaa-id"
+	AttributeChainID      = "wormscan-chain-id"
+	AttributeFailedAt     = "wormscan-failed-at"
+)
+
+const maxErrorClassLength = 128
+
+// ErrorClassifier is implemented by errors that define the class used to group failed messages.
+type ErrorClassifier interface {
+	ErrorClass() string
+}
+
+// variableErrorPart matches the parts of an error message that change between failures of the same
+// kind: the words with digits (VAA IDs, hashes, amounts, addresses, ports) and the long encoded words.
+var variableErrorPart = regexp.MustCompile(`[^\s:,;()\[\]{}"'=]*[0-9][^\s:,;()\[\]{}"'=]*|[A-Za-z0-9+/_-]{32,}`)
+
+// ErrorClass returns the class of an error. It is the class of the first error in the chain that
+// implements ErrorClassifier or, otherwise, the message of the innermost wrapped error without its
+// variable parts, so the sentinel errors are grouped by their message and the failures of the same
+// kind share a class.
+func ErrorClass(err error) string {
+	if err == nil {
+		return "unknown"
+	}
+	var classifier ErrorClassifier
+	if errors.As(err, &classifier) {
+		return classifier.ErrorClass()
+	}
+	for unwrapped := errors.Unwrap(err); unwrapped != nil; unwrapped = errors.Unwrap(err) {
+		err = unwrapped
+	}
+	class := variableErrorPart.ReplaceAllString(err.Error(), "*")
+	if len(class) > maxErrorClassLength {
+		class = class[:maxErrorClassLength]
+	}
+	return class
+}
+
+// Failure describes why a message could not be processed.
+type Failure struct {
+	VaaID   string
+	ChainID uint16
+	Err     error
+}
+
+// Attempts returns the number of times a message was received.
+func Attempts(msg aws_sqs_types.Message) int {
+	attempts, _ := strconv.Atoi(msg.Attributes[string(aws_sqs_types.MessageSystemAttributeNameApproximateReceiveCount)])
+	return attempts
+}
+
+// Failed records a processing failure of a message. When the message was received at least the
+// maximum number of attempts it is moved to the dead-letter queue with the failure in its attributes
+// and true is returned, otherwise it is left in the queue to be retried after the visibility timeout.
+func (c *Consumer) Failed(ctx context.Context, msg aws_sqs_types.Message, failure Failure) (bool, error) {
+	attempts := Attempts(msg)
+	if c.dlqURL == "" || attempts < c.maxAttempts {
+		return false, nil
+	}
+
+	attributes := make(map[string]aws_sqs_types.MessageAttributeValue, len(msg.MessageAttributes)+7)
+	for k, v := range msg.MessageAttributes {
+		attributes[k] = v
+	}
+	setAttribute(attributes, AttributeAttempts, strconv.Itoa(attempts))
+	setAttribute(attributes, AttributeErrorClass, ErrorClass(failure.Err))
+	if failure.Err != nil {
+		setAttribute(attributes, AttributeErrorMessage, failure.Err.Error())
+	}
+	setAttribute(attributes, AttributeSourceQueue, c.url)
+	setAttribute(attributes, AttributeVaaID, failure.VaaID)
+	if failure.ChainID != 0 {
+		setAttribute(attributes, AttributeChainID, strconv.FormatUint(uint64(failure.ChainID), 10))
+	}
+	setAttribute(attributes, AttributeFailedAt, time.Now().UTC().Format(time.RFC3339))
+
+	if err := sendMessage(ctx, c.api, c.dlqURL, msg, attributes); err != nil {
+		return false, err
+	}
+	return true, c.DeleteMessage(ctx, msg.ReceiptHandle)
+}
+
+func setAttribute(attributes map[string]aws_sqs_types.MessageAttributeValue, name, value string) {
+	if value == "" {
+		return
+	}
+	attributes[name] = aws_sqs_types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+}
+
+// sendMessage sends the body of a message to a queue, keeping the message group of FIFO queues.
+func sendMessage(ctx context.Context, api API, url string, msg aws_sqs_types.Message, attributes map[string]aws_sqs_types.MessageAttributeValue) error {
+	params := &aws_sqs.SendMessageInput{
+		QueueUrl:          aws.String(url),
+		MessageBody:       msg.Body,
+		MessageAttributes: attributes,
+	}
+	if strings.HasSuffix(url, ".fifo") {
+		groupID := msg.Attributes[string(aws_sqs_types.MessageSystemAttributeNameMessageGroupId)]
+		if groupID == "" {
+			groupID = "dlq"
+		}
+		params.MessageGroupId = aws.String(groupID)
+		params.MessageDeduplicationId = msg.MessageId
+	}
+	_, err := api.SendMessage(ctx, params)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -33,15 +34,17 @@ func Connect(
 	subContext, cancelFunc := context.WithTimeout(ctx, connectTimeout)
 	defer cancelFunc()
 
-	// build mongo options
+	// build mongo options, the commands executed in a traced context create a span.
 	options := options.Client().ApplyURI(uri)
+	cmdMonitor := tracing.NewMongoMonitor()
 	if enableQueryLog {
-		cmdMonitor := &event.CommandMonitor{
-			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
-				logger.Info(evt.Command.String())
-			}}
-		options.SetMonitor(cmdMonitor)
+		started := cmdMonitor.Started
+		cmdMonitor.Started = func(ctx context.Context, evt *event.CommandStartedEvent) {
+			logger.Info(evt.Command.String())
+			started(ctx, evt)
+		}
 	}
+	options.SetMonitor(cmdMonitor)
 
 	// Connect to MongoDB
 	client, err := mongo.Connect(subContext, options)
@@ -0,0 +1,106 @@
+// Package dlq lists, inspects, re-drives and purges the messages of the dead-letter queue of a service.
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+)
+
+// Actions supported on the dead-letter queue.
+const (
+	ActionList    = "list"
+	ActionInspect = "inspect"
+	ActionRedrive = "redrive"
+	ActionPurge   = "purge"
+)
+
+// Config is the configuration of an action on the dead-letter queue.
+type Config struct {
+	AwsEndpoint        string
+	AwsAccessKeyID     string
+	AwsSecretAccessKey string
+	AwsRegion          string
+	DLQSQSUrl          string
+	// TargetSQSUrl is the queue the messages are re-driven to. When it is empty each message
+	// is re-driven to the queue it failed in.
+	TargetSQSUrl string
+	Filter       sqs.DeadLetterFilter
+	Limit        int
+}
+
+// Run executes an action on the messages of the dead-letter queue that match the filter and writes
+// the messages or the result to out.
+func Run(ctx context.Context, action string, cfg *Config, out io.Writer) error {
+	awsCfg, err := newAwsConfig(ctx, cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to create aws config")
+	}
+	dlq := sqs.NewDeadLetterQueue(awsCfg, cfg.DLQSQSUrl)
+
+	switch action {
+	case ActionList, ActionInspect:
+		messages, err := dlq.List(ctx, &cfg.Filter, cfg.Limit)
+		if err != nil {
+			return errors.Wrap(err, "failed to list messages")
+		}
+		encoder := json.NewEncoder(out)
+		if action == ActionInspect {
+			encoder.SetIndent("", "  ")
+		}
+		for _, m := range messages {
+			if action == ActionList {
+				m.Body = ""
+			}
+			if err := encoder.Encode(m); err != nil {
+				return errors.Wrap(err, "failed to print message")
+			}
+		}
+	case ActionRedrive:
+		count, err := dlq.Redrive(ctx, &cfg.Filter, cfg.Limit, cfg.TargetSQSUrl)
+		if err != nil {
+			return errors.Wrapf(err, "failed to redrive messages after %d messages", count)
+		}
+		fmt.Fprintf(out, "%d messages re-driven\n", count)
+	case ActionPurge:
+		count, err := dlq.Purge(ctx, &cfg.Filter, cfg.Limit)
+		if err != nil {
+			return errors.Wrapf(err, "failed to purge messages after %d messages", count)
+		}
+		fmt.Fprintf(out, "%d messages purged\n", count)
+	default:
+		return errors.Errorf("unknown action %s", action)
+	}
+	return nil
+}
+
+func newAwsConfig(ctx context.Context, cfg *Config) (aws.Config, error) {
+	if cfg.AwsAccessKeyID != "" && cfg.AwsSecretAccessKey != "" {
+		credentials := credentials.NewStaticCredentialsProvider(cfg.AwsAccessKeyID, cfg.AwsSecretAccessKey, "")
+		customResolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+			if cfg.AwsEndpoint != "" {
+				return aws.Endpoint{
+					PartitionID:   "aws",
+					URL:           cfg.AwsEndpoint,
+					SigningRegion: region,
+				}, nil
+			}
+
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		})
+
+		return awsconfig.LoadDefaultConfig(ctx,
+			awsconfig.WithRegion(cfg.AwsRegion),
+			awsconfig.WithEndpointResolver(customResolver),
+			awsconfig.WithCredentialsProvider(credentials),
+		)
+	}
+	return awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AwsRegion))
+}
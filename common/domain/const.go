@@ -26,10 +26,16 @@ const (
 
 	// SourceTxStatusConfirmed indicates that the transaciton has been processed successfully.
 	SourceTxStatusConfirmed SourceTxStatus = "confirmed"
+
+	// SourceTxStatusInProgress indicates that the transaction published a message on the emitter chain
+	// but its VAA was not signed yet, only the data of the LogMessagePublished event is known.
+	SourceTxStatusInProgress SourceTxStatus = "inProgress"
 )
 
 const (
 	DstTxStatusFailedToProcess = "failed"
 	DstTxStatusConfirmed       = "completed"
 	DstTxStatusUnkonwn         = "unknown"
+	// DstTxStatusReorged indicates that the block of the destination transaction was removed by a chain reorganization.
+	DstTxStatusReorged = "reorged"
 )
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// RelayOrigin identifies the relay documents built by the explorer services, as opposed to the ones
+// stored by an external relayer engine.
+const RelayOrigin = "wormscan"
+
+// wormholeRelayerEmitters are the addresses of the generic relayer contracts by p2p network. The contracts
+// are deployed with the same address in every EVM chain.
+var wormholeRelayerEmitters = map[string]string{
+	P2pMainNet: "00000000000000000000000027428dd2d3dd32a4d7f7c497eaaa23130d894911",
+	P2pTestNet: "00000000000000000000000080ac94316391752a193c1c47e27d382b507c93f3",
+}
+
+// IsWormholeRelayerEmitter returns true if the emitter is a generic relayer contract of the p2p network.
+func IsWormholeRelayerEmitter(p2pNetwork string, emitter sdk.Address) bool {
+	address, ok := wormholeRelayerEmitters[p2pNetwork]
+	return ok && address == emitter.String()
+}
+
+// GetWormholeRelayerAddress returns the EVM address of the generic relayer contract of the p2p network.
+func GetWormholeRelayerAddress(p2pNetwork string) (string, bool) {
+	address, ok := wormholeRelayerEmitters[p2pNetwork]
+	if !ok {
+		return "", false
+	}
+	return "0x" + address[24:], true
+}
+
+// RelayStatus is the status of a generic relayer delivery.
+type RelayStatus string
+
+const (
+	// RelayStatusPending indicates that the delivery was requested but it was not executed on the target chain yet.
+	RelayStatusPending RelayStatus = "pending"
+	// RelayStatusDelivered indicates that the receiver contract was called successfully.
+	RelayStatusDelivered RelayStatus = "delivered"
+	// RelayStatusReceiverFailure indicates that the call to the receiver contract reverted and the refund was not sent.
+	RelayStatusReceiverFailure RelayStatus = "receiver_failure"
+	// RelayStatusForwardRequested indicates that the receiver requested new deliveries during the execution.
+	RelayStatusForwardRequested RelayStatus = "forward_requested"
+	// RelayStatusRefunded indicates that the call to the receiver contract reverted and the refund was sent.
+	RelayStatusRefunded RelayStatus = "refunded"
+)
+
+// Delivery statuses and refund statuses of the generic relayer Delivery event.
+const (
+	DeliveryStatusSuccess               uint8 = 0
+	DeliveryStatusReceiverFailure       uint8 = 1
+	DeliveryStatusForwardRequestFailure uint8 = 2
+	DeliveryStatusForwardRequestSuccess uint8 = 3
+
+	RefundStatusRefundSent                               uint8 = 0
+	RefundStatusRefundFail                               uint8 = 1
+	RefundStatusCrossChainRefundSent                     uint8 = 2
+	RefundStatusCrossChainRefundFailProviderNotSupported uint8 = 3
+	RefundStatusCrossChainRefundFailNotEnough            uint8 = 4
+	RefundStatusNoRefundRequested                        uint8 = 5
+)
+
+var deliveryStatusNames = map[uint8]string{
+	DeliveryStatusSuccess:               "Delivery Success",
+	DeliveryStatusReceiverFailure:       "Receiver Failure",
+	DeliveryStatusForwardRequestFailure: "Forward Request Failure",
+	DeliveryStatusForwardRequestSuccess: "Forward Request Success",
+}
+
+var refundStatusNames = map[uint8]string{
+	RefundStatusRefundSent:                               "Refund Sent",
+	RefundStatusRefundFail:                               "Refund Fail",
+	RefundStatusCrossChainRefundSent:                     "Cross Chain Refund Sent",
+	RefundStatusCrossChainRefundFailProviderNotSupported: "Cross Chain Refund Fail - Provider does not support the refund chain",
+	RefundStatusCrossChainRefundFailNotEnough:            "Cross Chain Refund Fail - Refund too low for cross chain refund",
+	RefundStatusNoRefundRequested:                        "No Refund Requested",
+}
+
+// DeliveryStatusName returns the description of a Delivery event status.
+func DeliveryStatusName(status uint8) string {
+	if name, ok := deliveryStatusNames[status]; ok {
+		return name
+	}
+	return DstTxStatusUnkonwn
+}
+
+// RefundStatusName returns the description of a Delivery event refund status.
+func RefundStatusName(status uint8) string {
+	if name, ok := refundStatusNames[status]; ok {
+		return name
+	}
+	return DstTxStatusUnkonwn
+}
+
+// GetRelayStatus returns the status of a delivery from the status and the refund status of its Delivery event.
+func GetRelayStatus(status, refundStatus uint8) RelayStatus {
+	switch status {
+	case DeliveryStatusSuccess:
+		return RelayStatusDelivered
+	case DeliveryStatusForwardRequestSuccess:
+		return RelayStatusForwardRequested
+	case DeliveryStatusReceiverFailure, DeliveryStatusForwardRequestFailure:
+		if refundStatus == RefundStatusRefundSent || refundStatus == RefundStatusCrossChainRefundSent {
+			return RelayStatusRefunded
+		}
+		return RelayStatusReceiverFailure
+	default:
+		return RelayStatusPending
+	}
+}
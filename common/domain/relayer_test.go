@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestGetRelayStatus(t *testing.T) {
+	var tests = []struct {
+		status       uint8
+		refundStatus uint8
+		want         RelayStatus
+	}{
+		{status: DeliveryStatusSuccess, refundStatus: RefundStatusRefundSent, want: RelayStatusDelivered},
+		{status: DeliveryStatusForwardRequestSuccess, refundStatus: RefundStatusNoRefundRequested, want: RelayStatusForwardRequested},
+		{status: DeliveryStatusReceiverFailure, refundStatus: RefundStatusRefundSent, want: RelayStatusRefunded},
+		{status: DeliveryStatusReceiverFailure, refundStatus: RefundStatusCrossChainRefundSent, want: RelayStatusRefunded},
+		{status: DeliveryStatusReceiverFailure, refundStatus: RefundStatusRefundFail, want: RelayStatusReceiverFailure},
+		{status: DeliveryStatusForwardRequestFailure, refundStatus: RefundStatusNoRefundRequested, want: RelayStatusReceiverFailure},
+		{status: 10, refundStatus: RefundStatusRefundSent, want: RelayStatusPending},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, GetRelayStatus(tt.status, tt.refundStatus))
+	}
+}
+
+func TestIsWormholeRelayerEmitter(t *testing.T) {
+	emitter, err := sdk.StringToAddress("27428dd2d3dd32a4d7f7c497eaaa23130d894911")
+	assert.NoError(t, err)
+	assert.True(t, IsWormholeRelayerEmitter(P2pMainNet, emitter))
+	assert.False(t, IsWormholeRelayerEmitter(P2pTestNet, emitter))
+
+	other, err := sdk.StringToAddress("3ee18b2214aff97000d974cf647e7c347e8fa585")
+	assert.NoError(t, err)
+	assert.False(t, IsWormholeRelayerEmitter(P2pMainNet, other))
+}
+
+func TestGetWormholeRelayerAddress(t *testing.T) {
+	address, ok := GetWormholeRelayerAddress(P2pMainNet)
+	assert.True(t, ok)
+	assert.Equal(t, "0x27428dd2d3dd32a4d7f7c497eaaa23130d894911", address)
+
+	_, ok = GetWormholeRelayerAddress("devnet")
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Sequence gap status constants.
+const (
+	GapStatusOpen   = "open"
+	GapStatusClosed = "closed"
+)
+
+// SequenceGap is a range of sequences of an emitter for which no VAA was found. The gaps are detected by the
+// pipeline chain health monitor and repaired by fly, and are stored in the sequenceGaps collection.
+type SequenceGap struct {
+	ID            string      `bson:"_id"`
+	EmitterChain  sdk.ChainID `bson:"emitterChain"`
+	EmitterAddr   string      `bson:"emitterAddr"`
+	StartSequence uint64      `bson:"startSequence"`
+	EndSequence   uint64      `bson:"endSequence"`
+	Missing       uint64      `bson:"missing"`
+	Status        string      `bson:"status"`
+	DetectedAt    time.Time   `bson:"detectedAt"`
+	UpdatedAt     time.Time   `bson:"updatedAt"`
+	ClosedAt      *time.Time  `bson:"closedAt,omitempty"`
+	AlertedAt     *time.Time  `bson:"alertedAt,omitempty"`
+	Repair        *GapRepair  `bson:"repair,omitempty"`
+}
+
+// GapRepair is the progress of the repair of a sequence gap.
+type GapRepair struct {
+	// NextSequence is the first sequence requested in the next repair of the gap.
+	NextSequence uint64 `bson:"nextSequence"`
+	// AttemptedAt is the time of the last repair of the gap.
+	AttemptedAt time.Time `bson:"attemptedAt"`
+}
+
+// NewSequenceGap creates an open gap between two sequences (both included).
+func NewSequenceGap(chainID sdk.ChainID, emitterAddr string, start, end uint64, now time.Time) *SequenceGap {
+	return &SequenceGap{
+		ID:            fmt.Sprintf("%d/%s/%d", chainID, emitterAddr, start),
+		EmitterChain:  chainID,
+		EmitterAddr:   emitterAddr,
+		StartSequence: start,
+		EndSequence:   end,
+		Missing:       end - start + 1,
+		Status:        GapStatusOpen,
+		DetectedAt:    now,
+		UpdatedAt:     now,
+	}
+}
+
+// ToMap returns a map representation of the SequenceGap.
+func (g *SequenceGap) ToMap() map[string]string {
+	return map[string]string{
+		"emitterChain":  g.EmitterChain.String(),
+		"emitterAddr":   g.EmitterAddr,
+		"startSequence": fmt.Sprint(g.StartSequence),
+		"endSequence":   fmt.Sprint(g.EndSequence),
+		"missing":       fmt.Sprint(g.Missing),
+		"detectedAt":    g.DetectedAt.String(),
+	}
+}
@@ -0,0 +1,72 @@
+// Package eventstest contains the events shared by the contract tests of the producers and the
+// consumers of the notification events.
+//
+// The valid fixtures are events every consumer must accept, including the events of the previous
+// versions that are still upgraded. The invalid fixtures are events every consumer must reject.
+package eventstest
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sort"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Fixture is a notification event as it is published by a producer.
+type Fixture struct {
+	// Name is the file name of the fixture.
+	Name string
+	// Event is the event type.
+	Event string
+	// Body is the JSON encoded event.
+	Body []byte
+}
+
+// Valid returns the events the consumers must accept.
+func Valid() []Fixture {
+	return load("fixtures/valid")
+}
+
+// Invalid returns the events the consumers must reject.
+func Invalid() []Fixture {
+	return load("fixtures/invalid")
+}
+
+// ByEvent returns the fixtures of an event type.
+func ByEvent(fixtures []Fixture, eventType string) []Fixture {
+	var filtered []Fixture
+	for _, f := range fixtures {
+		if f.Event == eventType {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// load reads the fixtures of a directory, the fixtures are embedded so a read error is a
+// programming error.
+func load(dir string) []Fixture {
+	entries, err := fixtures.ReadDir(dir)
+	if err != nil {
+		panic(err)
+	}
+	result := make([]Fixture, 0, len(entries))
+	for _, entry := range entries {
+		body, err := fixtures.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var header struct {
+			Event string `json:"event"`
+		}
+		if err := json.Unmarshal(body, &header); err != nil {
+			panic(err)
+		}
+		result = append(result, Fixture{Name: entry.Name(), Event: header.Event, Body: body})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
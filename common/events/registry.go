@@ -0,0 +1,221 @@
+package events
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Current schema versions of the events, the producers publish the events with these versions.
+//
+// To change the data of an event type add the schema of the new version in the schemas directory,
+// register it with the upgrade from the previous version and bump the current version. The consumers
+// upgrade the events of previous versions, so they must be deployed before the producers.
+const (
+	SignedVaaVersion           = "1"
+	LogMessagePublishedVersion = "2"
+)
+
+// legacyVersion is the version of the events published before the version field was added, their
+// data is the same as the version 1.
+const legacyVersion = "1"
+
+var (
+	// ErrUnknownEvent is returned for an event type without schema.
+	ErrUnknownEvent = errors.New("unknown event type")
+	// ErrUnknownVersion is returned for a version of a known event type without schema.
+	ErrUnknownVersion = errors.New("unknown event version")
+	// ErrInvalidEvent is returned for an event that does not match its schema.
+	ErrInvalidEvent = errors.New("invalid event")
+)
+
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// UpgradeFunc converts the data of an event to the next version of its schema.
+type UpgradeFunc func(data json.RawMessage) (json.RawMessage, error)
+
+// contract is a version of an event type.
+type contract struct {
+	schema *jsonschema.Schema
+	// next is the version the upgrade converts the data to, it is empty for the current version.
+	next    string
+	upgrade UpgradeFunc
+}
+
+// registry holds the contracts of the event types.
+type registry struct {
+	envelope  *jsonschema.Schema
+	current   map[string]string
+	contracts map[string]map[string]*contract
+}
+
+var defaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *registry {
+	r := newRegistry(mustCompile("notification-event.json"))
+	r.register(SignedVaaType, "1", mustCompile("signed-vaa.v1.json"))
+	r.register(LogMessagePublishedMesageType, "1", mustCompile("log-message-published.v1.json"))
+	r.register(LogMessagePublishedMesageType, "2", mustCompile("log-message-published.v2.json"))
+	r.registerUpgrade(LogMessagePublishedMesageType, "1", "2", upgradeLogMessagePublishedV1)
+	r.setCurrent(SignedVaaType, SignedVaaVersion)
+	r.setCurrent(LogMessagePublishedMesageType, LogMessagePublishedVersion)
+	return r
+}
+
+func newRegistry(envelope *jsonschema.Schema) *registry {
+	return &registry{
+		envelope:  envelope,
+		current:   make(map[string]string),
+		contracts: make(map[string]map[string]*contract),
+	}
+}
+
+// mustCompile compiles an embedded schema, the schemas are part of the binary so an invalid
+// schema is a programming error.
+func mustCompile(name string) *jsonschema.Schema {
+	f, err := schemas.Open("schemas/" + name)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	compiler.AssertFormat = true
+	compiler.AssertContent = true
+	if err := compiler.AddResource(name, f); err != nil {
+		panic(err)
+	}
+	return compiler.MustCompile(name)
+}
+
+func (r *registry) register(eventType, version string, schema *jsonschema.Schema) {
+	if r.contracts[eventType] == nil {
+		r.contracts[eventType] = make(map[string]*contract)
+	}
+	r.contracts[eventType][version] = &contract{schema: schema}
+}
+
+func (r *registry) registerUpgrade(eventType, from, to string, upgrade UpgradeFunc) {
+	c := r.contracts[eventType][from]
+	c.next = to
+	c.upgrade = upgrade
+}
+
+func (r *registry) setCurrent(eventType, version string) {
+	r.current[eventType] = version
+}
+
+// contract returns the contract of an event type and version.
+func (r *registry) contract(eventType, version string) (*contract, error) {
+	versions, ok := r.contracts[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, eventType)
+	}
+	if version == "" {
+		version = legacyVersion
+	}
+	c, ok := versions[version]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s version %s", ErrUnknownVersion, eventType, version)
+	}
+	return c, nil
+}
+
+// validate validates the envelope and the data of an event.
+func (r *registry) validate(e *NotificationEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := validateJSON(r.envelope, body); err != nil {
+		return err
+	}
+	c, err := r.contract(e.Event, e.Version)
+	if err != nil {
+		return err
+	}
+	return validateJSON(c.schema, e.Data)
+}
+
+// upgrade returns a copy of the event converted to the current version of its type.
+func (r *registry) upgrade(e *NotificationEvent) (*NotificationEvent, error) {
+	upgraded := *e
+	if upgraded.Version == "" {
+		upgraded.Version = legacyVersion
+	}
+	for upgraded.Version != r.current[e.Event] {
+		c, err := r.contract(upgraded.Event, upgraded.Version)
+		if err != nil {
+			return nil, err
+		}
+		if c.upgrade == nil {
+			return nil, fmt.Errorf("%w: %s version %s can not be upgraded", ErrUnknownVersion, e.Event, upgraded.Version)
+		}
+		if err := validateJSON(c.schema, upgraded.Data); err != nil {
+			return nil, err
+		}
+		data, err := c.upgrade(upgraded.Data)
+		if err != nil {
+			return nil, fmt.Errorf("upgrading %s from version %s to %s: %w", e.Event, upgraded.Version, c.next, err)
+		}
+		upgraded.Data = data
+		upgraded.Version = c.next
+	}
+	return &upgraded, nil
+}
+
+func validateJSON(schema *jsonschema.Schema, data []byte) error {
+	// the numbers are decoded as json.Number to validate the integers out of the float64 range.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEvent, err)
+	}
+	if err := schema.Validate(v); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidEvent, err)
+	}
+	return nil
+}
+
+// Validate validates an event against the schema of its type and version.
+func Validate(e *NotificationEvent) error {
+	return defaultRegistry.validate(e)
+}
+
+// Upgrade returns a copy of the event converted to the current version of its type, the data of
+// the previous versions is validated before each upgrade.
+func Upgrade(e *NotificationEvent) (*NotificationEvent, error) {
+	return defaultRegistry.upgrade(e)
+}
+
+// Decode validates an event, upgrades it to the current version of its type and returns its data.
+// Unlike GetEventData, the missing or invalid fields are reported instead of being left empty.
+func Decode[T EventData](e *NotificationEvent) (T, error) {
+	var data T
+	var eventType string
+	switch any(data).(type) {
+	case SignedVaa:
+		eventType = SignedVaaType
+	case LogMessagePublished:
+		eventType = LogMessagePublishedMesageType
+	}
+	if e.Event != eventType {
+		return data, fmt.Errorf("%w: %s event can not be decoded as %s", ErrInvalidEvent, e.Event, eventType)
+	}
+
+	upgraded, err := Upgrade(e)
+	if err != nil {
+		return data, err
+	}
+	if err := Validate(upgraded); err != nil {
+		return data, err
+	}
+	err = json.Unmarshal(upgraded.Data, &data)
+	return data, err
+}
@@ -0,0 +1,118 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events/eventstest"
+)
+
+func unmarshalFixture(t *testing.T, f eventstest.Fixture) *NotificationEvent {
+	var e NotificationEvent
+	require.NoError(t, json.Unmarshal(f.Body, &e), f.Name)
+	return &e
+}
+
+func TestValidate_Fixtures(t *testing.T) {
+	for _, f := range eventstest.Valid() {
+		assert.NoError(t, Validate(unmarshalFixture(t, f)), f.Name)
+	}
+	for _, f := range eventstest.Invalid() {
+		assert.Error(t, Validate(unmarshalFixture(t, f)), f.Name)
+	}
+}
+
+func TestValidate_Errors(t *testing.T) {
+	e := unmarshalFixture(t, eventstest.ByEvent(eventstest.Invalid(), SignedVaaType)[0])
+	assert.ErrorIs(t, Validate(e), ErrInvalidEvent)
+
+	e = unmarshalFixture(t, eventstest.ByEvent(eventstest.Valid(), SignedVaaType)[0])
+	e.Version = "9"
+	assert.ErrorIs(t, Validate(e), ErrUnknownVersion)
+
+	e.Event = "unknown"
+	assert.ErrorIs(t, Validate(e), ErrUnknownEvent)
+
+	e = unmarshalFixture(t, eventstest.ByEvent(eventstest.Valid(), SignedVaaType)[0])
+	e.TrackID = ""
+	assert.ErrorIs(t, Validate(e), ErrInvalidEvent)
+}
+
+func TestDecode(t *testing.T) {
+	for _, f := range eventstest.ByEvent(eventstest.Valid(), SignedVaaType) {
+		signedVaa, err := Decode[SignedVaa](unmarshalFixture(t, f))
+		require.NoError(t, err, f.Name)
+		assert.Equal(t, "2/000000000000000000000000f890982f9310df57d00f659cf4fd87e65aded8d7/162727", signedVaa.ID)
+		assert.Equal(t, uint64(162727), signedVaa.Sequence)
+		assert.NotEmpty(t, signedVaa.Vaa)
+	}
+
+	for _, f := range eventstest.ByEvent(eventstest.Valid(), LogMessagePublishedMesageType) {
+		lmp, err := Decode[LogMessagePublished](unmarshalFixture(t, f))
+		require.NoError(t, err, f.Name)
+		assert.Equal(t, uint64(3418), lmp.Attributes.Sequence)
+
+		_, err = Decode[SignedVaa](unmarshalFixture(t, f))
+		assert.ErrorIs(t, err, ErrInvalidEvent, f.Name)
+	}
+}
+
+func TestNewNotificationEvent(t *testing.T) {
+	signedVaa := SignedVaa{
+		ID:             "2/000000000000000000000000f890982f9310df57d00f659cf4fd87e65aded8d7/162727",
+		EmitterChain:   2,
+		EmitterAddress: "000000000000000000000000f890982f9310df57d00f659cf4fd87e65aded8d7",
+		Sequence:       162727,
+		Timestamp:      time.Date(2023, 8, 4, 14, 43, 48, 0, time.UTC),
+		Vaa:            []byte{1, 0, 0, 0, 0},
+	}
+	e, err := NewNotificationEvent("track", "fly", SignedVaaType, signedVaa)
+	require.NoError(t, err)
+	assert.Equal(t, SignedVaaVersion, e.Version)
+
+	signedVaa.Vaa = nil
+	_, err = NewNotificationEvent("track", "fly", SignedVaaType, signedVaa)
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+
+	_, err = NewNotificationEvent("track", "fly", "unknown", signedVaa)
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+}
+
+func TestUpgrade(t *testing.T) {
+	// the version 2 of log-message-published normalizes the payload.
+	for _, f := range eventstest.ByEvent(eventstest.Valid(), LogMessagePublishedMesageType) {
+		upgraded, err := Upgrade(unmarshalFixture(t, f))
+		require.NoError(t, err, f.Name)
+		assert.Equal(t, LogMessagePublishedVersion, upgraded.Version, f.Name)
+		assert.NoError(t, Validate(upgraded), f.Name)
+
+		var data LogMessagePublished
+		require.NoError(t, json.Unmarshal(upgraded.Data, &data))
+		assert.Regexp(t, "^010017[0-9a-f]+$", data.Attributes.Payload, f.Name)
+		assert.Equal(t, uint64(3418), data.Attributes.Sequence, f.Name)
+	}
+
+	// the data of the previous version is validated before the upgrade.
+	e := unmarshalFixture(t, eventstest.ByEvent(eventstest.Invalid(), LogMessagePublishedMesageType)[0])
+	_, err := Upgrade(e)
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+
+	// a version without upgrade to the current version can not be decoded.
+	r := newDefaultRegistry()
+	r.setCurrent(SignedVaaType, "2")
+	_, err = r.upgrade(unmarshalFixture(t, eventstest.ByEvent(eventstest.Valid(), SignedVaaType)[0]))
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestUpgradeLogMessagePublishedV1(t *testing.T) {
+	data := json.RawMessage(`{"chainId":2,"attributes":{"sequence":1,"payload":"0x01AB"}}`)
+	upgraded, err := upgradeLogMessagePublishedV1(data)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"chainId":2,"attributes":{"sequence":1,"payload":"01ab"}}`, string(upgraded))
+
+	_, err = upgradeLogMessagePublishedV1(json.RawMessage(`{"attributes":{"payload":1}}`))
+	assert.Error(t, err)
+}
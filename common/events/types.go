@@ -2,6 +2,7 @@ package events
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -17,21 +18,34 @@ type NotificationEvent struct {
 	Version   string          `json:"version"`
 	Timestamp time.Time       `json:"timestamp"`
 	Data      json.RawMessage `json:"data"`
+	// TraceContext is the trace context of the event when it is sent through a channel without
+	// message attributes, e.g. a redis pubsub channel.
+	TraceContext map[string]string `json:"traceContext,omitempty"`
 }
 
+// NewNotificationEvent creates an event with the current version of its type, the event is validated
+// against the schema of the version.
 func NewNotificationEvent[T EventData](trackID, source, _type string, data T) (*NotificationEvent, error) {
+	version, ok := defaultRegistry.current[_type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, _type)
+	}
 	p, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
-	return &NotificationEvent{
+	e := &NotificationEvent{
 		TrackID:   trackID,
 		Source:    source,
 		Event:     _type,
 		Data:      json.RawMessage(p),
-		Version:   "1",
+		Version:   version,
 		Timestamp: time.Now(),
-	}, nil
+	}
+	if err := Validate(e); err != nil {
+		return nil, err
+	}
+	return e, nil
 }
 
 type EventData interface {
@@ -65,6 +79,8 @@ type LogMessagePublished struct {
 	Attributes  PublishedLogMessageAttributes `json:"attributes"`
 }
 
+// PublishedLogMessageAttributes are the attributes of a published message, the payload is lowercase
+// hex without 0x prefix since the version 2 of the event.
 type PublishedLogMessageAttributes struct {
 	Sender           string `json:"sender"`
 	Sequence         uint64 `json:"sequence"`
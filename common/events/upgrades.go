@@ -0,0 +1,34 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// upgradeLogMessagePublishedV1 converts a log-message-published event from the version 1 to the
+// version 2, whose payload is lowercase hex without 0x prefix. The version 1 accepted both forms,
+// so the consumers had to guess.
+func upgradeLogMessagePublishedV1(data json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(fields["attributes"], &attributes); err != nil {
+		return nil, err
+	}
+	var payload string
+	if err := json.Unmarshal(attributes["payload"], &payload); err != nil {
+		return nil, err
+	}
+	payload = strings.ToLower(strings.TrimPrefix(payload, "0x"))
+
+	var err error
+	if attributes["payload"], err = json.Marshal(payload); err != nil {
+		return nil, err
+	}
+	if fields["attributes"], err = json.Marshal(attributes); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
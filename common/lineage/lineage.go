@@ -0,0 +1,184 @@
+// Package lineage records the processing of each VAA by the services it passes through, so the
+// outcome of every stage can be inspected without searching the logs.
+package lineage
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Collection is the collection of the processing lineage of the VAAs.
+const Collection = "vaaProcessing"
+
+// Stage is a service that processes the VAAs.
+type Stage string
+
+const (
+	StageFly             Stage = "fly"
+	StagePipeline        Stage = "pipeline"
+	StageParser          Stage = "parser"
+	StageTxTracker       Stage = "tx-tracker"
+	StageAnalytics       Stage = "analytics"
+	StageContractWatcher Stage = "contract-watcher"
+)
+
+// Stages are the stages in the order a VAA passes through them.
+var Stages = []Stage{StageFly, StagePipeline, StageParser, StageTxTracker, StageAnalytics, StageContractWatcher}
+
+// Outcome is the result of the processing of a VAA by a stage.
+type Outcome string
+
+const (
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+	// OutcomeSkipped indicates that the stage received the VAA but it does not apply to it.
+	OutcomeSkipped Outcome = "skipped"
+)
+
+// Entry is the last processing of a VAA by a stage.
+type Entry struct {
+	Version    string    `bson:"version" json:"version"`
+	TrackID    string    `bson:"trackId,omitempty" json:"trackId,omitempty"`
+	StartedAt  time.Time `bson:"startedAt" json:"startedAt"`
+	FinishedAt time.Time `bson:"finishedAt" json:"finishedAt"`
+	DurationMs int64     `bson:"durationMs" json:"durationMs"`
+	Outcome    Outcome   `bson:"outcome" json:"outcome"`
+	Error      string    `bson:"error,omitempty" json:"error,omitempty"`
+	// Reason explains an outcome that is not self-evident, e.g. why the VAA was skipped.
+	Reason string `bson:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// Document is the processing lineage of a VAA, it holds the last entry of each stage.
+type Document struct {
+	ID        string           `bson:"_id"`
+	Stages    map[Stage]*Entry `bson:"stages"`
+	UpdatedAt time.Time        `bson:"updatedAt"`
+}
+
+// Indexes returns the indexes of the lineage collection. The documents expire after the retention
+// period since their last update, and the wildcard index on the stages covers the lookups by any
+// field of a stage, e.g. the VAAs that failed in the parser or the entries of a track ID.
+func Indexes(retention time.Duration) []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "updatedAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds())),
+		},
+		{
+			Keys: bson.D{{Key: "stages.$**", Value: 1}},
+		},
+	}
+}
+
+// version is the version of the service, it can be set at build time with
+// -ldflags "-X github.com/wormhole-foundation/wormhole-explorer/common/lineage.version=<version>".
+var version string
+
+// Version returns the version of the running service: the build time version if it was set, otherwise
+// the VCS revision or the module version embedded in the binary.
+func Version() string {
+	if version != "" {
+		return version
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+			return setting.Value[:12]
+		}
+	}
+	if info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "unknown"
+}
+
+// Recorder records the processing of the VAAs by a stage. A nil recorder does not record anything.
+type Recorder struct {
+	collection *mongo.Collection
+	stage      Stage
+	version    string
+	logger     *zap.Logger
+}
+
+// NewRecorder creates a recorder for the stage.
+func NewRecorder(db *mongo.Database, stage Stage, logger *zap.Logger) *Recorder {
+	return &Recorder{
+		collection: db.Collection(Collection),
+		stage:      stage,
+		version:    Version(),
+		logger:     logger.With(zap.String("module", "LineageRecorder")),
+	}
+}
+
+// Record records the processing of a VAA that started at startedAt, it failed if err is not nil.
+// The errors saving the entry are logged, they must not interrupt the processing of the VAA.
+func (r *Recorder) Record(ctx context.Context, vaaID, trackID string, startedAt time.Time, err error) {
+	r.RecordWithReason(ctx, vaaID, trackID, startedAt, "", err)
+}
+
+// RecordWithReason records the processing of a VAA like Record, with the reason of a partial result,
+// e.g. a transfer whose notional could not be computed.
+func (r *Recorder) RecordWithReason(ctx context.Context, vaaID, trackID string, startedAt time.Time, reason string, err error) {
+	if r == nil {
+		return
+	}
+	outcome := OutcomeSucceeded
+	if err != nil {
+		outcome = OutcomeFailed
+	}
+	entry := r.newEntry(trackID, startedAt, time.Now(), outcome, err)
+	entry.Reason = reason
+	r.save(ctx, vaaID, entry)
+}
+
+// Skip records that the stage received a VAA that does not apply to it.
+func (r *Recorder) Skip(ctx context.Context, vaaID, trackID string, startedAt time.Time, reason string) {
+	if r == nil {
+		return
+	}
+	entry := r.newEntry(trackID, startedAt, time.Now(), OutcomeSkipped, nil)
+	entry.Reason = reason
+	r.save(ctx, vaaID, entry)
+}
+
+func (r *Recorder) newEntry(trackID string, startedAt, finishedAt time.Time, outcome Outcome, err error) *Entry {
+	entry := Entry{
+		Version:    r.version,
+		TrackID:    trackID,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		DurationMs: finishedAt.Sub(startedAt).Milliseconds(),
+		Outcome:    outcome,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return &entry
+}
+
+func (r *Recorder) save(ctx context.Context, vaaID string, entry *Entry) {
+	_, err := r.collection.UpdateByID(ctx, vaaID, newUpdate(r.stage, entry), options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Warn("Error saving vaa processing lineage",
+			zap.String("vaaId", vaaID), zap.String("stage", string(r.stage)), zap.Error(err))
+	}
+}
+
+// newUpdate returns the update that replaces the entry of the stage, the entries of the other stages are kept.
+func newUpdate(stage Stage, entry *Entry) bson.M {
+	return bson.M{
+		"$set": bson.M{
+			"stages." + string(stage): entry,
+			"updatedAt":               entry.FinishedAt,
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package lineage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/test-go/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewEntry(t *testing.T) {
+	r := &Recorder{stage: StageParser, version: "abc"}
+	startedAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	finishedAt := startedAt.Add(1500 * time.Millisecond)
+
+	entry := r.newEntry("track", startedAt, finishedAt, OutcomeFailed, errors.New("parser not found"))
+
+	assert.Equal(t, &Entry{
+		Version:    "abc",
+		TrackID:    "track",
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		DurationMs: 1500,
+		Outcome:    OutcomeFailed,
+		Error:      "parser not found",
+	}, entry)
+}
+
+func TestNewUpdate(t *testing.T) {
+	finishedAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	entry := &Entry{Version: "abc", FinishedAt: finishedAt, Outcome: OutcomeSucceeded}
+
+	update := newUpdate(StageTxTracker, entry)
+
+	assert.Equal(t, bson.M{"$set": bson.M{"stages.tx-tracker": entry, "updatedAt": finishedAt}}, update)
+}
+
+func TestIndexes(t *testing.T) {
+	indexes := Indexes(30 * 24 * time.Hour)
+
+	assert.Len(t, indexes, 2)
+	assert.Equal(t, bson.D{{Key: "updatedAt", Value: 1}}, indexes[0].Keys)
+	assert.Equal(t, int32(2592000), *indexes[0].Options.ExpireAfterSeconds)
+	assert.Equal(t, bson.D{{Key: "stages.$**", Value: 1}}, indexes[1].Keys)
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	assert.NotPanics(t, func() {
+		r.Record(context.Background(), "2/a/1", "track", time.Now(), nil)
+		r.Skip(context.Background(), "2/a/1", "track", time.Now(), "not a transfer")
+	})
+}
+
+func TestVersion(t *testing.T) {
+	assert.NotEmpty(t, Version())
+
+	version = "1.2.3"
+	defer func() { version = "" }()
+	assert.Equal(t, "1.2.3", Version())
+}
@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes of the spans of the explorer.
+const (
+	VaaIDKey   = attribute.Key("vaa.id")
+	TrackIDKey = attribute.Key("track.id")
+)
+
+// Inject returns the trace context of ctx as a map to send it in the attributes of a message,
+// it returns nil when ctx has no trace context.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns a copy of ctx with the trace context received in the attributes of a message.
+func Extract(ctx context.Context, attributes map[string]string) context.Context {
+	if len(attributes) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(attributes))
+}
+
+// StartConsumerSpan starts the span of the processing of a message, child of the trace context sent by
+// the producer in the attributes of the message.
+func StartConsumerSpan(ctx context.Context, tracerName, spanName string, attributes map[string]string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer(tracerName).Start(Extract(ctx, attributes), spanName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...))
+}
+
+// StartProducerSpan starts the span of the publication of a message, the trace context to send with the
+// message is obtained with Inject.
+func StartProducerSpan(ctx context.Context, tracerName, spanName string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer(tracerName).Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,97 @@
+// Package tracing configures the OpenTelemetry tracing of the explorer services and propagates the
+// trace context through the message brokers, the redis channels and the HTTP requests.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Exporter types.
+const (
+	// ExporterNone does not export the spans, the trace context is still propagated.
+	ExporterNone = "none"
+	// ExporterOTLP exports the spans to an OTLP collector over HTTP.
+	ExporterOTLP = "otlp"
+)
+
+// Config represents the tracing configuration of a service.
+type Config struct {
+	Exporter     string  `env:"TRACING_EXPORTER,default=none"`
+	OTLPEndpoint string  `env:"TRACING_OTLP_ENDPOINT,default=localhost:4318"`
+	OTLPInsecure bool    `env:"TRACING_OTLP_INSECURE,default=true"`
+	SampleRatio  float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
+}
+
+// ShutdownFunc flushes the pending spans and stops the exporter.
+type ShutdownFunc func(context.Context) error
+
+// Init sets the global tracer provider and propagator of the service. With the none exporter the
+// spans are not recorded but the trace context received by the service is propagated.
+func Init(ctx context.Context, service string, cfg Config, logger *zap.Logger) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter: %s", cfg.Exporter)
+	}
+
+	// without endpoint the exporter uses the OTEL_EXPORTER_OTLP_ENDPOINT environment variable.
+	var opts []otlptracehttp.Option
+	if cfg.OTLPEndpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+	}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(service),
+			semconv.ServiceVersion(lineage.Version()))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Warn("Error exporting traces", zap.Error(err))
+	}))
+
+	logger.Info("Tracing enabled",
+		zap.String("exporter", cfg.Exporter),
+		zap.String("endpoint", cfg.OTLPEndpoint),
+		zap.Float64("sampleRatio", cfg.SampleRatio))
+	return provider.Shutdown, nil
+}
+
+// Tracer returns a tracer of the global tracer provider, it can be created before Init is called.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// EndSpan records the error, if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
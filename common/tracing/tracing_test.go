@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.uber.org/zap"
+)
+
+func newTestProvider(t *testing.T) *tracetest.SpanRecorder {
+	_, err := Init(context.Background(), "test", Config{Exporter: ExporterNone}, zap.NewNop())
+	require.NoError(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	return recorder
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	_, err := Init(context.Background(), "test", Config{Exporter: "jaeger"}, zap.NewNop())
+	assert.EqualError(t, err, "unknown tracing exporter: jaeger")
+}
+
+func TestInjectExtract(t *testing.T) {
+	newTestProvider(t)
+
+	assert.Nil(t, Inject(context.Background()))
+
+	ctx, span := Tracer("test").Start(context.Background(), "publish")
+	defer span.End()
+	attributes := Inject(ctx)
+	assert.Contains(t, attributes, "traceparent")
+
+	_, child := StartConsumerSpan(context.Background(), "test", "consume", attributes, VaaIDKey.String("2/a/1"))
+	defer child.End()
+	assert.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+}
+
+func TestMongoMonitor(t *testing.T) {
+	recorder := newTestProvider(t)
+	monitor := NewMongoMonitor()
+
+	// commands outside a trace are not traced.
+	monitor.Started(context.Background(), &event.CommandStartedEvent{CommandName: "find", RequestID: 1})
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1}})
+	assert.Empty(t, recorder.Ended())
+
+	ctx, span := Tracer("test").Start(context.Background(), "process")
+	command, _ := bson.Marshal(bson.D{{Key: "update", Value: "parsedVaa"}})
+	monitor.Started(ctx, &event.CommandStartedEvent{Command: command, DatabaseName: "wormscan", CommandName: "update", RequestID: 2})
+	monitor.Failed(ctx, &event.CommandFailedEvent{Failure: "duplicate key", CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 2}})
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "mongo.update", spans[0].Name())
+	assert.Equal(t, span.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), semconv.DBMongoDBCollection("parsedVaa"))
+}
+
+func TestTransport(t *testing.T) {
+	recorder := newTestProvider(t)
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, span := Tracer("test").Start(context.Background(), "process")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "HTTP POST", spans[0].Name())
+	assert.Contains(t, traceparent, spans[0].SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, spans[0].SpanContext().SpanID().String())
+}
+
+func TestFiberMiddleware(t *testing.T) {
+	recorder := newTestProvider(t)
+	app := fiber.New()
+	app.Use(NewFiberMiddleware())
+	app.Get("/api/v1/vaas/:chain", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	ctx, span := Tracer("test").Start(context.Background(), "client")
+	span.End()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/vaas/2", nil)
+	for k, v := range Inject(ctx) {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /api/v1/vaas/:chain", spans[1].Name())
+	assert.Equal(t, span.SpanContext().TraceID(), spans[1].SpanContext().TraceID())
+}
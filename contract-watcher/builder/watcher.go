@@ -1,15 +1,20 @@
 package builder
 
 import (
+	"fmt"
 	"time"
 
 	solana_go "github.com/gagliardetto/solana-go"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/algorand"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/ankr"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/aptos"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/cosmos"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/near"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/solana"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/sui"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/terra"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
@@ -22,7 +27,8 @@ func CreateAnkrEvmWatcher(rateLimit int, chainURL string, wb config.WatcherBlock
 	evmLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	ankrClient := ankr.NewAnkrSDK(chainURL, evmLimiter, metrics)
 	params := watcher.EVMParams{ChainID: wb.ChainID, Blockchain: wb.Name, SizeBlocks: wb.SizeBlocks,
-		WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock, MethodsByAddress: wb.MethodsByAddress}
+		WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock, MethodsByAddress: wb.MethodsByAddress,
+		ConfirmationDepth: wb.ConfirmationDepth, ReorgWindow: wb.ReorgWindow}
 	return watcher.NewEVMWatcher(ankrClient, repo, params, metrics, logger)
 }
 
@@ -42,7 +48,8 @@ func CreateTerraWatcher(rateLimit int, chainURL string, wb config.WatcherBlockch
 	terraLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	terraClient := terra.NewTerraSDK(chainURL, terraLimiter, metrics)
 	params := watcher.TerraParams{ChainID: wb.ChainID, Blockchain: wb.Name,
-		ContractAddress: wb.Address, WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock}
+		ContractAddress: wb.Address, WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock,
+		ConfirmationDepth: wb.ConfirmationDepth}
 	return watcher.NewTerraWatcher(terraClient, params, repo, metrics, logger)
 }
 
@@ -50,18 +57,74 @@ func CreateAptosWatcher(rateLimit int, chainURL string, wb config.WatcherBlockch
 	aptosLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	aptosClient := aptos.NewAptosSDK(chainURL, aptosLimiter, metrics)
 	params := watcher.AptosParams{
-		Blockchain:      wb.Name,
-		ContractAddress: wb.Address,
-		SizeBlocks:      wb.SizeBlocks,
-		WaitSeconds:     wb.WaitSeconds,
-		InitialBlock:    wb.InitialBlock}
+		Blockchain:        wb.Name,
+		ContractAddress:   wb.Address,
+		SizeBlocks:        wb.SizeBlocks,
+		WaitSeconds:       wb.WaitSeconds,
+		InitialBlock:      wb.InitialBlock,
+		ConfirmationDepth: wb.ConfirmationDepth}
 	return watcher.NewAptosWatcher(aptosClient, params, repo, metrics, logger)
 }
 
+func CreateSuiWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	suiLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	suiClient := sui.NewSuiSDK(chainURL, suiLimiter, metrics)
+	params := watcher.SuiParams{
+		Blockchain:        wb.Name,
+		SizeBlocks:        wb.SizeBlocks,
+		WaitSeconds:       wb.WaitSeconds,
+		InitialBlock:      wb.InitialBlock,
+		ConfirmationDepth: wb.ConfirmationDepth,
+		MethodsByAddress:  wb.MethodsByAddress}
+	return watcher.NewSuiWatcher(suiClient, params, repo, metrics, logger)
+}
+
+func CreateAlgorandWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	algorandLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	algorandClient := algorand.NewAlgorandSDK(chainURL, algorandLimiter, metrics)
+	params := watcher.AlgorandParams{
+		Blockchain:        wb.Name,
+		SizeBlocks:        wb.SizeBlocks,
+		WaitSeconds:       wb.WaitSeconds,
+		InitialBlock:      wb.InitialBlock,
+		ConfirmationDepth: wb.ConfirmationDepth,
+		MethodsByAddress:  wb.MethodsByAddress}
+	return watcher.NewAlgorandWatcher(algorandClient, params, repo, metrics, logger)
+}
+
+func CreateNearWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	nearLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	nearClient := near.NewNearSDK(chainURL, nearLimiter, metrics)
+	params := watcher.NearParams{
+		Blockchain:        wb.Name,
+		SizeBlocks:        wb.SizeBlocks,
+		WaitSeconds:       wb.WaitSeconds,
+		InitialBlock:      wb.InitialBlock,
+		ConfirmationDepth: wb.ConfirmationDepth,
+		MethodsByAddress:  wb.MethodsByAddress}
+	return watcher.NewNearWatcher(nearClient, params, repo, metrics, logger)
+}
+
+// CreateCosmwasmWatcher creates a watcher for a Cosmos-SDK chain with CosmWasm contracts.
+func CreateCosmwasmWatcher(rateLimit int, chainURL string, wb config.WatcherBlockchain, logger *zap.Logger, repo *storage.Repository, metrics metrics.Metrics) watcher.ContractWatcher {
+	cosmosLimiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	cosmosClient := cosmos.NewCosmosSDK(chainURL, cosmosLimiter, metrics)
+	params := watcher.CosmwasmParams{
+		ChainID:           wb.ChainID,
+		Blockchain:        wb.Name,
+		SizeBlocks:        wb.SizeBlocks,
+		WaitSeconds:       wb.WaitSeconds,
+		InitialBlock:      wb.InitialBlock,
+		ConfirmationDepth: wb.ConfirmationDepth,
+		MethodsByAddress:  wb.MethodsByAddress}
+	return watcher.NewCosmwasmWatcher(cosmosClient, params, repo, metrics, logger)
+}
+
 func CreateEvmWatcher(
 	rateLimit int,
 	chainURL string,
 	wb config.WatcherBlockchainAddresses,
+	traceInternalCalls bool,
 	logger *zap.Logger,
 	repo *storage.Repository,
 	metrics metrics.Metrics,
@@ -70,13 +133,115 @@ func CreateEvmWatcher(
 	limiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
 	client := evm.NewEvmSDK(chainURL, limiter, metrics)
 	params := watcher.EVMParams{
-		ChainID:          wb.ChainID,
-		Blockchain:       wb.Name,
-		SizeBlocks:       wb.SizeBlocks,
-		WaitSeconds:      wb.WaitSeconds,
-		InitialBlock:     wb.InitialBlock,
-		MethodsByAddress: wb.MethodsByAddress,
+		ChainID:            wb.ChainID,
+		Blockchain:         wb.Name,
+		SizeBlocks:         wb.SizeBlocks,
+		WaitSeconds:        wb.WaitSeconds,
+		InitialBlock:       wb.InitialBlock,
+		MethodsByAddress:   wb.MethodsByAddress,
+		TraceInternalCalls: traceInternalCalls,
+		ConfirmationDepth:  wb.ConfirmationDepth,
+		ReorgWindow:        wb.ReorgWindow,
 	}
 
 	return watcher.NewEvmStandardWatcher(client, params, repo, metrics, logger)
 }
+
+// CreateEvmLogsWatcher creates a watcher that detects the redeems from the events emitted by the contracts.
+func CreateEvmLogsWatcher(
+	rateLimit int,
+	chainURL string,
+	wb config.WatcherBlockchainAddresses,
+	logger *zap.Logger,
+	repo *storage.Repository,
+	metrics metrics.Metrics,
+) watcher.ContractWatcher {
+
+	limiter := ratelimit.New(rateLimit, ratelimit.Per(time.Second))
+	client := evm.NewEvmSDK(chainURL, limiter, metrics)
+	params := watcher.EVMParams{
+		ChainID:           wb.ChainID,
+		Blockchain:        wb.Name,
+		SizeBlocks:        wb.SizeBlocks,
+		WaitSeconds:       wb.WaitSeconds,
+		InitialBlock:      wb.InitialBlock,
+		EventsByAddress:   wb.EventsByAddress,
+		ConfirmationDepth: wb.ConfirmationDepth,
+		ReorgWindow:       wb.ReorgWindow,
+	}
+
+	return watcher.NewEvmLogsWatcher(client, params, repo, metrics, logger)
+}
+
+// CreateWatcher creates a watcher from its declarative definition.
+func CreateWatcher(spec config.WatcherSpec, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) (watcher.ContractWatcher, error) {
+	switch spec.Provider {
+	case config.ProviderAnkr:
+		wb, err := spec.ToWatcherBlockchainAddresses()
+		if err != nil {
+			return nil, err
+		}
+		return CreateAnkrEvmWatcher(spec.RequestsPerSecond, spec.URL, wb, repo, metrics, logger), nil
+	case config.ProviderEvm:
+		wb, err := spec.ToWatcherBlockchainAddresses()
+		if err != nil {
+			return nil, err
+		}
+		return CreateEvmWatcher(spec.RequestsPerSecond, spec.URL, wb, spec.TraceInternalCalls, logger, repo, metrics), nil
+	case config.ProviderEvmLogs:
+		wb, err := spec.ToWatcherBlockchainAddresses()
+		if err != nil {
+			return nil, err
+		}
+		return CreateEvmLogsWatcher(spec.RequestsPerSecond, spec.URL, wb, logger, repo, metrics), nil
+	case config.ProviderSolana:
+		return CreateSolanaWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	case config.ProviderTerra:
+		return CreateTerraWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	case config.ProviderAptos:
+		return CreateAptosWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	case config.ProviderSui:
+		return CreateSuiWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	case config.ProviderAlgorand:
+		return CreateAlgorandWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	case config.ProviderNear:
+		return CreateNearWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	case config.ProviderCosmwasm:
+		return CreateCosmwasmWatcher(spec.RequestsPerSecond, spec.URL, spec.ToWatcherBlockchain(), logger, repo, metrics), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %s for watcher %s", spec.Provider, spec.Name)
+	}
+}
+
+// CreateWatchers creates the watchers of a watchers configuration.
+//
+// Ankr watchers that use the same url share the client, and therefore the rate limit.
+func CreateWatchers(cfg *config.WatchersConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) ([]watcher.ContractWatcher, error) {
+	ankrClients := make(map[string]*ankr.AnkrSDK)
+	result := make([]watcher.ContractWatcher, 0, len(cfg.Watchers))
+	for _, spec := range cfg.Watchers {
+		if spec.Provider == config.ProviderAnkr {
+			client, ok := ankrClients[spec.URL]
+			if !ok {
+				limiter := ratelimit.New(spec.RequestsPerSecond, ratelimit.Per(time.Second))
+				client = ankr.NewAnkrSDK(spec.URL, limiter, metrics)
+				ankrClients[spec.URL] = client
+			}
+			wb, err := spec.ToWatcherBlockchainAddresses()
+			if err != nil {
+				return nil, err
+			}
+			params := watcher.EVMParams{ChainID: wb.ChainID, Blockchain: wb.Name, SizeBlocks: wb.SizeBlocks,
+				WaitSeconds: wb.WaitSeconds, InitialBlock: wb.InitialBlock, MethodsByAddress: wb.MethodsByAddress,
+				ConfirmationDepth: wb.ConfirmationDepth, ReorgWindow: wb.ReorgWindow}
+			result = append(result, watcher.NewEVMWatcher(client, repo, params, metrics, logger))
+			continue
+		}
+		w, err := CreateWatcher(spec, repo, metrics, logger)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, w)
+	}
+	return result, nil
+}
@@ -0,0 +1,39 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+func TestCreateWatchers_AnkrReorgWindow(t *testing.T) {
+	t.Setenv("ANKR_URL", "http://localhost:8545")
+	t.Setenv("ANKR_REQUESTS_PER_SECOND", "1")
+	cfg, err := config.LoadWatchers("", "mainnet")
+	require.NoError(t, err)
+	bsc, ok := cfg.FindWatcher(vaa.ChainIDBSC)
+	require.True(t, ok)
+	require.Equal(t, config.ProviderAnkr, bsc.Provider)
+	require.NotZero(t, bsc.ReorgWindow)
+
+	w, err := CreateWatcher(*bsc, nil, metrics.NewNoopMetrics(), zap.NewNop())
+	require.NoError(t, err)
+	assert.True(t, w.(*watcher.EVMWatcher).DetectsReorgs())
+
+	watchers, err := CreateWatchers(&config.WatchersConfiguration{Watchers: []config.WatcherSpec{*bsc}}, nil,
+		metrics.NewNoopMetrics(), zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, watchers, 1)
+	assert.True(t, watchers[0].(*watcher.EVMWatcher).DetectsReorgs())
+
+	bsc.ReorgWindow = 0
+	w, err = CreateWatcher(*bsc, nil, metrics.NewNoopMetrics(), zap.NewNop())
+	require.NoError(t, err)
+	assert.False(t, w.(*watcher.EVMWatcher).DetectsReorgs())
+}
@@ -6,13 +6,14 @@ import (
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
-	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/builder"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -37,18 +38,10 @@ func Run(config *config.BackfillerConfiguration) {
 	alerts := alert.NewDummyClient()
 
 	// create repositories
-	repo := storage.NewRepository(db.Database, metrics, alerts, logger)
+	lineageRecorder := lineage.NewRecorder(db.Database, lineage.StageContractWatcher, logger)
+	repo := storage.NewRepository(db.Database, metrics, alerts, lineageRecorder, logger)
 
-	var watcher watcher.ContractWatcher
-
-	switch config.Network {
-	case domain.P2pMainNet:
-		watcher = newWatcherForMainnet(config, repo, metrics, logger)
-	case domain.P2pTestNet:
-		watcher = newWatcherForTestnet(config, repo, metrics, logger)
-	default:
-		logger.Fatal("P2P network not supported")
-	}
+	watcher := newWatcher(config, repo, metrics, logger)
 
 	logger.Info("Processing backfill ...",
 		zap.String("network", config.Network),
@@ -66,74 +59,36 @@ func Run(config *config.BackfillerConfiguration) {
 
 }
 
-func newWatcherForMainnet(cfg *config.BackfillerConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) watcher.ContractWatcher {
-	var watcher watcher.ContractWatcher
-	switch cfg.ChainName {
-	case config.ETHEREUM_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ETHEREUM_MAINNET, repo, metrics, logger)
-	case config.POLYGON_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.POLYGON_MAINNET, repo, metrics, logger)
-	case config.BSC_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BSC_MAINNET, repo, metrics, logger)
-	case config.FANTOM_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.FANTOM_MAINNET, repo, metrics, logger)
-	case config.AVALANCHE_MAINNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.AVALANCHE_MAINNET, repo, metrics, logger)
-	case config.SOLANA_MAINNET.ChainID.String():
-		watcher = builder.CreateSolanaWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.SOLANA_MAINNET, logger, repo, metrics)
-	case config.TERRA_MAINNET.ChainID.String():
-		watcher = builder.CreateTerraWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.TERRA_MAINNET, logger, repo, metrics)
-	case config.APTOS_MAINNET.ChainID.String():
-		watcher = builder.CreateAptosWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.APTOS_MAINNET, logger, repo, metrics)
-	case config.OASIS_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OASIS_MAINNET, logger, repo, metrics)
-	case config.MOONBEAM_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.MOONBEAM_MAINNET, logger, repo, metrics)
-	case config.CELO_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.CELO_MAINNET, logger, repo, metrics)
-	case config.ARBITRUM_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ARBITRUM_MAINNET, logger, repo, metrics)
-	case config.OPTIMISM_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OPTIMISM_MAINNET, logger, repo, metrics)
-	case config.BASE_MAINNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_MAINNET, logger, repo, metrics)
-	default:
-		logger.Fatal("chain not supported")
+// newWatcher creates the watcher of the chain to backfill.
+//
+// The watcher definition is taken from the watchers configuration, using the chain url and rate limit of the backfiller.
+func newWatcher(cfg *config.BackfillerConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) watcher.ContractWatcher {
+	watchersConfig, err := config.LoadWatchers(cfg.WatchersConfigPath, cfg.Network)
+	if err != nil {
+		logger.Fatal("failed to load watchers configuration", zap.Error(err))
 	}
-	return watcher
-}
 
-func newWatcherForTestnet(cfg *config.BackfillerConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) watcher.ContractWatcher {
-	var watcher watcher.ContractWatcher
-	switch cfg.ChainName {
-	case config.ETHEREUM_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ETHEREUM_TESTNET, repo, metrics, logger)
-	case config.POLYGON_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.POLYGON_TESTNET, repo, metrics, logger)
-	case config.BSC_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BSC_TESTNET, repo, metrics, logger)
-	case config.FANTOM_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.FANTOM_TESTNET, repo, metrics, logger)
-	case config.AVALANCHE_TESTNET.ChainID.String():
-		watcher = builder.CreateAnkrEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.AVALANCHE_TESTNET, repo, metrics, logger)
-	case config.SOLANA_TESTNET.ChainID.String():
-		watcher = builder.CreateSolanaWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.SOLANA_TESTNET, logger, repo, metrics)
-	case config.APTOS_TESTNET.ChainID.String():
-		watcher = builder.CreateAptosWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.APTOS_TESTNET, logger, repo, metrics)
-	case config.OASIS_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OASIS_TESTNET, logger, repo, metrics)
-	case config.MOONBEAM_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.MOONBEAM_TESTNET, logger, repo, metrics)
-	case config.CELO_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.CELO_TESTNET, logger, repo, metrics)
-	case config.ARBITRUM_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.ARBITRUM_TESTNET, logger, repo, metrics)
-	case config.OPTIMISM_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.OPTIMISM_TESTNET, logger, repo, metrics)
-	case config.BASE_TESTNET.ChainID.String():
-		watcher = builder.CreateEvmWatcher(cfg.RateLimitPerSecond, cfg.ChainUrl, config.BASE_TESTNET, logger, repo, metrics)
-	default:
-		logger.Fatal("chain not supported")
+	chainID, err := vaa.ChainIDFromString(cfg.ChainName)
+	if err != nil {
+		logger.Fatal("chain not supported", zap.String("chain", cfg.ChainName))
+	}
+	spec, ok := watchersConfig.FindWatcher(chainID)
+	if !ok {
+		logger.Fatal("chain not supported", zap.String("chain", cfg.ChainName))
+	}
+
+	spec.URL = cfg.ChainUrl
+	spec.RequestsPerSecond = cfg.RateLimitPerSecond
+	if cfg.Provider != "" {
+		spec.Provider = cfg.Provider
+	}
+	if err := spec.Validate(); err != nil {
+		logger.Fatal("invalid watcher configuration", zap.Error(err))
+	}
+
+	watcher, err := builder.CreateWatcher(*spec, repo, metrics, logger)
+	if err != nil {
+		logger.Fatal("failed to create watcher", zap.Error(err))
 	}
 	return watcher
 }
@@ -39,7 +39,7 @@ func addServiceCommand(root *cobra.Command) {
 }
 
 func addBackfillerCommand(parent *cobra.Command) {
-	var network, mongoUri, mongoDb, chainName, chainURL, logLevel string
+	var network, mongoUri, mongoDb, chainName, chainURL, provider, watchersConfigPath, logLevel string
 	var fromBlock, toBlock, pageSize uint64
 	var rateLimit int
 	var persistBlock bool
@@ -54,6 +54,8 @@ func addBackfillerCommand(parent *cobra.Command) {
 				MongoDatabase:      mongoDb,
 				ChainName:          chainName,
 				ChainUrl:           chainURL,
+				Provider:           provider,
+				WatchersConfigPath: watchersConfigPath,
 				FromBlock:          fromBlock,
 				ToBlock:            toBlock,
 				RateLimitPerSecond: rateLimit,
@@ -70,6 +72,8 @@ func addBackfillerCommand(parent *cobra.Command) {
 	backfillerCommand.Flags().StringVar(&mongoDb, "mongo-database", "", "Mongo database")
 	backfillerCommand.Flags().StringVar(&chainName, "chain-name", "", "chain name")
 	backfillerCommand.Flags().StringVar(&chainURL, "chain-url", "", "chain URL")
+	backfillerCommand.Flags().StringVar(&provider, "provider", "", "rpc provider type (ankr, evm, evm-logs, solana, terra or aptos), defaults to the provider of the watchers configuration")
+	backfillerCommand.Flags().StringVar(&watchersConfigPath, "watchers-config", "", "watchers configuration file, defaults to the configuration of the network")
 	backfillerCommand.Flags().Uint64Var(&fromBlock, "from", 0, "first block to be processed")
 	backfillerCommand.Flags().Uint64Var(&toBlock, "to", 0, "last block to be processed (included)")
 	backfillerCommand.Flags().IntVar(&rateLimit, "rate-limit", 3, "rate limit per second")
@@ -10,20 +10,18 @@ import (
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
-	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/builder"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/http/infrastructure"
 	cwAlert "github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/alert"
-	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/ankr"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/processor"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/watcher"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.uber.org/ratelimit"
 	"go.uber.org/zap"
 )
 
@@ -38,39 +36,8 @@ func handleExit() {
 	}
 }
 
-type watchersConfig struct {
-	ankr      []config.WatcherBlockchainAddresses
-	aptos     *config.WatcherBlockchain
-	arbitrum  *config.WatcherBlockchainAddresses
-	avalanche *config.WatcherBlockchainAddresses
-	base      *config.WatcherBlockchainAddresses
-	ethereum  *config.WatcherBlockchainAddresses
-	celo      *config.WatcherBlockchainAddresses
-	moonbeam  *config.WatcherBlockchainAddresses
-	oasis     *config.WatcherBlockchainAddresses
-	optimism  *config.WatcherBlockchainAddresses
-	polygon   *config.WatcherBlockchainAddresses
-	solana    *config.WatcherBlockchain
-	terra     *config.WatcherBlockchain
-	rateLimit rateLimitConfig
-}
-
-type rateLimitConfig struct {
-	ankr      int
-	aptos     int
-	arbitrum  int
-	avalanche int
-	base      int
-	celo      int
-	ethereum  int
-	moonbeam  int
-	oasis     int
-	optimism  int
-	polygon   int
-	solana    int
-	terra     int
-}
-
+// Run starts the contract watcher with the configuration from the environment and blocks until a
+// termination signal is received.
 func Run() {
 
 	defer handleExit()
@@ -83,6 +50,31 @@ func Run() {
 
 	logger := logger.New("wormhole-explorer-contract-watcher", logger.WithLevel(config.LogLevel))
 
+	stop := Start(rootCtx, config, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("Finished wormhole-explorer-contract-watcher")
+}
+
+// Start starts the watchers and the http server of the contract watcher with the given
+// configuration. The watchers run until rootCtx is cancelled and the returned function releases
+// the remaining resources.
+func Start(rootCtx context.Context, config *config.ServiceConfiguration, logger *zap.Logger) (stop func()) {
+
 	logger.Info("Starting wormhole-explorer-contract-watcher ...")
 
 	//setup DB connection
@@ -104,7 +96,8 @@ func Run() {
 	alerts := newAlertClient(config, logger)
 
 	// create repositories
-	repo := storage.NewRepository(db.Database, metrics, alerts, logger)
+	lineageRecorder := lineage.NewRecorder(db.Database, lineage.StageContractWatcher, logger)
+	repo := storage.NewRepository(db.Database, metrics, alerts, lineageRecorder, logger)
 
 	// create watchers
 	watchers := newWatchers(config, repo, metrics, logger)
@@ -119,202 +112,35 @@ func Run() {
 
 	logger.Info("Started wormhole-explorer-contract-watcher")
 
-	// Waiting for signal
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("Terminating with root context cancelled.")
-	case signal := <-sigterm:
-		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
-	}
-
-	logger.Info("root context cancelled, exiting...")
-	rootCtxCancel()
-
-	logger.Info("Closing processor ...")
-	processor.Close()
-
-	logger.Info("closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
+	return func() {
+		logger.Info("Closing processor ...")
+		processor.Close()
 
-	logger.Info("Closing Http server ...")
-	server.Stop()
+		logger.Info("closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
 
-	logger.Info("Finished wormhole-explorer-contract-watcher")
+		logger.Info("Closing Http server ...")
+		server.Stop()
+	}
 }
 
 func newHealthChecks(ctx context.Context, db *mongo.Database) ([]health.Check, error) {
 	return []health.Check{health.Mongo(db)}, nil
 }
 
-func newWatchers(config *config.ServiceConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) []watcher.ContractWatcher {
-	var watchers *watchersConfig
-	switch config.P2pNetwork {
-	case domain.P2pMainNet:
-		watchers = newWatchersForMainnet(config)
-	case domain.P2pTestNet:
-		watchers = newWatchersForTestnet(config)
-	default:
-		watchers = &watchersConfig{}
-	}
-
-	result := make([]watcher.ContractWatcher, 0)
-
-	// add evm watchers
-	evmLimiter := ratelimit.New(watchers.rateLimit.ankr, ratelimit.Per(time.Second))
-	ankrClient := ankr.NewAnkrSDK(config.AnkrUrl, evmLimiter, metrics)
-	for _, w := range watchers.ankr {
-		params := watcher.EVMParams{ChainID: w.ChainID, Blockchain: w.Name, SizeBlocks: w.SizeBlocks,
-			WaitSeconds: w.WaitSeconds, InitialBlock: w.InitialBlock, MethodsByAddress: w.MethodsByAddress}
-		result = append(result, watcher.NewEVMWatcher(ankrClient, repo, params, metrics, logger))
-	}
-
-	// add ethereum watcher
-	if watchers.ethereum != nil {
-		ethereumWatcher := builder.CreateEvmWatcher(watchers.rateLimit.ethereum, config.EthereumUrl, *watchers.ethereum, logger, repo, metrics)
-		result = append(result, ethereumWatcher)
-	}
-
-	// add solana watcher
-	if watchers.solana != nil {
-		solanaWatcher := builder.CreateSolanaWatcher(watchers.rateLimit.solana, config.SolanaUrl, *watchers.solana, logger, repo, metrics)
-		result = append(result, solanaWatcher)
-	}
-
-	// add avalanche watcher
-	if watchers.avalanche != nil {
-		avalancheWatcher := builder.CreateEvmWatcher(watchers.rateLimit.avalanche, config.AvalancheUrl, *watchers.avalanche, logger, repo, metrics)
-		result = append(result, avalancheWatcher)
-	}
-
-	// add terra watcher
-	if watchers.terra != nil {
-		terraWatcher := builder.CreateTerraWatcher(watchers.rateLimit.terra, config.TerraUrl, *watchers.terra, logger, repo, metrics)
-		result = append(result, terraWatcher)
-	}
-
-	// add aptos watcher
-	if watchers.aptos != nil {
-		aptosWatcher := builder.CreateAptosWatcher(watchers.rateLimit.aptos, config.AptosUrl, *watchers.aptos, logger, repo, metrics)
-		result = append(result, aptosWatcher)
-	}
-
-	// add oasis watcher
-	if watchers.oasis != nil {
-		oasisWatcher := builder.CreateEvmWatcher(watchers.rateLimit.oasis, config.OasisUrl, *watchers.oasis, logger, repo, metrics)
-		result = append(result, oasisWatcher)
-	}
-
-	// add moonbeam watcher
-	if watchers.moonbeam != nil {
-		moonbeamWatcher := builder.CreateEvmWatcher(watchers.rateLimit.moonbeam, config.MoonbeamUrl, *watchers.moonbeam, logger, repo, metrics)
-		result = append(result, moonbeamWatcher)
-	}
-
-	// add celo watcher
-	if watchers.celo != nil {
-		celoWatcher := builder.CreateEvmWatcher(watchers.rateLimit.celo, config.CeloUrl, *watchers.celo, logger, repo, metrics)
-		result = append(result, celoWatcher)
-	}
-
-	// add optimism watcher
-	if watchers.optimism != nil {
-		optimismWatcher := builder.CreateEvmWatcher(watchers.rateLimit.optimism, config.OptimismUrl, *watchers.optimism, logger, repo, metrics)
-		result = append(result, optimismWatcher)
-	}
-
-	// add arbitrum watcher
-	if watchers.arbitrum != nil {
-		arbitrumWatcher := builder.CreateEvmWatcher(watchers.rateLimit.arbitrum, config.ArbitrumUrl, *watchers.arbitrum, logger, repo, metrics)
-		result = append(result, arbitrumWatcher)
-	}
-
-	// add base watcher
-	if watchers.base != nil {
-		baseWatcher := builder.CreateEvmWatcher(watchers.rateLimit.base, config.BaseUrl, *watchers.base, logger, repo, metrics)
-		result = append(result, baseWatcher)
-	}
-
-	// add polygon watcher
-	if watchers.polygon != nil {
-		polygonWatcher := builder.CreateEvmWatcher(watchers.rateLimit.polygon, config.PolygonUrl, *watchers.polygon, logger, repo, metrics)
-		result = append(result, polygonWatcher)
+func newWatchers(cfg *config.ServiceConfiguration, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) []watcher.ContractWatcher {
+	watchersConfig, err := config.LoadWatchers(cfg.WatchersConfigPath, cfg.P2pNetwork)
+	if err != nil {
+		logger.Fatal("failed to load watchers configuration", zap.Error(err))
 	}
-
-	return result
-}
-
-func newWatchersForMainnet(cfg *config.ServiceConfiguration) *watchersConfig {
-	return &watchersConfig{
-		ankr: []config.WatcherBlockchainAddresses{
-			config.BSC_MAINNET,
-			config.FANTOM_MAINNET,
-		},
-		aptos:     &config.APTOS_MAINNET,
-		arbitrum:  &config.ARBITRUM_MAINNET,
-		avalanche: &config.AVALANCHE_MAINNET,
-		base:      &config.BASE_MAINNET,
-		celo:      &config.CELO_MAINNET,
-		ethereum:  &config.ETHEREUM_MAINNET,
-		moonbeam:  &config.MOONBEAM_MAINNET,
-		oasis:     &config.OASIS_MAINNET,
-		optimism:  &config.OPTIMISM_MAINNET,
-		polygon:   &config.POLYGON_MAINNET,
-		solana:    &config.SOLANA_MAINNET,
-		terra:     &config.TERRA_MAINNET,
-
-		rateLimit: rateLimitConfig{
-			ankr:      cfg.AnkrRequestsPerSecond,
-			avalanche: cfg.AvalancheRequestsPerSecond,
-			aptos:     cfg.AptosRequestsPerSecond,
-			arbitrum:  cfg.ArbitrumRequestsPerSecond,
-			base:      cfg.BaseRequestsPerSecond,
-			celo:      cfg.CeloRequestsPerSecond,
-			ethereum:  cfg.EthereumRequestsPerSecond,
-			moonbeam:  cfg.MoonbeamRequestsPerSecond,
-			oasis:     cfg.OasisRequestsPerSecond,
-			optimism:  cfg.OptimismRequestsPerSecond,
-			polygon:   cfg.PolygonRequestsPerSecond,
-			solana:    cfg.SolanaRequestsPerSecond,
-			terra:     cfg.TerraRequestsPerSecond,
-		},
+	if err := watchersConfig.Validate(); err != nil {
+		logger.Fatal("invalid watchers configuration", zap.Error(err))
 	}
-}
-
-func newWatchersForTestnet(cfg *config.ServiceConfiguration) *watchersConfig {
-	return &watchersConfig{
-		ankr: []config.WatcherBlockchainAddresses{
-			config.BSC_TESTNET,
-			config.FANTOM_TESTNET,
-		},
-		aptos:     &config.APTOS_TESTNET,
-		arbitrum:  &config.ARBITRUM_TESTNET,
-		avalanche: &config.AVALANCHE_TESTNET,
-		celo:      &config.CELO_TESTNET,
-		base:      &config.BASE_TESTNET,
-		ethereum:  &config.ETHEREUM_TESTNET,
-		moonbeam:  &config.MOONBEAM_TESTNET,
-		oasis:     &config.OASIS_TESTNET,
-		optimism:  &config.OPTIMISM_TESTNET,
-		polygon:   &config.POLYGON_TESTNET,
-		solana:    &config.SOLANA_TESTNET,
-		rateLimit: rateLimitConfig{
-			ankr:      cfg.AnkrRequestsPerSecond,
-			avalanche: cfg.AvalancheRequestsPerSecond,
-			aptos:     cfg.AptosRequestsPerSecond,
-			arbitrum:  cfg.ArbitrumRequestsPerSecond,
-			base:      cfg.BaseRequestsPerSecond,
-			celo:      cfg.CeloRequestsPerSecond,
-			ethereum:  cfg.EthereumRequestsPerSecond,
-			moonbeam:  cfg.MoonbeamRequestsPerSecond,
-			oasis:     cfg.OasisRequestsPerSecond,
-			optimism:  cfg.OptimismRequestsPerSecond,
-			polygon:   cfg.PolygonRequestsPerSecond,
-			solana:    cfg.SolanaRequestsPerSecond,
-			terra:     cfg.TerraRequestsPerSecond,
-		},
+	watchers, err := builder.CreateWatchers(watchersConfig, repo, metrics, logger)
+	if err != nil {
+		logger.Fatal("failed to create watchers", zap.Error(err))
 	}
+	return watchers
 }
 
 func newAlertClient(config *config.ServiceConfiguration, logger *zap.Logger) alert.AlertClient {
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+//go:embed abis/*.json
+var defaultABIs embed.FS
+
+// LoadABI loads a contract ABI.
+//
+// The name references one of the ABIs shipped with the watcher (e.g. token_bridge), otherwise it is read as a JSON file.
+func LoadABI(name string) (*abi.ABI, error) {
+	data, err := defaultABIs.ReadFile(path.Join("abis", name+".json"))
+	if err != nil {
+		data, err = os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read abi %s: %w", name, err)
+		}
+	}
+	contractABI, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse abi %s: %w", name, err)
+	}
+	return &contractABI, nil
+}
+
+// ResolveVaaArgument returns the path of the input that contains the VAA in the method call.
+//
+// The path is the input name, or the input name followed by the tuple component names separated by dots
+// (e.g. params.encodedWormholeMessage). When argument is empty, the single bytes input of the method is used.
+// The referenced value must be of type bytes or bytes[].
+func ResolveVaaArgument(method *abi.Method, argument string) ([]string, error) {
+	if argument == "" {
+		var candidates []string
+		for _, input := range method.Inputs {
+			if input.Type.T == abi.BytesTy {
+				candidates = append(candidates, input.Name)
+			}
+		}
+		if len(candidates) != 1 {
+			return nil, fmt.Errorf("vaaArgument is required, method %s has %d bytes inputs", method.Name, len(candidates))
+		}
+		argument = candidates[0]
+	}
+
+	fields := strings.Split(argument, ".")
+	var argType *abi.Type
+	for _, input := range method.Inputs {
+		if input.Name == fields[0] {
+			t := input.Type
+			argType = &t
+			break
+		}
+	}
+	if argType == nil {
+		return nil, fmt.Errorf("method %s has no input %s", method.Name, fields[0])
+	}
+
+	for _, field := range fields[1:] {
+		if argType.T != abi.TupleTy {
+			return nil, fmt.Errorf("input %s of method %s is not a tuple", argument, method.Name)
+		}
+		var next *abi.Type
+		for i, name := range argType.TupleRawNames {
+			if name == field {
+				next = argType.TupleElems[i]
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("method %s has no input %s", method.Name, argument)
+		}
+		argType = next
+	}
+
+	isBytes := argType.T == abi.BytesTy
+	isBytesSlice := argType.T == abi.SliceTy && argType.Elem.T == abi.BytesTy
+	if !isBytes && !isBytesSlice {
+		return nil, fmt.Errorf("input %s of method %s must be bytes or bytes[], found %s", argument, method.Name, argType.String())
+	}
+	return fields, nil
+}
+
+// newBlockchainEvent checks the arguments of an event definition and resolves its defaults.
+func newBlockchainEvent(event *abi.Event, spec EventSpec) (BlockchainEvent, error) {
+	result := BlockchainEvent{
+		ID:               event.ID.Hex(),
+		Name:             spec.Name,
+		ABI:              event,
+		ChainArgument:    spec.ChainArgument,
+		EmitterArgument:  spec.EmitterArgument,
+		SequenceArgument: spec.SequenceArgument,
+	}
+	if result.Name == "" {
+		result.Name = event.Name
+	}
+	if result.ChainArgument == "" {
+		result.ChainArgument = "emitterChainId"
+	}
+	if result.SequenceArgument == "" {
+		result.SequenceArgument = "sequence"
+	}
+	if spec.EmitterAddress != "" {
+		if spec.EmitterArgument != "" {
+			return BlockchainEvent{}, errors.New("emitterArgument and emitterAddress are mutually exclusive")
+		}
+		address, err := vaa.StringToAddress(spec.EmitterAddress)
+		if err != nil {
+			return BlockchainEvent{}, fmt.Errorf("invalid emitterAddress %s: %w", spec.EmitterAddress, err)
+		}
+		result.EmitterAddress = &address
+	} else if result.EmitterArgument == "" {
+		result.EmitterArgument = "emitterAddress"
+	}
+
+	if err := checkEventArgument(event, result.ChainArgument, abi.UintTy); err != nil {
+		return BlockchainEvent{}, err
+	}
+	if err := checkEventArgument(event, result.SequenceArgument, abi.UintTy); err != nil {
+		return BlockchainEvent{}, err
+	}
+	if result.EmitterAddress == nil {
+		if err := checkEventArgument(event, result.EmitterArgument, abi.FixedBytesTy, abi.AddressTy); err != nil {
+			return BlockchainEvent{}, err
+		}
+	}
+	return result, nil
+}
+
+// checkEventArgument checks that the event has an argument with the name and one of the types.
+//
+// Unsigned integers must fit in 64 bits and fixed bytes must be bytes32.
+func checkEventArgument(event *abi.Event, name string, types ...byte) error {
+	for _, input := range event.Inputs {
+		if input.Name != name {
+			continue
+		}
+		for _, t := range types {
+			if input.Type.T != t {
+				continue
+			}
+			if t == abi.UintTy && input.Type.Size > 64 {
+				break
+			}
+			if t == abi.FixedBytesTy && input.Type.Size != 32 {
+				break
+			}
+			return nil
+		}
+		return fmt.Errorf("argument %s of event %s has unsupported type %s", name, event.Name, input.Type.String())
+	}
+	return fmt.Errorf("event %s has no argument %s", event.Name, name)
+}
@@ -19,32 +19,9 @@ type ServiceConfiguration struct {
 	AlertEnabled  bool   `env:"ALERT_ENABLED,required"`
 	AlertApiKey   string `env:"ALERT_API_KEY"`
 
-	AnkrUrl                    string `env:"ANKR_URL,required"`
-	AnkrRequestsPerSecond      int    `env:"ANKR_REQUESTS_PER_SECOND,required"`
-	AptosUrl                   string `env:"APTOS_URL,required"`
-	AptosRequestsPerSecond     int    `env:"APTOS_REQUESTS_PER_SECOND,required"`
-	ArbitrumUrl                string `env:"ARBITRUM_URL,required"`
-	ArbitrumRequestsPerSecond  int    `env:"ARBITRUM_REQUESTS_PER_SECOND,required"`
-	AvalancheUrl               string `env:"AVALANCHE_URL,required"`
-	AvalancheRequestsPerSecond int    `env:"AVALANCHE_REQUESTS_PER_SECOND,required"`
-	BaseUrl                    string `env:"BASE_URL,required"`
-	BaseRequestsPerSecond      int    `env:"BASE_REQUESTS_PER_SECOND,required"`
-	CeloUrl                    string `env:"CELO_URL,required"`
-	CeloRequestsPerSecond      int    `env:"CELO_REQUESTS_PER_SECOND,required"`
-	EthereumUrl                string `env:"ETHEREUM_URL,required"`
-	EthereumRequestsPerSecond  int    `env:"ETHEREUM_REQUESTS_PER_SECOND,required"`
-	MoonbeamUrl                string `env:"MOONBEAM_URL,required"`
-	MoonbeamRequestsPerSecond  int    `env:"MOONBEAM_REQUESTS_PER_SECOND,required"`
-	OptimismUrl                string `env:"OPTIMISM_URL,required"`
-	OptimismRequestsPerSecond  int    `env:"OPTIMISM_REQUESTS_PER_SECOND,required"`
-	OasisUrl                   string `env:"OASIS_URL,required"`
-	OasisRequestsPerSecond     int    `env:"OASIS_REQUESTS_PER_SECOND,required"`
-	PolygonUrl                 string `env:"POLYGON_URL,required"`
-	PolygonRequestsPerSecond   int    `env:"POLYGON_REQUESTS_PER_SECOND,required"`
-	SolanaUrl                  string `env:"SOLANA_URL,required"`
-	SolanaRequestsPerSecond    int    `env:"SOLANA_REQUESTS_PER_SECOND,required"`
-	TerraUrl                   string `env:"TERRA_URL,required"`
-	TerraRequestsPerSecond     int    `env:"TERRA_REQUESTS_PER_SECOND,required"`
+	// WatchersConfigPath is the path of the watchers configuration file.
+	// When it is empty the default configuration of the p2p network is used.
+	WatchersConfigPath string `env:"WATCHERS_CONFIG_PATH"`
 }
 
 // BackfillerConfiguration represents the application configuration when running as backfiller.
@@ -54,6 +31,8 @@ type BackfillerConfiguration struct {
 	MongoDatabase      string `env:"MONGODB_DATABASE,required"`
 	ChainName          string `env:"CHAIN_NAME,required"`
 	ChainUrl           string `env:"CHAIN_URL,required"`
+	Provider           string `env:"PROVIDER"`
+	WatchersConfigPath string `env:"WATCHERS_CONFIG_PATH"`
 	FromBlock          uint64 `env:"FROM_BLOCK,required"`
 	ToBlock            uint64 `env:"TO_BLOCK,required"`
 	Network            string `env:"NETWORK,required"`
@@ -1,6 +1,9 @@
 package config
 
-import "github.com/wormhole-foundation/wormhole/sdk/vaa"
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
 
 const (
 	//Method names for wormhole token bridge contract.
@@ -18,6 +21,12 @@ const (
 	//Method name for wormhole tBTC gateway
 	MethodReceiveTbtc = "receiveTbtc"
 
+	//Method name for wormhole relayer contract.
+	MethodDeliver = "deliver"
+
+	//Method name for wormhole circle integration contract.
+	MethodRedeemTokensWithPayload = "redeemTokensWithPayload"
+
 	//Method ids for wormhole token bridge contract
 	MethodIDCompleteTransfer     = "0xc6878519"
 	MethodIDWrapAndTransfer      = "0x9981509f"
@@ -31,6 +40,12 @@ const (
 
 	//Method id for wormhole tBTC gateway
 	MethodIDReceiveTbtc = "0x5d21a596"
+
+	//Method id for wormhole relayer contract.
+	MethodIDDeliver = "0xa60eb4c8"
+
+	//Method id for wormhole circle integration contract.
+	MethodIDRedeemTokensWithPayload = "0x57bf927b"
 )
 
 type WatcherBlockchain struct {
@@ -40,6 +55,10 @@ type WatcherBlockchain struct {
 	SizeBlocks   uint8
 	WaitSeconds  uint16
 	InitialBlock int64
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block.
+	ConfirmationDepth uint64
+	// MethodsByAddress are the watched methods of the sui, algorand, near and cosmwasm watchers.
+	MethodsByAddress map[string][]BlockchainMethod
 }
 
 type WatcherBlockchainAddresses struct {
@@ -50,9 +69,33 @@ type WatcherBlockchainAddresses struct {
 	// Initial block indicates for the supported contracts, the oldest block from which to start processing.
 	InitialBlock     int64
 	MethodsByAddress map[string][]BlockchainMethod
+	EventsByAddress  map[string][]BlockchainEvent
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block.
+	ConfirmationDepth uint64
+	// ReorgWindow is the number of block hashes remembered to detect reorgs, zero disables the detection.
+	ReorgWindow uint64
 }
 
 type BlockchainMethod struct {
 	ID   string
 	Name string
+	// ABI is the method definition used to decode the call input. When it is nil the VAA is
+	// expected to be the first dynamic argument of the call.
+	ABI *abi.Method
+	// VaaArgument is the path of the input that contains the VAA.
+	VaaArgument []string
+}
+
+// BlockchainEvent is an event emitted when a VAA is redeemed.
+type BlockchainEvent struct {
+	// ID is the event topic.
+	ID   string
+	Name string
+	ABI  *abi.Event
+	// ChainArgument, EmitterArgument and SequenceArgument are the event arguments that identify the VAA.
+	ChainArgument    string
+	EmitterArgument  string
+	SequenceArgument string
+	// EmitterAddress is the emitter of the VAA when the event does not include it.
+	EmitterAddress *vaa.Address
 }
@@ -0,0 +1,470 @@
+package config
+
+import (
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"golang.org/x/crypto/sha3"
+	"gopkg.in/yaml.v3"
+)
+
+// RPC provider types supported by the watchers.
+const (
+	ProviderAnkr = "ankr"
+	ProviderEvm  = "evm"
+	// ProviderEvmLogs detects the redeems from the events emitted by the contracts using eth_getLogs.
+	ProviderEvmLogs = "evm-logs"
+	ProviderSolana  = "solana"
+	ProviderTerra   = "terra"
+	ProviderAptos   = "aptos"
+	// ProviderSui processes the checkpoints of the Sui full node JSON-RPC API.
+	ProviderSui = "sui"
+	// ProviderAlgorand searches the application calls with the Algorand indexer API.
+	ProviderAlgorand = "algorand"
+	// ProviderNear processes the blocks of the NEAR JSON-RPC API.
+	ProviderNear = "near"
+	// ProviderCosmwasm searches the contract executions with the Cosmos-SDK LCD API (Injective, Xpla, Sei, Wormchain).
+	ProviderCosmwasm = "cosmwasm"
+)
+
+//go:embed watchers/*.yaml
+var defaultWatchers embed.FS
+
+var (
+	evmAddressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	methodIDRegex   = regexp.MustCompile(`^0x[0-9a-fA-F]{8}$`)
+)
+
+// WatchersConfiguration is the declarative definition of the watched chains.
+type WatchersConfiguration struct {
+	Watchers []WatcherSpec `yaml:"watchers" json:"watchers"`
+}
+
+// WatcherSpec defines a watcher for a chain.
+type WatcherSpec struct {
+	// Chain is the wormhole chain name, e.g. ethereum.
+	Chain string `yaml:"chain" json:"chain"`
+	// Name identifies the watcher, it is used to store the last processed block.
+	Name              string `yaml:"name" json:"name"`
+	Provider          string `yaml:"provider" json:"provider"`
+	URL               string `yaml:"url" json:"url"`
+	RequestsPerSecond int    `yaml:"requestsPerSecond" json:"requestsPerSecond"`
+	SizeBlocks        uint8  `yaml:"sizeBlocks" json:"sizeBlocks"`
+	WaitSeconds       uint16 `yaml:"waitSeconds" json:"waitSeconds"`
+	InitialBlock      int64  `yaml:"initialBlock" json:"initialBlock"`
+	// Address is the contract address for non-EVM chains.
+	Address string `yaml:"address,omitempty" json:"address,omitempty"`
+	// Contracts are the watched contracts for EVM chains.
+	Contracts []ContractSpec `yaml:"contracts,omitempty" json:"contracts,omitempty"`
+	// TraceInternalCalls enables the detection of redeems made through other contracts (e.g. multicall, proxies
+	// or aggregators) using the call traces of the blocks. The rpc node must support debug_traceBlockByNumber.
+	TraceInternalCalls bool `yaml:"traceInternalCalls,omitempty" json:"traceInternalCalls,omitempty"`
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block, so only the blocks
+	// that are unlikely to be reorganized are processed.
+	ConfirmationDepth uint64 `yaml:"confirmationDepth,omitempty" json:"confirmationDepth,omitempty"`
+	// ReorgWindow is the number of processed block hashes remembered to detect chain reorganizations.
+	// Zero disables the detection. Only supported by the evm, evm-logs and ankr providers, the other providers
+	// watch chains with deterministic finality (see the README).
+	ReorgWindow uint64 `yaml:"reorgWindow,omitempty" json:"reorgWindow,omitempty"`
+}
+
+// ContractSpec defines a watched EVM contract.
+//
+// When ABI is set the calls are decoded with the contract ABI, which is the name of one of the ABIs shipped with the
+// watcher (token_bridge, token_bridge_relayer, tbtc_gateway, wormhole_relayer, circle_integration, ntt_transceiver)
+// or the path of a JSON ABI file.
+//
+// Methods are used by the ankr and evm providers, events by the evm-logs provider.
+//
+// The sui, algorand, near and cosmwasm providers also use methods, where the name is the called function:
+// module::function for Sui packages, the first application argument for Algorand applications, the method name
+// for NEAR contracts and the execute message for CosmWasm contracts. VaaArgument is the field of the NEAR or
+// CosmWasm message that contains the VAA.
+type ContractSpec struct {
+	Address string       `yaml:"address" json:"address"`
+	ABI     string       `yaml:"abi,omitempty" json:"abi,omitempty"`
+	Methods []MethodSpec `yaml:"methods,omitempty" json:"methods,omitempty"`
+	Events  []EventSpec  `yaml:"events,omitempty" json:"events,omitempty"`
+}
+
+// MethodSpec defines a redeem method of an EVM contract.
+//
+// The method selector is taken from ID or computed from Signature. When both are set they must match.
+// VaaArgument is the input of the method that contains the VAA, see ResolveVaaArgument.
+type MethodSpec struct {
+	Name        string `yaml:"name" json:"name"`
+	ID          string `yaml:"id,omitempty" json:"id,omitempty"`
+	Signature   string `yaml:"signature,omitempty" json:"signature,omitempty"`
+	VaaArgument string `yaml:"vaaArgument,omitempty" json:"vaaArgument,omitempty"`
+}
+
+// EventSpec defines an event emitted by an EVM contract when a VAA is redeemed.
+//
+// Event is the name of the event in the contract ABI. The VAA ID is built from the event arguments named in
+// ChainArgument, EmitterArgument and SequenceArgument (emitterChainId, emitterAddress and sequence by default).
+// EmitterAddress sets a fixed emitter for events that do not include it, e.g. the wormhole relayer deliveries.
+// Name is stored as the destination method and defaults to the event name.
+type EventSpec struct {
+	Name             string `yaml:"name,omitempty" json:"name,omitempty"`
+	Event            string `yaml:"event" json:"event"`
+	ChainArgument    string `yaml:"chainArgument,omitempty" json:"chainArgument,omitempty"`
+	EmitterArgument  string `yaml:"emitterArgument,omitempty" json:"emitterArgument,omitempty"`
+	SequenceArgument string `yaml:"sequenceArgument,omitempty" json:"sequenceArgument,omitempty"`
+	EmitterAddress   string `yaml:"emitterAddress,omitempty" json:"emitterAddress,omitempty"`
+}
+
+// LoadWatchers loads the watchers configuration. The configuration must be validated with Validate before use.
+//
+// When filePath is empty the default configuration of the p2p network is used. Values with the ${VAR} syntax
+// are expanded from the environment variables. JSON files are also accepted since JSON is valid YAML.
+func LoadWatchers(filePath string, p2pNetwork string) (*WatchersConfiguration, error) {
+	var data []byte
+	var err error
+	if filePath != "" {
+		data, err = os.ReadFile(filePath)
+	} else {
+		data, err = defaultWatchers.ReadFile(path.Join("watchers", p2pNetwork+".yaml"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watchers configuration: %w", err)
+	}
+	return ParseWatchers(data)
+}
+
+// ParseWatchers parses a watchers configuration.
+func ParseWatchers(data []byte) (*WatchersConfiguration, error) {
+	var cfg WatchersConfiguration
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse watchers configuration: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks the watchers configuration.
+func (c *WatchersConfiguration) Validate() error {
+	if len(c.Watchers) == 0 {
+		return errors.New("watchers configuration has no watchers")
+	}
+	names := make(map[string]bool, len(c.Watchers))
+	var errs []string
+	for i := range c.Watchers {
+		w := &c.Watchers[i]
+		if names[w.Name] {
+			errs = append(errs, fmt.Sprintf("watcher %s: duplicated name", w.Name))
+		}
+		names[w.Name] = true
+		if err := w.Validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid watchers configuration: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Validate checks a watcher definition.
+func (w *WatcherSpec) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("watcher for chain %s: name is required", w.Chain)
+	}
+	if _, err := w.ChainID(); err != nil {
+		return fmt.Errorf("watcher %s: %w", w.Name, err)
+	}
+	if w.URL == "" {
+		return fmt.Errorf("watcher %s: url is required", w.Name)
+	}
+	if w.RequestsPerSecond <= 0 {
+		return fmt.Errorf("watcher %s: requestsPerSecond must be greater than zero", w.Name)
+	}
+	if w.WaitSeconds == 0 {
+		return fmt.Errorf("watcher %s: waitSeconds must be greater than zero", w.Name)
+	}
+	if w.ReorgWindow > 0 && w.Provider != ProviderEvm && w.Provider != ProviderEvmLogs && w.Provider != ProviderAnkr {
+		return fmt.Errorf("watcher %s: reorgWindow is only supported by the %s, %s and %s providers", w.Name, ProviderEvm, ProviderEvmLogs, ProviderAnkr)
+	}
+	if w.ConfirmationDepth > 0 && w.Provider == ProviderSolana {
+		return fmt.Errorf("watcher %s: confirmationDepth is not supported by the %s provider", w.Name, ProviderSolana)
+	}
+
+	switch w.Provider {
+	case ProviderEvmLogs:
+		if len(w.Contracts) == 0 {
+			return fmt.Errorf("watcher %s: at least one contract is required", w.Name)
+		}
+		if w.SizeBlocks == 0 {
+			return fmt.Errorf("watcher %s: sizeBlocks must be greater than zero", w.Name)
+		}
+		if w.TraceInternalCalls {
+			return fmt.Errorf("watcher %s: traceInternalCalls is only supported by the %s provider", w.Name, ProviderEvm)
+		}
+		for _, c := range w.Contracts {
+			if !evmAddressRegex.MatchString(c.Address) {
+				return fmt.Errorf("watcher %s: invalid contract address %s", w.Name, c.Address)
+			}
+			if len(c.Events) == 0 {
+				return fmt.Errorf("watcher %s: contract %s has no events", w.Name, c.Address)
+			}
+			if _, err := c.blockchainEvents(); err != nil {
+				return fmt.Errorf("watcher %s: %w", w.Name, err)
+			}
+		}
+	case ProviderAnkr, ProviderEvm:
+		if len(w.Contracts) == 0 {
+			return fmt.Errorf("watcher %s: at least one contract is required", w.Name)
+		}
+		if w.SizeBlocks == 0 {
+			return fmt.Errorf("watcher %s: sizeBlocks must be greater than zero", w.Name)
+		}
+		if w.TraceInternalCalls && w.Provider != ProviderEvm {
+			return fmt.Errorf("watcher %s: traceInternalCalls is only supported by the %s provider", w.Name, ProviderEvm)
+		}
+		for _, c := range w.Contracts {
+			if !evmAddressRegex.MatchString(c.Address) {
+				return fmt.Errorf("watcher %s: invalid contract address %s", w.Name, c.Address)
+			}
+			if len(c.Methods) == 0 {
+				return fmt.Errorf("watcher %s: contract %s has no methods", w.Name, c.Address)
+			}
+			for _, m := range c.Methods {
+				if m.Name == "" {
+					return fmt.Errorf("watcher %s: contract %s has a method without name", w.Name, c.Address)
+				}
+			}
+			if _, err := c.blockchainMethods(); err != nil {
+				return fmt.Errorf("watcher %s: %w", w.Name, err)
+			}
+		}
+	case ProviderSui, ProviderAlgorand, ProviderNear, ProviderCosmwasm:
+		if len(w.Contracts) == 0 {
+			return fmt.Errorf("watcher %s: at least one contract is required", w.Name)
+		}
+		if w.SizeBlocks == 0 {
+			return fmt.Errorf("watcher %s: sizeBlocks must be greater than zero", w.Name)
+		}
+		if w.TraceInternalCalls {
+			return fmt.Errorf("watcher %s: traceInternalCalls is only supported by the %s provider", w.Name, ProviderEvm)
+		}
+		for _, c := range w.Contracts {
+			if c.Address == "" {
+				return fmt.Errorf("watcher %s: contract address is required", w.Name)
+			}
+			if _, err := strconv.ParseUint(c.Address, 10, 64); w.Provider == ProviderAlgorand && err != nil {
+				return fmt.Errorf("watcher %s: invalid application id %s", w.Name, c.Address)
+			}
+			if len(c.Methods) == 0 {
+				return fmt.Errorf("watcher %s: contract %s has no methods", w.Name, c.Address)
+			}
+			for _, m := range c.Methods {
+				if m.Name == "" {
+					return fmt.Errorf("watcher %s: contract %s has a method without name", w.Name, c.Address)
+				}
+				if w.Provider == ProviderSui && len(strings.Split(m.Name, "::")) != 2 {
+					return fmt.Errorf("watcher %s: method %s of package %s must be module::function", w.Name, m.Name, c.Address)
+				}
+			}
+		}
+	case ProviderSolana, ProviderTerra, ProviderAptos:
+		if w.Address == "" {
+			return fmt.Errorf("watcher %s: address is required", w.Name)
+		}
+	default:
+		return fmt.Errorf("watcher %s: unknown provider %s", w.Name, w.Provider)
+	}
+	return nil
+}
+
+// ChainID returns the wormhole chain ID of the watcher.
+func (w *WatcherSpec) ChainID() (vaa.ChainID, error) {
+	chainID, err := vaa.ChainIDFromString(w.Chain)
+	if err != nil || chainID == vaa.ChainIDUnset {
+		return vaa.ChainIDUnset, fmt.Errorf("unknown chain %s", w.Chain)
+	}
+	return chainID, nil
+}
+
+// IsEvm returns true when the watcher uses an EVM provider.
+func (w *WatcherSpec) IsEvm() bool {
+	return w.Provider == ProviderAnkr || w.Provider == ProviderEvm || w.Provider == ProviderEvmLogs
+}
+
+// ToWatcherBlockchain converts the definition of a non-EVM watcher.
+func (w *WatcherSpec) ToWatcherBlockchain() WatcherBlockchain {
+	chainID, _ := w.ChainID()
+	return WatcherBlockchain{
+		ChainID:           chainID,
+		Name:              w.Name,
+		Address:           w.Address,
+		SizeBlocks:        w.SizeBlocks,
+		WaitSeconds:       w.WaitSeconds,
+		InitialBlock:      w.InitialBlock,
+		ConfirmationDepth: w.ConfirmationDepth,
+		MethodsByAddress:  w.contractMethods(),
+	}
+}
+
+// contractMethods returns the watched methods of a non-EVM watcher by contract address.
+// The ID of the methods is the name of the called function.
+func (w *WatcherSpec) contractMethods() map[string][]BlockchainMethod {
+	if len(w.Contracts) == 0 {
+		return nil
+	}
+	methodsByAddress := make(map[string][]BlockchainMethod, len(w.Contracts))
+	for _, c := range w.Contracts {
+		for _, m := range c.Methods {
+			method := BlockchainMethod{ID: m.Name, Name: m.Name}
+			if m.VaaArgument != "" {
+				method.VaaArgument = strings.Split(m.VaaArgument, ".")
+			}
+			methodsByAddress[c.Address] = append(methodsByAddress[c.Address], method)
+		}
+	}
+	return methodsByAddress
+}
+
+// ToWatcherBlockchainAddresses converts the definition of an EVM watcher.
+func (w *WatcherSpec) ToWatcherBlockchainAddresses() (WatcherBlockchainAddresses, error) {
+	chainID, _ := w.ChainID()
+	methodsByAddress := make(map[string][]BlockchainMethod, len(w.Contracts))
+	eventsByAddress := make(map[string][]BlockchainEvent)
+	for _, c := range w.Contracts {
+		address := strings.ToLower(c.Address)
+		methods, err := c.blockchainMethods()
+		if err != nil {
+			return WatcherBlockchainAddresses{}, fmt.Errorf("watcher %s: %w", w.Name, err)
+		}
+		if len(methods) > 0 {
+			methodsByAddress[address] = append(methodsByAddress[address], methods...)
+		}
+		events, err := c.blockchainEvents()
+		if err != nil {
+			return WatcherBlockchainAddresses{}, fmt.Errorf("watcher %s: %w", w.Name, err)
+		}
+		if len(events) > 0 {
+			eventsByAddress[address] = append(eventsByAddress[address], events...)
+		}
+	}
+	return WatcherBlockchainAddresses{
+		ChainID:           chainID,
+		Name:              w.Name,
+		SizeBlocks:        w.SizeBlocks,
+		WaitSeconds:       w.WaitSeconds,
+		InitialBlock:      w.InitialBlock,
+		MethodsByAddress:  methodsByAddress,
+		EventsByAddress:   eventsByAddress,
+		ConfirmationDepth: w.ConfirmationDepth,
+		ReorgWindow:       w.ReorgWindow,
+	}, nil
+}
+
+// blockchainMethods resolves the watched methods of the contract, including their ABI definition.
+func (c *ContractSpec) blockchainMethods() ([]BlockchainMethod, error) {
+	var contractABI *abi.ABI
+	if c.ABI != "" {
+		var err error
+		contractABI, err = LoadABI(c.ABI)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", c.Address, err)
+		}
+	}
+
+	methods := make([]BlockchainMethod, 0, len(c.Methods))
+	for _, m := range c.Methods {
+		id, err := m.MethodID()
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", m.Name, err)
+		}
+		method := BlockchainMethod{ID: id, Name: m.Name}
+		if contractABI != nil {
+			selector, _ := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+			method.ABI, err = contractABI.MethodById(selector)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: selector %s not found in abi %s", m.Name, id, c.ABI)
+			}
+			method.VaaArgument, err = ResolveVaaArgument(method.ABI, m.VaaArgument)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", m.Name, err)
+			}
+		} else if m.VaaArgument != "" {
+			return nil, fmt.Errorf("method %s: vaaArgument requires the contract abi", m.Name)
+		}
+		methods = append(methods, method)
+	}
+	return methods, nil
+}
+
+// blockchainEvents resolves the watched events of the contract.
+func (c *ContractSpec) blockchainEvents() ([]BlockchainEvent, error) {
+	if len(c.Events) == 0 {
+		return nil, nil
+	}
+	if c.ABI == "" {
+		return nil, fmt.Errorf("contract %s: abi is required to watch events", c.Address)
+	}
+	contractABI, err := LoadABI(c.ABI)
+	if err != nil {
+		return nil, fmt.Errorf("contract %s: %w", c.Address, err)
+	}
+
+	events := make([]BlockchainEvent, 0, len(c.Events))
+	for _, e := range c.Events {
+		event, ok := contractABI.Events[e.Event]
+		if !ok {
+			return nil, fmt.Errorf("contract %s: event %s not found in abi %s", c.Address, e.Event, c.ABI)
+		}
+		be, err := newBlockchainEvent(&event, e)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: event %s: %w", c.Address, e.Event, err)
+		}
+		events = append(events, be)
+	}
+	return events, nil
+}
+
+// FindWatcher returns the watcher definition for a chain.
+func (c *WatchersConfiguration) FindWatcher(chainID vaa.ChainID) (*WatcherSpec, bool) {
+	for i := range c.Watchers {
+		if id, err := c.Watchers[i].ChainID(); err == nil && id == chainID {
+			return &c.Watchers[i], true
+		}
+	}
+	return nil, false
+}
+
+// MethodID returns the lowercase method selector with the 0x prefix.
+func (m *MethodSpec) MethodID() (string, error) {
+	var fromSignature string
+	if m.Signature != "" {
+		fromSignature = selector(m.Signature)
+	}
+	if m.ID == "" {
+		if fromSignature == "" {
+			return "", errors.New("id or signature is required")
+		}
+		return fromSignature, nil
+	}
+	if !methodIDRegex.MatchString(m.ID) {
+		return "", fmt.Errorf("invalid method id %s", m.ID)
+	}
+	id := strings.ToLower(m.ID)
+	if fromSignature != "" && fromSignature != id {
+		return "", fmt.Errorf("method id %s does not match signature %s (%s)", id, m.Signature, fromSignature)
+	}
+	return id, nil
+}
+
+// selector computes the 4-byte selector of a method signature, e.g. completeTransfer(bytes).
+func selector(signature string) string {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(strings.ReplaceAll(signature, " ", "")))
+	return "0x" + hex.EncodeToString(h.Sum(nil)[:4])
+}
@@ -0,0 +1,378 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+var envVarRegex = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+
+// setDefaultWatchersEnv sets every variable referenced by the default configuration of a network.
+func setDefaultWatchersEnv(t *testing.T, p2pNetwork string) {
+	data, err := defaultWatchers.ReadFile("watchers/" + p2pNetwork + ".yaml")
+	assert.NoError(t, err)
+	for _, m := range envVarRegex.FindAllStringSubmatch(string(data), -1) {
+		if strings.HasSuffix(m[1], "_REQUESTS_PER_SECOND") {
+			t.Setenv(m[1], "10")
+		} else {
+			t.Setenv(m[1], "http://localhost")
+		}
+	}
+}
+
+func TestLoadWatchers_Defaults(t *testing.T) {
+
+	for _, network := range []string{"mainnet", "testnet"} {
+		setDefaultWatchersEnv(t, network)
+
+		cfg, err := LoadWatchers("", network)
+		assert.NoError(t, err)
+		assert.NoError(t, cfg.Validate(), network)
+	}
+}
+
+func TestLoadWatchers_MainnetEthereum(t *testing.T) {
+
+	setDefaultWatchersEnv(t, "mainnet")
+
+	cfg, err := LoadWatchers("", "mainnet")
+	assert.NoError(t, err)
+
+	spec, ok := cfg.FindWatcher(vaa.ChainIDEthereum)
+	assert.True(t, ok)
+	assert.Equal(t, "http://localhost", spec.URL)
+	assert.Equal(t, 10, spec.RequestsPerSecond)
+
+	w, err := spec.ToWatcherBlockchainAddresses()
+	assert.NoError(t, err)
+
+	methods := w.MethodsByAddress["0x3ee18b2214aff97000d974cf647e7c347e8fa585"]
+	assert.Len(t, methods, 4)
+	assert.Equal(t, MethodIDCompleteTransfer, methods[0].ID)
+	assert.Equal(t, MethodCompleteTransfer, methods[0].Name)
+	assert.Equal(t, "completeTransfer", methods[0].ABI.Name)
+	assert.Equal(t, []string{"encodedVm"}, methods[0].VaaArgument)
+	assert.Equal(t, MethodIDCompleteAndUnwrapETH, methods[1].ID)
+	assert.Equal(t, "completeTransferAndUnwrapETH", methods[1].ABI.Name)
+
+	methods = w.MethodsByAddress["0x27428dd2d3dd32a4d7f7c497eaaa23130d894911"]
+	assert.Len(t, methods, 1)
+	assert.Equal(t, MethodIDDeliver, methods[0].ID)
+	assert.Equal(t, []string{"encodedDeliveryVAA"}, methods[0].VaaArgument)
+
+	methods = w.MethodsByAddress["0xaada05bd399372f0b0463744c09113c137636f6a"]
+	assert.Len(t, methods, 1)
+	assert.Equal(t, MethodIDRedeemTokensWithPayload, methods[0].ID)
+	assert.Equal(t, []string{"params", "encodedWormholeMessage"}, methods[0].VaaArgument)
+}
+
+func TestLoadWatchers_RelayerDeliveries(t *testing.T) {
+
+	relayers := map[string]string{
+		"mainnet": "0x27428dd2d3dd32a4d7f7c497eaaa23130d894911",
+		"testnet": "0x80ac94316391752a193c1c47e27d382b507c93f3",
+	}
+	for network, relayer := range relayers {
+		setDefaultWatchersEnv(t, network)
+
+		cfg, err := LoadWatchers("", network)
+		assert.NoError(t, err)
+
+		var found int
+		for _, spec := range cfg.Watchers {
+			if spec.Provider != ProviderEvmLogs {
+				continue
+			}
+			w, err := spec.ToWatcherBlockchainAddresses()
+			assert.NoError(t, err)
+			events := w.EventsByAddress[relayer]
+			if assert.Len(t, events, 1, spec.Name) {
+				assert.Equal(t, "Delivery", events[0].ABI.Name, spec.Name)
+				found++
+			}
+		}
+		assert.NotZero(t, found, network)
+	}
+}
+
+func TestLoadWatchers_UnknownNetwork(t *testing.T) {
+
+	_, err := LoadWatchers("", "devnet")
+	assert.Error(t, err)
+}
+
+func TestLoadWatchers_File(t *testing.T) {
+
+	f, err := os.CreateTemp(t.TempDir(), "watchers-*.json")
+	assert.NoError(t, err)
+	_, err = f.WriteString(`{"watchers": [{"chain": "solana", "name": "solana", "provider": "solana",
+		"url": "http://localhost", "requestsPerSecond": 5, "sizeBlocks": 50, "waitSeconds": 10,
+		"address": "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb"}]}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	cfg, err := LoadWatchers(f.Name(), "mainnet")
+	assert.NoError(t, err)
+	assert.NoError(t, cfg.Validate())
+	assert.Len(t, cfg.Watchers, 1)
+	assert.Equal(t, vaa.ChainIDSolana, cfg.Watchers[0].ToWatcherBlockchain().ChainID)
+}
+
+func TestWatchersConfiguration_Validate(t *testing.T) {
+
+	evmWatcher := func() WatcherSpec {
+		return WatcherSpec{
+			Chain:             "ethereum",
+			Name:              "eth",
+			Provider:          ProviderEvm,
+			URL:               "http://localhost",
+			RequestsPerSecond: 1,
+			SizeBlocks:        100,
+			WaitSeconds:       10,
+			Contracts: []ContractSpec{{
+				Address: "0x3ee18B2214AFF97000D974cf647E7C347E8fa585",
+				ABI:     "token_bridge",
+				Methods: []MethodSpec{{Name: "completeTransfer", Signature: "completeTransfer(bytes)"}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(w *WatcherSpec)
+	}{
+		{name: "unknown chain", modify: func(w *WatcherSpec) { w.Chain = "unknown" }},
+		{name: "unknown provider", modify: func(w *WatcherSpec) { w.Provider = "unknown" }},
+		{name: "missing url", modify: func(w *WatcherSpec) { w.URL = "" }},
+		{name: "missing rate limit", modify: func(w *WatcherSpec) { w.RequestsPerSecond = 0 }},
+		{name: "invalid address", modify: func(w *WatcherSpec) { w.Contracts[0].Address = "0x1234" }},
+		{name: "missing methods", modify: func(w *WatcherSpec) { w.Contracts[0].Methods = nil }},
+		{name: "selector mismatch", modify: func(w *WatcherSpec) { w.Contracts[0].Methods[0].ID = "0xe8059810" }},
+		{name: "invalid selector", modify: func(w *WatcherSpec) { w.Contracts[0].Methods[0].ID = "0xzz" }},
+		{name: "unknown abi", modify: func(w *WatcherSpec) { w.Contracts[0].ABI = "unknown" }},
+		{name: "method not in abi", modify: func(w *WatcherSpec) { w.Contracts[0].ABI = "tbtc_gateway" }},
+		{name: "unknown vaa argument", modify: func(w *WatcherSpec) { w.Contracts[0].Methods[0].VaaArgument = "vm" }},
+		{name: "vaa argument without abi", modify: func(w *WatcherSpec) {
+			w.Contracts[0].ABI = ""
+			w.Contracts[0].Methods[0].VaaArgument = "encodedVm"
+		}},
+		{name: "trace with ankr provider", modify: func(w *WatcherSpec) {
+			w.Provider = ProviderAnkr
+			w.TraceInternalCalls = true
+		}},
+		{name: "reorg window with terra provider", modify: func(w *WatcherSpec) {
+			w.Chain = "terra"
+			w.Provider = ProviderTerra
+			w.Address = "terra1dq03ugtd40zu9hcgdzrsq6z2z4hwhc9tqk2uy5"
+			w.Contracts = nil
+			w.ReorgWindow = 128
+		}},
+		{name: "confirmation depth with solana provider", modify: func(w *WatcherSpec) {
+			w.Chain = "solana"
+			w.Provider = ProviderSolana
+			w.Address = "wormDTUJ6AWPNvk59vGQbDvGJmqbDTdgWgAqcLBCgUb"
+			w.ConfirmationDepth = 32
+		}},
+	}
+
+	valid := WatchersConfiguration{Watchers: []WatcherSpec{evmWatcher()}}
+	assert.NoError(t, valid.Validate())
+
+	reorgAware := evmWatcher()
+	reorgAware.ConfirmationDepth = 32
+	reorgAware.ReorgWindow = 256
+	assert.NoError(t, reorgAware.Validate())
+	wb, err := reorgAware.ToWatcherBlockchainAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(32), wb.ConfirmationDepth)
+	assert.Equal(t, uint64(256), wb.ReorgWindow)
+
+	ankrReorgAware := evmWatcher()
+	ankrReorgAware.Provider = ProviderAnkr
+	ankrReorgAware.ReorgWindow = 128
+	assert.NoError(t, ankrReorgAware.Validate())
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := evmWatcher()
+			tt.modify(&w)
+			cfg := WatchersConfiguration{Watchers: []WatcherSpec{w}}
+			assert.Error(t, cfg.Validate())
+		})
+	}
+
+	duplicated := WatchersConfiguration{Watchers: []WatcherSpec{evmWatcher(), evmWatcher()}}
+	assert.Error(t, duplicated.Validate())
+}
+
+func TestMethodSpec_MethodID(t *testing.T) {
+
+	id, err := (&MethodSpec{Signature: "completeTransferAndUnwrapETH(bytes)"}).MethodID()
+	assert.NoError(t, err)
+	assert.Equal(t, MethodIDCompleteAndUnwrapETH, id)
+
+	id, err = (&MethodSpec{ID: "0xC6878519"}).MethodID()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xc6878519", id)
+
+	_, err = (&MethodSpec{Name: "completeTransfer"}).MethodID()
+	assert.Error(t, err)
+}
+
+func TestResolveVaaArgument(t *testing.T) {
+
+	relayerABI, err := LoadABI("wormhole_relayer")
+	assert.NoError(t, err)
+	deliver := relayerABI.Methods["deliver"]
+
+	// deliver has several bytes inputs, the argument is required.
+	_, err = ResolveVaaArgument(&deliver, "")
+	assert.Error(t, err)
+
+	path, err := ResolveVaaArgument(&deliver, "encodedVMs")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"encodedVMs"}, path)
+
+	_, err = ResolveVaaArgument(&deliver, "relayerRefundAddress")
+	assert.Error(t, err)
+
+	circleABI, err := LoadABI("circle_integration")
+	assert.NoError(t, err)
+	redeem := circleABI.Methods["redeemTokensWithPayload"]
+
+	path, err = ResolveVaaArgument(&redeem, "params.encodedWormholeMessage")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"params", "encodedWormholeMessage"}, path)
+
+	_, err = ResolveVaaArgument(&redeem, "params")
+	assert.Error(t, err)
+
+	_, err = ResolveVaaArgument(&redeem, "params.unknown")
+	assert.Error(t, err)
+}
+
+func TestWatcherSpec_ValidateEvmLogs(t *testing.T) {
+
+	logsWatcher := func() WatcherSpec {
+		return WatcherSpec{
+			Chain:             "ethereum",
+			Name:              "eth",
+			Provider:          ProviderEvmLogs,
+			URL:               "http://localhost",
+			RequestsPerSecond: 1,
+			SizeBlocks:        100,
+			WaitSeconds:       10,
+			Contracts: []ContractSpec{{
+				Address: "0x3ee18B2214AFF97000D974cf647E7C347E8fa585",
+				ABI:     "token_bridge",
+				Events:  []EventSpec{{Event: "TransferRedeemed"}},
+			}},
+		}
+	}
+
+	w := logsWatcher()
+	assert.NoError(t, w.Validate())
+
+	wb, err := w.ToWatcherBlockchainAddresses()
+	assert.NoError(t, err)
+	events := wb.EventsByAddress["0x3ee18b2214aff97000d974cf647e7c347e8fa585"]
+	assert.Len(t, events, 1)
+	assert.Equal(t, "TransferRedeemed", events[0].Name)
+	assert.Equal(t, "emitterChainId", events[0].ChainArgument)
+	assert.Equal(t, "emitterAddress", events[0].EmitterArgument)
+	assert.Equal(t, "sequence", events[0].SequenceArgument)
+
+	tests := []struct {
+		name   string
+		modify func(w *WatcherSpec)
+	}{
+		{name: "missing events", modify: func(w *WatcherSpec) { w.Contracts[0].Events = nil }},
+		{name: "missing abi", modify: func(w *WatcherSpec) { w.Contracts[0].ABI = "" }},
+		{name: "unknown event", modify: func(w *WatcherSpec) { w.Contracts[0].Events[0].Event = "Unknown" }},
+		{name: "unknown argument", modify: func(w *WatcherSpec) { w.Contracts[0].Events[0].SequenceArgument = "nonce" }},
+		{name: "invalid argument type", modify: func(w *WatcherSpec) { w.Contracts[0].Events[0].ChainArgument = "emitterAddress" }},
+		{name: "emitter argument and address", modify: func(w *WatcherSpec) {
+			w.Contracts[0].Events[0].EmitterArgument = "emitterAddress"
+			w.Contracts[0].Events[0].EmitterAddress = "0x27428DD2d3DD32A4D7f7C497eAaa23130d894911"
+		}},
+		{name: "trace internal calls", modify: func(w *WatcherSpec) { w.TraceInternalCalls = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := logsWatcher()
+			tt.modify(&w)
+			assert.Error(t, w.Validate())
+		})
+	}
+}
+
+func TestWatcherSpec_ValidateNonEvm(t *testing.T) {
+
+	suiWatcher := func() WatcherSpec {
+		return WatcherSpec{
+			Chain:             "sui",
+			Name:              "sui",
+			Provider:          ProviderSui,
+			URL:               "http://localhost",
+			RequestsPerSecond: 1,
+			SizeBlocks:        10,
+			WaitSeconds:       10,
+			Contracts: []ContractSpec{{
+				Address: "0x26efee2b51c911237888e5dc6702868abca3c7ac12c53f76ef8eba0697695e3d",
+				Methods: []MethodSpec{{Name: "complete_transfer::authorize_transfer"}},
+			}},
+		}
+	}
+
+	w := suiWatcher()
+	assert.NoError(t, w.Validate())
+
+	algorand := suiWatcher()
+	algorand.Chain, algorand.Name, algorand.Provider = "algorand", "algorand", ProviderAlgorand
+	algorand.Contracts[0].Address = "842126029"
+	algorand.Contracts[0].Methods[0].Name = "completeVAA"
+	assert.NoError(t, algorand.Validate())
+
+	translator := suiWatcher()
+	translator.Chain, translator.Name, translator.Provider = "sei", "sei", ProviderCosmwasm
+	translator.Contracts[0].Address = "sei189adguawugk3e55zn63z8r9ll29xrjwca636ra7v7gxuzn98sxyqwzt47l"
+	translator.Contracts[0].Methods[0] = MethodSpec{Name: "complete_transfer_and_convert", VaaArgument: "vaa"}
+	assert.NoError(t, translator.Validate())
+
+	wb := translator.ToWatcherBlockchain()
+	methods := wb.MethodsByAddress["sei189adguawugk3e55zn63z8r9ll29xrjwca636ra7v7gxuzn98sxyqwzt47l"]
+	assert.Len(t, methods, 1)
+	assert.Equal(t, "complete_transfer_and_convert", methods[0].ID)
+	assert.Equal(t, []string{"vaa"}, methods[0].VaaArgument)
+
+	tests := []struct {
+		name   string
+		modify func(w *WatcherSpec)
+	}{
+		{name: "missing contracts", modify: func(w *WatcherSpec) { w.Contracts = nil }},
+		{name: "missing size blocks", modify: func(w *WatcherSpec) { w.SizeBlocks = 0 }},
+		{name: "missing address", modify: func(w *WatcherSpec) { w.Contracts[0].Address = "" }},
+		{name: "missing methods", modify: func(w *WatcherSpec) { w.Contracts[0].Methods = nil }},
+		{name: "missing method name", modify: func(w *WatcherSpec) { w.Contracts[0].Methods[0].Name = "" }},
+		{name: "method without module", modify: func(w *WatcherSpec) { w.Contracts[0].Methods[0].Name = "authorize_transfer" }},
+		{name: "trace internal calls", modify: func(w *WatcherSpec) { w.TraceInternalCalls = true }},
+		{name: "invalid application id", modify: func(w *WatcherSpec) {
+			w.Chain, w.Provider = "algorand", ProviderAlgorand
+			w.Contracts[0].Methods[0].Name = "completeVAA"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := suiWatcher()
+			tt.modify(&w)
+			assert.Error(t, w.Validate())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package algorand
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+const clientName = "algorand"
+
+// AlgorandSDK is a client for the Algorand indexer API.
+type AlgorandSDK struct {
+	client  *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+type healthResponse struct {
+	Round uint64 `json:"round"`
+}
+
+// ApplicationTransaction are the fields of an application call.
+type ApplicationTransaction struct {
+	ApplicationID   uint64   `json:"application-id"`
+	ApplicationArgs [][]byte `json:"application-args"`
+	OnCompletion    string   `json:"on-completion"`
+}
+
+// Transaction is an Algorand transaction. Only the outer transactions have an ID, the inner transactions
+// created by the application calls are included in InnerTxns.
+type Transaction struct {
+	ID                     string                  `json:"id"`
+	Sender                 string                  `json:"sender"`
+	TxType                 string                  `json:"tx-type"`
+	ConfirmedRound         uint64                  `json:"confirmed-round"`
+	RoundTime              int64                   `json:"round-time"`
+	ApplicationTransaction *ApplicationTransaction `json:"application-transaction"`
+	InnerTxns              []Transaction           `json:"inner-txns"`
+}
+
+// TransactionsResponse is a page of the transactions search.
+type TransactionsResponse struct {
+	CurrentRound uint64        `json:"current-round"`
+	NextToken    string        `json:"next-token"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// NewAlgorandSDK creates a new AlgorandSDK.
+func NewAlgorandSDK(url string, rl ratelimit.Limiter, metrics metrics.Metrics) *AlgorandSDK {
+	return &AlgorandSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		metrics: metrics,
+	}
+}
+
+// GetLatestRound returns the last round processed by the indexer.
+func (s *AlgorandSDK) GetLatestRound(ctx context.Context) (uint64, error) {
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&healthResponse{}).
+		Get("health")
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-latest-round", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return 0, ErrTooManyRequests
+		}
+		return 0, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*healthResponse)
+	if result == nil {
+		return 0, fmt.Errorf("empty response")
+	}
+	return result.Round, nil
+}
+
+// GetApplicationTransactions returns a page of the transactions that call an application between two rounds,
+// including the transactions that call it from an inner transaction.
+func (s *AlgorandSDK) GetApplicationTransactions(ctx context.Context, applicationID uint64, fromRound, toRound uint64, nextToken string) (*TransactionsResponse, error) {
+	s.rl.Take()
+	req := s.client.R().
+		SetContext(ctx).
+		SetResult(&TransactionsResponse{}).
+		SetQueryParam("application-id", strconv.FormatUint(applicationID, 10)).
+		SetQueryParam("min-round", strconv.FormatUint(fromRound, 10)).
+		SetQueryParam("max-round", strconv.FormatUint(toRound, 10)).
+		SetQueryParam("limit", "1000")
+	if nextToken != "" {
+		req.SetQueryParam("next", nextToken)
+	}
+	resp, err := req.Get("v2/transactions")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-application-transactions", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*TransactionsResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result, nil
+}
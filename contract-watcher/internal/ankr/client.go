@@ -106,3 +106,47 @@ func (s AnkrSDK) GetBlockchainStats(ctx context.Context, blockchain string) (*Bl
 	return &response, err
 
 }
+
+// GetBlocks returns the headers of the blocks between fromBlock and toBlock.
+func (s AnkrSDK) GetBlocks(ctx context.Context, blockchain string, fromBlock, toBlock int64) (*BlocksResponse, error) {
+	request := BlocksRequest{
+		ID:      rand.Int63(),
+		Jsonrpc: "2.0",
+		Method:  "ankr_getBlocks",
+		Params: BlocksRequestParams{
+			Blockchain: blockchain,
+			FromBlock:  fromBlock,
+			ToBlock:    toBlock,
+		},
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", s.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	s.rl.Take()
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	s.metrics.IncRpcRequest(clientName, "get-blocks", res.StatusCode)
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response BlocksResponse
+	err = json.Unmarshal(body, &response)
+
+	return &response, err
+}
@@ -124,3 +124,30 @@ type BlockchainStatsResponse struct {
 		} `json:"stats"`
 	} `json:"result"`
 }
+
+type BlocksRequest struct {
+	ID      int64               `json:"id"`
+	Jsonrpc string              `json:"jsonrpc"`
+	Method  string              `json:"method"`
+	Params  BlocksRequestParams `json:"params"`
+}
+
+type BlocksRequestParams struct {
+	Blockchain string `json:"blockchain"`
+	FromBlock  int64  `json:"fromBlock"`
+	ToBlock    int64  `json:"toBlock"`
+}
+
+type BlocksResponse struct {
+	ID      int64  `json:"id"`
+	Jsonrpc string `json:"jsonrpc"`
+	Result  struct {
+		Blocks []struct {
+			Blockchain string `json:"blockchain"`
+			Number     string `json:"number"`
+			Hash       string `json:"hash"`
+			ParentHash string `json:"parentHash"`
+			Timestamp  string `json:"timestamp"`
+		} `json:"blocks"`
+	} `json:"result"`
+}
@@ -0,0 +1,140 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+const clientName = "cosmos"
+
+// MsgExecuteContractType is the type of the messages that execute a CosmWasm contract.
+const MsgExecuteContractType = "/cosmwasm.wasm.v1.MsgExecuteContract"
+
+// PageSize is the number of transactions requested by page.
+const PageSize = 100
+
+// CosmosSDK is a client for the LCD API of the Cosmos-SDK chains with CosmWasm contracts.
+type CosmosSDK struct {
+	client  *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+type latestBlockResponse struct {
+	Block struct {
+		Header struct {
+			Height string `json:"height"`
+		} `json:"header"`
+	} `json:"block"`
+}
+
+// Message is a transaction message. Contract and Msg are only set for the contract executions.
+type Message struct {
+	Type     string          `json:"@type"`
+	Sender   string          `json:"sender"`
+	Contract string          `json:"contract"`
+	Msg      json.RawMessage `json:"msg"`
+}
+
+type TxResponse struct {
+	Height    string     `json:"height"`
+	TxHash    string     `json:"txhash"`
+	Code      int        `json:"code"`
+	RawLog    string     `json:"raw_log"`
+	Timestamp *time.Time `json:"timestamp"`
+	Tx        struct {
+		Body struct {
+			Messages []Message `json:"messages"`
+		} `json:"body"`
+	} `json:"tx"`
+}
+
+type txsResponse struct {
+	TxResponses []TxResponse `json:"tx_responses"`
+}
+
+// NewCosmosSDK creates a new CosmosSDK.
+func NewCosmosSDK(url string, rl ratelimit.Limiter, metrics metrics.Metrics) *CosmosSDK {
+	return &CosmosSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		metrics: metrics,
+	}
+}
+
+// GetLatestBlock returns the height of the latest block.
+func (s *CosmosSDK) GetLatestBlock(ctx context.Context) (uint64, error) {
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&latestBlockResponse{}).
+		Get("cosmos/base/tendermint/v1beta1/blocks/latest")
+
+	if err != nil {
+		return 0, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-latest-block", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return 0, ErrTooManyRequests
+		}
+		return 0, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*latestBlockResponse)
+	if result == nil {
+		return 0, fmt.Errorf("empty response")
+	}
+	return strconv.ParseUint(result.Block.Header.Height, 10, 64)
+}
+
+// GetContractTransactions returns a page of the transactions that executed a contract between two heights,
+// in ascending order. The page is full when it has PageSize transactions.
+func (s *CosmosSDK) GetContractTransactions(ctx context.Context, contract string, fromHeight, toHeight uint64, offset int) ([]TxResponse, error) {
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&txsResponse{}).
+		SetQueryParamsFromValues(map[string][]string{
+			"events": {
+				fmt.Sprintf("execute._contract_address='%s'", contract),
+				fmt.Sprintf("tx.height>=%d", fromHeight),
+				fmt.Sprintf("tx.height<=%d", toHeight),
+			},
+			"order_by":          {"ORDER_BY_ASC"},
+			"pagination.offset": {strconv.Itoa(offset)},
+			"pagination.limit":  {strconv.Itoa(PageSize)},
+		}).
+		Get("cosmos/tx/v1beta1/txs")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-contract-transactions", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*txsResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.TxResponses, nil
+}
@@ -116,6 +116,108 @@ func (s *EvmSDK) GetTransactionReceipt(ctx context.Context, txHash string) (*Tra
 	return &result.Result, nil
 }
 
+// GetBlockHeader returns the block without its transactions.
+func (s *EvmSDK) GetBlockHeader(ctx context.Context, block uint64) (*BlockHeader, error) {
+	s.rl.Take()
+	req := newEvmRequest("eth_getBlockByNumber", utils.EncodeHex(block), false)
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&getBlockHeaderResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-block-header", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getBlockHeaderResponse)
+	if result == nil || result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
+// GetLogs returns the logs emitted by the addresses in the block range that match the topics.
+func (s *EvmSDK) GetLogs(ctx context.Context, fromBlock, toBlock uint64, addresses []string, topics [][]string) ([]Log, error) {
+	s.rl.Take()
+	filter := LogFilter{
+		FromBlock: utils.EncodeHex(fromBlock),
+		ToBlock:   utils.EncodeHex(toBlock),
+		Address:   addresses,
+		Topics:    topics,
+	}
+	req := newEvmRequest("eth_getLogs", filter)
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&getLogsResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-logs", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getLogsResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("rpc error %d: %s", result.Error.Code, result.Error.Message)
+	}
+	return result.Result, nil
+}
+
+// GetBlockTraces returns the call traces of the transactions of a block, in the same order as the block transactions.
+func (s *EvmSDK) GetBlockTraces(ctx context.Context, block uint64) ([]TransactionTrace, error) {
+	s.rl.Take()
+	req := newEvmRequest("debug_traceBlockByNumber", utils.EncodeHex(block), map[string]string{"tracer": "callTracer"})
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(&traceBlockResponse{}).
+		Post("")
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.metrics.IncRpcRequest(clientName, "get-block-traces", resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return nil, ErrTooManyRequests
+		}
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*traceBlockResponse)
+	if result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("rpc error %d: %s", result.Error.Code, result.Error.Message)
+	}
+	return result.Result, nil
+}
+
 func newEvmRequest(method string, params ...any) EvmRequest {
 	return EvmRequest{
 		Jsonrpc: "2.0",
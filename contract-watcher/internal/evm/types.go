@@ -28,6 +28,7 @@ type Transaction struct {
 
 type GetBlockResult struct {
 	Hash         string        `json:"hash"`
+	ParentHash   string        `json:"parentHash"`
 	Number       string        `json:"number"`
 	Timestamp    string        `json:"timestamp"`
 	Transactions []Transaction `json:"transactions"`
@@ -58,6 +59,67 @@ type TransactionReceiptResult struct {
 	Type              string  `json:"type"`
 }
 
+type BlockHeader struct {
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Number     string `json:"number"`
+	Timestamp  string `json:"timestamp"`
+}
+
+type getBlockHeaderResponse struct {
+	Result *BlockHeader `json:"result"`
+}
+
+type Log struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	BlockHash        string   `json:"blockHash"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+	Removed          bool     `json:"removed"`
+}
+
+type LogFilter struct {
+	FromBlock string     `json:"fromBlock"`
+	ToBlock   string     `json:"toBlock"`
+	Address   []string   `json:"address"`
+	Topics    [][]string `json:"topics"`
+}
+
+type getLogsResponse struct {
+	Result []Log     `json:"result"`
+	Error  *rpcError `json:"error"`
+}
+
+// CallTrace is a call frame of the callTracer.
+type CallTrace struct {
+	Type   string      `json:"type"`
+	From   string      `json:"from"`
+	To     string      `json:"to"`
+	Input  string      `json:"input"`
+	Output string      `json:"output"`
+	Error  string      `json:"error"`
+	Calls  []CallTrace `json:"calls"`
+}
+
+type TransactionTrace struct {
+	TxHash string    `json:"txHash"`
+	Result CallTrace `json:"result"`
+}
+
+type traceBlockResponse struct {
+	Result []TransactionTrace `json:"result"`
+	Error  *rpcError          `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
 type EvmRequest struct {
 	Jsonrpc string `json:"jsonrpc"`
 	Method  string `json:"method"`
@@ -11,4 +11,5 @@ type Metrics interface {
 	SetCurrentBlock(chain sdk.ChainID, block uint64)
 	IncDestinationTrxSaved(chain sdk.ChainID)
 	IncRpcRequest(client string, method string, statusCode int)
+	IncReorg(chain sdk.ChainID)
 }
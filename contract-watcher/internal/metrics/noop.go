@@ -22,3 +22,6 @@ func (m *NoopMetrics) IncDestinationTrxSaved(chain sdk.ChainID) {
 
 func (m *NoopMetrics) IncRpcRequest(client string, method string, statusCode int) {
 }
+
+func (m *NoopMetrics) IncReorg(chain sdk.ChainID) {
+}
@@ -13,6 +13,7 @@ type PrometheusMetrics struct {
 	lastBlock           *prometheus.GaugeVec
 	currentBlock        *prometheus.GaugeVec
 	requestsTotal       *prometheus.CounterVec
+	reorgCount          *prometheus.CounterVec
 }
 
 // NewPrometheusMetrics returns a new instance of PrometheusMetrics.
@@ -53,11 +54,19 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		[]string{"client", "operation", "status_code"},
 	)
 
+	reorgCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "reorg_count_by_chain",
+			Help:        "Total number of chain reorganizations detected by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain"})
+
 	return &PrometheusMetrics{
 		lastBlock:           lastBlock,
 		currentBlock:        currentBlock,
 		destinationTrxCount: destinationTrxCount,
 		requestsTotal:       requestsTotal,
+		reorgCount:          reorgCount,
 	}
 }
 
@@ -76,3 +85,7 @@ func (m *PrometheusMetrics) IncDestinationTrxSaved(chain sdk.ChainID) {
 func (m *PrometheusMetrics) IncRpcRequest(client string, operation string, statusCode int) {
 	m.requestsTotal.WithLabelValues(client, operation, strconv.Itoa(statusCode)).Inc()
 }
+
+func (m *PrometheusMetrics) IncReorg(chain sdk.ChainID) {
+	m.reorgCount.WithLabelValues(chain.String()).Inc()
+}
@@ -0,0 +1,241 @@
+package near
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var (
+	ErrTooManyRequests = fmt.Errorf("too many requests")
+	// ErrBlockNotFound is returned for the heights without block, NEAR can skip heights.
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+const clientName = "near"
+
+// NearSDK is a client for the NEAR JSON-RPC API.
+type NearSDK struct {
+	client  *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+type BlockHeader struct {
+	Height    uint64 `json:"height"`
+	Hash      string `json:"hash"`
+	PrevHash  string `json:"prev_hash"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+// ChunkHeader is the header of a chunk of a block. The chunks not produced in the block are repeated from a
+// previous block, their HeightIncluded is lower than the block height.
+type ChunkHeader struct {
+	ChunkHash      string `json:"chunk_hash"`
+	ShardID        uint64 `json:"shard_id"`
+	HeightIncluded uint64 `json:"height_included"`
+}
+
+type Block struct {
+	Header BlockHeader   `json:"header"`
+	Chunks []ChunkHeader `json:"chunks"`
+}
+
+// FunctionCall is a call to a contract method, Args is the JSON message of the call.
+type FunctionCall struct {
+	MethodName string `json:"method_name"`
+	Args       []byte `json:"args"`
+	Gas        uint64 `json:"gas"`
+	Deposit    string `json:"deposit"`
+}
+
+type Transaction struct {
+	Hash       string            `json:"hash"`
+	SignerID   string            `json:"signer_id"`
+	ReceiverID string            `json:"receiver_id"`
+	Actions    []json.RawMessage `json:"actions"`
+}
+
+// FunctionCalls returns the function call actions of the transaction. The other actions are encoded as
+// a string (e.g. "CreateAccount") or as an object with a different key, so they are skipped.
+func (t *Transaction) FunctionCalls() []FunctionCall {
+	var calls []FunctionCall
+	for _, action := range t.Actions {
+		var a struct {
+			FunctionCall *FunctionCall `json:"FunctionCall"`
+		}
+		if err := json.Unmarshal(action, &a); err != nil || a.FunctionCall == nil {
+			continue
+		}
+		calls = append(calls, *a.FunctionCall)
+	}
+	return calls
+}
+
+type Chunk struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+// TransactionStatus is the final execution status of a transaction and its receipts.
+type TransactionStatus struct {
+	Status map[string]json.RawMessage `json:"status"`
+}
+
+// IsSuccess returns true when the transaction and all its receipts were executed successfully.
+func (t *TransactionStatus) IsSuccess() bool {
+	_, failure := t.Status["Failure"]
+	return !failure && len(t.Status) > 0
+}
+
+type nearRequest struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+	ID      string `json:"id"`
+}
+
+type rpcError struct {
+	Name    string `json:"name"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Cause   struct {
+		Name string `json:"name"`
+	} `json:"cause"`
+}
+
+func (e *rpcError) err() error {
+	if e.Cause.Name == "UNKNOWN_BLOCK" || e.Cause.Name == "UNKNOWN_CHUNK" {
+		return ErrBlockNotFound
+	}
+	return fmt.Errorf("rpc error %d: %s %s", e.Code, e.Message, e.Cause.Name)
+}
+
+// rpcResponse is implemented by the responses to get the JSON-RPC error, which the nodes can return with an
+// error status code.
+type rpcResponse interface {
+	rpcError() *rpcError
+}
+
+type getBlockResponse struct {
+	Result *Block    `json:"result"`
+	Error  *rpcError `json:"error"`
+}
+
+func (r *getBlockResponse) rpcError() *rpcError { return r.Error }
+
+type getChunkResponse struct {
+	Result *Chunk    `json:"result"`
+	Error  *rpcError `json:"error"`
+}
+
+func (r *getChunkResponse) rpcError() *rpcError { return r.Error }
+
+type getTransactionStatusResponse struct {
+	Result *TransactionStatus `json:"result"`
+	Error  *rpcError          `json:"error"`
+}
+
+func (r *getTransactionStatusResponse) rpcError() *rpcError { return r.Error }
+
+// NewNearSDK creates a new NearSDK.
+func NewNearSDK(url string, rl ratelimit.Limiter, metrics metrics.Metrics) *NearSDK {
+	return &NearSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		metrics: metrics,
+	}
+}
+
+// GetLatestBlock returns the height of the latest final block.
+func (s *NearSDK) GetLatestBlock(ctx context.Context) (uint64, error) {
+	var result getBlockResponse
+	if err := s.call(ctx, "get-latest-block", &result, "block", map[string]string{"finality": "final"}); err != nil {
+		return 0, err
+	}
+	if result.Error != nil {
+		return 0, result.Error.err()
+	}
+	if result.Result == nil {
+		return 0, fmt.Errorf("empty response")
+	}
+	return result.Result.Header.Height, nil
+}
+
+// GetBlock returns a block and the headers of its chunks.
+func (s *NearSDK) GetBlock(ctx context.Context, height uint64) (*Block, error) {
+	var result getBlockResponse
+	if err := s.call(ctx, "get-block", &result, "block", map[string]uint64{"block_id": height}); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error.err()
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
+// GetChunk returns the transactions of a chunk.
+func (s *NearSDK) GetChunk(ctx context.Context, chunkHash string) (*Chunk, error) {
+	var result getChunkResponse
+	if err := s.call(ctx, "get-chunk", &result, "chunk", map[string]string{"chunk_id": chunkHash}); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error.err()
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
+// GetTransactionStatus returns the execution status of a transaction.
+func (s *NearSDK) GetTransactionStatus(ctx context.Context, txHash, signerID string) (*TransactionStatus, error) {
+	var result getTransactionStatusResponse
+	if err := s.call(ctx, "get-transaction-status", &result, "tx", []string{txHash, signerID}); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error.err()
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
+func (s *NearSDK) call(ctx context.Context, metricName string, result rpcResponse, method string, params any) error {
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(nearRequest{Jsonrpc: "2.0", Method: method, Params: params, ID: "dontcare"}).
+		SetResult(result).
+		SetError(result).
+		Post("")
+
+	if err != nil {
+		return err
+	}
+
+	s.metrics.IncRpcRequest(clientName, metricName, resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return ErrTooManyRequests
+		}
+		// the caller handles the JSON-RPC error.
+		if result.rpcError() != nil {
+			return nil
+		}
+		return fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package sui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+)
+
+var ErrTooManyRequests = fmt.Errorf("too many requests")
+
+const clientName = "sui"
+
+// MaxTransactionsPerRequest is the maximum number of transactions of a sui_multiGetTransactionBlocks request.
+const MaxTransactionsPerRequest = 50
+
+// SuiSDK is a client for the Sui full node JSON-RPC API.
+type SuiSDK struct {
+	client  *resty.Client
+	rl      ratelimit.Limiter
+	metrics metrics.Metrics
+}
+
+// Checkpoint is a Sui checkpoint, the unit of the watcher blocks.
+type Checkpoint struct {
+	SequenceNumber string   `json:"sequenceNumber"`
+	Digest         string   `json:"digest"`
+	TimestampMs    string   `json:"timestampMs"`
+	Transactions   []string `json:"transactions"`
+}
+
+// ProgrammableTransaction is the transaction kind used by the wallets to call Move functions.
+type ProgrammableTransaction struct {
+	Kind         string                       `json:"kind"`
+	Inputs       []TransactionInput           `json:"inputs"`
+	Transactions []map[string]json.RawMessage `json:"transactions"`
+}
+
+// TransactionInput is an input of a programmable transaction. Pure inputs have a value, object inputs an object ID.
+type TransactionInput struct {
+	Type      string          `json:"type"`
+	ValueType string          `json:"valueType"`
+	Value     json.RawMessage `json:"value"`
+	ObjectID  string          `json:"objectId"`
+}
+
+// MoveCall is a call to a Move function of a programmable transaction.
+type MoveCall struct {
+	Package  string `json:"package"`
+	Module   string `json:"module"`
+	Function string `json:"function"`
+}
+
+type TransactionBlock struct {
+	Digest      string `json:"digest"`
+	Transaction struct {
+		Data struct {
+			Sender      string                  `json:"sender"`
+			Transaction ProgrammableTransaction `json:"transaction"`
+		} `json:"data"`
+	} `json:"transaction"`
+	Effects struct {
+		Status struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"status"`
+	} `json:"effects"`
+	TimestampMs string `json:"timestampMs"`
+	Checkpoint  string `json:"checkpoint"`
+}
+
+// MoveCalls returns the Move functions called by the transaction.
+func (t *TransactionBlock) MoveCalls() []MoveCall {
+	var calls []MoveCall
+	for _, command := range t.Transaction.Data.Transaction.Transactions {
+		raw, ok := command["MoveCall"]
+		if !ok {
+			continue
+		}
+		var call MoveCall
+		if err := json.Unmarshal(raw, &call); err != nil {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// IsSuccess returns true when the transaction was executed successfully.
+func (t *TransactionBlock) IsSuccess() bool {
+	return t.Effects.Status.Status == "success"
+}
+
+type suiRequest struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+	ID      int    `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type getLatestCheckpointResponse struct {
+	Result string    `json:"result"`
+	Error  *rpcError `json:"error"`
+}
+
+type getCheckpointResponse struct {
+	Result *Checkpoint `json:"result"`
+	Error  *rpcError   `json:"error"`
+}
+
+type multiGetTransactionsResponse struct {
+	Result []TransactionBlock `json:"result"`
+	Error  *rpcError          `json:"error"`
+}
+
+// NewSuiSDK creates a new SuiSDK.
+func NewSuiSDK(url string, rl ratelimit.Limiter, metrics metrics.Metrics) *SuiSDK {
+	return &SuiSDK{
+		rl:      rl,
+		client:  resty.New().SetBaseURL(url),
+		metrics: metrics,
+	}
+}
+
+// GetLatestCheckpoint returns the sequence number of the latest executed checkpoint.
+func (s *SuiSDK) GetLatestCheckpoint(ctx context.Context) (uint64, error) {
+	var result getLatestCheckpointResponse
+	if err := s.call(ctx, "get-latest-checkpoint", &result, "sui_getLatestCheckpointSequenceNumber"); err != nil {
+		return 0, err
+	}
+	if result.Error != nil {
+		return 0, fmt.Errorf("rpc error %d: %s", result.Error.Code, result.Error.Message)
+	}
+	return strconv.ParseUint(result.Result, 10, 64)
+}
+
+// GetCheckpoint returns a checkpoint with the digests of its transactions.
+func (s *SuiSDK) GetCheckpoint(ctx context.Context, checkpoint uint64) (*Checkpoint, error) {
+	var result getCheckpointResponse
+	if err := s.call(ctx, "get-checkpoint", &result, "sui_getCheckpoint", strconv.FormatUint(checkpoint, 10)); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("rpc error %d: %s", result.Error.Code, result.Error.Message)
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("empty response")
+	}
+	return result.Result, nil
+}
+
+// GetTransactions returns the transactions with their input and effects.
+// At most MaxTransactionsPerRequest digests are accepted.
+func (s *SuiSDK) GetTransactions(ctx context.Context, digests []string) ([]TransactionBlock, error) {
+	options := map[string]bool{"showInput": true, "showEffects": true}
+	var result multiGetTransactionsResponse
+	if err := s.call(ctx, "get-transactions", &result, "sui_multiGetTransactionBlocks", digests, options); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("rpc error %d: %s", result.Error.Code, result.Error.Message)
+	}
+	return result.Result, nil
+}
+
+func (s *SuiSDK) call(ctx context.Context, metricName string, result any, method string, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	s.rl.Take()
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetBody(suiRequest{Jsonrpc: "2.0", Method: method, Params: params, ID: 1}).
+		SetResult(result).
+		Post("")
+
+	if err != nil {
+		return err
+	}
+
+	s.metrics.IncRpcRequest(clientName, metricName, resp.StatusCode())
+
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusTooManyRequests {
+			return ErrTooManyRequests
+		}
+		return fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+	return nil
+}
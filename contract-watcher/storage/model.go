@@ -3,6 +3,7 @@ package storage
 import (
 	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -42,8 +43,41 @@ func (t *TransactionUpdate) ToMap() map[string]string {
 	}
 }
 
+// RelayResultLog is the decoded Delivery event of a generic relayer delivery.
+type RelayResultLog struct {
+	TransactionHash   string `bson:"transactionHash"`
+	VaaHash           string `bson:"vaaHash"`
+	Status            string `bson:"status"`
+	RefundStatus      string `bson:"refundStatus"`
+	GasUsed           string `bson:"gasUsed"`
+	SourceChain       string `bson:"sourceChain"`
+	SourceVaaSequence string `bson:"sourceVaaSequence"`
+}
+
+// RelayDelivery is the result of a generic relayer delivery on the target chain.
+type RelayDelivery struct {
+	// ID is the ID of the delivery VAA.
+	ID        string
+	ChainID   vaa.ChainID
+	Status    domain.RelayStatus
+	TxHash    string
+	GasUsed   int64
+	ResultLog RelayResultLog
+	Timestamp *time.Time
+	UpdatedAt time.Time
+}
+
 type WatcherBlock struct {
 	ID          string    `bson:"_id"`
 	BlockNumber int64     `bson:"blockNumber"`
 	UpdatedAt   time.Time `bson:"updatedAt"`
 }
+
+// BlockHash is the hash of a processed block, used to detect chain reorganizations.
+type BlockHash struct {
+	ID          string    `bson:"_id"`
+	Blockchain  string    `bson:"blockchain"`
+	BlockNumber int64     `bson:"blockNumber"`
+	Hash        string    `bson:"hash"`
+	UpdatedAt   time.Time `bson:"updatedAt"`
+}
@@ -3,9 +3,13 @@ package storage
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	cwAlert "github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -24,23 +28,35 @@ type Repository struct {
 	log         *zap.Logger
 	metrics     metrics.Metrics
 	alerts      alert.AlertClient
+	lineage     *lineage.Recorder
 	collections struct {
 		watcherBlock       *mongo.Collection
 		globalTransactions *mongo.Collection
+		blockHashes        *mongo.Collection
+		relays             *mongo.Collection
 	}
 }
 
 // NewRepository create a new respository instance.
-func NewRepository(db *mongo.Database, metrics metrics.Metrics, alerts alert.AlertClient, log *zap.Logger) *Repository {
-	return &Repository{db, log, metrics, alerts, struct {
+func NewRepository(db *mongo.Database, metrics metrics.Metrics, alerts alert.AlertClient, lineage *lineage.Recorder, log *zap.Logger) *Repository {
+	return &Repository{db, log, metrics, alerts, lineage, struct {
 		watcherBlock       *mongo.Collection
 		globalTransactions *mongo.Collection
+		blockHashes        *mongo.Collection
+		relays             *mongo.Collection
 	}{
 		watcherBlock:       db.Collection("watcherBlock"),
 		globalTransactions: db.Collection("globalTransactions"),
+		blockHashes:        db.Collection("watcherBlockHashes"),
+		relays:             db.Collection("relays"),
 	}}
 }
 
+// Lineage returns the recorder of the processing of the VAAs by the contract watcher.
+func (s *Repository) Lineage() *lineage.Recorder {
+	return s.lineage
+}
+
 func indexedAt(t time.Time) IndexingTimestamps {
 	return IndexingTimestamps{
 		IndexedAt: t,
@@ -71,6 +87,33 @@ func (s *Repository) UpsertGlobalTransaction(ctx context.Context, chainID sdk.Ch
 
 }
 
+// UpsertRelayDelivery saves the result of a generic relayer delivery in the relay of the delivery VAA.
+func (s *Repository) UpsertRelayDelivery(ctx context.Context, delivery RelayDelivery) error {
+	set := bson.M{
+		"data.status":                            delivery.Status,
+		"data.toTxHash":                          delivery.TxHash,
+		"data.metadata.deliveryRecord.resultLog": delivery.ResultLog,
+		"data.metadata.deliveryRecord.gasUsed":   delivery.GasUsed,
+		"data.metadata.deliveryRecord.chainId":   delivery.ChainID,
+		"updatedAt":                              delivery.UpdatedAt,
+	}
+	if delivery.Status == domain.RelayStatusDelivered || delivery.Status == domain.RelayStatusForwardRequested {
+		set["data.completedAt"] = delivery.Timestamp
+	} else {
+		set["data.failedAt"] = delivery.Timestamp
+	}
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": bson.M{"origin": domain.RelayOrigin},
+	}
+
+	_, err := s.collections.relays.UpdateByID(ctx, delivery.ID, update, options.Update().SetUpsert(true))
+	if err != nil {
+		s.log.Error("Error saving relay delivery", zap.String("id", delivery.ID), zap.Error(err))
+	}
+	return err
+}
+
 func (s *Repository) GetGlobalTransactionByID(ctx context.Context, id string) (TransactionUpdate, error) {
 	var tx TransactionUpdate
 	err := s.collections.globalTransactions.FindOne(ctx, bson.M{"_id": id}).Decode(&tx)
@@ -108,3 +151,88 @@ func (s *Repository) GetCurrentBlock(ctx context.Context, blockchain string, def
 	}
 	return block.BlockNumber, nil
 }
+
+// SaveBlockHash stores the hash of a processed block.
+func (s *Repository) SaveBlockHash(ctx context.Context, blockchain string, block uint64, hash string) error {
+	blockHash := BlockHash{
+		ID:          fmt.Sprintf("%s-%d", blockchain, block),
+		Blockchain:  blockchain,
+		BlockNumber: int64(block),
+		Hash:        hash,
+		UpdatedAt:   time.Now(),
+	}
+	update := bson.M{"$set": blockHash}
+	_, err := s.collections.blockHashes.UpdateByID(ctx, blockHash.ID, update, options.Update().SetUpsert(true))
+	if err != nil {
+		s.log.Error("Error inserting block hash", zap.Error(err))
+	}
+	return err
+}
+
+// GetBlockHashes returns the stored block hashes between fromBlock and toBlock, from the newest to the oldest.
+func (s *Repository) GetBlockHashes(ctx context.Context, blockchain string, fromBlock, toBlock uint64) ([]BlockHash, error) {
+	filter := bson.D{
+		{Key: "blockchain", Value: blockchain},
+		{Key: "blockNumber", Value: bson.D{
+			{Key: "$gte", Value: int64(fromBlock)},
+			{Key: "$lte", Value: int64(toBlock)},
+		}},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "blockNumber", Value: -1}})
+	cur, err := s.collections.blockHashes.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var result []BlockHash
+	if err := cur.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteBlockHashes removes the stored block hashes from fromBlock onwards.
+func (s *Repository) DeleteBlockHashes(ctx context.Context, blockchain string, fromBlock uint64) error {
+	filter := bson.D{
+		{Key: "blockchain", Value: blockchain},
+		{Key: "blockNumber", Value: bson.D{{Key: "$gte", Value: int64(fromBlock)}}},
+	}
+	_, err := s.collections.blockHashes.DeleteMany(ctx, filter)
+	return err
+}
+
+// PruneBlockHashes removes the stored block hashes older than beforeBlock.
+func (s *Repository) PruneBlockHashes(ctx context.Context, blockchain string, beforeBlock uint64) error {
+	filter := bson.D{
+		{Key: "blockchain", Value: blockchain},
+		{Key: "blockNumber", Value: bson.D{{Key: "$lt", Value: int64(beforeBlock)}}},
+	}
+	_, err := s.collections.blockHashes.DeleteMany(ctx, filter)
+	return err
+}
+
+// MarkDestinationTxsReorged sets the reorged status to the destination transactions of the chain
+// included between fromBlock and toBlock. It returns the number of updated transactions.
+func (s *Repository) MarkDestinationTxsReorged(ctx context.Context, chainID sdk.ChainID, fromBlock, toBlock uint64) (int64, error) {
+	// the block number of the destination transactions is stored as a decimal string.
+	blocks := make([]string, 0, toBlock-fromBlock+1)
+	for block := fromBlock; block <= toBlock; block++ {
+		blocks = append(blocks, strconv.FormatUint(block, 10))
+	}
+	filter := bson.D{
+		{Key: "destinationTx.chainId", Value: chainID},
+		{Key: "destinationTx.blockNumber", Value: bson.D{{Key: "$in", Value: blocks}}},
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "destinationTx.status", Value: domain.DstTxStatusReorged},
+			{Key: "destinationTx.updatedAt", Value: time.Now()},
+		}},
+		{Key: "$inc", Value: bson.D{{Key: "revision", Value: 1}}},
+	}
+	result, err := s.collections.globalTransactions.UpdateMany(ctx, filter, update)
+	if err != nil {
+		s.log.Error("Error marking reorged destination transactions", zap.Error(err))
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,268 @@
+package watcher
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/algorand"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const algorandMaxRetries = 10
+const algorandRetryDelay = 5 * time.Second
+
+// AlgorandParams are the params for the algorand watcher.
+type AlgorandParams struct {
+	Blockchain   string
+	SizeBlocks   uint8
+	WaitSeconds  uint16
+	InitialBlock int64
+	// ConfirmationDepth is the number of rounds the watcher lags behind the latest round.
+	ConfirmationDepth uint64
+	// MethodsByAddress are the watched methods (first application argument) by application ID.
+	MethodsByAddress map[string][]config.BlockchainMethod
+}
+
+// AlgorandWatcher is a watcher for the Algorand chain. The watcher blocks are the rounds.
+//
+// The redeems are the calls to the watched applications whose first argument is one of the watched methods,
+// e.g. completeVAA for the token bridge. The VAA is the second argument of the call.
+type AlgorandWatcher struct {
+	client        *algorand.AlgorandSDK
+	chainID       vaa.ChainID
+	blockchain    string
+	methodsByApp  map[uint64]map[string]string
+	sizeBlocks    uint8
+	waitSeconds   uint16
+	initialBlock  int64
+	confirmations uint64
+	repository    *storage.Repository
+	logger        *zap.Logger
+	close         chan bool
+	wg            sync.WaitGroup
+	metrics       metrics.Metrics
+}
+
+// NewAlgorandWatcher creates a new algorand watcher.
+func NewAlgorandWatcher(client *algorand.AlgorandSDK, params AlgorandParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *AlgorandWatcher {
+	chainID := vaa.ChainIDAlgorand
+	logger = logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(chainID)))
+	methodsByApp := make(map[uint64]map[string]string)
+	for address, methods := range params.MethodsByAddress {
+		appID, err := strconv.ParseUint(address, 10, 64)
+		if err != nil {
+			logger.Error("invalid application id", zap.String("address", address), zap.Error(err))
+			continue
+		}
+		methodsByApp[appID] = make(map[string]string, len(methods))
+		for _, method := range methods {
+			methodsByApp[appID][method.ID] = method.Name
+		}
+	}
+	return &AlgorandWatcher{
+		client:        client,
+		chainID:       chainID,
+		blockchain:    params.Blockchain,
+		methodsByApp:  methodsByApp,
+		sizeBlocks:    params.SizeBlocks,
+		waitSeconds:   params.WaitSeconds,
+		initialBlock:  params.InitialBlock,
+		confirmations: params.ConfirmationDepth,
+		repository:    repo,
+		metrics:       metrics,
+		logger:        logger,
+	}
+}
+
+// Start starts the algorand watcher.
+func (w *AlgorandWatcher) Start(ctx context.Context) error {
+	// get the current round for the chain.
+	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current block", zap.Error(err))
+		return err
+	}
+	currentBlock := uint64(cBlock)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest round indexed.
+			latestBlock, err := w.client.GetLatestRound(ctx)
+			if err != nil {
+				w.logger.Error("cannot get latest round", zap.Error(err))
+			}
+			// only process the rounds with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
+			maxBlocks := uint64(w.sizeBlocks)
+			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
+			if currentBlock < lastBlock {
+				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				totalBlocks := getTotalBlocks(lastBlock, currentBlock, maxBlocks)
+				failed := false
+				for i := uint64(0); i < totalBlocks; i++ {
+					fromBlock, toBlock := getPage(currentBlock, i, maxBlocks, lastBlock)
+					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+					if failedBlock, err := w.processBlocks(ctx, fromBlock, toBlock, true); err != nil {
+						// resume from the failed round so its transactions are not lost.
+						currentBlock = failedBlock
+						failed = true
+						break
+					}
+					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+				}
+				if failed {
+					continue
+				}
+			} else {
+				w.logger.Debug("waiting for new blocks")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+			if lastBlock > currentBlock {
+				currentBlock = lastBlock
+			}
+		}
+	}
+}
+
+// Backfill processes the rounds between fromBlock and toBlock.
+func (w *AlgorandWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
+	for i := uint64(0); i < totalBlocks; i++ {
+		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
+		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		if failedBlock, err := w.processBlocks(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			w.logger.Error("cannot backfill blocks", zap.Uint64("block", failedBlock), zap.Error(err))
+			return
+		}
+		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+	}
+}
+
+// Close closes the algorand watcher.
+func (w *AlgorandWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
+
+// processBlocks searches the calls to the watched applications between two rounds.
+// It returns fromBlock when the search fails, so the caller can retry the whole range.
+func (w *AlgorandWatcher) processBlocks(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) (uint64, error) {
+	err := retry.Do(
+		func() error {
+			for appID := range w.methodsByApp {
+				var nextToken string
+				for {
+					page, err := w.client.GetApplicationTransactions(ctx, appID, fromBlock, toBlock, nextToken)
+					if err != nil {
+						w.logger.Error("cannot get application transactions", zap.Uint64("applicationId", appID),
+							zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+						return err
+					}
+					for _, tx := range page.Transactions {
+						for _, globalTx := range w.getTransactionUpdates(tx) {
+							log := w.logger.With(zap.String("txHash", tx.ID), zap.Uint64("block", tx.ConfirmedRound))
+							updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+						}
+					}
+					if page.NextToken == "" || len(page.Transactions) == 0 {
+						break
+					}
+					nextToken = page.NextToken
+				}
+			}
+
+			if updateWatcherBlock {
+				// update the last round processed in the database.
+				watcherBlock := storage.WatcherBlock{
+					ID:          w.blockchain,
+					BlockNumber: int64(toBlock),
+					UpdatedAt:   time.Now(),
+				}
+				return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+			}
+			return nil
+		},
+		retry.Attempts(algorandMaxRetries),
+		retry.Delay(algorandRetryDelay),
+	)
+	if err != nil {
+		w.logger.Error("cannot process rounds", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+		return fromBlock, err
+	}
+	return toBlock, nil
+}
+
+// getTransactionUpdates returns the destination transactions of the VAAs redeemed by a transaction group,
+// including the redeems made from inner transactions.
+//
+// The indexer only returns the committed transactions, which were executed successfully.
+func (w *AlgorandWatcher) getTransactionUpdates(tx algorand.Transaction) []storage.TransactionUpdate {
+	var timestamp *time.Time
+	if tx.RoundTime > 0 {
+		t := time.Unix(tx.RoundTime, 0)
+		timestamp = &t
+	}
+
+	var result []storage.TransactionUpdate
+	var visit func(call algorand.Transaction)
+	visit = func(call algorand.Transaction) {
+		for _, inner := range call.InnerTxns {
+			visit(inner)
+		}
+		app := call.ApplicationTransaction
+		if app == nil || len(app.ApplicationArgs) < 2 {
+			return
+		}
+		method, ok := w.methodsByApp[app.ApplicationID][string(app.ApplicationArgs[0])]
+		if !ok {
+			return
+		}
+		v, err := vaa.Unmarshal(app.ApplicationArgs[1])
+		if err != nil {
+			w.logger.Warn("cannot get VAA from transaction", zap.String("txHash", tx.ID), zap.String("method", method), zap.Error(err))
+			return
+		}
+		if isGovernanceVaa(v) {
+			return
+		}
+		updatedAt := time.Now()
+		result = append(result, storage.TransactionUpdate{
+			ID: v.MessageID(),
+			Destination: storage.DestinationTx{
+				ChainID:     w.chainID,
+				Status:      domain.DstTxStatusConfirmed,
+				Method:      method,
+				TxHash:      tx.ID,
+				From:        call.Sender,
+				To:          strconv.FormatUint(app.ApplicationID, 10),
+				BlockNumber: strconv.FormatUint(tx.ConfirmedRound, 10),
+				Timestamp:   timestamp,
+				UpdatedAt:   &updatedAt,
+			},
+		})
+	}
+	visit(tx)
+	return result
+}
@@ -0,0 +1,78 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/algorand"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+func TestAlgorandWatcher_getTransactionUpdates(t *testing.T) {
+	server := newTestServer(t, "ALGORAND_RECORD_URL", func(r *http.Request, _ map[string]json.RawMessage) string {
+		switch r.URL.Path {
+		case "/health":
+			return "algorand/health.json"
+		case "/v2/transactions":
+			if r.URL.Query().Get("next") != "" {
+				return "algorand/transactions_last_page.json"
+			}
+			return "algorand/transactions.json"
+		}
+		return ""
+	})
+	client := algorand.NewAlgorandSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+	params := AlgorandParams{
+		Blockchain: "algorand",
+		MethodsByAddress: map[string][]config.BlockchainMethod{
+			"842126029": {{ID: "completeVAA", Name: "completeVAA"}},
+			"invalid":   {{ID: "completeVAA", Name: "completeVAA"}},
+		},
+	}
+	w := NewAlgorandWatcher(client, params, nil, metrics.NewNoopMetrics(), zap.NewNop())
+	assert.Len(t, w.methodsByApp, 1)
+	ctx := context.Background()
+
+	round, err := client.GetLatestRound(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(29000500), round)
+
+	page, err := client.GetApplicationTransactions(ctx, 842126029, 29000000, 29000200, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "jLPxAQAAAAAAAAAA", page.NextToken)
+	assert.Len(t, page.Transactions, 4)
+
+	// redeem of a token transfer.
+	updates := w.getTransactionUpdates(page.Transactions[0])
+	assert.Len(t, updates, 1)
+	assert.Equal(t, testTransferVaaID, updates[0].ID)
+	assert.Equal(t, domain.DstTxStatusConfirmed, updates[0].Destination.Status)
+	assert.Equal(t, "completeVAA", updates[0].Destination.Method)
+	assert.Equal(t, "LJ6EAHX4C6JKJVYLR4B2UBDHCHBL3XLMBRFMBYCYXRUDDXJ35YUA", updates[0].Destination.TxHash)
+	assert.Equal(t, "842126029", updates[0].Destination.To)
+	assert.Equal(t, "29000100", updates[0].Destination.BlockNumber)
+
+	// governance VAAs are not tracked.
+	assert.Empty(t, w.getTransactionUpdates(page.Transactions[1]))
+
+	// redeem from an inner transaction.
+	updates = w.getTransactionUpdates(page.Transactions[2])
+	assert.Len(t, updates, 1)
+	assert.Equal(t, testTransferWithPayloadVaaID, updates[0].ID)
+	assert.Equal(t, "QDK4ZJ4EUWJ6NS6VVJS6LYVMFP3ZEPG5CDGB5Q7MK7ULKLQNXT5A", updates[0].Destination.TxHash)
+	assert.Equal(t, "M7UT7JWIVROIDGMQVJZUBQGBNNIIVOYRPC7JWMGQES4KYJIZHVCRZEGFRQ", updates[0].Destination.From)
+
+	// call to another method of the application.
+	assert.Empty(t, w.getTransactionUpdates(page.Transactions[3]))
+
+	page, err = client.GetApplicationTransactions(ctx, 842126029, 29000000, 29000200, page.NextToken)
+	assert.NoError(t, err)
+	assert.Empty(t, page.Transactions)
+}
@@ -29,6 +29,8 @@ type AptosParams struct {
 	SizeBlocks      uint8
 	WaitSeconds     uint16
 	InitialBlock    int64
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block.
+	ConfirmationDepth uint64
 }
 
 type AptosWatcher struct {
@@ -39,6 +41,7 @@ type AptosWatcher struct {
 	sizeBlocks      uint8
 	waitSeconds     uint16
 	initialBlock    int64
+	confirmations   uint64
 	repository      *storage.Repository
 	logger          *zap.Logger
 	close           chan bool
@@ -56,6 +59,7 @@ func NewAptosWatcher(client *aptos.AptosSDK, params AptosParams, repo *storage.R
 		sizeBlocks:      params.SizeBlocks,
 		waitSeconds:     params.WaitSeconds,
 		initialBlock:    params.InitialBlock,
+		confirmations:   params.ConfirmationDepth,
 		repository:      repo,
 		metrics:         metrics,
 		logger:          logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(chainID))),
@@ -83,10 +87,12 @@ func (w *AptosWatcher) Start(ctx context.Context) error {
 			return nil
 		default:
 			// get the latest block for the chain.
-			lastBlock, err := w.client.GetLatestBlock(ctx)
+			latestBlock, err := w.client.GetLatestBlock(ctx)
 			if err != nil {
 				w.logger.Error("cannot get latest block", zap.Error(err))
 			}
+			// only process the blocks with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
 			maxBlocks := uint64(w.sizeBlocks)
 			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
 			if currentBlock < lastBlock {
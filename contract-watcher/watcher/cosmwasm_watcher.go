@@ -0,0 +1,290 @@
+package watcher
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/cosmos"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const cosmwasmMaxRetries = 10
+const cosmwasmRetryDelay = 5 * time.Second
+
+// cosmwasmDefaultVaaArgument is the field of the execute messages that contains the base64 encoded VAA,
+// e.g. {"submit_vaa": {"data": "..."}} for the token bridge.
+const cosmwasmDefaultVaaArgument = "data"
+
+// CosmwasmParams are the params for the cosmwasm watcher.
+type CosmwasmParams struct {
+	ChainID      vaa.ChainID
+	Blockchain   string
+	SizeBlocks   uint8
+	WaitSeconds  uint16
+	InitialBlock int64
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block.
+	ConfirmationDepth uint64
+	// MethodsByAddress are the watched execute messages by contract address.
+	MethodsByAddress map[string][]config.BlockchainMethod
+}
+
+// CosmwasmWatcher is a watcher for the Cosmos-SDK chains with CosmWasm contracts (Injective, Xpla, Sei and
+// Wormchain).
+//
+// The redeems are the executions of the watched contracts with one of the watched messages. The VAA is base64
+// encoded in a field of the message.
+type CosmwasmWatcher struct {
+	client           *cosmos.CosmosSDK
+	chainID          vaa.ChainID
+	blockchain       string
+	methodsByAddress map[string][]config.BlockchainMethod
+	sizeBlocks       uint8
+	waitSeconds      uint16
+	initialBlock     int64
+	confirmations    uint64
+	repository       *storage.Repository
+	logger           *zap.Logger
+	close            chan bool
+	wg               sync.WaitGroup
+	metrics          metrics.Metrics
+}
+
+// NewCosmwasmWatcher creates a new cosmwasm watcher.
+func NewCosmwasmWatcher(client *cosmos.CosmosSDK, params CosmwasmParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *CosmwasmWatcher {
+	return &CosmwasmWatcher{
+		client:           client,
+		chainID:          params.ChainID,
+		blockchain:       params.Blockchain,
+		methodsByAddress: params.MethodsByAddress,
+		sizeBlocks:       params.SizeBlocks,
+		waitSeconds:      params.WaitSeconds,
+		initialBlock:     params.InitialBlock,
+		confirmations:    params.ConfirmationDepth,
+		repository:       repo,
+		metrics:          metrics,
+		logger:           logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
+	}
+}
+
+// Start starts the cosmwasm watcher.
+func (w *CosmwasmWatcher) Start(ctx context.Context) error {
+	// get the current block for the chain.
+	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current block", zap.Error(err))
+		return err
+	}
+	currentBlock := uint64(cBlock)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest block for the chain.
+			latestBlock, err := w.client.GetLatestBlock(ctx)
+			if err != nil {
+				w.logger.Error("cannot get latest block", zap.Error(err))
+			}
+			// only process the blocks with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
+			maxBlocks := uint64(w.sizeBlocks)
+			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
+			if currentBlock < lastBlock {
+				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				totalBlocks := getTotalBlocks(lastBlock, currentBlock, maxBlocks)
+				failed := false
+				for i := uint64(0); i < totalBlocks; i++ {
+					fromBlock, toBlock := getPage(currentBlock, i, maxBlocks, lastBlock)
+					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+					if failedBlock, err := w.processBlocks(ctx, fromBlock, toBlock, true); err != nil {
+						// resume from the failed block so its transactions are not lost.
+						currentBlock = failedBlock
+						failed = true
+						break
+					}
+					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+				}
+				if failed {
+					continue
+				}
+			} else {
+				w.logger.Debug("waiting for new blocks")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+			if lastBlock > currentBlock {
+				currentBlock = lastBlock
+			}
+		}
+	}
+}
+
+// Backfill processes the blocks between fromBlock and toBlock.
+func (w *CosmwasmWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
+	for i := uint64(0); i < totalBlocks; i++ {
+		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
+		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		if failedBlock, err := w.processBlocks(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			w.logger.Error("cannot backfill blocks", zap.Uint64("block", failedBlock), zap.Error(err))
+			return
+		}
+		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+	}
+}
+
+// Close closes the cosmwasm watcher.
+func (w *CosmwasmWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
+
+// processBlocks searches the executions of the watched contracts between two blocks.
+// It returns fromBlock when the search fails, so the caller can retry the whole range.
+func (w *CosmwasmWatcher) processBlocks(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) (uint64, error) {
+	err := retry.Do(
+		func() error {
+			for contract := range w.methodsByAddress {
+				for offset := 0; ; offset += cosmos.PageSize {
+					txs, err := w.client.GetContractTransactions(ctx, contract, fromBlock, toBlock, offset)
+					if err != nil {
+						w.logger.Error("cannot get contract transactions", zap.String("contract", contract),
+							zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+						return err
+					}
+					for _, tx := range txs {
+						for _, globalTx := range w.getTransactionUpdates(tx) {
+							log := w.logger.With(zap.String("txHash", tx.TxHash), zap.String("block", tx.Height))
+							updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+						}
+					}
+					if len(txs) < cosmos.PageSize {
+						break
+					}
+				}
+			}
+
+			if updateWatcherBlock {
+				// update the last block number processed in the database.
+				watcherBlock := storage.WatcherBlock{
+					ID:          w.blockchain,
+					BlockNumber: int64(toBlock),
+					UpdatedAt:   time.Now(),
+				}
+				return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+			}
+			return nil
+		},
+		retry.Attempts(cosmwasmMaxRetries),
+		retry.Delay(cosmwasmRetryDelay),
+	)
+	if err != nil {
+		w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+		return fromBlock, err
+	}
+	return toBlock, nil
+}
+
+// getTransactionUpdates returns the destination transactions of the VAAs submitted by the messages of a
+// transaction to the watched contracts.
+func (w *CosmwasmWatcher) getTransactionUpdates(tx cosmos.TxResponse) []storage.TransactionUpdate {
+	status := domain.DstTxStatusConfirmed
+	if tx.Code != 0 {
+		status = domain.DstTxStatusFailedToProcess
+	}
+
+	var result []storage.TransactionUpdate
+	for _, msg := range tx.Tx.Body.Messages {
+		if msg.Type != cosmos.MsgExecuteContractType {
+			continue
+		}
+		methods, ok := w.methodsByAddress[msg.Contract]
+		if !ok {
+			continue
+		}
+		execute, err := decodeExecuteMsg(msg.Msg)
+		if err != nil {
+			continue
+		}
+		for _, method := range methods {
+			body, ok := execute[method.ID]
+			if !ok {
+				continue
+			}
+			v, err := getCosmwasmVaa(body, method.VaaArgument)
+			if err != nil {
+				w.logger.Warn("cannot get VAA from transaction", zap.String("txHash", tx.TxHash), zap.String("method", method.Name), zap.Error(err))
+				continue
+			}
+			if isGovernanceVaa(v) {
+				continue
+			}
+			updatedAt := time.Now()
+			result = append(result, storage.TransactionUpdate{
+				ID: v.MessageID(),
+				Destination: storage.DestinationTx{
+					ChainID:     w.chainID,
+					Status:      status,
+					Method:      method.Name,
+					TxHash:      tx.TxHash,
+					From:        msg.Sender,
+					To:          msg.Contract,
+					BlockNumber: tx.Height,
+					Timestamp:   tx.Timestamp,
+					UpdatedAt:   &updatedAt,
+				},
+			})
+		}
+	}
+	return result
+}
+
+// decodeExecuteMsg decodes the execute message of a contract, which has a single field with the message name.
+// Some chains (e.g. Injective) encode the message as a JSON string.
+func decodeExecuteMsg(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		raw = json.RawMessage(encoded)
+	}
+	var execute map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &execute); err != nil {
+		return nil, err
+	}
+	return execute, nil
+}
+
+// getCosmwasmVaa returns the base64 encoded VAA of the body of an execute message.
+func getCosmwasmVaa(body []byte, path []string) (*vaa.VAA, error) {
+	if len(path) == 0 {
+		path = []string{cosmwasmDefaultVaaArgument}
+	}
+	value, err := getJSONString(body, path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	return vaa.Unmarshal(data)
+}
@@ -0,0 +1,80 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/cosmos"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+func TestCosmwasmWatcher_getTransactionUpdates(t *testing.T) {
+	server := newTestServer(t, "COSMWASM_RECORD_URL", func(r *http.Request, _ map[string]json.RawMessage) string {
+		switch r.URL.Path {
+		case "/cosmos/base/tendermint/v1beta1/blocks/latest":
+			return "cosmos/latest_block.json"
+		case "/cosmos/tx/v1beta1/txs":
+			return "cosmos/txs.json"
+		}
+		return ""
+	})
+	client := cosmos.NewCosmosSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+	params := CosmwasmParams{
+		ChainID:    vaa.ChainIDInjective,
+		Blockchain: "injective",
+		MethodsByAddress: map[string][]config.BlockchainMethod{
+			"inj1ghd753shjuwexxywmgs4xz7x2q732vcnxxynfn": {
+				{ID: "submit_vaa", Name: "submit_vaa"},
+				{ID: "complete_transfer_with_payload", Name: "complete_transfer_with_payload"},
+			},
+		},
+	}
+	w := NewCosmwasmWatcher(client, params, nil, metrics.NewNoopMetrics(), zap.NewNop())
+	ctx := context.Background()
+
+	height, err := client.GetLatestBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(32000100), height)
+
+	txs, err := client.GetContractTransactions(ctx, "inj1ghd753shjuwexxywmgs4xz7x2q732vcnxxynfn", 32000000, 32000100, 0)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 3)
+
+	// redeem of a token transfer.
+	updates := w.getTransactionUpdates(txs[0])
+	assert.Len(t, updates, 1)
+	assert.Equal(t, testTransferVaaID, updates[0].ID)
+	assert.Equal(t, domain.DstTxStatusConfirmed, updates[0].Destination.Status)
+	assert.Equal(t, "submit_vaa", updates[0].Destination.Method)
+	assert.Equal(t, "32000050", updates[0].Destination.BlockNumber)
+	assert.Equal(t, vaa.ChainIDInjective, updates[0].Destination.ChainID)
+
+	// failed redeem with the message encoded as a JSON string.
+	updates = w.getTransactionUpdates(txs[1])
+	assert.Len(t, updates, 1)
+	assert.Equal(t, testTransferWithPayloadVaaID, updates[0].ID)
+	assert.Equal(t, domain.DstTxStatusFailedToProcess, updates[0].Destination.Status)
+	assert.Equal(t, "complete_transfer_with_payload", updates[0].Destination.Method)
+
+	// governance VAAs are not tracked.
+	assert.Empty(t, w.getTransactionUpdates(txs[2]))
+}
+
+func Test_getCosmwasmVaa_CustomArgument(t *testing.T) {
+	// the translator contracts receive the VAA in the vaa field.
+	body := []byte(`{"vaa":"AQAAAAMAZFOeQAAAAAEAAgAAAAAAAAAAAAAAAD7hiyIUr/lwANl0z2R+fDR+j6WFAAAAAAAAACoBAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"}`)
+	v, err := getCosmwasmVaa(body, []string{"vaa"})
+	assert.NoError(t, err)
+	assert.Equal(t, testTransferVaaID, v.MessageID())
+
+	_, err = getCosmwasmVaa(body, nil)
+	assert.Error(t, err)
+}
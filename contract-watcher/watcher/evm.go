@@ -3,13 +3,18 @@ package watcher
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -28,6 +33,14 @@ type EVMParams struct {
 	WaitSeconds      uint16
 	InitialBlock     int64
 	MethodsByAddress map[string][]config.BlockchainMethod
+	// EventsByAddress are the watched events of the log-based watcher.
+	EventsByAddress map[string][]config.BlockchainEvent
+	// TraceInternalCalls enables the processing of redeems made through other contracts.
+	TraceInternalCalls bool
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block.
+	ConfirmationDepth uint64
+	// ReorgWindow is the number of block hashes remembered to detect reorgs, zero disables the detection.
+	ReorgWindow uint64
 }
 
 type EVMAddressesParams struct {
@@ -87,6 +100,89 @@ func parseInput(input string) (*vaa.VAA, error) {
 	return vaa, nil
 }
 
+// getVaasFromInput decodes the method call and returns the VAAs of the VAA argument.
+//
+// Methods without ABI definition are decoded with parseInput.
+func getVaasFromInput(method config.BlockchainMethod, input string) ([]*vaa.VAA, error) {
+	if method.ABI == nil {
+		v, err := parseInput(input)
+		if err != nil {
+			return nil, err
+		}
+		return []*vaa.VAA{v}, nil
+	}
+
+	data, err := hex.DecodeString(utils.Remove0x(input))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("invalid input length %d", len(data))
+	}
+	values, err := method.ABI.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := getArgument(method.ABI.Inputs, values, method.VaaArgument)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawVaas [][]byte
+	switch v := value.(type) {
+	case []byte:
+		rawVaas = [][]byte{v}
+	case [][]byte:
+		rawVaas = v
+	default:
+		return nil, fmt.Errorf("unexpected type %T for argument %s", value, strings.Join(method.VaaArgument, "."))
+	}
+
+	result := make([]*vaa.VAA, 0, len(rawVaas))
+	for _, raw := range rawVaas {
+		v, err := vaa.Unmarshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// getArgument returns the value of an unpacked argument, following the tuple components of the path.
+func getArgument(inputs abi.Arguments, values []interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty argument path")
+	}
+	var value interface{}
+	found := false
+	for i, input := range inputs {
+		if input.Name == path[0] && i < len(values) {
+			value = values[i]
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("argument %s not found", path[0])
+	}
+
+	// tuples are unpacked as structs with the component names in camel case.
+	for _, field := range path[1:] {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("argument %s is not a tuple", strings.Join(path, "."))
+		}
+		fv := rv.FieldByName(abi.ToCamelCase(field))
+		if !fv.IsValid() {
+			return nil, fmt.Errorf("argument %s not found", strings.Join(path, "."))
+		}
+		value = fv.Interface()
+	}
+	return value, nil
+}
+
 func getBlockNumber(s string, logger *zap.Logger) string {
 	value, err := strconv.ParseInt(utils.Remove0x(s), 16, 64)
 	if err != nil {
@@ -106,6 +202,24 @@ func getTimestamp(s string, logger *zap.Logger) *time.Time {
 	return &tm
 }
 
+// findInternalCalls returns the nested calls of a transaction trace to the watched methods.
+func findInternalCalls(trace evm.CallTrace, methodsByAddress map[string][]config.BlockchainMethod) []evm.CallTrace {
+	var result []evm.CallTrace
+	for _, call := range trace.Calls {
+		// delegate and static calls cannot redeem, delegate calls run the target code in the context of the caller.
+		if call.Type != "DELEGATECALL" && call.Type != "STATICCALL" {
+			for _, method := range methodsByAddress[strings.ToLower(call.To)] {
+				if method.ID == getMethodIDByInput(call.Input) {
+					result = append(result, call)
+					break
+				}
+			}
+		}
+		result = append(result, findInternalCalls(call, methodsByAddress)...)
+	}
+	return result
+}
+
 func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransaction, methodsByAddress map[string][]config.BlockchainMethod, repository *storage.Repository, logger *zap.Logger) {
 	// get methodID from the transaction.
 	txMethod := getMethodIDByInput(tx.Input)
@@ -125,8 +239,8 @@ func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransac
 
 	for _, method := range methods {
 		if method.ID == txMethod {
-			// get vaa from transaction input
-			vaa, err := parseInput(tx.Input)
+			// get the vaas from transaction input
+			vaas, err := getVaasFromInput(method, tx.Input)
 			if err != nil {
 				log.Error("cannot parse VAA", zap.Error(err))
 				return
@@ -140,24 +254,26 @@ func processTransaction(ctx context.Context, chainID vaa.ChainID, tx *EvmTransac
 				return
 			}
 
-			updatedAt := time.Now()
-			globalTx := storage.TransactionUpdate{
-				ID: vaa.MessageID(),
-				Destination: storage.DestinationTx{
-					ChainID:     chainID,
-					Status:      getTxStatus(txStatusCode),
-					Method:      method.Name,
-					TxHash:      utils.Remove0x(tx.Hash),
-					To:          tx.To,
-					From:        tx.From,
-					BlockNumber: getBlockNumber(tx.BlockNumber, log),
-					Timestamp:   getTimestamp(tx.BlockTimestamp, log),
-					UpdatedAt:   &updatedAt,
-				},
-			}
+			for _, vaa := range vaas {
+				updatedAt := time.Now()
+				globalTx := storage.TransactionUpdate{
+					ID: vaa.MessageID(),
+					Destination: storage.DestinationTx{
+						ChainID:     chainID,
+						Status:      getTxStatus(txStatusCode),
+						Method:      method.Name,
+						TxHash:      utils.Remove0x(tx.Hash),
+						To:          tx.To,
+						From:        tx.From,
+						BlockNumber: getBlockNumber(tx.BlockNumber, log),
+						Timestamp:   getTimestamp(tx.BlockTimestamp, log),
+						UpdatedAt:   &updatedAt,
+					},
+				}
 
-			// update global transaction and check if it should be updated.
-			updateGlobalTransaction(ctx, chainID, globalTx, repository, log)
+				// update global transaction and check if it should be updated.
+				updateGlobalTransaction(ctx, chainID, globalTx, repository, log)
+			}
 			break
 		}
 	}
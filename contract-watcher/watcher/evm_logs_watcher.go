@@ -0,0 +1,390 @@
+package watcher
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// EvmLogsWatcher detects the redeems from the events emitted by the watched contracts.
+//
+// Instead of fetching every block, it gets the logs of a range of blocks with a single eth_getLogs request,
+// so the redeems made through other contracts are also detected.
+type EvmLogsWatcher struct {
+	client          *evm.EvmSDK
+	chainID         vaa.ChainID
+	blockchain      string
+	addresses       []string
+	topics          []string
+	eventsByAddress map[string][]config.BlockchainEvent
+	maxBlocks       uint64
+	waitSeconds     uint16
+	initialBlock    int64
+	confirmations   uint64
+	reorg           *reorgHandler
+	repository      *storage.Repository
+	logger          *zap.Logger
+	close           chan bool
+	wg              sync.WaitGroup
+	metrics         metrics.Metrics
+}
+
+func NewEvmLogsWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *EvmLogsWatcher {
+	addresses := make([]string, 0, len(params.EventsByAddress))
+	topics := make([]string, 0, len(params.EventsByAddress))
+	for address, events := range params.EventsByAddress {
+		addresses = append(addresses, address)
+		for _, event := range events {
+			topics = append(topics, event.ID)
+		}
+	}
+	logger = logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID)))
+	getBlockHash := func(ctx context.Context, block uint64) (string, error) {
+		header, err := client.GetBlockHeader(ctx, block)
+		if err != nil {
+			return "", err
+		}
+		return header.Hash, nil
+	}
+	return &EvmLogsWatcher{
+		client:          client,
+		chainID:         params.ChainID,
+		blockchain:      params.Blockchain,
+		addresses:       addresses,
+		topics:          topics,
+		eventsByAddress: params.EventsByAddress,
+		maxBlocks:       uint64(params.SizeBlocks),
+		waitSeconds:     params.WaitSeconds,
+		initialBlock:    params.InitialBlock,
+		confirmations:   params.ConfirmationDepth,
+		reorg:           newReorgHandler(params.ChainID, params.Blockchain, params.ReorgWindow, repo, getBlockHash, metrics, logger),
+		repository:      repo,
+		metrics:         metrics,
+		logger:          logger,
+	}
+}
+
+func (w *EvmLogsWatcher) Start(ctx context.Context) error {
+	// get the current block for the chain.
+	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current block", zap.Error(err))
+		return err
+	}
+	currentBlock := uint64(cBlock)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest block for the chain.
+			latestBlock, err := w.client.GetLatestBlock(ctx)
+			if err != nil {
+				w.logger.Error("cannot get latest block", zap.Error(err))
+			}
+			// only process the blocks with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
+			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
+
+			if currentBlock < lastBlock {
+				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				totalBlocks := getTotalBlocks(lastBlock, currentBlock, w.maxBlocks)
+				for i := uint64(0); i < totalBlocks; i++ {
+					fromBlock, toBlock := getPage(currentBlock, i, w.maxBlocks, lastBlock)
+					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+					w.processBlocks(ctx, fromBlock, toBlock, true)
+					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+				}
+			} else {
+				w.logger.Debug("waiting for new blocks")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+			if lastBlock > currentBlock {
+				currentBlock = lastBlock
+			}
+		}
+	}
+}
+
+func (w *EvmLogsWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
+	for i := uint64(0); i < totalBlocks; i++ {
+		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
+		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		w.processBlocks(ctx, fromBlock, toBlock, persistBlock)
+		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+	}
+}
+
+func (w *EvmLogsWatcher) processBlocks(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) {
+	err := retry.Do(
+		func() error {
+			// check if the range is a descendant of the last processed block, otherwise re-process from the
+			// last block that is still in the canonical chain.
+			var lastHeader *evm.BlockHeader
+			if w.reorg != nil {
+				header, err := w.client.GetBlockHeader(ctx, fromBlock)
+				if err != nil {
+					w.logger.Error("cannot get block", zap.Uint64("block", fromBlock), zap.Error(err))
+					return err
+				}
+				resumeBlock, reorged, err := w.reorg.check(ctx, fromBlock, header.ParentHash)
+				if err != nil {
+					w.logger.Error("cannot check chain reorganization", zap.Uint64("block", fromBlock), zap.Error(err))
+					return err
+				}
+				if reorged {
+					fromBlock = resumeBlock
+				}
+				lastHeader, err = w.client.GetBlockHeader(ctx, toBlock)
+				if err != nil {
+					w.logger.Error("cannot get block", zap.Uint64("block", toBlock), zap.Error(err))
+					return err
+				}
+			}
+
+			logs, err := w.client.GetLogs(ctx, fromBlock, toBlock, w.addresses, [][]string{w.topics})
+			if err != nil {
+				w.logger.Error("cannot get logs", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+				return err
+			}
+
+			// the receipts and the block timestamps are shared by the logs of the same transaction or block.
+			receipts := make(map[string]*evm.TransactionReceiptResult)
+			timestamps := make(map[string]*time.Time)
+
+			for _, l := range logs {
+				// logs removed by a chain reorganization.
+				if l.Removed || len(l.Topics) == 0 {
+					continue
+				}
+				event, ok := findEvent(w.eventsByAddress[strings.ToLower(l.Address)], l.Topics[0])
+				if !ok {
+					continue
+				}
+
+				log := w.logger.With(
+					zap.String("txHash", l.TransactionHash),
+					zap.String("event", event.Name),
+					zap.String("block", l.BlockNumber))
+
+				values, err := decodeLog(event, l)
+				if err != nil {
+					log.Error("cannot decode log", zap.Error(err))
+					continue
+				}
+				vaaID, err := getVaaID(event, values)
+				if err != nil {
+					log.Error("cannot get vaa id from log", zap.Error(err))
+					continue
+				}
+
+				receipt, ok := receipts[l.TransactionHash]
+				if !ok {
+					receipt, err = w.client.GetTransactionReceipt(ctx, l.TransactionHash)
+					if err != nil {
+						log.Error("cannot get transaction receipt", zap.Error(err))
+						return err
+					}
+					receipts[l.TransactionHash] = receipt
+				}
+
+				timestamp, ok := timestamps[l.BlockNumber]
+				if !ok {
+					blockNumber, err := utils.DecodeUint64(l.BlockNumber)
+					if err != nil {
+						log.Error("cannot decode block number", zap.Error(err))
+						continue
+					}
+					header, err := w.client.GetBlockHeader(ctx, blockNumber)
+					if err != nil {
+						log.Error("cannot get block", zap.Error(err))
+						return err
+					}
+					timestamp = getTimestamp(header.Timestamp, log)
+					timestamps[l.BlockNumber] = timestamp
+				}
+
+				var to string
+				if receipt.To != nil {
+					to = *receipt.To
+				}
+				updatedAt := time.Now()
+				globalTx := storage.TransactionUpdate{
+					ID: vaaID,
+					Destination: storage.DestinationTx{
+						ChainID:     w.chainID,
+						Status:      getTxStatus(receipt.Status),
+						Method:      event.Name,
+						TxHash:      utils.Remove0x(l.TransactionHash),
+						To:          to,
+						From:        receipt.From,
+						BlockNumber: getBlockNumber(l.BlockNumber, log),
+						Timestamp:   timestamp,
+						UpdatedAt:   &updatedAt,
+					},
+				}
+
+				// update global transaction and check if it should be updated.
+				updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+
+				// the generic relayer deliveries also store the result of the delivery.
+				if isRelayerDelivery(event) {
+					delivery, err := newRelayDelivery(w.chainID, vaaID, values, l.TransactionHash, timestamp)
+					if err != nil {
+						log.Error("cannot decode relayer delivery", zap.Error(err))
+					} else if err := w.repository.UpsertRelayDelivery(ctx, *delivery); err != nil {
+						return err
+					}
+				}
+
+				if w.reorg != nil {
+					if blockNumber, err := utils.DecodeUint64(l.BlockNumber); err == nil {
+						if err := w.reorg.save(ctx, blockNumber, l.BlockHash); err != nil {
+							log.Error("cannot save block hash", zap.Error(err))
+						}
+					}
+				}
+			}
+
+			// the hash of the last block of the range is used to check the next range.
+			if w.reorg != nil {
+				if err := w.reorg.save(ctx, toBlock, lastHeader.Hash); err != nil {
+					w.logger.Error("cannot save block hash", zap.Uint64("block", toBlock), zap.Error(err))
+				}
+			}
+
+			if updateWatcherBlock {
+				// update the last block number processed in the database.
+				watcherBlock := storage.WatcherBlock{
+					ID:          w.blockchain,
+					BlockNumber: int64(toBlock),
+					UpdatedAt:   time.Now(),
+				}
+				return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+			}
+			return nil
+		},
+		retry.Attempts(evmMaxRetries),
+		retry.Delay(evmRetryDelay),
+	)
+	if err != nil {
+		w.logger.Error("cannot process blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock), zap.Error(err))
+	}
+}
+
+func (w *EvmLogsWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
+
+// findEvent returns the watched event of the log topic.
+func findEvent(events []config.BlockchainEvent, topic string) (config.BlockchainEvent, bool) {
+	for _, event := range events {
+		if strings.EqualFold(event.ID, topic) {
+			return event, true
+		}
+	}
+	return config.BlockchainEvent{}, false
+}
+
+// decodeLog returns the arguments of the event emitted in the log.
+func decodeLog(event config.BlockchainEvent, l evm.Log) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+
+	var indexed abi.Arguments
+	for _, input := range event.ABI.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	topics := make([]common.Hash, 0, len(l.Topics))
+	for _, topic := range l.Topics[1:] {
+		topics = append(topics, common.HexToHash(topic))
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexed, topics); err != nil {
+		return nil, err
+	}
+
+	data, err := hex.DecodeString(utils.Remove0x(l.Data))
+	if err != nil {
+		return nil, err
+	}
+	if err := event.ABI.Inputs.UnpackIntoMap(values, data); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// getVaaID returns the ID of the redeemed VAA from the event arguments.
+func getVaaID(event config.BlockchainEvent, values map[string]interface{}) (string, error) {
+	chainID, err := getUint64Argument(values, event.ChainArgument)
+	if err != nil {
+		return "", err
+	}
+	if chainID > math.MaxUint16 {
+		return "", fmt.Errorf("invalid chain id %d", chainID)
+	}
+	sequence, err := getUint64Argument(values, event.SequenceArgument)
+	if err != nil {
+		return "", err
+	}
+
+	var emitter vaa.Address
+	if event.EmitterAddress != nil {
+		emitter = *event.EmitterAddress
+	} else {
+		switch v := values[event.EmitterArgument].(type) {
+		case [32]byte:
+			emitter = vaa.Address(v)
+		case common.Address:
+			emitter, _ = vaa.BytesToAddress(v.Bytes())
+		default:
+			return "", fmt.Errorf("unexpected type %T for argument %s", v, event.EmitterArgument)
+		}
+	}
+
+	return fmt.Sprintf("%d/%s/%d", vaa.ChainID(chainID), emitter, sequence), nil
+}
+
+func getUint64Argument(values map[string]interface{}, name string) (uint64, error) {
+	switch v := values[name].(type) {
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unexpected type %T for argument %s", v, name)
+	}
+}
@@ -0,0 +1,157 @@
+package watcher
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func newTestEvents(t *testing.T, abiName string, spec config.EventSpec) []config.BlockchainEvent {
+	c := config.ContractSpec{
+		Address: "0x3ee18B2214AFF97000D974cf647E7C347E8fa585",
+		ABI:     abiName,
+		Events:  []config.EventSpec{spec},
+	}
+	w := config.WatcherSpec{Chain: "ethereum", Name: "eth", Contracts: []config.ContractSpec{c}}
+	wb, err := w.ToWatcherBlockchainAddresses()
+	assert.NoError(t, err)
+	return wb.EventsByAddress["0x3ee18b2214aff97000d974cf647e7c347e8fa585"]
+}
+
+func getVaaIDFromLog(event config.BlockchainEvent, l evm.Log) (string, error) {
+	values, err := decodeLog(event, l)
+	if err != nil {
+		return "", err
+	}
+	return getVaaID(event, values)
+}
+
+func Test_getVaaIDFromLog_TransferRedeemed(t *testing.T) {
+	events := newTestEvents(t, "token_bridge", config.EventSpec{Event: "TransferRedeemed"})
+	assert.Len(t, events, 1)
+	assert.Equal(t, "0xcaf280c8cfeba144da67230d9b009c8f868a75bac9a528fa0474be1ba317c169", events[0].ID)
+
+	l := evm.Log{
+		Address: "0x3ee18b2214aff97000d974cf647e7c347e8fa585",
+		Topics: []string{
+			"0xcaf280c8cfeba144da67230d9b009c8f868a75bac9a528fa0474be1ba317c169",
+			"0x0000000000000000000000000000000000000000000000000000000000000001",
+			"0xec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5",
+			"0x0000000000000000000000000000000000000000000000000000000000043924",
+		},
+		Data: "0x",
+	}
+
+	event, ok := findEvent(events, l.Topics[0])
+	assert.True(t, ok)
+
+	vaaID, err := getVaaIDFromLog(event, l)
+	assert.NoError(t, err)
+	assert.Equal(t, "1/ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5/276772", vaaID)
+
+	_, ok = findEvent(events, "0x0000000000000000000000000000000000000000000000000000000000000001")
+	assert.False(t, ok)
+}
+
+func Test_getVaaIDFromLog_Delivery(t *testing.T) {
+	events := newTestEvents(t, "wormhole_relayer", config.EventSpec{
+		Name:           "deliver",
+		Event:          "Delivery",
+		ChainArgument:  "sourceChain",
+		EmitterAddress: "0x27428DD2d3DD32A4D7f7C497eAaa23130d894911",
+	})
+	assert.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "deliver", event.Name)
+
+	data, err := event.ABI.Inputs.NonIndexed().Pack(
+		common.HexToHash("0x01"), uint8(0), big.NewInt(21000), uint8(0), []byte{}, []byte{})
+	assert.NoError(t, err)
+
+	l := evm.Log{
+		Topics: []string{
+			event.ID,
+			"0x0000000000000000000000001111111111111111111111111111111111111111",
+			"0x0000000000000000000000000000000000000000000000000000000000000005",
+			"0x000000000000000000000000000000000000000000000000000000000000000a",
+		},
+		Data: "0x" + hex.EncodeToString(data),
+	}
+
+	vaaID, err := getVaaIDFromLog(event, l)
+	assert.NoError(t, err)
+	assert.Equal(t, "5/00000000000000000000000027428dd2d3dd32a4d7f7c497eaaa23130d894911/10", vaaID)
+}
+
+func Test_getVaaIDFromLog_InvalidLog(t *testing.T) {
+	events := newTestEvents(t, "token_bridge", config.EventSpec{Event: "TransferRedeemed"})
+
+	l := evm.Log{
+		Topics: []string{events[0].ID, "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		Data:   "0x",
+	}
+	_, err := getVaaIDFromLog(events[0], l)
+	assert.Error(t, err)
+}
+
+func Test_newRelayDelivery(t *testing.T) {
+	events := newTestEvents(t, "wormhole_relayer", config.EventSpec{
+		Event:          "Delivery",
+		ChainArgument:  "sourceChain",
+		EmitterAddress: "0x27428DD2d3DD32A4D7f7C497eAaa23130d894911",
+	})
+	event := events[0]
+	assert.True(t, isRelayerDelivery(event))
+
+	tests := []struct {
+		name         string
+		status       uint8
+		refundStatus uint8
+		want         domain.RelayStatus
+	}{
+		{name: "delivered", status: domain.DeliveryStatusSuccess, refundStatus: domain.RefundStatusRefundSent, want: domain.RelayStatusDelivered},
+		{name: "refunded", status: domain.DeliveryStatusReceiverFailure, refundStatus: domain.RefundStatusRefundSent, want: domain.RelayStatusRefunded},
+		{name: "receiver failure", status: domain.DeliveryStatusReceiverFailure, refundStatus: domain.RefundStatusRefundFail, want: domain.RelayStatusReceiverFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := event.ABI.Inputs.NonIndexed().Pack(
+				common.HexToHash("0x01"), tt.status, big.NewInt(21000), tt.refundStatus, []byte{}, []byte{})
+			assert.NoError(t, err)
+			l := evm.Log{
+				Topics: []string{
+					event.ID,
+					"0x0000000000000000000000001111111111111111111111111111111111111111",
+					"0x0000000000000000000000000000000000000000000000000000000000000005",
+					"0x000000000000000000000000000000000000000000000000000000000000000a",
+				},
+				Data: "0x" + hex.EncodeToString(data),
+			}
+
+			values, err := decodeLog(event, l)
+			assert.NoError(t, err)
+			vaaID, err := getVaaID(event, values)
+			assert.NoError(t, err)
+
+			timestamp := time.Now()
+			delivery, err := newRelayDelivery(vaa.ChainIDArbitrum, vaaID, values, "0xabcd", &timestamp)
+			assert.NoError(t, err)
+			assert.Equal(t, "5/00000000000000000000000027428dd2d3dd32a4d7f7c497eaaa23130d894911/10", delivery.ID)
+			assert.Equal(t, tt.want, delivery.Status)
+			assert.Equal(t, "abcd", delivery.TxHash)
+			assert.Equal(t, int64(21000), delivery.GasUsed)
+			assert.Equal(t, "21000", delivery.ResultLog.GasUsed)
+			assert.Equal(t, "polygon", delivery.ResultLog.SourceChain)
+			assert.Equal(t, "10", delivery.ResultLog.SourceVaaSequence)
+			assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000001", delivery.ResultLog.VaaHash)
+		})
+	}
+}
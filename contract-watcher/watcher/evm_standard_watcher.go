@@ -29,6 +29,9 @@ type EvmStandardWatcher struct {
 	maxBlocks        uint64
 	waitSeconds      uint16
 	initialBlock     int64
+	traceCalls       bool
+	confirmations    uint64
+	reorg            *reorgHandler
 	repository       *storage.Repository
 	logger           *zap.Logger
 	close            chan bool
@@ -41,6 +44,14 @@ func NewEvmStandardWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.R
 	for address := range params.MethodsByAddress {
 		addresses = append(addresses, address)
 	}
+	logger = logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID)))
+	getBlockHash := func(ctx context.Context, block uint64) (string, error) {
+		header, err := client.GetBlockHeader(ctx, block)
+		if err != nil {
+			return "", err
+		}
+		return header.Hash, nil
+	}
 	return &EvmStandardWatcher{
 		client:           client,
 		chainID:          params.ChainID,
@@ -50,9 +61,12 @@ func NewEvmStandardWatcher(client *evm.EvmSDK, params EVMParams, repo *storage.R
 		maxBlocks:        uint64(params.SizeBlocks),
 		waitSeconds:      params.WaitSeconds,
 		initialBlock:     params.InitialBlock,
+		traceCalls:       params.TraceInternalCalls,
+		confirmations:    params.ConfirmationDepth,
+		reorg:            newReorgHandler(params.ChainID, params.Blockchain, params.ReorgWindow, repo, getBlockHash, metrics, logger),
 		repository:       repo,
 		metrics:          metrics,
-		logger:           logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
+		logger:           logger,
 	}
 }
 
@@ -77,21 +91,32 @@ func (w *EvmStandardWatcher) Start(ctx context.Context) error {
 			return nil
 		default:
 			// get the latest block for the chain.
-			lastBlock, err := w.client.GetLatestBlock(ctx)
+			latestBlock, err := w.client.GetLatestBlock(ctx)
 			if err != nil {
 				w.logger.Error("cannot get latest block", zap.Error(err))
 			}
+			// only process the blocks with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
 			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
 
 			if currentBlock < lastBlock {
 				w.metrics.SetLastBlock(w.chainID, lastBlock)
 				totalBlocks := getTotalBlocks(lastBlock, currentBlock, w.maxBlocks)
+				failed := false
 				for i := uint64(0); i < totalBlocks; i++ {
 					fromBlock, toBlock := getPage(currentBlock, i, w.maxBlocks, lastBlock)
 					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-					w.processBlock(ctx, fromBlock, toBlock, true)
+					if failedBlock, err := w.processBlock(ctx, fromBlock, toBlock, true); err != nil {
+						// resume from the failed block so its transactions are not lost.
+						currentBlock = failedBlock
+						failed = true
+						break
+					}
 					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 				}
+				if failed {
+					continue
+				}
 				// process all the blocks between current and last block.
 			} else {
 				w.logger.Debug("waiting for new blocks")
@@ -115,15 +140,22 @@ func (w *EvmStandardWatcher) Backfill(ctx context.Context, fromBlock uint64, toB
 	for i := uint64(0); i < totalBlocks; i++ {
 		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
 		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
-		w.processBlock(ctx, fromBlock, toBlock, persistBlock)
+		if failedBlock, err := w.processBlock(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			w.logger.Error("cannot backfill blocks", zap.Uint64("block", failedBlock), zap.Error(err))
+			return
+		}
 		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
 	}
 }
 
-func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) {
+// processBlock processes the blocks between fromBlock and toBlock. It stops at the first block that can not be
+// processed and returns it, so the caller can retry from there instead of marking the block as processed.
+func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) (uint64, error) {
 	for block := fromBlock; block <= toBlock; block++ {
 		w.logger.Debug("processing block", zap.Uint64("block", block))
-		retry.Do(
+		var reorged bool
+		var resumeBlock uint64
+		err := retry.Do(
 			func() error {
 				// get the transactions for the block.
 				blockResult, err := w.client.GetBlock(ctx, block)
@@ -135,48 +167,76 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 					return nil
 				}
 
+				// check if the block is a descendant of the last processed block.
+				if w.reorg != nil {
+					resumeBlock, reorged, err = w.reorg.check(ctx, block, blockResult.ParentHash)
+					if err != nil {
+						w.logger.Error("cannot check chain reorganization", zap.Uint64("block", block), zap.Error(err))
+						return err
+					}
+					if reorged {
+						return nil
+					}
+				}
+
+				// get the call traces of the block to find redeems made through other contracts.
+				var traces map[string]evm.CallTrace
+				if w.traceCalls {
+					traces, err = w.getBlockTraces(ctx, block, blockResult.Transactions)
+					if err != nil {
+						w.logger.Error("cannot get block traces", zap.Uint64("block", block), zap.Error(err))
+						return err
+					}
+				}
+
 				for _, tx := range blockResult.Transactions {
 
 					// only process transactions to the contract address.
 					_, ok := w.methodsByAddress[strings.ToLower(tx.To)]
+					if ok {
+						evmTx := &EvmTransaction{
+							Hash:           tx.Hash,
+							From:           tx.From,
+							To:             tx.To,
+							Status:         w.getTxStatus(ctx, block, tx.Hash),
+							BlockNumber:    tx.BlockNumber,
+							BlockTimestamp: blockResult.Timestamp,
+							Input:          tx.Input,
+						}
+						processTransaction(ctx, w.chainID, evmTx, w.methodsByAddress, w.repository, w.logger)
+						continue
+					}
+
+					trace, ok := traces[strings.ToLower(tx.Hash)]
 					if !ok {
 						continue
 					}
+					for _, call := range findInternalCalls(trace, w.methodsByAddress) {
+						call := call
+						txStatus := w.getTxStatus(ctx, block, tx.Hash)
+						evmTx := &EvmTransaction{
+							Hash: tx.Hash,
+							From: call.From,
+							To:   call.To,
+							Status: func() (string, error) {
+								// a reverted internal call does not revert the transaction when the error is handled.
+								if call.Error != "" {
+									return TxStatusFailReverted, nil
+								}
+								return txStatus()
+							},
+							BlockNumber:    tx.BlockNumber,
+							BlockTimestamp: blockResult.Timestamp,
+							Input:          call.Input,
+						}
+						processTransaction(ctx, w.chainID, evmTx, w.methodsByAddress, w.repository, w.logger)
+					}
+				}
 
-					evmTx := &EvmTransaction{
-						Hash: tx.Hash,
-						From: tx.From,
-						To:   tx.To,
-						Status: func() (string, error) {
-							var status string
-							// add retry to get the transaction receipt.
-							err := retry.Do(
-								func() error {
-									tranctionReceipt, err := w.client.GetTransactionReceipt(ctx, tx.Hash)
-									if err != nil {
-										w.logger.Error("cannot get tranction receipt",
-											zap.Uint64("block", block),
-											zap.String("txHash", tx.Hash),
-											zap.Error(err))
-										if err == evm.ErrTooManyRequests {
-											return err
-										}
-										return nil
-									}
-									// get the status of the transaction
-									status = tranctionReceipt.Status
-									return nil
-								},
-								retry.Attempts(evmMaxRetries),
-								retry.Delay(evmRetryDelay),
-							)
-							return status, err
-						},
-						BlockNumber:    tx.BlockNumber,
-						BlockTimestamp: blockResult.Timestamp,
-						Input:          tx.Input,
+				if w.reorg != nil {
+					if err := w.reorg.save(ctx, block, blockResult.Hash); err != nil {
+						w.logger.Error("cannot save block hash", zap.Uint64("block", block), zap.Error(err))
 					}
-					processTransaction(ctx, w.chainID, evmTx, w.methodsByAddress, w.repository, w.logger)
 				}
 
 				if updateWatcherBlock {
@@ -193,7 +253,63 @@ func (w *EvmStandardWatcher) processBlock(ctx context.Context, fromBlock uint64,
 			retry.Attempts(evmMaxRetries),
 			retry.Delay(evmRetryDelay),
 		)
+		if err != nil {
+			w.logger.Error("cannot process block", zap.Uint64("block", block), zap.Error(err))
+			return block, err
+		}
+		// re-process the blocks replaced by the reorg.
+		if reorged {
+			block = resumeBlock - 1
+		}
+	}
+	return toBlock, nil
+}
+
+// getTxStatus returns a function that gets the status of the transaction from its receipt.
+func (w *EvmStandardWatcher) getTxStatus(ctx context.Context, block uint64, txHash string) EvmGetStatusFunc {
+	return func() (string, error) {
+		var status string
+		// add retry to get the transaction receipt.
+		err := retry.Do(
+			func() error {
+				tranctionReceipt, err := w.client.GetTransactionReceipt(ctx, txHash)
+				if err != nil {
+					w.logger.Error("cannot get tranction receipt",
+						zap.Uint64("block", block),
+						zap.String("txHash", txHash),
+						zap.Error(err))
+					if err == evm.ErrTooManyRequests {
+						return err
+					}
+					return nil
+				}
+				// get the status of the transaction
+				status = tranctionReceipt.Status
+				return nil
+			},
+			retry.Attempts(evmMaxRetries),
+			retry.Delay(evmRetryDelay),
+		)
+		return status, err
+	}
+}
+
+// getBlockTraces returns the call traces of the block by transaction hash.
+func (w *EvmStandardWatcher) getBlockTraces(ctx context.Context, block uint64, txs []evm.Transaction) (map[string]evm.CallTrace, error) {
+	traces, err := w.client.GetBlockTraces(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]evm.CallTrace, len(traces))
+	for i, trace := range traces {
+		// older nodes do not return the hash, the traces follow the order of the block transactions.
+		txHash := trace.TxHash
+		if txHash == "" && i < len(txs) {
+			txHash = txs[i].Hash
+		}
+		result[strings.ToLower(txHash)] = trace.Result
 	}
+	return result, nil
 }
 
 func (w *EvmStandardWatcher) Close() {
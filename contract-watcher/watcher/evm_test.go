@@ -1,13 +1,149 @@
 package watcher
 
 import (
+	"encoding/hex"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/evm"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 func Test_parseInput(t *testing.T) {
-	_, err := parseInput("0xc68785190000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000041801000000030d001752692f4c9833d07d300d083af2485690244e432c8874577735982f7f5222d77a27ee7703bf15263be091cf76d5e7f614d1b273228aabe022779e803e731a7e0001a9aa6115f959f70d20b0c5d48db0c8bd5ab646fde61b3a8260f1afe77bae5dc11e48d43275ee32107f52f8eec12b0a454218d74a74073fec861f250bc8472e6d0006671461db8795cba03f7f0990875319171314d3182b1273805a521f240917be65634b9f2118dafe5845aeda5e7051de4284013802de32ccf8666f3d5a1a4b6ae901070c56fea7f99f1709ac593bf86a115b5adc0b865c3a832c21457fa8445b273cd714994c1dfeeece9aa36b7ca06914cfc7cffaf8b69afcf2f3fb8161f193c6231d0008a4b00cf265c31a5d5a93feebb75e2a32b69d0797d74a48d64529335cd9eb9c3368f45a57fbf03f149726bce3f0c5e95bf1affa8f73c8b6337a8d6b7bf7d56eaa010958c78eafaa1dbb7584dfb1f1336a9c0336b08e81b2c38e1f874d5a3596608f901959b3f43b453c14a35e0d801eb6aee9f63f3e302436780733926f83cf0186b9000a7ee0949495ae6d7b4a9c9669289c69d94b984dc3f7a5ad7d2e0c64b587db2d393eb6b791acc359c809bc04b78a3d7da1e71bc568eaf158da9e8a39ca58fe2a5d000b49b0beb343015b1fff9520f0749a89ecf4582a8a064b0dcc26afd8d6a6cad5f96ed990c9098df0cbe0cbded34608066942e0d8b3295a2fe81ececbc843d18379010c80a5bbb3504afd60cf290b6a7dd5b79fb39b082864aa0ae049e3ee939830730913ac155431479796b8f704abf65caa89cafa90b6c0c8a732cdb5a24b21661698000d523336c7038d5079b8107507dc659a6ffc7e975a036024f322159e40126708405f90c8ee86ec766a6fae9c25fb62f88f4a03a27a8f4972f083bd9d9287668aa7011079ebe7ed680ed5ca973f68ba93b7ea01cadf2e0b6686083c2bb8ed04a0cbf9356b198588d9fbcdd4eb519b63416aecf1924bd826c9ad277209c173408d16a65d0011f8e3eb4770c9e4e3ce2592970126037caee2f3121e78301edb3eed86645e54db0e4d14b093d526f154c9e0cbb2744eadd91970e506167b9e7730177aa22015b00012ef1e55545b5b4b1ed2cf18c1012838a1c02e586b015a1ec996bdbaa7ce6026f31ee78cfcb125cc732f58adbd5604998cac3f9deb4d07205e0fdfc94a18e98e780064053c510000247f0001ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f50000000000043924200100000000000000000000000000000000000000000000000000000021596de513000000000000000000000000bba39fd2935d5769116ce38d46a71bde9cf030990002000000000000000000000000cc35f4c022992cdb0ab7b19fb45d4173a34fde02000200000000000000000000000000000000000000000000000000000000000000000000000000000000")
+	_, err := parseInput(completeTransferInput)
 	assert.Nil(t, err)
 
 }
+
+const completeTransferInput = "0xc68785190000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000041801000000030d001752692f4c9833d07d300d083af2485690244e432c8874577735982f7f5222d77a27ee7703bf15263be091cf76d5e7f614d1b273228aabe022779e803e731a7e0001a9aa6115f959f70d20b0c5d48db0c8bd5ab646fde61b3a8260f1afe77bae5dc11e48d43275ee32107f52f8eec12b0a454218d74a74073fec861f250bc8472e6d0006671461db8795cba03f7f0990875319171314d3182b1273805a521f240917be65634b9f2118dafe5845aeda5e7051de4284013802de32ccf8666f3d5a1a4b6ae901070c56fea7f99f1709ac593bf86a115b5adc0b865c3a832c21457fa8445b273cd714994c1dfeeece9aa36b7ca06914cfc7cffaf8b69afcf2f3fb8161f193c6231d0008a4b00cf265c31a5d5a93feebb75e2a32b69d0797d74a48d64529335cd9eb9c3368f45a57fbf03f149726bce3f0c5e95bf1affa8f73c8b6337a8d6b7bf7d56eaa010958c78eafaa1dbb7584dfb1f1336a9c0336b08e81b2c38e1f874d5a3596608f901959b3f43b453c14a35e0d801eb6aee9f63f3e302436780733926f83cf0186b9000a7ee0949495ae6d7b4a9c9669289c69d94b984dc3f7a5ad7d2e0c64b587db2d393eb6b791acc359c809bc04b78a3d7da1e71bc568eaf158da9e8a39ca58fe2a5d000b49b0beb343015b1fff9520f0749a89ecf4582a8a064b0dcc26afd8d6a6cad5f96ed990c9098df0cbe0cbded34608066942e0d8b3295a2fe81ececbc843d18379010c80a5bbb3504afd60cf290b6a7dd5b79fb39b082864aa0ae049e3ee939830730913ac155431479796b8f704abf65caa89cafa90b6c0c8a732cdb5a24b21661698000d523336c7038d5079b8107507dc659a6ffc7e975a036024f322159e40126708405f90c8ee86ec766a6fae9c25fb62f88f4a03a27a8f4972f083bd9d9287668aa7011079ebe7ed680ed5ca973f68ba93b7ea01cadf2e0b6686083c2bb8ed04a0cbf9356b198588d9fbcdd4eb519b63416aecf1924bd826c9ad277209c173408d16a65d0011f8e3eb4770c9e4e3ce2592970126037caee2f3121e78301edb3eed86645e54db0e4d14b093d526f154c9e0cbb2744eadd91970e506167b9e7730177aa22015b00012ef1e55545b5b4b1ed2cf18c1012838a1c02e586b015a1ec996bdbaa7ce6026f31ee78cfcb125cc732f58adbd5604998cac3f9deb4d07205e0fdfc94a18e98e780064053c510000247f0001ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f50000000000043924200100000000000000000000000000000000000000000000000000000021596de513000000000000000000000000bba39fd2935d5769116ce38d46a71bde9cf030990002000000000000000000000000cc35f4c022992cdb0ab7b19fb45d4173a34fde02000200000000000000000000000000000000000000000000000000000000000000000000000000000000"
+
+// newTestMethod returns the watched method of a contract ABI shipped with the watcher.
+func newTestMethod(t *testing.T, abiName, id, vaaArgument string) config.BlockchainMethod {
+	contractABI, err := config.LoadABI(abiName)
+	assert.NoError(t, err)
+	selector, err := hex.DecodeString(id[2:])
+	assert.NoError(t, err)
+	method, err := contractABI.MethodById(selector)
+	assert.NoError(t, err)
+	path, err := config.ResolveVaaArgument(method, vaaArgument)
+	assert.NoError(t, err)
+	return config.BlockchainMethod{ID: id, Name: method.Name, ABI: method, VaaArgument: path}
+}
+
+func newTestVaa(t *testing.T, sequence uint64) []byte {
+	v := &vaa.VAA{
+		Version:          1,
+		Timestamp:        time.Unix(1683201600, 0),
+		Nonce:            1,
+		Sequence:         sequence,
+		ConsistencyLevel: 1,
+		EmitterChain:     vaa.ChainIDSolana,
+		EmitterAddress:   vaa.Address{1, 2, 3},
+		Payload:          []byte{1},
+	}
+	data, err := v.Marshal()
+	assert.NoError(t, err)
+	return data
+}
+
+func Test_getVaasFromInput_WithoutABI(t *testing.T) {
+	vaas, err := getVaasFromInput(config.BlockchainMethod{ID: config.MethodIDCompleteTransfer}, completeTransferInput)
+	assert.NoError(t, err)
+	assert.Len(t, vaas, 1)
+}
+
+func Test_getVaasFromInput_TokenBridge(t *testing.T) {
+	method := newTestMethod(t, "token_bridge", config.MethodIDCompleteTransfer, "")
+
+	expected, err := parseInput(completeTransferInput)
+	assert.NoError(t, err)
+
+	vaas, err := getVaasFromInput(method, completeTransferInput)
+	assert.NoError(t, err)
+	assert.Len(t, vaas, 1)
+	assert.Equal(t, expected.MessageID(), vaas[0].MessageID())
+}
+
+func Test_getVaasFromInput_VaaNotFirstArgument(t *testing.T) {
+	method := newTestMethod(t, "wormhole_relayer", config.MethodIDDeliver, "encodedDeliveryVAA")
+
+	data, err := method.ABI.Inputs.Pack(
+		[][]byte{newTestVaa(t, 1), newTestVaa(t, 2)},
+		newTestVaa(t, 3),
+		common.HexToAddress("0x27428DD2d3DD32A4D7f7C497eAaa23130d894911"),
+		[]byte{},
+	)
+	assert.NoError(t, err)
+	input := config.MethodIDDeliver + hex.EncodeToString(data)
+
+	vaas, err := getVaasFromInput(method, input)
+	assert.NoError(t, err)
+	assert.Len(t, vaas, 1)
+	assert.Equal(t, uint64(3), vaas[0].Sequence)
+
+	method = newTestMethod(t, "wormhole_relayer", config.MethodIDDeliver, "encodedVMs")
+	vaas, err = getVaasFromInput(method, input)
+	assert.NoError(t, err)
+	assert.Len(t, vaas, 2)
+	assert.Equal(t, uint64(2), vaas[1].Sequence)
+}
+
+func Test_getVaasFromInput_Tuple(t *testing.T) {
+	method := newTestMethod(t, "circle_integration", config.MethodIDRedeemTokensWithPayload, "params.encodedWormholeMessage")
+
+	params := struct {
+		EncodedWormholeMessage []byte
+		CircleBridgeMessage    []byte
+		CircleAttestation      []byte
+	}{
+		EncodedWormholeMessage: newTestVaa(t, 7),
+		CircleBridgeMessage:    []byte{1, 2},
+		CircleAttestation:      []byte{3, 4},
+	}
+	data, err := method.ABI.Inputs.Pack(params)
+	assert.NoError(t, err)
+
+	vaas, err := getVaasFromInput(method, config.MethodIDRedeemTokensWithPayload+hex.EncodeToString(data))
+	assert.NoError(t, err)
+	assert.Len(t, vaas, 1)
+	assert.Equal(t, uint64(7), vaas[0].Sequence)
+	assert.Equal(t, vaa.ChainIDSolana, vaas[0].EmitterChain)
+}
+
+func Test_getVaasFromInput_InvalidInput(t *testing.T) {
+	method := newTestMethod(t, "token_bridge", config.MethodIDCompleteTransfer, "")
+
+	_, err := getVaasFromInput(method, "0xc687")
+	assert.Error(t, err)
+
+	_, err = getVaasFromInput(method, config.MethodIDCompleteTransfer+"0000")
+	assert.Error(t, err)
+}
+
+func Test_findInternalCalls(t *testing.T) {
+	methodsByAddress := map[string][]config.BlockchainMethod{
+		"0x3ee18b2214aff97000d974cf647e7c347e8fa585": {{ID: config.MethodIDCompleteTransfer, Name: config.MethodCompleteTransfer}},
+	}
+
+	trace := evm.CallTrace{
+		Type: "CALL",
+		To:   "0x1111111254eeb25477b68fb85ed929f73a960582",
+		Calls: []evm.CallTrace{
+			{Type: "CALL", To: "0x3ee18B2214AFF97000D974cf647E7C347E8fa585", Input: config.MethodIDCompleteTransfer + "00"},
+			{Type: "STATICCALL", To: "0x3ee18b2214aff97000d974cf647e7c347e8fa585", Input: config.MethodIDCompleteTransfer + "01"},
+			{Type: "CALL", To: "0x3ee18b2214aff97000d974cf647e7c347e8fa585", Input: config.MethodIDTransferTokens + "02"},
+			{Type: "DELEGATECALL", To: "0x2222222222222222222222222222222222222222", Calls: []evm.CallTrace{
+				{Type: "CALL", To: "0x3ee18b2214aff97000d974cf647e7c347e8fa585", Input: config.MethodIDCompleteTransfer + "03", Error: "execution reverted"},
+			}},
+		},
+	}
+
+	calls := findInternalCalls(trace, methodsByAddress)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, config.MethodIDCompleteTransfer+"00", calls[0].Input)
+	assert.Equal(t, config.MethodIDCompleteTransfer+"03", calls[1].Input)
+	assert.Equal(t, "execution reverted", calls[1].Error)
+}
@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,6 +25,8 @@ type EVMWatcher struct {
 	sizeBlocks       uint8
 	waitSeconds      uint16
 	initialBlock     int64
+	confirmations    uint64
+	reorg            *reorgHandler
 	repository       *storage.Repository
 	logger           *zap.Logger
 	close            chan bool
@@ -36,6 +39,17 @@ func NewEVMWatcher(client *ankr.AnkrSDK, repo *storage.Repository, params EVMPar
 	for address := range params.MethodsByAddress {
 		addresses = append(addresses, address)
 	}
+	logger = logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID)))
+	getBlockHash := func(ctx context.Context, block uint64) (string, error) {
+		r, err := client.GetBlocks(ctx, params.Blockchain, int64(block), int64(block))
+		if err != nil {
+			return "", err
+		}
+		if len(r.Result.Blocks) == 0 {
+			return "", fmt.Errorf("block %d not found", block)
+		}
+		return r.Result.Blocks[0].Hash, nil
+	}
 	return &EVMWatcher{
 		client:           client,
 		chainID:          params.ChainID,
@@ -45,9 +59,11 @@ func NewEVMWatcher(client *ankr.AnkrSDK, repo *storage.Repository, params EVMPar
 		sizeBlocks:       params.SizeBlocks,
 		waitSeconds:      params.WaitSeconds,
 		initialBlock:     params.InitialBlock,
+		confirmations:    params.ConfirmationDepth,
+		reorg:            newReorgHandler(params.ChainID, params.Blockchain, params.ReorgWindow, repo, getBlockHash, metrics, logger),
 		repository:       repo,
 		metrics:          metrics,
-		logger:           logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(params.ChainID))),
+		logger:           logger,
 	}
 }
 
@@ -78,7 +94,11 @@ func (w *EVMWatcher) Start(ctx context.Context) error {
 			}
 			lastBlock := currentBlock
 			if len(stats.Result.Stats) > 0 {
-				lastBlock = stats.Result.Stats[0].LatestBlockNumber
+				// only process the blocks with the confirmation depth.
+				latestBlock := stats.Result.Stats[0].LatestBlockNumber
+				if latestBlock > 0 {
+					lastBlock = int64(getConfirmedBlock(uint64(latestBlock), w.confirmations))
+				}
 			}
 
 			if currentBlock < lastBlock {
@@ -123,6 +143,24 @@ func (w *EVMWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uin
 }
 
 func (w *EVMWatcher) processBlock(ctx context.Context, currentBlock int64, lastBlock int64, updateWatcherBlock bool) {
+	// ankr only returns the transactions of the contracts, so the stored hash of the last processed block is compared
+	// with the canonical chain instead of the parent hash of every block.
+	var lastBlockHash string
+	if w.reorg != nil {
+		resumeBlock, reorged, err := w.reorg.verify(ctx, uint64(currentBlock))
+		if err != nil {
+			w.logger.Error("cannot check chain reorganization", zap.Int64("block", currentBlock), zap.Error(err))
+		} else if reorged {
+			// re-process the blocks replaced by the reorg.
+			currentBlock = int64(resumeBlock)
+		}
+		// get the hash before the transactions, so a reorg of the range is detected on the next call.
+		lastBlockHash, err = w.reorg.getBlockHash(ctx, uint64(lastBlock))
+		if err != nil {
+			w.logger.Error("cannot get block hash", zap.Int64("block", lastBlock), zap.Error(err))
+		}
+	}
+
 	pageToken := ""
 	hasPage := true
 
@@ -192,6 +230,17 @@ func (w *EVMWatcher) processBlock(ctx context.Context, currentBlock int64, lastB
 			hasPage = false
 		}
 	}
+
+	if lastBlockHash != "" {
+		if err := w.reorg.save(ctx, uint64(lastBlock), lastBlockHash); err != nil {
+			w.logger.Error("cannot save block hash", zap.Int64("block", lastBlock), zap.Error(err))
+		}
+	}
+}
+
+// DetectsReorgs returns true when the watcher checks the processed blocks for chain reorganizations.
+func (w *EVMWatcher) DetectsReorgs() bool {
+	return w.reorg != nil
 }
 
 func (w *EVMWatcher) Close() {
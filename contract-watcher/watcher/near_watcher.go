@@ -0,0 +1,302 @@
+package watcher
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/near"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const nearMaxRetries = 10
+const nearRetryDelay = 5 * time.Second
+
+// nearDefaultVaaArgument is the argument of the NEAR methods that contains the hex encoded VAA.
+const nearDefaultVaaArgument = "vaa"
+
+// NearParams are the params for the near watcher.
+type NearParams struct {
+	Blockchain   string
+	SizeBlocks   uint8
+	WaitSeconds  uint16
+	InitialBlock int64
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest final block.
+	ConfirmationDepth uint64
+	// MethodsByAddress are the watched methods by contract account.
+	MethodsByAddress map[string][]config.BlockchainMethod
+}
+
+// NearWatcher is a watcher for the NEAR chain.
+//
+// The redeems are the transactions with a function call to one of the watched methods. The VAA is hex encoded
+// in the JSON arguments of the call.
+type NearWatcher struct {
+	client           *near.NearSDK
+	chainID          vaa.ChainID
+	blockchain       string
+	methodsByAddress map[string][]config.BlockchainMethod
+	sizeBlocks       uint8
+	waitSeconds      uint16
+	initialBlock     int64
+	confirmations    uint64
+	repository       *storage.Repository
+	logger           *zap.Logger
+	close            chan bool
+	wg               sync.WaitGroup
+	metrics          metrics.Metrics
+}
+
+// NewNearWatcher creates a new near watcher.
+func NewNearWatcher(client *near.NearSDK, params NearParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *NearWatcher {
+	chainID := vaa.ChainIDNear
+	return &NearWatcher{
+		client:           client,
+		chainID:          chainID,
+		blockchain:       params.Blockchain,
+		methodsByAddress: params.MethodsByAddress,
+		sizeBlocks:       params.SizeBlocks,
+		waitSeconds:      params.WaitSeconds,
+		initialBlock:     params.InitialBlock,
+		confirmations:    params.ConfirmationDepth,
+		repository:       repo,
+		metrics:          metrics,
+		logger:           logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(chainID))),
+	}
+}
+
+// Start starts the near watcher.
+func (w *NearWatcher) Start(ctx context.Context) error {
+	// get the current block for the chain.
+	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current block", zap.Error(err))
+		return err
+	}
+	currentBlock := uint64(cBlock)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest final block for the chain.
+			latestBlock, err := w.client.GetLatestBlock(ctx)
+			if err != nil {
+				w.logger.Error("cannot get latest block", zap.Error(err))
+			}
+			// only process the blocks with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
+			maxBlocks := uint64(w.sizeBlocks)
+			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
+			if currentBlock < lastBlock {
+				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				totalBlocks := getTotalBlocks(lastBlock, currentBlock, maxBlocks)
+				failed := false
+				for i := uint64(0); i < totalBlocks; i++ {
+					fromBlock, toBlock := getPage(currentBlock, i, maxBlocks, lastBlock)
+					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+					if failedBlock, err := w.processBlock(ctx, fromBlock, toBlock, true); err != nil {
+						// resume from the failed block so its transactions are not lost.
+						currentBlock = failedBlock
+						failed = true
+						break
+					}
+					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+				}
+				if failed {
+					continue
+				}
+			} else {
+				w.logger.Debug("waiting for new blocks")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+			if lastBlock > currentBlock {
+				currentBlock = lastBlock
+			}
+		}
+	}
+}
+
+// Backfill processes the blocks between fromBlock and toBlock.
+func (w *NearWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
+	for i := uint64(0); i < totalBlocks; i++ {
+		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
+		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		if failedBlock, err := w.processBlock(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			w.logger.Error("cannot backfill blocks", zap.Uint64("block", failedBlock), zap.Error(err))
+			return
+		}
+		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+	}
+}
+
+// Close closes the near watcher.
+func (w *NearWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
+
+// processBlock processes the blocks between fromBlock and toBlock. It stops at the first block that can not be
+// processed and returns it, so the caller can retry from there instead of marking the block as processed.
+func (w *NearWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) (uint64, error) {
+	for block := fromBlock; block <= toBlock; block++ {
+		w.logger.Debug("processing block", zap.Uint64("block", block))
+		err := retry.Do(
+			func() error {
+				result, err := w.client.GetBlock(ctx, block)
+				if err != nil && !errors.Is(err, near.ErrBlockNotFound) {
+					w.logger.Error("cannot get block", zap.Uint64("block", block), zap.Error(err))
+					return err
+				}
+
+				// the heights can be skipped in NEAR.
+				if result != nil {
+					blockTime := time.Unix(0, int64(result.Header.Timestamp))
+					for _, chunkHeader := range result.Chunks {
+						// the chunk was already processed in a previous block.
+						if chunkHeader.HeightIncluded != result.Header.Height {
+							continue
+						}
+						chunk, err := w.client.GetChunk(ctx, chunkHeader.ChunkHash)
+						if err != nil {
+							w.logger.Error("cannot get chunk", zap.Uint64("block", block), zap.String("chunk", chunkHeader.ChunkHash), zap.Error(err))
+							return err
+						}
+						for _, tx := range chunk.Transactions {
+							if err := w.processTransaction(ctx, tx, block, &blockTime); err != nil {
+								return err
+							}
+						}
+					}
+				}
+
+				if updateWatcherBlock {
+					// update the last block number processed in the database.
+					watcherBlock := storage.WatcherBlock{
+						ID:          w.blockchain,
+						BlockNumber: int64(block),
+						UpdatedAt:   time.Now(),
+					}
+					return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+				}
+				return nil
+			},
+			retry.Attempts(nearMaxRetries),
+			retry.Delay(nearRetryDelay),
+		)
+		if err != nil {
+			w.logger.Error("cannot process block", zap.Uint64("block", block), zap.Error(err))
+			return block, err
+		}
+	}
+	return toBlock, nil
+}
+
+func (w *NearWatcher) processTransaction(ctx context.Context, tx near.Transaction, block uint64, blockTime *time.Time) error {
+	redeems := w.getRedeems(tx)
+	if len(redeems) == 0 {
+		return nil
+	}
+
+	log := w.logger.With(zap.String("txHash", tx.Hash), zap.Uint64("block", block))
+	txStatus, err := w.client.GetTransactionStatus(ctx, tx.Hash, tx.SignerID)
+	if err != nil {
+		log.Error("cannot get transaction status", zap.Error(err))
+		return err
+	}
+	status := domain.DstTxStatusFailedToProcess
+	if txStatus.IsSuccess() {
+		status = domain.DstTxStatusConfirmed
+	}
+
+	for _, redeem := range redeems {
+		updatedAt := time.Now()
+		globalTx := storage.TransactionUpdate{
+			ID: redeem.vaa.MessageID(),
+			Destination: storage.DestinationTx{
+				ChainID:     w.chainID,
+				Status:      status,
+				Method:      redeem.method,
+				TxHash:      tx.Hash,
+				From:        tx.SignerID,
+				To:          tx.ReceiverID,
+				BlockNumber: strconv.FormatUint(block, 10),
+				Timestamp:   blockTime,
+				UpdatedAt:   &updatedAt,
+			},
+		}
+		// update global transaction and check if it should be updated.
+		updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+	}
+	return nil
+}
+
+// nearRedeem is a VAA submitted to a watched method.
+type nearRedeem struct {
+	method string
+	vaa    *vaa.VAA
+}
+
+// getRedeems returns the VAAs submitted by the function calls of a transaction to the watched methods.
+func (w *NearWatcher) getRedeems(tx near.Transaction) []nearRedeem {
+	methods, ok := w.methodsByAddress[tx.ReceiverID]
+	if !ok {
+		return nil
+	}
+	var result []nearRedeem
+	for _, call := range tx.FunctionCalls() {
+		for _, method := range methods {
+			if call.MethodName != method.ID {
+				continue
+			}
+			v, err := getNearVaa(call.Args, method.VaaArgument)
+			if err != nil {
+				w.logger.Warn("cannot get VAA from transaction", zap.String("txHash", tx.Hash), zap.String("method", method.Name), zap.Error(err))
+				continue
+			}
+			if !isGovernanceVaa(v) {
+				result = append(result, nearRedeem{method: method.Name, vaa: v})
+			}
+		}
+	}
+	return result
+}
+
+// getNearVaa returns the hex encoded VAA of the JSON arguments of a function call.
+func getNearVaa(args []byte, path []string) (*vaa.VAA, error) {
+	if len(path) == 0 {
+		path = []string{nearDefaultVaaArgument}
+	}
+	value, err := getJSONString(args, path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	return vaa.Unmarshal(data)
+}
@@ -0,0 +1,74 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/near"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+func TestNearWatcher_getRedeems(t *testing.T) {
+	server := newTestServer(t, "NEAR_RECORD_URL", func(_ *http.Request, body map[string]json.RawMessage) string {
+		switch rpcMethod(body) {
+		case "block":
+			var params struct {
+				BlockID uint64 `json:"block_id"`
+			}
+			_ = json.Unmarshal(body["params"], &params)
+			if params.BlockID == 94000010 {
+				return "near/block.json"
+			}
+			return "near/unknown_block.json"
+		case "chunk":
+			return "near/chunk.json"
+		case "tx":
+			return "near/tx.json"
+		}
+		return ""
+	})
+	client := near.NewNearSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+	params := NearParams{
+		Blockchain: "near",
+		MethodsByAddress: map[string][]config.BlockchainMethod{
+			"contract.portalbridge.near": {{ID: "submit_vaa", Name: "submit_vaa"}},
+		},
+	}
+	w := NewNearWatcher(client, params, nil, metrics.NewNoopMetrics(), zap.NewNop())
+	ctx := context.Background()
+
+	// the heights can be skipped.
+	_, err := client.GetBlock(ctx, 94000011)
+	assert.ErrorIs(t, err, near.ErrBlockNotFound)
+
+	block, err := client.GetBlock(ctx, 94000010)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(94000010), block.Header.Height)
+	assert.Len(t, block.Chunks, 2)
+
+	chunk, err := client.GetChunk(ctx, block.Chunks[0].ChunkHash)
+	assert.NoError(t, err)
+	assert.Len(t, chunk.Transactions, 3)
+
+	// redeem of a token transfer.
+	redeems := w.getRedeems(chunk.Transactions[0])
+	assert.Len(t, redeems, 1)
+	assert.Equal(t, testTransferVaaID, redeems[0].vaa.MessageID())
+	assert.Equal(t, "submit_vaa", redeems[0].method)
+
+	// transaction to another account.
+	assert.Empty(t, w.getRedeems(chunk.Transactions[1]))
+
+	// governance VAAs are not tracked.
+	assert.Empty(t, w.getRedeems(chunk.Transactions[2]))
+
+	status, err := client.GetTransactionStatus(ctx, chunk.Transactions[0].Hash, chunk.Transactions[0].SignerID)
+	assert.NoError(t, err)
+	assert.True(t, status.IsSuccess())
+}
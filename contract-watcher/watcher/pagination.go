@@ -12,3 +12,11 @@ func getPage(currentBlock, index, pageSize, lastBlock uint64) (uint64, uint64) {
 	}
 	return fromBlock, toBlock
 }
+
+// getConfirmedBlock returns the newest block with the confirmation depth.
+func getConfirmedBlock(lastBlock, confirmationDepth uint64) uint64 {
+	if lastBlock < confirmationDepth {
+		return 0
+	}
+	return lastBlock - confirmationDepth
+}
@@ -0,0 +1,68 @@
+package watcher
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// relayerDeliveryEvent is the event emitted by the generic relayer contract when a delivery is executed.
+const relayerDeliveryEvent = "Delivery"
+
+// isRelayerDelivery returns true if the event is the Delivery event of the generic relayer contract.
+func isRelayerDelivery(event config.BlockchainEvent) bool {
+	return event.ABI != nil && event.ABI.Name == relayerDeliveryEvent
+}
+
+// newRelayDelivery creates the result of a generic relayer delivery from the arguments of its Delivery event.
+func newRelayDelivery(chainID vaa.ChainID, vaaID string, values map[string]interface{}, txHash string, timestamp *time.Time) (*storage.RelayDelivery, error) {
+	status, ok := values["status"].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T for argument status", values["status"])
+	}
+	refundStatus, ok := values["refundStatus"].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T for argument refundStatus", values["refundStatus"])
+	}
+	gasUsed, ok := values["gasUsed"].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T for argument gasUsed", values["gasUsed"])
+	}
+	vaaHash, ok := values["deliveryVaaHash"].([32]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T for argument deliveryVaaHash", values["deliveryVaaHash"])
+	}
+
+	var sourceChain, sequence string
+	if v, err := getUint64Argument(values, "sourceChain"); err == nil {
+		sourceChain = vaa.ChainID(v).String()
+	}
+	if v, err := getUint64Argument(values, "sequence"); err == nil {
+		sequence = fmt.Sprintf("%d", v)
+	}
+
+	return &storage.RelayDelivery{
+		ID:      vaaID,
+		ChainID: chainID,
+		Status:  domain.GetRelayStatus(status, refundStatus),
+		TxHash:  utils.Remove0x(txHash),
+		GasUsed: gasUsed.Int64(),
+		ResultLog: storage.RelayResultLog{
+			TransactionHash:   txHash,
+			VaaHash:           fmt.Sprintf("0x%x", vaaHash),
+			Status:            domain.DeliveryStatusName(status),
+			RefundStatus:      domain.RefundStatusName(refundStatus),
+			GasUsed:           gasUsed.String(),
+			SourceChain:       sourceChain,
+			SourceVaaSequence: sequence,
+		},
+		Timestamp: timestamp,
+		UpdatedAt: time.Now(),
+	}, nil
+}
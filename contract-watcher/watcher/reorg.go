@@ -0,0 +1,191 @@
+package watcher
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// BlockHashRepository stores the hashes of the processed blocks.
+type BlockHashRepository interface {
+	SaveBlockHash(ctx context.Context, blockchain string, block uint64, hash string) error
+	GetBlockHashes(ctx context.Context, blockchain string, fromBlock, toBlock uint64) ([]storage.BlockHash, error)
+	DeleteBlockHashes(ctx context.Context, blockchain string, fromBlock uint64) error
+	PruneBlockHashes(ctx context.Context, blockchain string, beforeBlock uint64) error
+	MarkDestinationTxsReorged(ctx context.Context, chainID vaa.ChainID, fromBlock, toBlock uint64) (int64, error)
+	UpdateWatcherBlock(ctx context.Context, chainID vaa.ChainID, watcherBlock storage.WatcherBlock) error
+}
+
+// GetBlockHashFunc returns the hash of a block of the canonical chain.
+type GetBlockHashFunc func(ctx context.Context, block uint64) (string, error)
+
+// reorgHandler detects chain reorganizations by remembering the hashes of the last processed blocks.
+//
+// When the parent hash of a new block does not match the stored hash of the previous block, the handler looks for
+// the newest stored block that is still in the canonical chain, marks the destination transactions of the newer
+// blocks as reorged and returns the block from which the watcher must re-process the chain.
+type reorgHandler struct {
+	chainID      vaa.ChainID
+	blockchain   string
+	window       uint64
+	repository   BlockHashRepository
+	getBlockHash GetBlockHashFunc
+	metrics      metrics.Metrics
+	logger       *zap.Logger
+	lastPruned   uint64
+}
+
+// newReorgHandler creates a reorgHandler that remembers the hashes of the last window blocks.
+// It returns nil when window is zero, which disables the reorg detection.
+func newReorgHandler(chainID vaa.ChainID, blockchain string, window uint64, repository BlockHashRepository,
+	getBlockHash GetBlockHashFunc, metrics metrics.Metrics, logger *zap.Logger) *reorgHandler {
+	if window == 0 {
+		return nil
+	}
+	return &reorgHandler{
+		chainID:      chainID,
+		blockchain:   blockchain,
+		window:       window,
+		repository:   repository,
+		getBlockHash: getBlockHash,
+		metrics:      metrics,
+		logger:       logger,
+	}
+}
+
+// check compares the parent hash of a block with the stored hash of the previous block.
+//
+// When a reorg is detected, the affected blocks are rolled back and it returns true and the first block to re-process.
+func (h *reorgHandler) check(ctx context.Context, block uint64, parentHash string) (uint64, bool, error) {
+	if block == 0 || parentHash == "" {
+		return 0, false, nil
+	}
+	previous, err := h.repository.GetBlockHashes(ctx, h.blockchain, block-1, block-1)
+	if err != nil {
+		return 0, false, err
+	}
+	// the previous block was not processed or is older than the window.
+	if len(previous) == 0 || strings.EqualFold(previous[0].Hash, parentHash) {
+		return 0, false, nil
+	}
+
+	ancestor, err := h.findCommonAncestor(ctx, block-1)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := h.rollback(ctx, ancestor+1, block-1); err != nil {
+		return 0, false, err
+	}
+	return ancestor + 1, true, nil
+}
+
+// verify compares the hash of the newest stored block, up to block, with the hash of the canonical chain.
+//
+// It is used by the providers that only fetch the transactions of the watched contracts, so they do not read the
+// parent hash of every block. When a reorg is detected, the affected blocks are rolled back and it returns true and
+// the first block to re-process.
+func (h *reorgHandler) verify(ctx context.Context, block uint64) (uint64, bool, error) {
+	var fromBlock uint64
+	if block > h.window {
+		fromBlock = block - h.window
+	}
+	hashes, err := h.repository.GetBlockHashes(ctx, h.blockchain, fromBlock, block)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(hashes) == 0 {
+		return 0, false, nil
+	}
+	newest := uint64(hashes[0].BlockNumber)
+	hash, err := h.getBlockHash(ctx, newest)
+	if err != nil {
+		return 0, false, err
+	}
+	if strings.EqualFold(hashes[0].Hash, hash) {
+		return 0, false, nil
+	}
+
+	ancestor, err := h.findCommonAncestor(ctx, newest)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := h.rollback(ctx, ancestor+1, newest); err != nil {
+		return 0, false, err
+	}
+	return ancestor + 1, true, nil
+}
+
+// findCommonAncestor returns the newest stored block, up to block, that is still in the canonical chain.
+func (h *reorgHandler) findCommonAncestor(ctx context.Context, block uint64) (uint64, error) {
+	var fromBlock uint64
+	if block > h.window {
+		fromBlock = block - h.window
+	}
+	hashes, err := h.repository.GetBlockHashes(ctx, h.blockchain, fromBlock, block)
+	if err != nil {
+		return 0, err
+	}
+	for _, stored := range hashes {
+		hash, err := h.getBlockHash(ctx, uint64(stored.BlockNumber))
+		if err != nil {
+			return 0, err
+		}
+		if strings.EqualFold(stored.Hash, hash) {
+			return uint64(stored.BlockNumber), nil
+		}
+	}
+
+	// the reorg is deeper than the window, the whole window is re-processed.
+	h.logger.Warn("common ancestor not found in the reorg window", zap.Uint64("block", block), zap.Uint64("window", h.window))
+	if len(hashes) > 0 && hashes[len(hashes)-1].BlockNumber > 0 {
+		return uint64(hashes[len(hashes)-1].BlockNumber) - 1, nil
+	}
+	return fromBlock, nil
+}
+
+// rollback marks the destination transactions between fromBlock and toBlock as reorged and moves the watcher back.
+func (h *reorgHandler) rollback(ctx context.Context, fromBlock, toBlock uint64) error {
+	count, err := h.repository.MarkDestinationTxsReorged(ctx, h.chainID, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+	if err := h.repository.DeleteBlockHashes(ctx, h.blockchain, fromBlock); err != nil {
+		return err
+	}
+	if fromBlock > 0 {
+		watcherBlock := storage.WatcherBlock{
+			ID:          h.blockchain,
+			BlockNumber: int64(fromBlock - 1),
+			UpdatedAt:   time.Now(),
+		}
+		if err := h.repository.UpdateWatcherBlock(ctx, h.chainID, watcherBlock); err != nil {
+			return err
+		}
+	}
+	h.metrics.IncReorg(h.chainID)
+	h.logger.Warn("chain reorganization detected",
+		zap.Uint64("from", fromBlock),
+		zap.Uint64("to", toBlock),
+		zap.Int64("reorgedTxs", count))
+	return nil
+}
+
+// save stores the hash of a processed block and prunes the hashes older than the window.
+func (h *reorgHandler) save(ctx context.Context, block uint64, hash string) error {
+	if err := h.repository.SaveBlockHash(ctx, h.blockchain, block, hash); err != nil {
+		return err
+	}
+	// the saved blocks are not contiguous for every provider, so the hashes are pruned when a window boundary is crossed.
+	if boundary := block / h.window * h.window; boundary >= h.window && boundary > h.lastPruned {
+		if err := h.repository.PruneBlockHashes(ctx, h.blockchain, boundary-h.window); err != nil {
+			return err
+		}
+		h.lastPruned = boundary
+	}
+	return nil
+}
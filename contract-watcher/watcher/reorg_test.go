@@ -0,0 +1,185 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// fakeBlockHashRepository is an in-memory BlockHashRepository.
+type fakeBlockHashRepository struct {
+	hashes       map[uint64]string
+	reorgedFrom  uint64
+	reorgedTo    uint64
+	watcherBlock int64
+}
+
+func newFakeBlockHashRepository() *fakeBlockHashRepository {
+	return &fakeBlockHashRepository{hashes: make(map[uint64]string), watcherBlock: -1}
+}
+
+func (r *fakeBlockHashRepository) SaveBlockHash(_ context.Context, _ string, block uint64, hash string) error {
+	r.hashes[block] = hash
+	return nil
+}
+
+func (r *fakeBlockHashRepository) GetBlockHashes(_ context.Context, blockchain string, fromBlock, toBlock uint64) ([]storage.BlockHash, error) {
+	var result []storage.BlockHash
+	for block, hash := range r.hashes {
+		if block >= fromBlock && block <= toBlock {
+			result = append(result, storage.BlockHash{Blockchain: blockchain, BlockNumber: int64(block), Hash: hash})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].BlockNumber > result[j].BlockNumber })
+	return result, nil
+}
+
+func (r *fakeBlockHashRepository) DeleteBlockHashes(_ context.Context, _ string, fromBlock uint64) error {
+	for block := range r.hashes {
+		if block >= fromBlock {
+			delete(r.hashes, block)
+		}
+	}
+	return nil
+}
+
+func (r *fakeBlockHashRepository) PruneBlockHashes(_ context.Context, _ string, beforeBlock uint64) error {
+	for block := range r.hashes {
+		if block < beforeBlock {
+			delete(r.hashes, block)
+		}
+	}
+	return nil
+}
+
+func (r *fakeBlockHashRepository) MarkDestinationTxsReorged(_ context.Context, _ vaa.ChainID, fromBlock, toBlock uint64) (int64, error) {
+	r.reorgedFrom, r.reorgedTo = fromBlock, toBlock
+	return 1, nil
+}
+
+func (r *fakeBlockHashRepository) UpdateWatcherBlock(_ context.Context, _ vaa.ChainID, watcherBlock storage.WatcherBlock) error {
+	r.watcherBlock = watcherBlock.BlockNumber
+	return nil
+}
+
+func hashOf(prefix string, block uint64) string {
+	return fmt.Sprintf("0x%s%d", prefix, block)
+}
+
+// newTestReorgHandler creates a handler whose canonical chain has the hashes "0xa<n>" up to forkBlock and "0xb<n>" after.
+func newTestReorgHandler(repo *fakeBlockHashRepository, window, forkBlock uint64) *reorgHandler {
+	getBlockHash := func(_ context.Context, block uint64) (string, error) {
+		if block > forkBlock {
+			return hashOf("b", block), nil
+		}
+		return hashOf("a", block), nil
+	}
+	return newReorgHandler(vaa.ChainIDPolygon, "polygon", window, repo, getBlockHash, metrics.NewNoopMetrics(), zap.NewNop())
+}
+
+func TestReorgHandler_Disabled(t *testing.T) {
+	assert.Nil(t, newReorgHandler(vaa.ChainIDPolygon, "polygon", 0, newFakeBlockHashRepository(), nil, metrics.NewNoopMetrics(), zap.NewNop()))
+}
+
+func TestReorgHandler_NoReorg(t *testing.T) {
+	repo := newFakeBlockHashRepository()
+	h := newTestReorgHandler(repo, 10, 100)
+	for block := uint64(90); block <= 95; block++ {
+		assert.NoError(t, h.save(context.Background(), block, hashOf("a", block)))
+	}
+
+	_, reorged, err := h.check(context.Background(), 96, hashOf("a", 95))
+	assert.NoError(t, err)
+	assert.False(t, reorged)
+
+	// the previous block was not processed.
+	_, reorged, err = h.check(context.Background(), 120, hashOf("b", 119))
+	assert.NoError(t, err)
+	assert.False(t, reorged)
+	assert.Equal(t, int64(-1), repo.watcherBlock)
+}
+
+func TestReorgHandler_Reorg(t *testing.T) {
+	repo := newFakeBlockHashRepository()
+	// the blocks after 92 were replaced.
+	h := newTestReorgHandler(repo, 10, 92)
+	for block := uint64(90); block <= 95; block++ {
+		assert.NoError(t, h.save(context.Background(), block, hashOf("a", block)))
+	}
+
+	resumeBlock, reorged, err := h.check(context.Background(), 96, hashOf("b", 95))
+	assert.NoError(t, err)
+	assert.True(t, reorged)
+	assert.Equal(t, uint64(93), resumeBlock)
+	assert.Equal(t, uint64(93), repo.reorgedFrom)
+	assert.Equal(t, uint64(95), repo.reorgedTo)
+	assert.Equal(t, int64(92), repo.watcherBlock)
+
+	hashes, err := repo.GetBlockHashes(context.Background(), "polygon", 0, 200)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 3)
+	assert.Equal(t, int64(92), hashes[0].BlockNumber)
+}
+
+func TestReorgHandler_DeepReorg(t *testing.T) {
+	repo := newFakeBlockHashRepository()
+	// none of the stored blocks is in the canonical chain.
+	h := newTestReorgHandler(repo, 10, 50)
+	for block := uint64(90); block <= 95; block++ {
+		assert.NoError(t, h.save(context.Background(), block, hashOf("a", block)))
+	}
+
+	resumeBlock, reorged, err := h.check(context.Background(), 96, hashOf("b", 95))
+	assert.NoError(t, err)
+	assert.True(t, reorged)
+	assert.Equal(t, uint64(90), resumeBlock)
+	assert.Equal(t, uint64(90), repo.reorgedFrom)
+	assert.Equal(t, int64(89), repo.watcherBlock)
+	assert.Empty(t, repo.hashes)
+}
+
+func TestReorgHandler_Verify(t *testing.T) {
+	repo := newFakeBlockHashRepository()
+	// the blocks after 250 were replaced, only the last block of every page is stored.
+	h := newTestReorgHandler(repo, 256, 250)
+	for _, block := range []uint64{199, 299} {
+		assert.NoError(t, h.save(context.Background(), block, hashOf("a", block)))
+	}
+
+	_, reorged, err := h.verify(context.Background(), 200)
+	assert.NoError(t, err)
+	assert.False(t, reorged)
+
+	resumeBlock, reorged, err := h.verify(context.Background(), 300)
+	assert.NoError(t, err)
+	assert.True(t, reorged)
+	assert.Equal(t, uint64(200), resumeBlock)
+	assert.Equal(t, uint64(200), repo.reorgedFrom)
+	assert.Equal(t, uint64(299), repo.reorgedTo)
+	assert.Equal(t, int64(199), repo.watcherBlock)
+}
+
+func TestReorgHandler_Prune(t *testing.T) {
+	repo := newFakeBlockHashRepository()
+	h := newTestReorgHandler(repo, 10, 100)
+	for block := uint64(1); block <= 30; block++ {
+		assert.NoError(t, h.save(context.Background(), block, hashOf("a", block)))
+	}
+	_, ok := repo.hashes[19]
+	assert.False(t, ok)
+	_, ok = repo.hashes[20]
+	assert.True(t, ok)
+}
+
+func Test_getConfirmedBlock(t *testing.T) {
+	assert.Equal(t, uint64(100), getConfirmedBlock(100, 0))
+	assert.Equal(t, uint64(68), getConfirmedBlock(100, 32))
+	assert.Equal(t, uint64(0), getConfirmedBlock(10, 32))
+}
@@ -0,0 +1,305 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/sui"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const suiMaxRetries = 10
+const suiRetryDelay = 5 * time.Second
+
+// SuiParams are the params for the sui watcher.
+type SuiParams struct {
+	Blockchain   string
+	SizeBlocks   uint8
+	WaitSeconds  uint16
+	InitialBlock int64
+	// ConfirmationDepth is the number of checkpoints the watcher lags behind the latest checkpoint.
+	ConfirmationDepth uint64
+	// MethodsByAddress are the watched functions (module::function) by package ID.
+	MethodsByAddress map[string][]config.BlockchainMethod
+}
+
+// SuiWatcher is a watcher for the Sui chain. The watcher blocks are the checkpoints.
+//
+// The redeems are the transactions that call one of the watched functions. The VAA is passed to the wormhole
+// package as a pure vector<u8> input of the programmable transaction.
+type SuiWatcher struct {
+	client        *sui.SuiSDK
+	chainID       vaa.ChainID
+	blockchain    string
+	functions     map[string]string
+	sizeBlocks    uint8
+	waitSeconds   uint16
+	initialBlock  int64
+	confirmations uint64
+	repository    *storage.Repository
+	logger        *zap.Logger
+	close         chan bool
+	wg            sync.WaitGroup
+	metrics       metrics.Metrics
+}
+
+// NewSuiWatcher creates a new sui watcher.
+func NewSuiWatcher(client *sui.SuiSDK, params SuiParams, repo *storage.Repository, metrics metrics.Metrics, logger *zap.Logger) *SuiWatcher {
+	chainID := vaa.ChainIDSui
+	// index the functions by package::module::function.
+	functions := make(map[string]string)
+	for address, methods := range params.MethodsByAddress {
+		for _, method := range methods {
+			functions[suiFunctionKey(address, method.ID)] = method.Name
+		}
+	}
+	return &SuiWatcher{
+		client:        client,
+		chainID:       chainID,
+		blockchain:    params.Blockchain,
+		functions:     functions,
+		sizeBlocks:    params.SizeBlocks,
+		waitSeconds:   params.WaitSeconds,
+		initialBlock:  params.InitialBlock,
+		confirmations: params.ConfirmationDepth,
+		repository:    repo,
+		metrics:       metrics,
+		logger:        logger.With(zap.String("blockchain", params.Blockchain), zap.Uint16("chainId", uint16(chainID))),
+	}
+}
+
+// Start starts the sui watcher.
+func (w *SuiWatcher) Start(ctx context.Context) error {
+	// get the current checkpoint for the chain.
+	cBlock, err := w.repository.GetCurrentBlock(ctx, w.blockchain, w.initialBlock)
+	if err != nil {
+		w.logger.Error("cannot get current block", zap.Error(err))
+		return err
+	}
+	currentBlock := uint64(cBlock)
+	w.wg.Add(1)
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("clossing watcher by context")
+			w.wg.Done()
+			return nil
+		case <-w.close:
+			w.logger.Info("clossing watcher")
+			w.wg.Done()
+			return nil
+		default:
+			// get the latest checkpoint for the chain.
+			latestBlock, err := w.client.GetLatestCheckpoint(ctx)
+			if err != nil {
+				w.logger.Error("cannot get latest checkpoint", zap.Error(err))
+			}
+			// only process the checkpoints with the confirmation depth.
+			lastBlock := getConfirmedBlock(latestBlock, w.confirmations)
+			maxBlocks := uint64(w.sizeBlocks)
+			w.logger.Debug("current block", zap.Uint64("current", currentBlock), zap.Uint64("last", lastBlock))
+			if currentBlock < lastBlock {
+				w.metrics.SetLastBlock(w.chainID, lastBlock)
+				totalBlocks := getTotalBlocks(lastBlock, currentBlock, maxBlocks)
+				failed := false
+				for i := uint64(0); i < totalBlocks; i++ {
+					fromBlock, toBlock := getPage(currentBlock, i, maxBlocks, lastBlock)
+					w.logger.Debug("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+					if failedBlock, err := w.processBlock(ctx, fromBlock, toBlock, true); err != nil {
+						// resume from the failed checkpoint so its transactions are not lost.
+						currentBlock = failedBlock
+						failed = true
+						break
+					}
+					w.logger.Debug("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+				}
+				if failed {
+					continue
+				}
+			} else {
+				w.logger.Debug("waiting for new blocks")
+				select {
+				case <-ctx.Done():
+					w.wg.Done()
+					return nil
+				case <-time.After(time.Duration(w.waitSeconds) * time.Second):
+				}
+			}
+			if lastBlock > currentBlock {
+				currentBlock = lastBlock
+			}
+		}
+	}
+}
+
+// Backfill processes the checkpoints between fromBlock and toBlock.
+func (w *SuiWatcher) Backfill(ctx context.Context, fromBlock uint64, toBlock uint64, pageSize uint64, persistBlock bool) {
+	totalBlocks := getTotalBlocks(toBlock, fromBlock, pageSize)
+	for i := uint64(0); i < totalBlocks; i++ {
+		fromBlock, toBlock := getPage(fromBlock, i, pageSize, toBlock)
+		w.logger.Info("processing blocks", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+		if failedBlock, err := w.processBlock(ctx, fromBlock, toBlock, persistBlock); err != nil {
+			w.logger.Error("cannot backfill blocks", zap.Uint64("block", failedBlock), zap.Error(err))
+			return
+		}
+		w.logger.Info("blocks processed", zap.Uint64("from", fromBlock), zap.Uint64("to", toBlock))
+	}
+}
+
+// Close closes the sui watcher.
+func (w *SuiWatcher) Close() {
+	close(w.close)
+	w.wg.Wait()
+}
+
+// processBlock processes the checkpoints between fromBlock and toBlock. It stops at the first checkpoint that can not be
+// processed and returns it, so the caller can retry from there instead of marking the checkpoint as processed.
+func (w *SuiWatcher) processBlock(ctx context.Context, fromBlock uint64, toBlock uint64, updateWatcherBlock bool) (uint64, error) {
+	for block := fromBlock; block <= toBlock; block++ {
+		w.logger.Debug("processing checkpoint", zap.Uint64("block", block))
+		err := retry.Do(
+			func() error {
+				checkpoint, err := w.client.GetCheckpoint(ctx, block)
+				if err != nil {
+					w.logger.Error("cannot get checkpoint", zap.Uint64("block", block), zap.Error(err))
+					return err
+				}
+
+				for start := 0; start < len(checkpoint.Transactions); start += sui.MaxTransactionsPerRequest {
+					end := start + sui.MaxTransactionsPerRequest
+					if end > len(checkpoint.Transactions) {
+						end = len(checkpoint.Transactions)
+					}
+					txs, err := w.client.GetTransactions(ctx, checkpoint.Transactions[start:end])
+					if err != nil {
+						w.logger.Error("cannot get transactions", zap.Uint64("block", block), zap.Error(err))
+						return err
+					}
+					for _, tx := range txs {
+						for _, globalTx := range w.getTransactionUpdates(tx) {
+							log := w.logger.With(zap.String("txHash", tx.Digest), zap.Uint64("block", block))
+							updateGlobalTransaction(ctx, w.chainID, globalTx, w.repository, log)
+						}
+					}
+				}
+
+				if updateWatcherBlock {
+					// update the last checkpoint processed in the database.
+					watcherBlock := storage.WatcherBlock{
+						ID:          w.blockchain,
+						BlockNumber: int64(block),
+						UpdatedAt:   time.Now(),
+					}
+					return w.repository.UpdateWatcherBlock(ctx, w.chainID, watcherBlock)
+				}
+				return nil
+			},
+			retry.Attempts(suiMaxRetries),
+			retry.Delay(suiRetryDelay),
+		)
+		if err != nil {
+			w.logger.Error("cannot process checkpoint", zap.Uint64("block", block), zap.Error(err))
+			return block, err
+		}
+	}
+	return toBlock, nil
+}
+
+// getTransactionUpdates returns the destination transactions of the VAAs redeemed by a transaction.
+func (w *SuiWatcher) getTransactionUpdates(tx sui.TransactionBlock) []storage.TransactionUpdate {
+	var method, contract string
+	for _, call := range tx.MoveCalls() {
+		if name, ok := w.functions[suiFunctionKey(call.Package, call.Module+"::"+call.Function)]; ok {
+			method, contract = name, call.Package
+			break
+		}
+	}
+	if method == "" {
+		return nil
+	}
+
+	status := domain.DstTxStatusFailedToProcess
+	if tx.IsSuccess() {
+		status = domain.DstTxStatusConfirmed
+	}
+	var timestamp *time.Time
+	if ms, err := strconv.ParseInt(tx.TimestampMs, 10, 64); err == nil {
+		t := time.UnixMilli(ms)
+		timestamp = &t
+	}
+
+	var result []storage.TransactionUpdate
+	for _, v := range getSuiVaas(tx.Transaction.Data.Transaction.Inputs) {
+		updatedAt := time.Now()
+		result = append(result, storage.TransactionUpdate{
+			ID: v.MessageID(),
+			Destination: storage.DestinationTx{
+				ChainID:     w.chainID,
+				Status:      status,
+				Method:      method,
+				TxHash:      tx.Digest,
+				From:        tx.Transaction.Data.Sender,
+				To:          contract,
+				BlockNumber: tx.Checkpoint,
+				Timestamp:   timestamp,
+				UpdatedAt:   &updatedAt,
+			},
+		})
+	}
+	if len(result) == 0 {
+		w.logger.Warn("cannot get VAA from transaction", zap.String("txHash", tx.Digest), zap.String("method", method))
+	}
+	return result
+}
+
+// getSuiVaas returns the VAAs of the pure vector<u8> inputs of a programmable transaction.
+func getSuiVaas(inputs []sui.TransactionInput) []*vaa.VAA {
+	var result []*vaa.VAA
+	for _, input := range inputs {
+		if input.Type != "pure" || input.ValueType != "vector<u8>" {
+			continue
+		}
+		// the bytes are encoded as an array of numbers.
+		var data []uint16
+		if err := json.Unmarshal(input.Value, &data); err != nil {
+			continue
+		}
+		buf := make([]byte, len(data))
+		for i, b := range data {
+			if b > 0xff {
+				buf = nil
+				break
+			}
+			buf[i] = byte(b)
+		}
+		if buf == nil {
+			continue
+		}
+		v, err := vaa.Unmarshal(buf)
+		if err != nil || isGovernanceVaa(v) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// suiFunctionKey returns the key of a Move function, the package ID is normalized to 32 bytes.
+func suiFunctionKey(packageID, function string) string {
+	id := strings.TrimPrefix(strings.ToLower(packageID), "0x")
+	if len(id) < 64 {
+		id = strings.Repeat("0", 64-len(id)) + id
+	}
+	return fmt.Sprintf("0x%s::%s", id, function)
+}
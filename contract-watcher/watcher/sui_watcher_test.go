@@ -0,0 +1,85 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/internal/sui"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+func newTestSuiWatcher(t *testing.T) *SuiWatcher {
+	server := newTestServer(t, "SUI_RECORD_URL", func(_ *http.Request, body map[string]json.RawMessage) string {
+		switch rpcMethod(body) {
+		case "sui_getLatestCheckpointSequenceNumber":
+			return "sui/latest_checkpoint.json"
+		case "sui_getCheckpoint":
+			return "sui/checkpoint.json"
+		case "sui_multiGetTransactionBlocks":
+			return "sui/transactions.json"
+		}
+		return ""
+	})
+	client := sui.NewSuiSDK(server.URL, ratelimit.NewUnlimited(), metrics.NewNoopMetrics())
+	params := SuiParams{
+		Blockchain: "sui",
+		MethodsByAddress: map[string][]config.BlockchainMethod{
+			"0x26efee2b51c911237888e5dc6702868abca3c7ac12c53f76ef8eba0697695e3d": {
+				{ID: "complete_transfer::authorize_transfer", Name: "complete_transfer::authorize_transfer"},
+			},
+		},
+	}
+	return NewSuiWatcher(client, params, nil, metrics.NewNoopMetrics(), zap.NewNop())
+}
+
+func TestSuiWatcher_getTransactionUpdates(t *testing.T) {
+	w := newTestSuiWatcher(t)
+	ctx := context.Background()
+
+	latest, err := w.client.GetLatestCheckpoint(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5000200), latest)
+
+	checkpoint, err := w.client.GetCheckpoint(ctx, 5000123)
+	assert.NoError(t, err)
+	assert.Len(t, checkpoint.Transactions, 3)
+
+	txs, err := w.client.GetTransactions(ctx, checkpoint.Transactions)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 3)
+
+	// redeem of a token transfer.
+	updates := w.getTransactionUpdates(txs[0])
+	assert.Len(t, updates, 1)
+	assert.Equal(t, testTransferVaaID, updates[0].ID)
+	assert.Equal(t, domain.DstTxStatusConfirmed, updates[0].Destination.Status)
+	assert.Equal(t, "complete_transfer::authorize_transfer", updates[0].Destination.Method)
+	assert.Equal(t, "HKfz2d5HP7fQwxLsWcfJcTnhE2ZD3VFMDVGdUBzTuH3M", updates[0].Destination.TxHash)
+	assert.Equal(t, "5000123", updates[0].Destination.BlockNumber)
+	assert.Equal(t, int64(1689000000000), updates[0].Destination.Timestamp.UnixMilli())
+
+	// transaction that does not call a watched function.
+	assert.Empty(t, w.getTransactionUpdates(txs[1]))
+
+	// failed redeem.
+	updates = w.getTransactionUpdates(txs[2])
+	assert.Len(t, updates, 1)
+	assert.Equal(t, testTransferWithPayloadVaaID, updates[0].ID)
+	assert.Equal(t, domain.DstTxStatusFailedToProcess, updates[0].Destination.Status)
+}
+
+func Test_suiFunctionKey(t *testing.T) {
+	assert.Equal(t,
+		"0x0000000000000000000000000000000000000000000000000000000000000002::coin::join",
+		suiFunctionKey("0x2", "coin::join"))
+	assert.Equal(t,
+		suiFunctionKey("0x26EFEE2B51C911237888E5DC6702868ABCA3C7AC12C53F76EF8EBA0697695E3D", "complete_transfer::authorize_transfer"),
+		suiFunctionKey("26efee2b51c911237888e5dc6702868abca3c7ac12c53f76ef8eba0697695e3d", "complete_transfer::authorize_transfer"))
+}
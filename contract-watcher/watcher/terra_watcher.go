@@ -37,6 +37,7 @@ type TerraWatcher struct {
 	contractAddress string
 	waitSeconds     uint16
 	initialBlock    int64
+	confirmations   uint64
 	client          *http.Client
 	repository      *storage.Repository
 	logger          *zap.Logger
@@ -52,6 +53,8 @@ type TerraParams struct {
 	ContractAddress string
 	WaitSeconds     uint16
 	InitialBlock    int64
+	// ConfirmationDepth is the number of blocks the watcher lags behind the latest block.
+	ConfirmationDepth uint64
 }
 
 // NewTerraWatcher creates a new terra watcher.
@@ -63,6 +66,7 @@ func NewTerraWatcher(terraSDK *terra.TerraSDK, params TerraParams, repository *s
 		contractAddress: params.ContractAddress,
 		waitSeconds:     params.WaitSeconds,
 		initialBlock:    params.InitialBlock,
+		confirmations:   params.ConfirmationDepth,
 		client:          &http.Client{},
 		repository:      repository,
 		metrics:         metrics,
@@ -92,10 +96,15 @@ func (w *TerraWatcher) Start(ctx context.Context) error {
 			return nil
 		default:
 			// get the latest block for the terra chain.
-			lastBlock, err := w.terraSDK.GetLastBlock(ctx)
+			latestBlock, err := w.terraSDK.GetLastBlock(ctx)
 			if err != nil {
 				w.logger.Error("cannot get terra lastblock", zap.Error(err))
 			}
+			// only process the blocks with the confirmation depth.
+			lastBlock := latestBlock
+			if latestBlock > 0 {
+				lastBlock = int64(getConfirmedBlock(uint64(latestBlock), w.confirmations))
+			}
 
 			// check if there are new blocks to process.
 			if currentBlock < lastBlock {
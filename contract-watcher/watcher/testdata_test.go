@@ -0,0 +1,91 @@
+package watcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// testTransferVaaID is the ID of the token transfer VAA in the testdata.
+	testTransferVaaID = "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/42"
+	// testTransferWithPayloadVaaID is the ID of the second token transfer VAA in the testdata.
+	testTransferWithPayloadVaaID = "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/43"
+)
+
+// newTestServer returns a server that responds with the testdata file returned by route for each request.
+//
+// When the recordEnv environment variable is set to the url of a node, the requests are forwarded to it and the
+// responses are written to the testdata files, so the fixtures can be recorded again (see testdata/README.md).
+func newTestServer(t *testing.T, recordEnv string, route func(r *http.Request, body map[string]json.RawMessage) string) *httptest.Server {
+	recordURL := os.Getenv(recordEnv)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		var body map[string]json.RawMessage
+		if r.Method == http.MethodPost {
+			_ = json.Unmarshal(payload, &body)
+		}
+		file := route(r, body)
+		if file == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if recordURL != "" {
+			if err := recordTestdata(recordURL, r, payload, file); err != nil {
+				t.Errorf("cannot record testdata %s: %v", file, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Errorf("cannot read testdata %s: %v", file, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// recordTestdata forwards a request to the node and writes the response to the testdata file.
+func recordTestdata(url string, r *http.Request, payload []byte, file string) error {
+	target := strings.TrimSuffix(url, "/") + r.URL.Path
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	req, err := http.NewRequest(r.Method, target, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join("testdata", file), append(indented.Bytes(), '\n'), 0644)
+}
+
+// rpcMethod returns the method of a JSON-RPC request body.
+func rpcMethod(body map[string]json.RawMessage) string {
+	var method string
+	_ = json.Unmarshal(body["method"], &method)
+	return method
+}
@@ -2,7 +2,10 @@ package watcher
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/contract-watcher/storage"
@@ -19,6 +22,7 @@ var (
 type FuncGetGlobalTransactionById func(ctx context.Context, id string) (storage.TransactionUpdate, error)
 
 func updateGlobalTransaction(ctx context.Context, chainID sdk.ChainID, tx storage.TransactionUpdate, r *storage.Repository, log *zap.Logger) {
+	startedAt := time.Now()
 	updateGlobalTx, err := checkTxShouldBeUpdated(ctx, tx, r.GetGlobalTransactionByID)
 	if !updateGlobalTx {
 		log.Info("tx can not be updated",
@@ -26,10 +30,12 @@ func updateGlobalTransaction(ctx context.Context, chainID sdk.ChainID, tx storag
 			zap.String("txHash", tx.Destination.TxHash),
 			zap.String("status", tx.Destination.Status),
 			zap.Error(err))
+		r.Lineage().Skip(ctx, tx.ID, "", startedAt, err.Error())
 		return
 	}
 
 	err = r.UpsertGlobalTransaction(ctx, chainID, tx)
+	r.Lineage().Record(ctx, tx.ID, "", startedAt, err)
 	if err != nil {
 		log.Error("cannot save redeemed tx", zap.Error(err))
 	} else {
@@ -37,6 +43,12 @@ func updateGlobalTransaction(ctx context.Context, chainID sdk.ChainID, tx storag
 	}
 }
 
+// isGovernanceVaa returns true for the governance VAAs, which are also submitted to the redeem methods of
+// some contracts (e.g. submit_vaa) but are not transfers.
+func isGovernanceVaa(v *sdk.VAA) bool {
+	return v.EmitterChain == sdk.GovernanceChain && v.EmitterAddress == sdk.GovernanceEmitter
+}
+
 // checkTxShouldBeUpdated checks if the transaction should be updated.
 func checkTxShouldBeUpdated(ctx context.Context, tx storage.TransactionUpdate, getGlobalTransactionByIDFunc FuncGetGlobalTransactionById) (bool, error) {
 	switch tx.Destination.Status {
@@ -68,3 +80,24 @@ func checkTxShouldBeUpdated(ctx context.Context, tx storage.TransactionUpdate, g
 		return false, ErrInvalidTxStatus
 	}
 }
+
+// getJSONString returns the string value of a JSON object in a path of field names.
+func getJSONString(data []byte, path []string) (string, error) {
+	current := json.RawMessage(data)
+	for _, field := range path {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(current, &object); err != nil {
+			return "", err
+		}
+		value, ok := object[field]
+		if !ok {
+			return "", fmt.Errorf("field %s not found", field)
+		}
+		current = value
+	}
+	var value string
+	if err := json.Unmarshal(current, &value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/wormhole-foundation/wormhole-explorer/dev/cmd/service"
+)
+
+func main() {
+
+	fixtureFile := flag.String("fixture", "", "file with signed VAAs to load instead of listening to the p2p network")
+	flag.Parse()
+
+	service.Run(*fixtureFile)
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	analyticsService "github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/service"
+	analyticsConfig "github.com/wormhole-foundation/wormhole-explorer/analytics/config"
+	apiConfig "github.com/wormhole-foundation/wormhole-explorer/api/config"
+	"github.com/wormhole-foundation/wormhole-explorer/api/server"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+	contractWatcherService "github.com/wormhole-foundation/wormhole-explorer/contract-watcher/cmd/service"
+	contractWatcherConfig "github.com/wormhole-foundation/wormhole-explorer/contract-watcher/config"
+	"github.com/wormhole-foundation/wormhole-explorer/dev/config"
+	"github.com/wormhole-foundation/wormhole-explorer/dev/fixture"
+	flyService "github.com/wormhole-foundation/wormhole-explorer/fly/cmd/service"
+	flyConfig "github.com/wormhole-foundation/wormhole-explorer/fly/config"
+	parserService "github.com/wormhole-foundation/wormhole-explorer/parser/cmd/service"
+	parserConfig "github.com/wormhole-foundation/wormhole-explorer/parser/config"
+	pipelineService "github.com/wormhole-foundation/wormhole-explorer/pipeline/cmd/service"
+	pipelineConfig "github.com/wormhole-foundation/wormhole-explorer/pipeline/config"
+	projectorService "github.com/wormhole-foundation/wormhole-explorer/projector/cmd/service"
+	projectorConfig "github.com/wormhole-foundation/wormhole-explorer/projector/config"
+	spyService "github.com/wormhole-foundation/wormhole-explorer/spy/cmd/service"
+	spyConfig "github.com/wormhole-foundation/wormhole-explorer/spy/config"
+	txTrackerService "github.com/wormhole-foundation/wormhole-explorer/txtracker/cmd/service"
+	txTrackerConfig "github.com/wormhole-foundation/wormhole-explorer/txtracker/config"
+	"go.uber.org/zap"
+)
+
+// topics of the in-process broker shared by the services.
+const (
+	pipelineTopic      = "wormscan.vaas"
+	notificationsTopic = "wormscan.notifications"
+	flyVaasTopic       = "wormscan.fly.vaas"
+)
+
+// Run starts the services with the configuration from the environment and blocks until a
+// termination signal is received. fixtureFile overrides the VAA_FIXTURE_FILE variable.
+func Run(fixtureFile string) {
+
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+
+	cfg, err := config.New(rootCtx)
+	if err != nil {
+		log.Fatal("Error creating config", err)
+	}
+	if fixtureFile != "" {
+		cfg.VaaFixtureFile = fixtureFile
+	}
+
+	logger := logger.New("wormhole-explorer-dev", logger.WithLevel(cfg.LogLevel))
+
+	shutdownTracing, err := tracing.Init(rootCtx, "wormscan-dev", cfg.Tracing, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	stop := Start(rootCtx, rootCtxCancel, cfg, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("flushing traces...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Warn("failed to flush traces", zap.Error(err))
+	}
+
+	logger.Info("Finished wormhole-explorer in local development mode")
+}
+
+// Start starts the services in local development mode and loads the VAA fixture when it is
+// configured, otherwise fly receives the VAAs from the p2p network. The services that depend on
+// an external system that is not configured are disabled. rootCtxCancel is called when the p2p
+// network fails.
+func Start(rootCtx context.Context, rootCtxCancel context.CancelFunc, cfg *config.Configuration, logger *zap.Logger) (stop func()) {
+
+	logger.Info("Starting wormhole-explorer in local development mode ...")
+
+	// the entries are read before starting the services to fail fast on an invalid fixture.
+	var entries []fixture.Entry
+	if cfg.VaaFixtureFile != "" {
+		var err error
+		entries, err = fixture.ReadFile(cfg.VaaFixtureFile)
+		if err != nil {
+			logger.Fatal("failed to read vaa fixture", zap.String("file", cfg.VaaFixtureFile), zap.Error(err))
+		}
+	}
+
+	// the services are started downstream first, so that every consumer group of the in-process
+	// broker exists before the first VAA is published. The stop functions are called in the
+	// reverse order.
+	var stops []func()
+	start := func(service string, enabled bool, reason string, startFunc func(logger *zap.Logger) func()) {
+		if !enabled {
+			logger.Warn(reason + ", the " + service + " is disabled")
+			return
+		}
+		stops = append(stops, startFunc(logger.With(zap.String("service", service))))
+	}
+
+	start("api", true, "", func(logger *zap.Logger) func() {
+		return startAPI(rootCtx, cfg, logger)
+	})
+
+	start("projector", true, "", func(logger *zap.Logger) func() {
+		return projectorService.Start(rootCtx, newProjectorConfig(cfg), logger)
+	})
+
+	start("contract-watcher", cfg.ContractWatcherEnabled(), "WATCHERS_CONFIG_PATH is not set", func(logger *zap.Logger) func() {
+		return contractWatcherService.Start(rootCtx, newContractWatcherConfig(cfg), logger)
+	})
+
+	start("analytics", cfg.AnalyticsEnabled(), "INFLUX_URL, REDIS_URI or VAA_PAYLOAD_PARSER_URL is not set", func(logger *zap.Logger) func() {
+		return analyticsService.Start(rootCtx, newAnalyticsConfig(cfg), logger)
+	})
+
+	txTrackerCfg, err := newTxTrackerConfig(cfg)
+	start("tx-tracker", err == nil, "the RPC providers of the chains are not set", func(logger *zap.Logger) func() {
+		return txTrackerService.Start(rootCtx, txTrackerCfg, logger)
+	})
+
+	start("parser", cfg.ParserEnabled(), "VAA_PAYLOAD_PARSER_URL is not set", func(logger *zap.Logger) func() {
+		return parserService.Start(rootCtx, newParserConfig(cfg), logger)
+	})
+
+	start("pipeline", true, "", func(logger *zap.Logger) func() {
+		return pipelineService.Start(rootCtx, newPipelineConfig(cfg), logger)
+	})
+
+	start("spy", cfg.SpyEnabled(), "REDIS_URI is not set", func(logger *zap.Logger) func() {
+		return spyService.Start(rootCtx, newSpyConfig(cfg), logger)
+	})
+
+	start("fly", cfg.FlyEnabled(), "the VAAs are loaded from a fixture", func(logger *zap.Logger) func() {
+		return flyService.Start(rootCtx, rootCtxCancel, newFlyConfig(cfg), true, logger)
+	})
+
+	if len(entries) > 0 {
+		loadFixture(rootCtx, cfg, entries, logger)
+	}
+
+	logger.Info("Started wormhole-explorer in local development mode",
+		zap.String("api", "http://localhost:"+strconv.Itoa(cfg.ApiPort)))
+
+	return func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+}
+
+func startAPI(ctx context.Context, cfg *config.Configuration, logger *zap.Logger) (stop func()) {
+	var apiCfg apiConfig.AppConfig
+	apiCfg.DB.URL = cfg.MongoURI
+	apiCfg.DB.Name = cfg.MongoDatabase
+	apiCfg.PORT = cfg.ApiPort
+	apiCfg.LogLevel = cfg.LogLevel
+	apiCfg.RunMode = apiConfig.RunModeDevelopmernt
+	apiCfg.P2pNetwork = cfg.P2pNetwork
+	apiCfg.Environment = cfg.Environment
+	apiCfg.Cache.MetricExpiration = 10
+
+	srv, err := server.New(ctx, &apiCfg, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize API server", zap.Error(err))
+	}
+	srv.Start()
+	return srv.Stop
+}
+
+func newFlyConfig(cfg *config.Configuration) *flyConfig.Configuration {
+	return &flyConfig.Configuration{
+		Environment:                    cfg.Environment,
+		P2pNetwork:                     cfg.P2pNetwork,
+		MongoURI:                       cfg.MongoURI,
+		MongoDatabase:                  cfg.MongoDatabase,
+		ObservationsChannelSize:        15000,
+		VaasChannelSize:                5000,
+		HeartbeatsChannelSize:          50,
+		GovernorConfigChannelSize:      50,
+		GovernorStatusChannelSize:      50,
+		ApiPort:                        cfg.FlyPort,
+		P2pPort:                        cfg.FlyP2pPort,
+		HeartbeatsHistorySampleSeconds: 60,
+		HeartbeatsHistoryRetentionDays: 30,
+		VaaProcessingRetentionDays:     30,
+		QueueConfiguration: flyConfig.QueueConfiguration{
+			QueueBroker:   string(broker.TypeMemory),
+			VaasTopic:     flyVaasTopic,
+			ConsumerGroup: "fly",
+		},
+		RedisConfiguration: flyConfig.RedisConfiguration{
+			RedisURI:        cfg.RedisURI,
+			RedisPrefix:     cfg.RedisPrefix,
+			RedisVaaChannel: cfg.RedisVaaChannel,
+		},
+	}
+}
+
+func newSpyConfig(cfg *config.Configuration) *spyConfig.Configuration {
+	return &spyConfig.Configuration{
+		Env:          cfg.Environment,
+		LogLevel:     cfg.LogLevel,
+		Port:         cfg.SpyPort,
+		GrpcAddress:  cfg.SpyGrpcAddress,
+		RedisURI:     cfg.RedisURI,
+		RedisPrefix:  cfg.RedisPrefix,
+		RedisChannel: cfg.RedisVaaChannel,
+	}
+}
+
+func newPipelineConfig(cfg *config.Configuration) *pipelineConfig.Configuration {
+	return &pipelineConfig.Configuration{
+		Environment:   cfg.Environment,
+		LogLevel:      cfg.LogLevel,
+		Port:          cfg.PipelinePort,
+		P2pNetwork:    cfg.P2pNetwork,
+		MongoURI:      cfg.MongoURI,
+		MongoDatabase: cfg.MongoDatabase,
+		QueueConfiguration: pipelineConfig.QueueConfiguration{
+			QueueBroker:   string(broker.TypeMemory),
+			PipelineTopic: pipelineTopic,
+		},
+	}
+}
+
+func newProjectorConfig(cfg *config.Configuration) *projectorConfig.ServiceConfiguration {
+	return &projectorConfig.ServiceConfiguration{
+		Environment:   cfg.Environment,
+		LogLevel:      cfg.LogLevel,
+		Port:          cfg.ProjectorPort,
+		MongoURI:      cfg.MongoURI,
+		MongoDatabase: cfg.MongoDatabase,
+		// the operations of the fixture are not monitored, the SLA configuration is empty.
+		SLACheckIntervalSeconds: 60,
+	}
+}
+
+func newParserConfig(cfg *config.Configuration) *parserConfig.ServiceConfiguration {
+	return &parserConfig.ServiceConfiguration{
+		Environment:             cfg.Environment,
+		LogLevel:                cfg.LogLevel,
+		Port:                    cfg.ParserPort,
+		ConsumerMode:            "QUEUE",
+		MongoURI:                cfg.MongoURI,
+		MongoDatabase:           cfg.MongoDatabase,
+		DLQMaxAttempts:          5,
+		VaaPayloadParserURL:     cfg.VaaPayloadParserURL,
+		VaaPayloadParserTimeout: cfg.VaaPayloadParserTimeout,
+		P2pNetwork:              cfg.P2pNetwork,
+		QueueConfiguration: parserConfig.QueueConfiguration{
+			QueueBroker:        string(broker.TypeMemory),
+			PipelineTopic:      pipelineTopic,
+			NotificationsTopic: notificationsTopic,
+			DLQTopic:           "wormscan.parser.dlq",
+			ConsumerGroup:      "parser",
+		},
+	}
+}
+
+// newTxTrackerConfig creates the tx-tracker configuration, the RPC providers of the chains are
+// read from the same environment variables as the tx-tracker service.
+func newTxTrackerConfig(cfg *config.Configuration) (*txTrackerConfig.ServiceSettings, error) {
+	rpcProviders, err := txTrackerConfig.LoadFromEnv[txTrackerConfig.RpcProviderSettings]()
+	if err != nil {
+		return nil, err
+	}
+	return &txTrackerConfig.ServiceSettings{
+		MonitoringPort: cfg.TxTrackerPort,
+		Environment:    cfg.Environment,
+		LogLevel:       cfg.LogLevel,
+		P2pNetwork:     cfg.P2pNetwork,
+		AwsSettings: txTrackerConfig.AwsSettings{
+			DlqMaxAttempts: 5,
+		},
+		QueueSettings: txTrackerConfig.QueueSettings{
+			QueueBroker:        string(broker.TypeMemory),
+			PipelineTopic:      pipelineTopic,
+			NotificationsTopic: notificationsTopic,
+			DlqTopic:           "wormscan.tx-tracker.dlq",
+			ConsumerGroup:      "tx-tracker",
+		},
+		MongodbSettings: txTrackerConfig.MongodbSettings{
+			MongodbUri:      cfg.MongoURI,
+			MongodbDatabase: cfg.MongoDatabase,
+		},
+		RpcProviderSettings: *rpcProviders,
+	}, nil
+}
+
+func newAnalyticsConfig(cfg *config.Configuration) *analyticsConfig.Configuration {
+	return &analyticsConfig.Configuration{
+		Environment:             cfg.Environment,
+		LogLevel:                cfg.LogLevel,
+		Port:                    cfg.AnalyticsPort,
+		ConsumerMode:            "QUEUE",
+		DLQMaxAttempts:          5,
+		InfluxUrl:               cfg.InfluxURL,
+		InfluxToken:             cfg.InfluxToken,
+		InfluxOrganization:      cfg.InfluxOrganization,
+		InfluxBucketInfinite:    cfg.InfluxBucketInfinite,
+		InfluxBucket30Days:      cfg.InfluxBucket30Days,
+		InfluxBucket24Hours:     cfg.InfluxBucket24Hours,
+		MongodbURI:              cfg.MongoURI,
+		MongodbDatabase:         cfg.MongoDatabase,
+		P2pNetwork:              cfg.P2pNetwork,
+		CacheURL:                cfg.RedisURI,
+		CachePrefix:             cfg.RedisPrefix,
+		CacheChannel:            cfg.NotionalChannel,
+		VaaPayloadParserURL:     cfg.VaaPayloadParserURL,
+		VaaPayloadParserTimeout: cfg.VaaPayloadParserTimeout,
+		QueueConfiguration: analyticsConfig.QueueConfiguration{
+			QueueBroker:        string(broker.TypeMemory),
+			PipelineTopic:      pipelineTopic,
+			NotificationsTopic: notificationsTopic,
+			DLQTopic:           "wormscan.analytics.dlq",
+			ConsumerGroup:      "analytics",
+		},
+	}
+}
+
+func newContractWatcherConfig(cfg *config.Configuration) *contractWatcherConfig.ServiceConfiguration {
+	return &contractWatcherConfig.ServiceConfiguration{
+		Environment:        cfg.Environment,
+		LogLevel:           cfg.LogLevel,
+		Port:               cfg.ContractWatcherPort,
+		MongoURI:           cfg.MongoURI,
+		MongoDatabase:      cfg.MongoDatabase,
+		P2pNetwork:         cfg.P2pNetwork,
+		WatchersConfigPath: cfg.WatchersConfigPath,
+	}
+}
+
+// loadFixture stores the fixture VAAs, the pipeline publishes them as if fly had received them.
+func loadFixture(ctx context.Context, cfg *config.Configuration, entries []fixture.Entry, logger *zap.Logger) {
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	inserted, err := fixture.NewLoader(db.Database, logger).Load(ctx, entries)
+	if err != nil {
+		logger.Fatal("failed to load vaa fixture", zap.String("file", cfg.VaaFixtureFile), zap.Error(err))
+	}
+	logger.Info("Loaded vaa fixture",
+		zap.String("file", cfg.VaaFixtureFile),
+		zap.Int("vaas", len(entries)),
+		zap.Int("inserted", inserted))
+}
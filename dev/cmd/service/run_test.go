@@ -0,0 +1,96 @@
+//go:build integration
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"github.com/wormhole-foundation/wormhole-explorer/dev/config"
+	"github.com/wormhole-foundation/wormhole-explorer/dev/fixture"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	waitFor = 30 * time.Second
+	tick    = 500 * time.Millisecond
+)
+
+// TestStartWithFixture loads the example fixture and checks that every VAA goes through the
+// pipeline and the projector and is served by the API. It runs against the MongoDB replica set
+// of MONGODB_URI in a new database that is dropped at the end:
+//
+//	MONGODB_URI="mongodb://localhost:27017/?replicaSet=rs0" go test -tags integration ./cmd/service
+func TestStartWithFixture(t *testing.T) {
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI is not set")
+	}
+
+	cfg := &config.Configuration{
+		Environment:    "test",
+		LogLevel:       "ERROR",
+		P2pNetwork:     "mainnet",
+		MongoURI:       uri,
+		MongoDatabase:  fmt.Sprintf("wormscan-e2e-%d", time.Now().UnixNano()),
+		ApiPort:        18000,
+		PipelinePort:   "18001",
+		ParserPort:     "18002",
+		ProjectorPort:  "18003",
+		VaaFixtureFile: "../../fixtures/example.txt",
+	}
+	entries, err := fixture.ReadFile(cfg.VaaFixtureFile)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database(cfg.MongoDatabase)
+	defer func() {
+		_ = db.Drop(context.Background())
+		_ = client.Disconnect(context.Background())
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := Start(ctx, cancel, cfg, zap.NewNop())
+	defer func() {
+		cancel()
+		stop()
+	}()
+
+	for _, e := range entries {
+		id := e.VAA.MessageID()
+		url := fmt.Sprintf("http://localhost:%d/api/v1/vaas/%s", cfg.ApiPort, id)
+
+		assert.Eventually(t, func() bool {
+			var d lineage.Document
+			err := db.Collection(lineage.Collection).FindOne(ctx, bson.M{"_id": id}).Decode(&d)
+			return err == nil && d.Stages[lineage.StagePipeline] != nil &&
+				d.Stages[lineage.StagePipeline].Outcome == lineage.OutcomeSucceeded
+		}, waitFor, tick, "the pipeline did not publish the VAA %s", id)
+
+		assert.Eventually(t, func() bool {
+			n, err := db.Collection("operations").CountDocuments(ctx, bson.M{"_id": id})
+			return err == nil && n == 1
+		}, waitFor, tick, "the projector did not build the operation of the VAA %s", id)
+
+		assert.Eventually(t, func() bool {
+			res, err := http.Get(url)
+			if err != nil {
+				return false
+			}
+			res.Body.Close()
+			return res.StatusCode == http.StatusOK
+		}, waitFor, tick, "the API does not serve the VAA %s", id)
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+)
+
+// Configuration represents the configuration of the local development mode with the default values.
+// Every service shares the same database and p2p network, the message brokers are in-process.
+type Configuration struct {
+	Environment             string `env:"ENVIRONMENT,default=local"`
+	LogLevel                string `env:"LOG_LEVEL,default=INFO"`
+	P2pNetwork              string `env:"P2P_NETWORK,default=mainnet"`
+	MongoURI                string `env:"MONGODB_URI,default=mongodb://localhost:27017/?replicaSet=rs0"`
+	MongoDatabase           string `env:"MONGODB_DATABASE,default=wormscan"`
+	ApiPort                 int    `env:"API_PORT,default=8000"`
+	PipelinePort            string `env:"PIPELINE_PORT,default=8001"`
+	ParserPort              string `env:"PARSER_PORT,default=8002"`
+	ProjectorPort           string `env:"PROJECTOR_PORT,default=8003"`
+	FlyPort                 uint   `env:"FLY_PORT,default=8004"`
+	FlyP2pPort              uint   `env:"FLY_P2P_PORT,default=8999"`
+	TxTrackerPort           string `env:"TX_TRACKER_PORT,default=8005"`
+	AnalyticsPort           string `env:"ANALYTICS_PORT,default=8006"`
+	SpyPort                 string `env:"SPY_PORT,default=8007"`
+	SpyGrpcAddress          string `env:"SPY_GRPC_ADDRESS,default=0.0.0.0:7073"`
+	ContractWatcherPort     string `env:"CONTRACT_WATCHER_PORT,default=8008"`
+	VaaFixtureFile          string `env:"VAA_FIXTURE_FILE"`
+	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL"`
+	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT,default=10"`
+	// The redis is used by fly to publish the VAAs to the spy and by analytics as notional cache.
+	RedisURI        string `env:"REDIS_URI"`
+	RedisPrefix     string `env:"REDIS_PREFIX,default=wormscan-dev"`
+	RedisVaaChannel string `env:"REDIS_VAA_CHANNEL,default=gossip-signed-vaas"`
+	NotionalChannel string `env:"NOTIONAL_CHANNEL,default=WORMSCAN:NOTIONAL"`
+	// The influxdb is only used by analytics.
+	InfluxURL            string `env:"INFLUX_URL"`
+	InfluxToken          string `env:"INFLUX_TOKEN"`
+	InfluxOrganization   string `env:"INFLUX_ORGANIZATION"`
+	InfluxBucketInfinite string `env:"INFLUX_BUCKET_INFINITE"`
+	InfluxBucket30Days   string `env:"INFLUX_BUCKET_30_DAYS"`
+	InfluxBucket24Hours  string `env:"INFLUX_BUCKET_24_HOURS"`
+	// WatchersConfigPath is the contract watcher configuration with the RPC providers of the
+	// watched chains.
+	WatchersConfigPath string `env:"WATCHERS_CONFIG_PATH"`
+	// Tracing is shared by every service, the spans of all of them are exported by the same process.
+	Tracing tracing.Config
+}
+
+// New creates a configuration with the values from .env file and environment variables.
+func New(ctx context.Context) (*Configuration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration Configuration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// ParserEnabled returns true when the vaa payload parser needed by the parser is configured.
+func (c *Configuration) ParserEnabled() bool {
+	return c.VaaPayloadParserURL != ""
+}
+
+// FlyEnabled returns true when the VAAs are received from the p2p network instead of a fixture.
+func (c *Configuration) FlyEnabled() bool {
+	return c.VaaFixtureFile == ""
+}
+
+// SpyEnabled returns true when the redis where fly publishes the VAAs is configured.
+func (c *Configuration) SpyEnabled() bool {
+	return c.RedisURI != ""
+}
+
+// AnalyticsEnabled returns true when the influxdb, the notional cache and the vaa payload parser
+// needed by analytics are configured.
+func (c *Configuration) AnalyticsEnabled() bool {
+	return c.InfluxURL != "" && c.RedisURI != "" && c.ParserEnabled()
+}
+
+// ContractWatcherEnabled returns true when the configuration of the watched chains is set.
+func (c *Configuration) ContractWatcherEnabled() bool {
+	return c.WatchersConfigPath != ""
+}
@@ -0,0 +1,87 @@
+// Package fixture feeds the explorer with signed VAAs read from a file instead of the p2p network.
+//
+// A fixture file has one hex or base64 encoded signed VAA per line, optionally followed by the hash
+// of the transaction that emitted it. Empty lines and lines starting with # are ignored:
+//
+//	# chain 2, token bridge transfer
+//	01000000000100...  0x3a3c6a3e...
+package fixture
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Entry represents a signed VAA of a fixture file.
+type Entry struct {
+	VAA *vaa.VAA
+	// Data is the serialized signed VAA.
+	Data []byte
+	// TxHash is the hash of the transaction that emitted the VAA. When the fixture does not
+	// have it, the VAA hash is used so that the pipeline does not wait for an observation.
+	TxHash string
+}
+
+// ReadFile reads the VAAs of a fixture file.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads the VAAs of a fixture. Unlike the vaa dumps used to repair fly, fixtures are
+// written by hand so a line that can not be decoded is an error.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) > 2 {
+			return nil, fmt.Errorf("line %d: expected a vaa and an optional tx hash, got %d fields", n, len(fields))
+		}
+
+		data, err := decode(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		v, err := vaa.Unmarshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid vaa: %w", n, err)
+		}
+
+		txHash := v.HexDigest()
+		if len(fields) == 2 {
+			txHash = strings.TrimPrefix(fields[1], "0x")
+		}
+		entries = append(entries, Entry{VAA: v, Data: data, TxHash: txHash})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func decode(s string) ([]byte, error) {
+	if data, err := hex.DecodeString(strings.TrimPrefix(s, "0x")); err == nil {
+		return data, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("vaa is neither hex nor base64 encoded")
+	}
+	return data, nil
+}
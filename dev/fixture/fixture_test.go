@@ -0,0 +1,80 @@
+package fixture
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func newTestVAA(t *testing.T, chainID vaa.ChainID, seq uint64) []byte {
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 3,
+		Timestamp:        time.Unix(1690000000, 0),
+		Nonce:            1,
+		Sequence:         seq,
+		ConsistencyLevel: 1,
+		EmitterChain:     chainID,
+		EmitterAddress:   vaa.Address{31: 0x01},
+		Payload:          []byte{0x01, 0x02},
+	}
+	data, err := v.Marshal()
+	require.NoError(t, err)
+	return data
+}
+
+func TestRead(t *testing.T) {
+	first := newTestVAA(t, vaa.ChainIDEthereum, 1)
+	second := newTestVAA(t, vaa.ChainIDSolana, 2)
+	input := strings.Join([]string{
+		"# ethereum",
+		"0x" + hex.EncodeToString(first) + " 0xabcdef",
+		"",
+		base64.StdEncoding.EncodeToString(second),
+	}, "\n")
+
+	entries, err := Read(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "2/0000000000000000000000000000000000000000000000000000000000000001/1", entries[0].VAA.MessageID())
+	assert.Equal(t, first, entries[0].Data)
+	assert.Equal(t, "abcdef", entries[0].TxHash)
+
+	assert.Equal(t, vaa.ChainIDSolana, entries[1].VAA.EmitterChain)
+	assert.Equal(t, entries[1].VAA.HexDigest(), entries[1].TxHash)
+}
+
+func TestRead_InvalidLine(t *testing.T) {
+	valid := hex.EncodeToString(newTestVAA(t, vaa.ChainIDEthereum, 1))
+
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{name: "not encoded", input: valid + "\nnot-a-vaa", err: "line 2: vaa is neither hex nor base64 encoded"},
+		{name: "invalid vaa", input: "0102", err: "line 1: invalid vaa"},
+		{name: "too many fields", input: valid + " 0x01 extra", err: "line 1: expected a vaa and an optional tx hash"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tc.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestReadFile_Example(t *testing.T) {
+	entries, err := ReadFile("../fixtures/example.txt")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1000", entries[0].VAA.MessageID())
+}
@@ -0,0 +1,109 @@
+package fixture
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Loader stores fixture VAAs the same way fly stores the VAAs received from the p2p network,
+// so that the pipeline picks them up from the database.
+type Loader struct {
+	vaas        *mongo.Collection
+	vaasPythnet *mongo.Collection
+	vaaIdTxHash *mongo.Collection
+	logger      *zap.Logger
+}
+
+type vaaDocument struct {
+	Version          uint8       `bson:"version"`
+	EmitterChain     vaa.ChainID `bson:"emitterChain"`
+	EmitterAddr      string      `bson:"emitterAddr"`
+	Sequence         string      `bson:"sequence"`
+	GuardianSetIndex uint32      `bson:"guardianSetIndex"`
+	Vaa              []byte      `bson:"vaas"`
+	TxHash           string      `bson:"txHash,omitempty"`
+	Timestamp        *time.Time  `bson:"timestamp"`
+	UpdatedAt        *time.Time  `bson:"updatedAt"`
+	IndexedAt        time.Time   `bson:"indexedAt"`
+	Revision         int         `bson:"revision"`
+}
+
+type vaaIdTxHashDocument struct {
+	ChainID   vaa.ChainID `bson:"emitterChain"`
+	Emitter   string      `bson:"emitterAddr"`
+	Sequence  string      `bson:"sequence"`
+	TxHash    string      `bson:"txHash"`
+	UpdatedAt *time.Time  `bson:"updatedAt"`
+}
+
+// NewLoader creates a fixture loader.
+func NewLoader(db *mongo.Database, logger *zap.Logger) *Loader {
+	return &Loader{
+		vaas:        db.Collection("vaas"),
+		vaasPythnet: db.Collection("vaasPythnet"),
+		vaaIdTxHash: db.Collection("vaaIdTxHash"),
+		logger:      logger,
+	}
+}
+
+// Load upserts the VAAs and returns the number of VAAs that were not in the database.
+// VAAs already stored are left untouched, so loading the same fixture twice is a no-op.
+func (l *Loader) Load(ctx context.Context, entries []Entry) (int, error) {
+	inserted := 0
+	for _, e := range entries {
+		v := e.VAA
+		id := v.MessageID()
+		now := time.Now()
+		sequence := strconv.FormatUint(v.Sequence, 10)
+
+		collection := l.vaas
+		if v.EmitterChain == vaa.ChainIDPythNet {
+			collection = l.vaasPythnet
+		} else {
+			txHashDoc := vaaIdTxHashDocument{
+				ChainID:   v.EmitterChain,
+				Emitter:   v.EmitterAddress.String(),
+				Sequence:  sequence,
+				TxHash:    e.TxHash,
+				UpdatedAt: &now,
+			}
+			_, err := l.vaaIdTxHash.UpdateByID(ctx, id, bson.M{"$set": txHashDoc}, options.Update().SetUpsert(true))
+			if err != nil {
+				return inserted, err
+			}
+		}
+
+		doc := vaaDocument{
+			Version:          v.Version,
+			EmitterChain:     v.EmitterChain,
+			EmitterAddr:      v.EmitterAddress.String(),
+			Sequence:         sequence,
+			GuardianSetIndex: v.GuardianSetIndex,
+			Vaa:              e.Data,
+			Timestamp:        &v.Timestamp,
+			UpdatedAt:        &now,
+			IndexedAt:        now,
+			Revision:         1,
+		}
+		if v.EmitterChain != vaa.ChainIDPythNet {
+			doc.TxHash = e.TxHash
+		}
+		update := bson.M{"$setOnInsert": doc}
+		result, err := collection.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return inserted, err
+		}
+		if result.UpsertedCount > 0 {
+			inserted++
+			l.logger.Debug("Fixture vaa inserted", zap.String("id", id))
+		}
+	}
+	return inserted, nil
+}
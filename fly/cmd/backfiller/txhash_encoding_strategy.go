@@ -39,6 +39,7 @@ func RunTxHashEncoding(cfg TxHashEncondingConfig) {
 		metrics.NewDummyMetrics(),
 		db.Database,
 		producer.NewVAAInMemory(logger).Push,
+		nil,
 		logger)
 
 	workerTxHashEncoding(ctx, logger, repository, vaa.ChainID(cfg.ChainID), cfg.PageSize)
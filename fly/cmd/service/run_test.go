@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/config"
+	"go.uber.org/zap"
+)
+
+func TestNewVAARedisProducerFuncLocalWithoutRedis(t *testing.T) {
+
+	pushFunc, err := newVAARedisProducerFunc(&config.Configuration{}, true, zap.NewNop())
+
+	assert.NoError(t, err)
+	assert.NoError(t, pushFunc(context.Background(), nil))
+}
+
+func TestNewVAARedisProducerFuncWithoutRedis(t *testing.T) {
+
+	_, err := newVAARedisProducerFunc(&config.Configuration{}, false, zap.NewNop())
+
+	assert.Error(t, err)
+}
+
+func TestNewVAARedisProducerFuncLocalWithRedis(t *testing.T) {
+
+	cfg := &config.Configuration{RedisConfiguration: config.RedisConfiguration{RedisURI: "localhost:6379"}}
+
+	_, err := newVAARedisProducerFunc(cfg, true, zap.NewNop())
+
+	assert.Error(t, err, "the local execution uses redis when it is configured")
+}
+
+func TestNewVAANotifierFuncWithoutRedis(t *testing.T) {
+
+	_, err := newVAANotifierFunc(&config.Configuration{}, false, zap.NewNop())
+
+	assert.Error(t, err)
+
+	notifyFunc, err := newVAANotifierFunc(&config.Configuration{}, true, zap.NewNop())
+
+	assert.NoError(t, err)
+	assert.NoError(t, notifyFunc(context.Background(), nil, nil))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/sethvargo/go-envconfig"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 const defaultMaxHealthTimeSeconds = 60
@@ -41,9 +42,11 @@ type P2pNetworkConfig struct {
 
 // GetP2pNetwork get p2p network config.
 func GetP2pNetwork() (*P2pNetworkConfig, error) {
+	return NewP2pNetworkConfig(os.Getenv("P2P_NETWORK"))
+}
 
-	p2pEnviroment := os.Getenv("P2P_NETWORK")
-
+// NewP2pNetworkConfig get the p2p network config of the p2p environment.
+func NewP2pNetworkConfig(p2pEnviroment string) (*P2pNetworkConfig, error) {
 	switch p2pEnviroment {
 	case domain.P2pMainNet:
 		return &P2pNetworkConfig{domain.P2pMainNet, MainNetP2ppNetworkID, MainNetP2pBootstrap}, nil
@@ -123,13 +126,63 @@ func GetPrefix() string {
 }
 
 type Configuration struct {
-	ObservationsChannelSize   int  `env:"OBSERVATIONS_CHANNEL_SIZE,required"`
-	VaasChannelSize           int  `env:"VAAS_CHANNEL_SIZE,required"`
-	HeartbeatsChannelSize     int  `env:"HEARTBEATS_CHANNEL_SIZE,required"`
-	GovernorConfigChannelSize int  `env:"GOVERNOR_CONFIG_CHANNEL_SIZE,required"`
-	GovernorStatusChannelSize int  `env:"GOVERNOR_STATUS_CHANNEL_SIZE,required"`
-	ApiPort                   uint `env:"API_PORT,required"`
-	P2pPort                   uint `env:"P2P_PORT,required"`
+	Environment               string `env:"ENVIRONMENT"`
+	P2pNetwork                string `env:"P2P_NETWORK,required"`
+	MongoURI                  string `env:"MONGODB_URI,required"`
+	MongoDatabase             string `env:"MONGODB_DATABASE,required"`
+	ObservationsChannelSize   int    `env:"OBSERVATIONS_CHANNEL_SIZE,required"`
+	VaasChannelSize           int    `env:"VAAS_CHANNEL_SIZE,required"`
+	HeartbeatsChannelSize     int    `env:"HEARTBEATS_CHANNEL_SIZE,required"`
+	GovernorConfigChannelSize int    `env:"GOVERNOR_CONFIG_CHANNEL_SIZE,required"`
+	GovernorStatusChannelSize int    `env:"GOVERNOR_STATUS_CHANNEL_SIZE,required"`
+	ApiPort                   uint   `env:"API_PORT,required"`
+	P2pPort                   uint   `env:"P2P_PORT,required"`
+	// HeartbeatsHistorySampleSeconds is the minimum time between two samples of the same guardian
+	// stored in the heartbeatsHistory collection.
+	HeartbeatsHistorySampleSeconds int `env:"HEARTBEATS_HISTORY_SAMPLE_SECONDS,default=60"`
+	// HeartbeatsHistoryRetentionDays is the time samples are kept in the heartbeatsHistory collection.
+	HeartbeatsHistoryRetentionDays int `env:"HEARTBEATS_HISTORY_RETENTION_DAYS,default=30"`
+	// VaaProcessingRetentionDays is the time the processing lineage of a VAA is kept after its last update.
+	VaaProcessingRetentionDays int `env:"VAA_PROCESSING_RETENTION_DAYS,default=30"`
+	RepairConfiguration
+	QueueConfiguration
+	RedisConfiguration
+	Tracing tracing.Config
+}
+
+// RedisConfiguration represents the redis where the VAAs are published to the spy and the last
+// sequences are notified. It is required unless fly runs in local mode.
+type RedisConfiguration struct {
+	RedisURI        string `env:"REDIS_URI"`
+	RedisPrefix     string `env:"REDIS_PREFIX"`
+	RedisVaaChannel string `env:"REDIS_VAA_CHANNEL"`
+}
+
+// QueueConfiguration represents the message broker configuration of the VAA queue. The SQS
+// broker uses the queue SQS_URL and the others the consumer group of the VAAs topic.
+type QueueConfiguration struct {
+	QueueBroker                string `env:"QUEUE_BROKER,default=sqs"`
+	QueueRedisURI              string `env:"QUEUE_REDIS_URI"`
+	QueueNatsURL               string `env:"QUEUE_NATS_URL"`
+	QueueNatsStreamMaxAgeHours int    `env:"QUEUE_NATS_STREAM_MAX_AGE_HOURS,default=168"`
+	QueueNatsStreamMaxBytes    int64  `env:"QUEUE_NATS_STREAM_MAX_BYTES,default=1073741824"`
+	VaasTopic                  string `env:"VAAS_TOPIC,default=wormscan.fly.vaas"`
+	ConsumerGroup              string `env:"CONSUMER_GROUP,default=fly"`
+}
+
+// RepairConfiguration represents the configuration of the worker that repairs missing VAAs.
+type RepairConfiguration struct {
+	RepairEnabled bool `env:"REPAIR_ENABLED,default=false"`
+	// RepairGuardianRPCs is a comma separated list of guardian public gRPC endpoints.
+	RepairGuardianRPCs string `env:"REPAIR_GUARDIAN_RPCS"`
+	// RepairExplorerURLs is a comma separated list of explorer API urls.
+	RepairExplorerURLs string `env:"REPAIR_EXPLORER_URLS"`
+	// RepairFilePath is the path of a file with one hex or base64 encoded signed VAA per line.
+	RepairFilePath           string `env:"REPAIR_FILE_PATH"`
+	RepairIntervalSeconds    int    `env:"REPAIR_INTERVAL_SECONDS,default=300"`
+	RepairMaxGaps            int64  `env:"REPAIR_MAX_GAPS,default=100"`
+	RepairMaxSequencesPerGap uint64 `env:"REPAIR_MAX_SEQUENCES_PER_GAP,default=100"`
+	RepairRetryDelaySeconds  int    `env:"REPAIR_RETRY_DELAY_SECONDS,default=3600"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
@@ -64,3 +64,6 @@ func (d *DummyMetrics) IncGovernorStatusInserted(guardianName string) {}
 
 // IncMaxSequenceCacheError increases the number of errors when updating max sequence cache.
 func (d *DummyMetrics) IncMaxSequenceCacheError(chain sdk.ChainID) {}
+
+// IncVaaRepaired increases the number of missing vaa repaired from external sources.
+func (d *DummyMetrics) IncVaaRepaired(chain sdk.ChainID) {}
@@ -12,6 +12,7 @@ type Metrics interface {
 	IncVaaInserted(chain sdk.ChainID)
 	IncVaaSendNotification(chain sdk.ChainID)
 	IncVaaTotal()
+	IncVaaRepaired(chain sdk.ChainID)
 
 	// observation metrics
 	IncObservationFromGossipNetwork(chain sdk.ChainID)
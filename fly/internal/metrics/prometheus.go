@@ -199,3 +199,8 @@ func (m *PrometheusMetrics) IncGovernorStatusInserted(guardianName string) {
 func (m *PrometheusMetrics) IncMaxSequenceCacheError(chain sdk.ChainID) {
 	m.maxSequenceCacheCount.WithLabelValues(chain.String()).Inc()
 }
+
+// IncVaaRepaired increases the number of missing vaa repaired from external sources.
+func (m *PrometheusMetrics) IncVaaRepaired(chain sdk.ChainID) {
+	m.vaaReceivedCount.WithLabelValues(chain.String(), "repaired").Inc()
+}
@@ -3,14 +3,16 @@ package migration
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // TODO: move this to migration tool that support mongodb.
-func Run(db *mongo.Database) error {
+func Run(db *mongo.Database, heartbeatsHistoryRetention, vaaProcessingRetention time.Duration) error {
 	// Created governorConfig collection.
 	err := db.CreateCollection(context.TODO(), "governorConfig")
 	if err != nil && isNotAlreadyExistsError(err) {
@@ -29,6 +31,20 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// Created heartbeatsHistory time series collection.
+	// Samples older than the retention period are removed by mongodb.
+	expireAfterSeconds := int64(heartbeatsHistoryRetention.Seconds())
+	heartbeatsHistoryOptions := options.CreateCollection().
+		SetTimeSeriesOptions(options.TimeSeries().
+			SetTimeField("timestamp").
+			SetMetaField("guardianAddr").
+			SetGranularity("minutes")).
+		SetExpireAfterSeconds(expireAfterSeconds)
+	err = db.CreateCollection(context.TODO(), "heartbeatsHistory", heartbeatsHistoryOptions)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// Created observations collection.
 	err = db.CreateCollection(context.TODO(), "observations")
 	if err != nil && isNotAlreadyExistsError(err) {
@@ -106,6 +122,58 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in observations collection by indexedAt, emitterChain and messageId.
+	// it covers the count of the messages observed by chain of the guardian statistics.
+	indexObservationsByIndexedAtAndEmitterChainAndMessageId := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "indexedAt", Value: 1},
+			{Key: "emitterChain", Value: 1},
+			{Key: "messageId", Value: 1}}}
+	_, err = db.Collection("observations").Indexes().CreateOne(context.TODO(), indexObservationsByIndexedAtAndEmitterChainAndMessageId)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in observations collection by guardianAddr and indexedAt.
+	indexObservationsByGuardianAddrAndIndexedAt := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "guardianAddr", Value: 1},
+			{Key: "indexedAt", Value: -1}}}
+	_, err = db.Collection("observations").Indexes().CreateOne(context.TODO(), indexObservationsByGuardianAddrAndIndexedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in heartbeatsHistory collection by guardian and timestamp.
+	indexHeartbeatsHistoryByGuardianAddrAndTimestamp := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "guardianAddr", Value: 1},
+			{Key: "timestamp", Value: 1}}}
+	_, err = db.Collection("heartbeatsHistory").Indexes().CreateOne(context.TODO(), indexHeartbeatsHistoryByGuardianAddrAndTimestamp)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in sequenceGaps collection by status, repair.attemptedAt and detectedAt.
+	// it covers the order in which the open gaps are repaired.
+	indexSequenceGapsByStatusAndRepairAttemptedAt := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "repair.attemptedAt", Value: 1},
+			{Key: "detectedAt", Value: 1}}}
+	_, err = db.Collection("sequenceGaps").Indexes().CreateOne(context.TODO(), indexSequenceGapsByStatusAndRepairAttemptedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create the indexes of the vaaProcessing collection, the lineage expires after the retention period.
+	for _, index := range lineage.Indexes(vaaProcessingRetention) {
+		_, err = db.Collection(lineage.Collection).Indexes().CreateOne(context.TODO(), index)
+		if err != nil && isNotAlreadyExistsError(err) {
+			return err
+		}
+	}
+
 	// create index in vaaIdTxHash collect.
 	indexVaaIdTxHashByTxHash := mongo.IndexModel{
 		Keys: bson.D{{Key: "txHash", Value: 1}}}
@@ -3,14 +3,18 @@ package processor
 import (
 	"context"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/deduplicator"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/guardiansets"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
 
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const tracerName = "github.com/wormhole-foundation/wormhole-explorer/fly/processor"
+
 type vaaGossipConsumer struct {
 	guardianSetHistory *guardiansets.GuardianSetHistory
 	nonPythProcess     VAAPushFunc
@@ -41,14 +45,21 @@ func NewVAAGossipConsumer(
 }
 
 // Push handles incoming VAAs depending on whether it is a pyth or non pyth.
-func (p *vaaGossipConsumer) Push(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
+func (p *vaaGossipConsumer) Push(ctx context.Context, v *vaa.VAA, serializedVaa []byte) (err error) {
+	// only the VAAs traced since their receipt are traced, i.e. the pyth VAAs are not traced.
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		var span trace.Span
+		ctx, span = tracing.Tracer(tracerName).Start(ctx, "fly.vaa.process",
+			trace.WithAttributes(tracing.VaaIDKey.String(v.MessageID())))
+		defer func() { tracing.EndSpan(span, err) }()
+	}
 
 	if err := p.guardianSetHistory.Verify(ctx, v); err != nil {
 		p.logger.Error("Received invalid vaa", zap.String("id", v.MessageID()))
 		return err
 	}
 
-	err := p.deduplicator.Apply(ctx, v.MessageID(), func() error {
+	err = p.deduplicator.Apply(ctx, v.MessageID(), func() error {
 		p.metrics.IncVaaUnfiltered(v.EmitterChain)
 		if vaa.ChainIDPythNet == v.EmitterChain {
 			return p.pythProcess(ctx, v, serializedVaa)
@@ -20,6 +20,7 @@ type VAAGossipConsumerSplitter struct {
 }
 
 type sppliterMessage struct {
+	ctx   context.Context
 	value *vaa.VAA
 	data  []byte
 }
@@ -52,6 +53,7 @@ func WithSize(v int) VAAGossipConsumerSplitterOption {
 // Push splits vaa message on different channels depending on whether it is a pyth or non pyth.
 func (p *VAAGossipConsumerSplitter) Push(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
 	msg := &sppliterMessage{
+		ctx:   ctx,
 		value: v,
 		data:  serializedVaa,
 	}
@@ -93,7 +95,7 @@ func (p *VAAGossipConsumerSplitter) executePyth(ctx context.Context) {
 			if !opened {
 				return
 			}
-			_ = p.push(ctx, m.value, m.data)
+			_ = p.push(m.ctx, m.value, m.data)
 		}
 	}
 }
@@ -107,7 +109,7 @@ func (p *VAAGossipConsumerSplitter) executeNonPyth(ctx context.Context) {
 			if !opened {
 				return
 			}
-			_ = p.push(ctx, m.value, m.data)
+			_ = p.push(m.ctx, m.value, m.data)
 		}
 	}
 }
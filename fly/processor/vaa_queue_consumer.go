@@ -3,11 +3,13 @@ package processor
 import (
 	"context"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/queue"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/storage"
 
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -43,6 +45,7 @@ func NewVAAQueueConsumer(
 func (c *VAAQueueConsumer) Start(ctx context.Context) {
 	go func() {
 		for msg := range c.consume(ctx) {
+			msgCtx := msg.Context()
 			v, err := vaa.Unmarshal(msg.Data())
 			if err != nil {
 				c.logger.Error("Error unmarshalling vaa", zap.Error(err))
@@ -57,8 +60,9 @@ func (c *VAAQueueConsumer) Start(ctx context.Context) {
 			}
 
 			c.metrics.IncVaaConsumedFromQueue(v.EmitterChain)
+			trace.SpanFromContext(msgCtx).SetAttributes(tracing.VaaIDKey.String(v.MessageID()))
 
-			err = c.repository.UpsertVaa(ctx, v, msg.Data())
+			err = c.repository.UpsertVaa(msgCtx, v, msg.Data())
 			if err != nil {
 				c.logger.Error("Error inserting vaa in repository",
 					zap.String("id", v.MessageID()),
@@ -67,7 +71,7 @@ func (c *VAAQueueConsumer) Start(ctx context.Context) {
 				continue
 			}
 
-			err = c.notifyFunc(ctx, v, msg.Data())
+			err = c.notifyFunc(msgCtx, v, msg.Data())
 			if err != nil {
 				c.metrics.IncMaxSequenceCacheError(v.EmitterChain)
 				c.logger.Error("Error notifying vaa",
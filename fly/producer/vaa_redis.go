@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 // RedisProducer represents a redis producer.
@@ -24,6 +25,8 @@ func NewRedisProducer(c *redis.Client, channel string) *RedisProducer {
 
 // Push pushes a NotificationEvent to redis.
 func (p *RedisProducer) Push(ctx context.Context, n *Notification) error {
+	// the redis channel has no message attributes, the trace context is sent in the event.
+	n.Event.TraceContext = tracing.Inject(ctx)
 	body, err := json.Marshal(n.Event)
 	if err != nil {
 		return err
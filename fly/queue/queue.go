@@ -8,4 +8,6 @@ type Message interface {
 	Done(context.Context)
 	Failed()
 	IsExpired() bool
+	// Context returns the context with the trace of the message.
+	Context() context.Context
 }
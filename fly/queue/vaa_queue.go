@@ -0,0 +1,152 @@
+package queue
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const tracerName = "github.com/wormhole-foundation/wormhole-explorer/fly/queue"
+
+var errMessageFailed = errors.New("message failed")
+
+// VAAQueueOption represents a VAA queue option function.
+type VAAQueueOption func(*VAAQueue)
+
+// VAAQueue represents a VAA queue in a message broker.
+type VAAQueue struct {
+	producer broker.Producer
+	consumer broker.Consumer
+	ch       chan Message
+	chSize   int
+	wg       sync.WaitGroup
+	logger   *zap.Logger
+}
+
+// NewVAAQueue creates a VAA queue instance.
+func NewVAAQueue(producer broker.Producer, consumer broker.Consumer, logger *zap.Logger, opts ...VAAQueueOption) *VAAQueue {
+	s := &VAAQueue{
+		producer: producer,
+		consumer: consumer,
+		chSize:   10,
+		logger:   logger}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ch = make(chan Message, s.chSize)
+	return s
+}
+
+// WithChannelSize allows to specify an channel size when setting a value.
+func WithChannelSize(size int) VAAQueueOption {
+	return func(d *VAAQueue) {
+		d.chSize = size
+	}
+}
+
+// Publish sends the message to the queue.
+func (q *VAAQueue) Publish(ctx context.Context, v *vaa.VAA, data []byte) error {
+	body := base64.StdEncoding.EncodeToString(data)
+	groupID := fmt.Sprintf("%d/%s", v.EmitterChain, v.EmitterAddress)
+	return q.producer.Publish(ctx, &broker.OutgoingMessage{
+		GroupID:         groupID,
+		DeduplicationID: v.MessageID(),
+		Body:            []byte(body),
+		Attributes:      tracing.Inject(ctx),
+	})
+}
+
+// Consume returns the channel with the received messages from the queue.
+func (q *VAAQueue) Consume(ctx context.Context) <-chan Message {
+	go func() {
+		for {
+			messages, err := q.consumer.Receive(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				q.logger.Error("Error getting messages from broker", zap.Error(err))
+				continue
+			}
+			expiredAt := time.Now().Add(q.consumer.VisibilityTimeout())
+			for _, msg := range messages {
+				body, err := base64.StdEncoding.DecodeString(string(msg.Body()))
+				if err != nil {
+					q.logger.Error("Error decoding message from broker", zap.Error(err))
+					continue
+				}
+
+				msgCtx, span := tracing.StartConsumerSpan(ctx, tracerName, "fly.vaa.consume", msg.Attributes())
+
+				//TODO check if callback is better than channel
+				q.wg.Add(1)
+				q.ch <- &brokerConsumerMessage{
+					message:   msg,
+					data:      body,
+					wg:        &q.wg,
+					logger:    q.logger,
+					expiredAt: expiredAt,
+					ctx:       msgCtx,
+					span:      span,
+				}
+			}
+			q.wg.Wait()
+		}
+	}()
+	return q.ch
+}
+
+// Close closes all consumer resources.
+func (q *VAAQueue) Close() {
+	close(q.ch)
+	if err := q.consumer.Close(); err != nil {
+		q.logger.Error("Error closing broker consumer", zap.Error(err))
+	}
+	if err := q.producer.Close(); err != nil {
+		q.logger.Error("Error closing broker producer", zap.Error(err))
+	}
+}
+
+type brokerConsumerMessage struct {
+	data      []byte
+	message   broker.Message
+	logger    *zap.Logger
+	expiredAt time.Time
+	wg        *sync.WaitGroup
+	ctx       context.Context
+	span      trace.Span
+}
+
+func (m *brokerConsumerMessage) Data() []byte {
+	return m.data
+}
+
+func (m *brokerConsumerMessage) Done(ctx context.Context) {
+	if err := m.message.Done(ctx); err != nil {
+		m.logger.Error("Error deleting message from broker", zap.Error(err))
+	}
+	m.span.End()
+	m.wg.Done()
+}
+
+func (m *brokerConsumerMessage) Failed() {
+	tracing.EndSpan(m.span, errMessageFailed)
+	m.wg.Done()
+}
+
+func (m *brokerConsumerMessage) IsExpired() bool {
+	return m.expiredAt.Before(time.Now())
+}
+
+func (m *brokerConsumerMessage) Context() context.Context {
+	return m.ctx
+}
@@ -0,0 +1,62 @@
+package repair
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ExplorerSource fetches signed VAAs from the guardian compatible API of another explorer instance.
+type ExplorerSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewExplorerSource creates a source for an explorer API base url.
+func NewExplorerSource(baseURL string) *ExplorerSource {
+	return &ExplorerSource{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name returns the explorer url.
+func (s *ExplorerSource) Name() string {
+	return "explorer " + s.baseURL
+}
+
+// GetSignedVAA calls the /v1/signed_vaa endpoint of the explorer.
+func (s *ExplorerSource) GetSignedVAA(ctx context.Context, chainID vaa.ChainID, emitterAddr vaa.Address, seq uint64) ([]byte, error) {
+	url := fmt.Sprintf("%s/v1/signed_vaa/%d/%s/%d", s.baseURL, chainID, emitterAddr.String(), seq)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrVaaNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", res.StatusCode, url)
+	}
+	var body struct {
+		VaaBytes []byte `json:"vaaBytes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.VaaBytes) == 0 {
+		return nil, ErrVaaNotFound
+	}
+	return body.VaaBytes, nil
+}
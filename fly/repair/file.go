@@ -0,0 +1,67 @@
+package repair
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// FileSource serves signed VAAs from a local file dump with one hex or base64 encoded VAA per line.
+type FileSource struct {
+	path string
+	vaas map[string][]byte
+}
+
+// NewFileSource loads the VAAs of a file dump. Lines that can not be decoded are skipped.
+func NewFileSource(path string) (*FileSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vaas := make(map[string][]byte)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			data, err = base64.StdEncoding.DecodeString(line)
+			if err != nil {
+				continue
+			}
+		}
+		v, err := vaa.Unmarshal(data)
+		if err != nil {
+			continue
+		}
+		vaas[v.MessageID()] = data
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &FileSource{path: path, vaas: vaas}, nil
+}
+
+// Name returns the file path.
+func (s *FileSource) Name() string {
+	return "file " + s.path
+}
+
+// GetSignedVAA returns the VAA from the file dump.
+func (s *FileSource) GetSignedVAA(_ context.Context, chainID vaa.ChainID, emitterAddr vaa.Address, seq uint64) ([]byte, error) {
+	data, ok := s.vaas[messageID(chainID, emitterAddr, seq)]
+	if !ok {
+		return nil, ErrVaaNotFound
+	}
+	return data, nil
+}
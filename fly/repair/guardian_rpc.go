@@ -0,0 +1,70 @@
+package repair
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// GuardianRPCSource fetches signed VAAs from the public gRPC endpoint of a guardian.
+type GuardianRPCSource struct {
+	endpoint string
+	conn     *grpc.ClientConn
+	client   publicrpcv1.PublicRPCServiceClient
+}
+
+// NewGuardianRPCSource creates a source for a guardian public RPC endpoint.
+// Endpoints prefixed with http:// are dialed without TLS, any other endpoint uses TLS.
+func NewGuardianRPCSource(endpoint string) (*GuardianRPCSource, error) {
+	target := strings.TrimPrefix(endpoint, "https://")
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if strings.HasPrefix(endpoint, "http://") {
+		target = strings.TrimPrefix(endpoint, "http://")
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &GuardianRPCSource{
+		endpoint: endpoint,
+		conn:     conn,
+		client:   publicrpcv1.NewPublicRPCServiceClient(conn),
+	}, nil
+}
+
+// Name returns the guardian endpoint.
+func (s *GuardianRPCSource) Name() string {
+	return "guardian " + s.endpoint
+}
+
+// GetSignedVAA calls GetSignedVAA on the guardian public RPC.
+func (s *GuardianRPCSource) GetSignedVAA(ctx context.Context, chainID vaa.ChainID, emitterAddr vaa.Address, seq uint64) ([]byte, error) {
+	res, err := s.client.GetSignedVAA(ctx, &publicrpcv1.GetSignedVAARequest{
+		MessageId: &publicrpcv1.MessageID{
+			EmitterChain:   publicrpcv1.ChainID(chainID),
+			EmitterAddress: emitterAddr.String(),
+			Sequence:       seq,
+		},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, ErrVaaNotFound
+		}
+		return nil, err
+	}
+	return res.VaaBytes, nil
+}
+
+// Close closes the connection to the guardian.
+func (s *GuardianRPCSource) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,194 @@
+package repair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Verifier validates the guardian signatures of a VAA.
+type Verifier interface {
+	Verify(ctx context.Context, v *vaa.VAA) error
+}
+
+// GapFinderFunc returns the open sequence gaps to repair.
+type GapFinderFunc func(ctx context.Context, limit int64) ([]domain.SequenceGap, error)
+
+// GapRepairUpdateFunc stores the repair progress of a sequence gap.
+type GapRepairUpdateFunc func(ctx context.Context, id string, repair domain.GapRepair) error
+
+// VaaUpsertFunc stores a signed VAA.
+type VaaUpsertFunc func(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error
+
+// Config is the repair worker configuration.
+type Config struct {
+	// Interval is the time between two repair runs.
+	Interval time.Duration
+	// MaxGaps is the maximum number of gaps repaired in a run.
+	MaxGaps int64
+	// MaxSequencesPerGap is the maximum number of sequences of a gap requested in a run. The next run
+	// continues from the last sequence requested.
+	MaxSequencesPerGap uint64
+	// RetryDelay is the minimum time before a sequence not found in any source is requested again.
+	RetryDelay time.Duration
+}
+
+// Repairer fetches missing VAAs from a list of sources, verifies them against the guardian set
+// and stores them so that the pipeline processes them as any other VAA.
+type Repairer struct {
+	cfg          Config
+	sources      []Source
+	verifier     Verifier
+	findGaps     GapFinderFunc
+	updateRepair GapRepairUpdateFunc
+	upsertVaa    VaaUpsertFunc
+	metrics      metrics.Metrics
+	logger       *zap.Logger
+	attempts     map[string]time.Time
+}
+
+// NewRepairer creates a new repair worker.
+func NewRepairer(cfg Config, sources []Source, verifier Verifier, findGaps GapFinderFunc, updateRepair GapRepairUpdateFunc,
+	upsertVaa VaaUpsertFunc, metrics metrics.Metrics, logger *zap.Logger) *Repairer {
+	return &Repairer{
+		cfg:          cfg,
+		sources:      sources,
+		verifier:     verifier,
+		findGaps:     findGaps,
+		updateRepair: updateRepair,
+		upsertVaa:    upsertVaa,
+		metrics:      metrics,
+		logger:       logger.With(zap.String("module", "VaaRepairer")),
+		attempts:     make(map[string]time.Time),
+	}
+}
+
+// Start runs the repair of the open gaps every interval until the context is cancelled.
+func (r *Repairer) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.repairGaps(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+func (r *Repairer) repairGaps(ctx context.Context, now time.Time) {
+	gaps, err := r.findGaps(ctx, r.cfg.MaxGaps)
+	if err != nil {
+		r.logger.Error("error finding sequence gaps", zap.Error(err))
+		return
+	}
+
+	// forget the attempts old enough to be retried.
+	for id, at := range r.attempts {
+		if now.Sub(at) >= r.cfg.RetryDelay {
+			delete(r.attempts, id)
+		}
+	}
+
+	for i := range gaps {
+		g := &gaps[i]
+		emitterAddr, err := vaa.StringToAddress(g.EmitterAddr)
+		if err != nil {
+			r.logger.Error("invalid emitter address", zap.String("gap", g.ID), zap.Error(err))
+			continue
+		}
+		sequences, next := r.nextSequences(g, emitterAddr, now)
+		r.Repair(ctx, g.EmitterChain, emitterAddr, sequences)
+
+		// the gap is stored as repaired even without sequences to request, so that the other gaps go first.
+		repair := domain.GapRepair{NextSequence: next, AttemptedAt: now}
+		if err := r.updateRepair(ctx, g.ID, repair); err != nil {
+			r.logger.Error("error updating sequence gap repair", zap.String("gap", g.ID), zap.Error(err))
+		}
+	}
+}
+
+// nextSequences returns up to MaxSequencesPerGap sequences of the gap to request and the sequence where the
+// next repair of the gap starts. The sequences start where the previous repair stopped and go back to the
+// start of the gap after the end, until a sequence requested less than RetryDelay ago is reached.
+func (r *Repairer) nextSequences(g *domain.SequenceGap, emitterAddr vaa.Address, now time.Time) ([]uint64, uint64) {
+	seq := g.StartSequence
+	if g.Repair != nil && g.Repair.NextSequence > g.StartSequence && g.Repair.NextSequence <= g.EndSequence {
+		seq = g.Repair.NextSequence
+	}
+
+	var sequences []uint64
+	for uint64(len(sequences)) < r.cfg.MaxSequencesPerGap {
+		id := messageID(g.EmitterChain, emitterAddr, seq)
+		if _, ok := r.attempts[id]; ok {
+			break
+		}
+		r.attempts[id] = now
+		sequences = append(sequences, seq)
+		if seq == g.EndSequence {
+			seq = g.StartSequence
+		} else {
+			seq++
+		}
+	}
+	return sequences, seq
+}
+
+// Repair fetches and stores the VAAs of the given sequences of an emitter.
+// It returns the number of VAAs stored.
+func (r *Repairer) Repair(ctx context.Context, chainID vaa.ChainID, emitterAddr vaa.Address, sequences []uint64) int {
+	var repaired int
+	for _, seq := range sequences {
+		id := messageID(chainID, emitterAddr, seq)
+		if err := r.repair(ctx, chainID, emitterAddr, seq); err != nil {
+			r.logger.Warn("could not repair vaa", zap.String("id", id), zap.Error(err))
+			continue
+		}
+		r.logger.Info("vaa repaired", zap.String("id", id))
+		r.metrics.IncVaaRepaired(chainID)
+		repaired++
+	}
+	return repaired
+}
+
+func (r *Repairer) repair(ctx context.Context, chainID vaa.ChainID, emitterAddr vaa.Address, seq uint64) error {
+	id := messageID(chainID, emitterAddr, seq)
+	for _, source := range r.sources {
+		data, err := source.GetSignedVAA(ctx, chainID, emitterAddr, seq)
+		if err != nil {
+			if !errors.Is(err, ErrVaaNotFound) {
+				r.logger.Warn("error fetching vaa", zap.String("id", id), zap.String("source", source.Name()), zap.Error(err))
+			}
+			continue
+		}
+		v, err := vaa.Unmarshal(data)
+		if err != nil {
+			r.logger.Warn("error unmarshalling vaa", zap.String("id", id), zap.String("source", source.Name()), zap.Error(err))
+			continue
+		}
+		if v.MessageID() != id {
+			r.logger.Warn("source returned another vaa", zap.String("id", id), zap.String("source", source.Name()),
+				zap.String("vaaId", v.MessageID()))
+			continue
+		}
+		if err := r.verifier.Verify(ctx, v); err != nil {
+			r.logger.Warn("invalid vaa", zap.String("id", id), zap.String("source", source.Name()), zap.Error(err))
+			continue
+		}
+		return r.upsertVaa(ctx, v, data)
+	}
+	return ErrVaaNotFound
+}
+
+func messageID(chainID vaa.ChainID, emitterAddr vaa.Address, seq uint64) string {
+	return fmt.Sprintf("%d/%s/%d", chainID, emitterAddr, seq)
+}
@@ -0,0 +1,186 @@
+package repair
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	publicrpcv1 "github.com/certusone/wormhole/node/pkg/proto/publicrpc/v1"
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var emitter = vaa.Address{0x01, 0x02, 0x03}
+
+type keyVerifier struct {
+	keys []eth_common.Address
+}
+
+func (k *keyVerifier) Verify(_ context.Context, v *vaa.VAA) error {
+	if !v.VerifySignatures(k.keys) {
+		return errors.New("VAA contains invalid signatures")
+	}
+	return nil
+}
+
+func signedVaa(t *testing.T, key *ecdsa.PrivateKey, seq uint64) []byte {
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 0,
+		Timestamp:        time.Unix(1700000000, 0),
+		Nonce:            1,
+		Sequence:         seq,
+		ConsistencyLevel: 1,
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   emitter,
+		Payload:          []byte("payload"),
+	}
+	v.AddSignature(key, 0)
+	data, err := v.Marshal()
+	require.NoError(t, err)
+	return data
+}
+
+// stubPublicRPC is a guardian public RPC server that serves the VAAs it knows.
+type stubPublicRPC struct {
+	publicrpcv1.UnimplementedPublicRPCServiceServer
+	vaas  map[uint64][]byte
+	calls int
+}
+
+func (s *stubPublicRPC) GetSignedVAA(_ context.Context, req *publicrpcv1.GetSignedVAARequest) (*publicrpcv1.GetSignedVAAResponse, error) {
+	s.calls++
+	data, ok := s.vaas[req.MessageId.Sequence]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "requested VAA not found in store")
+	}
+	return &publicrpcv1.GetSignedVAAResponse{VaaBytes: data}, nil
+}
+
+func startPublicRPC(t *testing.T, stub *stubPublicRPC) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	publicrpcv1.RegisterPublicRPCServiceServer(server, stub)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return "http://" + lis.Addr().String()
+}
+
+func TestRepairer_Repair(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	badKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	// the explorer returns a VAA signed by an unknown key for sequence 11, so it must be ignored
+	// and the guardian is queried.
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/signed_vaa/2/"+emitter.String()+"/11" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string][]byte{"vaaBytes": signedVaa(t, badKey, 11)})
+	}))
+	defer explorer.Close()
+
+	dump := filepath.Join(t.TempDir(), "vaas.txt")
+	content := "not a vaa\n" + hex.EncodeToString(signedVaa(t, key, 10)) + "\n"
+	require.NoError(t, os.WriteFile(dump, []byte(content), 0600))
+
+	stub := &stubPublicRPC{vaas: map[uint64][]byte{
+		11: signedVaa(t, key, 11),
+		12: signedVaa(t, key, 12),
+	}}
+	sources, err := NewSources(startPublicRPC(t, stub), explorer.URL, dump)
+	require.NoError(t, err)
+	require.Len(t, sources, 3)
+
+	stored := map[string][]byte{}
+	upsert := func(_ context.Context, v *vaa.VAA, data []byte) error {
+		stored[v.MessageID()] = data
+		return nil
+	}
+	r := NewRepairer(Config{}, sources, &keyVerifier{keys: []eth_common.Address{crypto.PubkeyToAddress(key.PublicKey)}},
+		nil, nil, upsert, metrics.NewDummyMetrics(), zap.NewNop())
+
+	repaired := r.Repair(context.Background(), vaa.ChainIDEthereum, emitter, []uint64{10, 11, 12, 13})
+	assert.Equal(t, 3, repaired)
+	assert.Len(t, stored, 3)
+	assert.Contains(t, stored, messageID(vaa.ChainIDEthereum, emitter, 10))
+	assert.Equal(t, signedVaa(t, key, 11), stored[messageID(vaa.ChainIDEthereum, emitter, 11)])
+	assert.Contains(t, stored, messageID(vaa.ChainIDEthereum, emitter, 12))
+	// sequence 10 is served by the file dump.
+	assert.Equal(t, 3, stub.calls)
+}
+
+func TestRepairer_RepairGaps(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	stub := &stubPublicRPC{vaas: map[uint64][]byte{5: signedVaa(t, key, 5)}}
+	sources, err := NewSources(startPublicRPC(t, stub), "", "")
+	require.NoError(t, err)
+
+	gap := domain.SequenceGap{
+		ID:            "2/" + emitter.String() + "/5",
+		EmitterChain:  vaa.ChainIDEthereum,
+		EmitterAddr:   emitter.String(),
+		StartSequence: 5,
+		EndSequence:   10,
+	}
+	findGaps := func(_ context.Context, _ int64) ([]domain.SequenceGap, error) {
+		return []domain.SequenceGap{gap}, nil
+	}
+	updateRepair := func(_ context.Context, id string, repair domain.GapRepair) error {
+		assert.Equal(t, gap.ID, id)
+		gap.Repair = &repair
+		return nil
+	}
+	var stored int
+	upsert := func(_ context.Context, _ *vaa.VAA, _ []byte) error {
+		stored++
+		return nil
+	}
+	cfg := Config{MaxGaps: 10, MaxSequencesPerGap: 4, RetryDelay: time.Hour}
+	r := NewRepairer(cfg, sources, &keyVerifier{keys: []eth_common.Address{crypto.PubkeyToAddress(key.PublicKey)}},
+		findGaps, updateRepair, upsert, metrics.NewDummyMetrics(), zap.NewNop())
+
+	now := time.Now()
+	r.repairGaps(context.Background(), now)
+	assert.Equal(t, 1, stored)
+	assert.Equal(t, 4, stub.calls)
+	assert.Equal(t, uint64(9), gap.Repair.NextSequence)
+
+	// the next run continues from the last sequence requested and stops at the sequences already requested.
+	r.repairGaps(context.Background(), now.Add(time.Minute))
+	assert.Equal(t, 6, stub.calls)
+	assert.Equal(t, uint64(5), gap.Repair.NextSequence)
+
+	// the sequences were already requested, so they are not requested again before the retry delay.
+	r.repairGaps(context.Background(), now.Add(2*time.Minute))
+	assert.Equal(t, 6, stub.calls)
+	assert.Equal(t, now.Add(2*time.Minute), gap.Repair.AttemptedAt)
+
+	r.repairGaps(context.Background(), now.Add(2*time.Hour))
+	assert.Equal(t, 10, stub.calls)
+	assert.Equal(t, uint64(9), gap.Repair.NextSequence)
+}
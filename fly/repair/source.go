@@ -0,0 +1,57 @@
+// Package repair fetches signed VAAs missed by fly from external sources and stores them.
+package repair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// ErrVaaNotFound is returned by a source that does not have the requested VAA.
+var ErrVaaNotFound = errors.New("vaa not found")
+
+// Source is a provider of signed VAAs.
+type Source interface {
+	// Name returns a description of the source used in logs.
+	Name() string
+	// GetSignedVAA returns the serialized signed VAA for the given emitter and sequence.
+	GetSignedVAA(ctx context.Context, chainID vaa.ChainID, emitterAddr vaa.Address, seq uint64) ([]byte, error)
+}
+
+// NewSources creates the sources from a comma separated list of guardian public RPC endpoints,
+// a comma separated list of explorer API urls and the path of a local file dump.
+// The sources are returned in the order they are queried: file dump, explorers and guardians.
+func NewSources(guardianRPCs, explorerURLs, filePath string) ([]Source, error) {
+	var sources []Source
+	if filePath != "" {
+		s, err := NewFileSource(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load vaa file dump: %w", err)
+		}
+		sources = append(sources, s)
+	}
+	for _, url := range splitList(explorerURLs) {
+		sources = append(sources, NewExplorerSource(url))
+	}
+	for _, endpoint := range splitList(guardianRPCs) {
+		s, err := NewGuardianRPCSource(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create guardian rpc source %s: %w", endpoint, err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -6,9 +6,9 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
 	flyAlert "github.com/wormhole-foundation/wormhole-explorer/fly/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/health"
-	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/storage"
 	"go.uber.org/zap"
 )
@@ -95,8 +95,8 @@ func (c *Controller) checkMongoStatus(ctx context.Context) error {
 }
 
 func (c *Controller) checkQueueStatus(ctx context.Context) error {
-	// vaa queue handle in memory [local enviroment]
-	if c.isLocal {
+	// vaa queue handle in memory [local enviroment] or in a broker other than SQS
+	if c.isLocal || c.consumer == nil {
 		return nil
 	}
 	// get queue attributes
@@ -73,6 +73,27 @@ type VaaIdTxHashUpdate struct {
 	UpdatedAt    *time.Time  `bson:"updatedAt"`
 }
 
+// HeartbeatHistory is a sample of a guardian heartbeat stored in the heartbeatsHistory time series collection.
+type HeartbeatHistory struct {
+	Timestamp          time.Time                 `bson:"timestamp"`
+	GuardianAddr       string                    `bson:"guardianAddr"`
+	NodeName           string                    `bson:"nodeName"`
+	Version            string                    `bson:"version"`
+	Features           []string                  `bson:"features"`
+	Counter            int64                     `bson:"counter"`
+	BootTimestamp      int64                     `bson:"bootTimestamp"`
+	HeartbeatTimestamp int64                     `bson:"heartbeatTimestamp"`
+	Networks           []HeartbeatNetworkHistory `bson:"networks"`
+}
+
+// HeartbeatNetworkHistory is the state of a chain reported in a heartbeat sample.
+type HeartbeatNetworkHistory struct {
+	ID              uint32 `bson:"id"`
+	Height          int64  `bson:"height"`
+	ContractAddress string `bson:"contractAddress"`
+	ErrorCount      uint64 `bson:"errorCount"`
+}
+
 func indexedAt(t time.Time) IndexingTimestamps {
 	return IndexingTimestamps{
 		IndexedAt: t,
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"sync"
+	"time"
+)
+
+// HeartbeatSampler decides which heartbeats are stored in the heartbeatsHistory collection.
+// Guardians send a heartbeat every few seconds, so only one heartbeat per guardian is kept
+// every sample interval.
+type HeartbeatSampler struct {
+	interval time.Duration
+	mu       sync.Mutex
+	last     map[string]time.Time
+}
+
+// NewHeartbeatSampler creates a HeartbeatSampler that keeps a heartbeat per guardian every interval.
+func NewHeartbeatSampler(interval time.Duration) *HeartbeatSampler {
+	return &HeartbeatSampler{interval: interval, last: make(map[string]time.Time)}
+}
+
+// Sample returns true if a heartbeat of the guardian received at time t must be stored.
+func (s *HeartbeatSampler) Sample(guardianAddr string, t time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.last[guardianAddr]; ok && t.Sub(last) < s.interval {
+		return false
+	}
+	s.last[guardianAddr] = t
+	return true
+}
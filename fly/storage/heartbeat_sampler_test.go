@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatSampler(t *testing.T) {
+	sampler := NewHeartbeatSampler(time.Minute)
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, sampler.Sample("guardian1", now))
+	assert.False(t, sampler.Sample("guardian1", now.Add(15*time.Second)))
+	assert.True(t, sampler.Sample("guardian2", now.Add(15*time.Second)))
+	assert.False(t, sampler.Sample("guardian1", now.Add(59*time.Second)))
+	assert.True(t, sampler.Sample("guardian1", now.Add(time.Minute)))
+	assert.False(t, sampler.Sample("guardian1", now.Add(90*time.Second)))
+}
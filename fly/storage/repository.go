@@ -13,6 +13,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	flyAlert "github.com/wormhole-foundation/wormhole-explorer/fly/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/track"
@@ -31,39 +32,46 @@ type Repository struct {
 	metrics     metrics.Metrics
 	db          *mongo.Database
 	afterUpdate producer.PushFunc
+	lineage     *lineage.Recorder
 	log         *zap.Logger
 	collections struct {
-		vaas           *mongo.Collection
-		heartbeats     *mongo.Collection
-		observations   *mongo.Collection
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		vaasPythnet    *mongo.Collection
-		vaaCounts      *mongo.Collection
-		vaaIdTxHash    *mongo.Collection
+		vaas              *mongo.Collection
+		heartbeats        *mongo.Collection
+		heartbeatsHistory *mongo.Collection
+		observations      *mongo.Collection
+		governorConfig    *mongo.Collection
+		governorStatus    *mongo.Collection
+		vaasPythnet       *mongo.Collection
+		vaaCounts         *mongo.Collection
+		vaaIdTxHash       *mongo.Collection
+		sequenceGaps      *mongo.Collection
 	}
 }
 
 // TODO wrap repository with a service that filters using redis
-func NewRepository(alertService alert.AlertClient, metrics metrics.Metrics, db *mongo.Database, vaaTopicFunc producer.PushFunc, log *zap.Logger) *Repository {
-	return &Repository{alertService, metrics, db, vaaTopicFunc, log, struct {
-		vaas           *mongo.Collection
-		heartbeats     *mongo.Collection
-		observations   *mongo.Collection
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		vaasPythnet    *mongo.Collection
-		vaaCounts      *mongo.Collection
-		vaaIdTxHash    *mongo.Collection
+func NewRepository(alertService alert.AlertClient, metrics metrics.Metrics, db *mongo.Database, vaaTopicFunc producer.PushFunc, lineage *lineage.Recorder, log *zap.Logger) *Repository {
+	return &Repository{alertService, metrics, db, vaaTopicFunc, lineage, log, struct {
+		vaas              *mongo.Collection
+		heartbeats        *mongo.Collection
+		heartbeatsHistory *mongo.Collection
+		observations      *mongo.Collection
+		governorConfig    *mongo.Collection
+		governorStatus    *mongo.Collection
+		vaasPythnet       *mongo.Collection
+		vaaCounts         *mongo.Collection
+		vaaIdTxHash       *mongo.Collection
+		sequenceGaps      *mongo.Collection
 	}{
-		vaas:           db.Collection("vaas"),
-		heartbeats:     db.Collection("heartbeats"),
-		observations:   db.Collection("observations"),
-		governorConfig: db.Collection("governorConfig"),
-		governorStatus: db.Collection("governorStatus"),
-		vaasPythnet:    db.Collection("vaasPythnet"),
-		vaaCounts:      db.Collection("vaaCounts"),
-		vaaIdTxHash:    db.Collection("vaaIdTxHash")}}
+		vaas:              db.Collection("vaas"),
+		heartbeats:        db.Collection("heartbeats"),
+		heartbeatsHistory: db.Collection("heartbeatsHistory"),
+		observations:      db.Collection("observations"),
+		governorConfig:    db.Collection("governorConfig"),
+		governorStatus:    db.Collection("governorStatus"),
+		vaasPythnet:       db.Collection("vaasPythnet"),
+		vaaCounts:         db.Collection("vaaCounts"),
+		vaaIdTxHash:       db.Collection("vaaIdTxHash"),
+		sequenceGaps:      db.Collection("sequenceGaps")}}
 }
 
 func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
@@ -115,33 +123,44 @@ func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []
 			}
 			s.alertClient.CreateAndSend(ctx, flyAlert.ErrorSaveVAA, alertContext)
 		}
+		// the same vaa is received from many guardians, only the first insert is recorded.
+		if err != nil || s.isNewRecord(result) {
+			s.lineage.Record(ctx, id, track.GetTrackID(id), now, err)
+		}
 	}
 	if err == nil && s.isNewRecord(result) {
 		s.metrics.IncVaaInserted(v.EmitterChain)
 		s.updateVAACount(v.EmitterChain)
 
-		// send signedvaa event to topic.
-		event, newErr := events.NewNotificationEvent[events.SignedVaa](
-			track.GetTrackID(v.MessageID()), "fly", events.SignedVaaType,
-			events.SignedVaa{
-				ID:               v.MessageID(),
-				EmitterChain:     uint16(v.EmitterChain),
-				EmitterAddress:   v.EmitterAddress.String(),
-				Sequence:         v.Sequence,
-				GuardianSetIndex: v.GuardianSetIndex,
-				Timestamp:        v.Timestamp,
-				Vaa:              serializedVaa,
-				TxHash:           vaaDoc.TxHash,
-				Version:          int(v.Version),
-			})
+		// send signedvaa event to topic. The VAA is already stored, so an event that does not match its
+		// schema is logged and not published instead of failing the upsert.
+		event, newErr := newSignedVaaEvent(v, serializedVaa, vaaDoc.TxHash)
 		if newErr != nil {
-			return newErr
+			s.log.Error("Error creating signed-vaa event", zap.String("id", id), zap.Error(newErr))
+			return nil
 		}
 		err = s.afterUpdate(ctx, &producer.Notification{ID: v.MessageID(), Event: event, EmitterChain: v.EmitterChain})
 	}
 	return err
 }
 
+// newSignedVaaEvent creates the signed-vaa event published for a new VAA.
+func newSignedVaaEvent(v *vaa.VAA, serializedVaa []byte, txHash string) (*events.NotificationEvent, error) {
+	return events.NewNotificationEvent[events.SignedVaa](
+		track.GetTrackID(v.MessageID()), "fly", events.SignedVaaType,
+		events.SignedVaa{
+			ID:               v.MessageID(),
+			EmitterChain:     uint16(v.EmitterChain),
+			EmitterAddress:   v.EmitterAddress.String(),
+			Sequence:         v.Sequence,
+			GuardianSetIndex: v.GuardianSetIndex,
+			Timestamp:        v.Timestamp,
+			Vaa:              serializedVaa,
+			TxHash:           txHash,
+			Version:          int(v.Version),
+		})
+}
+
 func (s *Repository) UpsertObservation(o *gossipv1.SignedObservation) error {
 	ctx := context.TODO()
 	vaaID := strings.Split(o.MessageId, "/")
@@ -282,6 +301,55 @@ func (s *Repository) UpsertHeartbeat(hb *gossipv1.Heartbeat) error {
 	return err
 }
 
+// InsertHeartbeatHistory stores a sample of the heartbeat in the heartbeatsHistory collection.
+func (s *Repository) InsertHeartbeatHistory(hb *gossipv1.Heartbeat) error {
+	networks := make([]HeartbeatNetworkHistory, 0, len(hb.Networks))
+	for _, n := range hb.Networks {
+		networks = append(networks, HeartbeatNetworkHistory{
+			ID:              n.Id,
+			Height:          n.Height,
+			ContractAddress: n.ContractAddress,
+			ErrorCount:      n.ErrorCount,
+		})
+	}
+	doc := HeartbeatHistory{
+		Timestamp:          time.Now(),
+		GuardianAddr:       hb.GuardianAddr,
+		NodeName:           hb.NodeName,
+		Version:            hb.Version,
+		Features:           hb.Features,
+		Counter:            hb.Counter,
+		BootTimestamp:      hb.BootTimestamp,
+		HeartbeatTimestamp: hb.Timestamp,
+		Networks:           networks,
+	}
+	_, err := s.collections.heartbeatsHistory.InsertOne(context.TODO(), doc)
+	return err
+}
+
+// FindOpenSequenceGaps returns the open sequence gaps detected by the pipeline chain health monitor.
+// The gaps never repaired come first, then the ones repaired least recently, so every gap is repaired in turn.
+func (s *Repository) FindOpenSequenceGaps(ctx context.Context, limit int64) ([]domain.SequenceGap, error) {
+	sort := bson.D{{Key: "repair.attemptedAt", Value: 1}, {Key: "detectedAt", Value: 1}}
+	opts := options.Find().SetSort(sort).SetLimit(limit)
+	cur, err := s.collections.sequenceGaps.Find(ctx, bson.M{"status": domain.GapStatusOpen}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var gaps []domain.SequenceGap
+	if err := cur.All(ctx, &gaps); err != nil {
+		return nil, err
+	}
+	return gaps, nil
+}
+
+// UpdateSequenceGapRepair stores the repair progress of a sequence gap.
+func (s *Repository) UpdateSequenceGapRepair(ctx context.Context, id string, repair domain.GapRepair) error {
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "repair", Value: repair}}}}
+	_, err := s.collections.sequenceGaps.UpdateByID(ctx, id, update)
+	return err
+}
+
 func (s *Repository) UpsertGovernorConfig(govC *gossipv1.SignedChainGovernorConfig) error {
 	id := hex.EncodeToString(govC.GuardianAddr)
 	now := time.Now()
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// TestNewSignedVaaEvent checks the published event against the contract of the consumers.
+func TestNewSignedVaaEvent(t *testing.T) {
+	emitter, err := vaa.StringToAddress("000000000000000000000000f890982f9310df57d00f659cf4fd87e65aded8d7")
+	require.NoError(t, err)
+	v := &vaa.VAA{
+		Version:          vaa.SupportedVAAVersion,
+		GuardianSetIndex: 3,
+		Timestamp:        time.Date(2023, 8, 4, 14, 43, 48, 0, time.UTC),
+		EmitterChain:     vaa.ChainIDEthereum,
+		EmitterAddress:   emitter,
+		Sequence:         162727,
+		ConsistencyLevel: 1,
+		Payload:          []byte{1, 2, 3},
+	}
+	serializedVaa, err := v.Marshal()
+	require.NoError(t, err)
+
+	for _, txHash := range []string{"", "406065c15b62426c51f987f5923fb376f6b60cb1c15724cc5460a08d18ccc337"} {
+		event, err := newSignedVaaEvent(v, serializedVaa, txHash)
+		require.NoError(t, err)
+		assert.Equal(t, events.SignedVaaVersion, event.Version)
+		assert.NoError(t, events.Validate(event))
+
+		signedVaa, err := events.Decode[events.SignedVaa](event)
+		require.NoError(t, err)
+		assert.Equal(t, v.MessageID(), signedVaa.ID)
+		assert.Equal(t, serializedVaa, signedVaa.Vaa)
+		assert.Equal(t, txHash, signedVaa.TxHash)
+	}
+}
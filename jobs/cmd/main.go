@@ -5,16 +5,23 @@ import (
 	"log"
 	"os"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/go-redis/redis"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/common/prices"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/config"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/checkpoint"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/coingecko"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/report"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/rollup"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
@@ -49,6 +56,25 @@ func main() {
 		}
 		transferReport := initTransferReportJob(context, aCfg, logger)
 		err = transferReport.Run(context)
+	case jobs.JobIDRollupVaaCount1h,
+		jobs.JobIDRollupAllMessages5m,
+		jobs.JobIDRollupTotalTx24h,
+		jobs.JobIDRollupAssetVolumes24h,
+		jobs.JobIDRollupChainActivity3h:
+		rCfg, errCfg := config.NewRollupConfiguration(context)
+		if errCfg != nil {
+			log.Fatal("error creating config", errCfg)
+		}
+		from, to, backfill, errRange := rCfg.BackfillRange()
+		if errRange != nil {
+			log.Fatal("error creating config", errRange)
+		}
+		rollupJob := initRollupJob(context, cfg.JobID, rCfg, logger)
+		if backfill {
+			err = rollupJob.Backfill(context, from, to)
+		} else {
+			err = rollupJob.Run(context)
+		}
 
 	default:
 		logger.Fatal("Invalid job id", zap.String("job_id", cfg.JobID))
@@ -86,7 +112,94 @@ func initTransferReportJob(ctx context.Context, cfg *config.TransferReportConfig
 	pricesCache.InitCache()
 	// init token provider.
 	tokenProvider := domain.NewTokenProvider(cfg.P2pNetwork)
-	return report.NewTransferReportJob(db.Database, cfg.PageSize, pricesCache, cfg.OutputPath, tokenProvider, logger)
+	// build the report filter.
+	from, to, err := cfg.Range()
+	if err != nil {
+		logger.Fatal("Invalid report range", zap.Error(err))
+	}
+	filter := report.Filter{
+		From:             from,
+		To:               to,
+		SourceChain:      toChainID(cfg.SourceChain),
+		DestinationChain: toChainID(cfg.DestinationChain),
+		AppID:            cfg.AppID,
+		TokenChain:       toChainID(cfg.TokenChain),
+		TokenAddress:     cfg.TokenAddress,
+	}
+	// init report storage.
+	var storage report.Storage = report.NewLocalStorage()
+	if cfg.OutputBucket != "" {
+		awsConfig, err := newAwsConfig(ctx, cfg)
+		if err != nil {
+			logger.Fatal("Failed to create aws config", zap.Error(err))
+		}
+		storage = report.NewS3Storage(awsConfig, cfg.OutputBucket)
+	}
+	// init checkpoints for incremental runs.
+	var checkpoints report.CheckpointStore
+	if cfg.Incremental {
+		checkpoints = checkpoint.NewRepository(db.Database)
+	}
+	return report.NewTransferReportJob(db.Database, cfg.PageSize, pricesCache, storage, cfg.OutputPath, cfg.OutputFormat,
+		filter, checkpoints, cfg.CheckpointID, tokenProvider, logger)
+}
+
+func toChainID(c *int) *sdk.ChainID {
+	if c == nil {
+		return nil
+	}
+	chainID := sdk.ChainID(*c)
+	return &chainID
+}
+
+func newAwsConfig(ctx context.Context, cfg *config.TransferReportConfiguration) (aws.Config, error) {
+	region := cfg.AwsRegion
+	if cfg.AwsAccessKeyID != "" && cfg.AwsSecretAccessKey != "" {
+		credentials := credentials.NewStaticCredentialsProvider(cfg.AwsAccessKeyID, cfg.AwsSecretAccessKey, "")
+		customResolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+			if cfg.AwsEndpoint != "" {
+				return aws.Endpoint{
+					PartitionID:       "aws",
+					URL:               cfg.AwsEndpoint,
+					SigningRegion:     region,
+					HostnameImmutable: true,
+				}, nil
+			}
+
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		})
+
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx,
+			awsconfig.WithRegion(region),
+			awsconfig.WithEndpointResolver(customResolver),
+			awsconfig.WithCredentialsProvider(credentials),
+		)
+		return awsCfg, err
+	}
+	return awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+}
+
+// initRollupJob initializes a rollup job.
+func initRollupJob(ctx context.Context, jobID string, cfg *config.RollupConfiguration, logger *zap.Logger) *rollup.RollupJob {
+	//setup DB connection
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	definition, err := rollup.NewDefinition(jobID, rollup.Buckets{
+		Infinite: cfg.InfluxBucketInfinite,
+		Days30:   cfg.InfluxBucket30Days,
+		Hours24:  cfg.InfluxBucket24Hours,
+	})
+	if err != nil {
+		logger.Fatal("Failed to create rollup definition", zap.Error(err))
+	}
+	// init influxdb client.
+	influxCli := influxdb2.NewClient(cfg.InfluxUrl, cfg.InfluxToken)
+	querier := rollup.NewInfluxQuerier(influxCli, cfg.InfluxOrganization)
+	writer := rollup.NewInfluxWriter(influxCli, cfg.InfluxOrganization)
+	checkpoints := checkpoint.NewRepository(db.Database)
+	return rollup.NewRollupJob(definition, querier, writer, checkpoints, cfg.MaxWindows, logger)
 }
 
 func handleExit() {
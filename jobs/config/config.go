@@ -4,6 +4,8 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
@@ -30,7 +32,39 @@ type TransferReportConfiguration struct {
 	PageSize      int64  `env:"PAGE_SIZE,default=100"`
 	PricesPath    string `env:"PRICES_PATH,required"`
 	OutputPath    string `env:"OUTPUT_PATH,required"`
+	OutputFormat  string `env:"OUTPUT_FORMAT,default=csv"`
 	P2pNetwork    string `env:"P2P_NETWORK,required"`
+	// filters
+	From             string `env:"REPORT_FROM"`
+	To               string `env:"REPORT_TO"`
+	SourceChain      *int   `env:"REPORT_SOURCE_CHAIN,noinit"`
+	DestinationChain *int   `env:"REPORT_DESTINATION_CHAIN,noinit"`
+	AppID            string `env:"REPORT_APP_ID"`
+	TokenChain       *int   `env:"REPORT_TOKEN_CHAIN,noinit"`
+	TokenAddress     string `env:"REPORT_TOKEN_ADDRESS"`
+	// incremental runs
+	Incremental  bool   `env:"REPORT_INCREMENTAL,default=false"`
+	CheckpointID string `env:"REPORT_CHECKPOINT_ID,default=JOB_TRANSFER_REPORT"`
+	// S3-compatible storage. When OUTPUT_BUCKET is empty the report is written to the local disk.
+	OutputBucket       string `env:"OUTPUT_BUCKET"`
+	AwsEndpoint        string `env:"AWS_ENDPOINT"`
+	AwsAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
+	AwsSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
+	AwsRegion          string `env:"AWS_REGION,default=us-east-1"`
+}
+
+type RollupConfiguration struct {
+	MongoURI             string `env:"MONGODB_URI,required"`
+	MongoDatabase        string `env:"MONGODB_DATABASE,required"`
+	InfluxUrl            string `env:"INFLUX_URL,required"`
+	InfluxToken          string `env:"INFLUX_TOKEN,required"`
+	InfluxOrganization   string `env:"INFLUX_ORGANIZATION,required"`
+	InfluxBucketInfinite string `env:"INFLUX_BUCKET_INFINITE,required"`
+	InfluxBucket30Days   string `env:"INFLUX_BUCKET_30_DAYS,required"`
+	InfluxBucket24Hours  string `env:"INFLUX_BUCKET_24_HOURS,required"`
+	MaxWindows           int    `env:"ROLLUP_MAX_WINDOWS,default=24"`
+	BackfillFrom         string `env:"ROLLUP_BACKFILL_FROM"`
+	BackfillTo           string `env:"ROLLUP_BACKFILL_TO"`
 }
 
 // New creates a default configuration with the values from .env file and environment variables.
@@ -68,3 +102,60 @@ func NewTransferReportConfiguration(ctx context.Context) (*TransferReportConfigu
 
 	return &configuration, nil
 }
+
+// New creates a rollup configuration with the values from .env file and environment variables.
+func NewRollupConfiguration(ctx context.Context) (*RollupConfiguration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration RollupConfiguration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// Range returns the report time range. Zero values mean the range is not bounded.
+func (c *TransferReportConfiguration) Range() (from, to time.Time, err error) {
+	if c.From != "" {
+		from, err = parseDate(c.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid REPORT_FROM: %w", err)
+		}
+	}
+	if c.To != "" {
+		to, err = parseDate(c.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid REPORT_TO: %w", err)
+		}
+	}
+	return from, to, nil
+}
+
+// BackfillRange returns the range to backfill. ok is false when no backfill was requested.
+//
+// Dates are accepted in RFC3339 or YYYY-MM-DD format, and the end date defaults to now.
+func (c *RollupConfiguration) BackfillRange() (from, to time.Time, ok bool, err error) {
+	if c.BackfillFrom == "" {
+		return time.Time{}, time.Time{}, false, nil
+	}
+	from, err = parseDate(c.BackfillFrom)
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("invalid ROLLUP_BACKFILL_FROM: %w", err)
+	}
+	to = time.Now().UTC()
+	if c.BackfillTo != "" {
+		to, err = parseDate(c.BackfillTo)
+		if err != nil {
+			return time.Time{}, time.Time{}, false, fmt.Errorf("invalid ROLLUP_BACKFILL_TO: %w", err)
+		}
+	}
+	return from, to, true, nil
+}
+
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
@@ -0,0 +1,57 @@
+// Package checkpoint persists the progress of incremental jobs.
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrNotFound is returned when a job has no saved checkpoint.
+var ErrNotFound = errors.New("checkpoint not found")
+
+// Checkpoint is the last position processed by a job.
+type Checkpoint struct {
+	ID        string    `bson:"_id"`
+	LastTime  time.Time `bson:"lastTime"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// Repository stores job checkpoints in the `jobCheckpoints` collection.
+type Repository struct {
+	collection *mongo.Collection
+}
+
+// NewRepository creates a new checkpoint repository.
+func NewRepository(db *mongo.Database) *Repository {
+	return &Repository{collection: db.Collection("jobCheckpoints")}
+}
+
+// Get returns the checkpoint of the given job.
+func (r *Repository) Get(ctx context.Context, id string) (*Checkpoint, error) {
+	var c Checkpoint
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Save upserts the checkpoint of the given job.
+func (r *Repository) Save(ctx context.Context, id string, lastTime time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"lastTime":  lastTime,
+			"updatedAt": time.Now(),
+		},
+	}
+	_, err := r.collection.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+	return err
+}
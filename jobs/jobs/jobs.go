@@ -7,6 +7,15 @@ const (
 	JobIDTransferReport = "JOB_TRANSFER_REPORT"
 )
 
+// Rollup job ids. Each one downsamples InfluxDB measurements into an aggregated measurement.
+const (
+	JobIDRollupVaaCount1h      = "JOB_ROLLUP_VAA_COUNT_1H"
+	JobIDRollupAllMessages5m   = "JOB_ROLLUP_ALL_MESSAGES_5M"
+	JobIDRollupTotalTx24h      = "JOB_ROLLUP_TOTAL_TX_24H"
+	JobIDRollupAssetVolumes24h = "JOB_ROLLUP_ASSET_VOLUMES_24H"
+	JobIDRollupChainActivity3h = "JOB_ROLLUP_CHAIN_ACTIVITY_3H"
+)
+
 // Job is the interface for jobs.
 type Job interface {
 	Run() error
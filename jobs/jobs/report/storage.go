@@ -0,0 +1,143 @@
+package report
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Storage creates the report files.
+type Storage interface {
+	Create(ctx context.Context, name string) (File, error)
+}
+
+// File is a report being written. The report is only stored when it is committed, so a failed
+// run does not leave a partial report behind.
+type File interface {
+	io.Writer
+	// Commit stores the report.
+	Commit() error
+	// Abort discards the report, it does nothing after a commit.
+	Abort() error
+}
+
+// LocalStorage writes the reports to the local disk.
+type LocalStorage struct{}
+
+// NewLocalStorage creates a new LocalStorage.
+func NewLocalStorage() *LocalStorage {
+	return &LocalStorage{}
+}
+
+// Create creates a temporary file next to the report, including its parent directories. The
+// temporary file is renamed to the report on commit.
+func (*LocalStorage) Create(_ context.Context, name string) (File, error) {
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &localFile{file: f, name: name}, nil
+}
+
+type localFile struct {
+	file *os.File
+	name string
+	done bool
+}
+
+func (l *localFile) Write(p []byte) (int, error) {
+	return l.file.Write(p)
+}
+
+func (l *localFile) Commit() error {
+	l.done = true
+	if err := l.file.Close(); err != nil {
+		os.Remove(l.file.Name())
+		return err
+	}
+	// the temporary files are created with mode 0600, the reports get the mode of os.Create.
+	if err := os.Chmod(l.file.Name(), 0o644); err != nil {
+		os.Remove(l.file.Name())
+		return err
+	}
+	return os.Rename(l.file.Name(), l.name)
+}
+
+func (l *localFile) Abort() error {
+	if l.done {
+		return nil
+	}
+	l.done = true
+	l.file.Close()
+	return os.Remove(l.file.Name())
+}
+
+// S3Storage writes the reports to an S3-compatible bucket.
+type S3Storage struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Storage creates a new S3Storage.
+//
+// Path-style addressing is used so the storage works with S3-compatible services like MinIO.
+func NewS3Storage(awsConfig aws.Config, bucket string) *S3Storage {
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
+	return &S3Storage{client: client, bucket: bucket}
+}
+
+// Create returns a file that buffers the report in a temporary file and uploads it on commit.
+func (s *S3Storage) Create(ctx context.Context, name string) (File, error) {
+	f, err := os.CreateTemp("", "report-*")
+	if err != nil {
+		return nil, err
+	}
+	return &s3Object{ctx: ctx, storage: s, key: name, file: f}, nil
+}
+
+type s3Object struct {
+	ctx     context.Context
+	storage *S3Storage
+	key     string
+	file    *os.File
+	done    bool
+}
+
+func (o *s3Object) Write(p []byte) (int, error) {
+	return o.file.Write(p)
+}
+
+func (o *s3Object) Commit() error {
+	o.done = true
+	defer os.Remove(o.file.Name())
+	defer o.file.Close()
+
+	if _, err := o.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := o.storage.client.PutObject(o.ctx, &s3.PutObjectInput{
+		Bucket: aws.String(o.storage.bucket),
+		Key:    aws.String(o.key),
+		Body:   o.file,
+	})
+	return err
+}
+
+func (o *s3Object) Abort() error {
+	if o.done {
+		return nil
+	}
+	o.done = true
+	o.file.Close()
+	return os.Remove(o.file.Name())
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestLocalStorage_Commit(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "reports", "report.csv")
+	file, err := NewLocalStorage().Create(context.Background(), name)
+	assert.NoError(t, err)
+	_, err = file.Write([]byte("content"))
+	assert.NoError(t, err)
+	assert.NoError(t, file.Commit())
+	assert.NoError(t, file.Abort())
+
+	content, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	info, err := os.Stat(name)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+}
+
+func TestLocalStorage_Abort(t *testing.T) {
+	dir := t.TempDir()
+	file, err := NewLocalStorage().Create(context.Background(), filepath.Join(dir, "report.csv"))
+	assert.NoError(t, err)
+	_, err = file.Write([]byte("partial"))
+	assert.NoError(t, err)
+	assert.NoError(t, file.Abort())
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFilterMatches(t *testing.T) {
+	polygon := sdk.ChainIDPolygon
+	trx := transactionResult{
+		DestinationChain: sdk.ChainIDPolygon,
+		TokenAddressHexa: "000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+		AppIds:           []string{"PORTAL_TOKEN_BRIDGE"},
+	}
+
+	assert.True(t, Filter{}.matches(trx))
+	assert.True(t, Filter{DestinationChain: &polygon, AppID: "PORTAL_TOKEN_BRIDGE"}.matches(trx))
+	assert.True(t, Filter{TokenAddress: trx.TokenAddressHexa}.matches(trx))
+	assert.False(t, Filter{AppID: "CCTP_WORMHOLE_INTEGRATION"}.matches(trx))
+	assert.False(t, Filter{TokenChain: &polygon}.matches(trx))
+}
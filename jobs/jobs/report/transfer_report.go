@@ -2,16 +2,17 @@ package report
 
 import (
 	"context"
-	"encoding/csv"
+	"errors"
 	"fmt"
 	"math/big"
-	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/prices"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/checkpoint"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -23,10 +24,34 @@ type TransferReportJob struct {
 	pageSize      int64
 	logger        *zap.Logger
 	pricesCache   *prices.CoinPricesCache
+	storage       Storage
 	outputPath    string
+	outputFormat  string
+	filter        Filter
+	checkpoints   CheckpointStore
+	checkpointID  string
 	tokenProvider *domain.TokenProvider
 }
 
+// Filter selects the transfers included in the report.
+type Filter struct {
+	// From is the inclusive lower bound of the VAA timestamp. Zero means no lower bound.
+	From time.Time
+	// To is the exclusive upper bound of the VAA timestamp. Zero means no upper bound.
+	To               time.Time
+	SourceChain      *sdk.ChainID
+	DestinationChain *sdk.ChainID
+	AppID            string
+	TokenChain       *sdk.ChainID
+	TokenAddress     string
+}
+
+// CheckpointStore stores the upper bound of the last report generated by an incremental job.
+type CheckpointStore interface {
+	Get(ctx context.Context, id string) (*checkpoint.Checkpoint, error)
+	Save(ctx context.Context, id string, lastTime time.Time) error
+}
+
 type transactionResult struct {
 	ID                string      `bson:"_id"`
 	SourceChain       sdk.ChainID `bson:"sourceChain"`
@@ -45,92 +70,150 @@ type transactionResult struct {
 }
 
 // NewTransferReportJob creates a new transfer report job.
-func NewTransferReportJob(database *mongo.Database, pageSize int64, pricesCache *prices.CoinPricesCache, outputPath string, tokenProvider *domain.TokenProvider, logger *zap.Logger) *TransferReportJob {
-	return &TransferReportJob{database: database, pageSize: pageSize, pricesCache: pricesCache, outputPath: outputPath, tokenProvider: tokenProvider, logger: logger}
+//
+// When checkpoints is not nil the job runs incrementally: each run starts where the previous one ended
+// and, if the filter has no upper bound, ends at the beginning of the current day.
+// The output path may contain the {from} and {to} placeholders, which are replaced by the dates of the report.
+func NewTransferReportJob(database *mongo.Database, pageSize int64, pricesCache *prices.CoinPricesCache, storage Storage, outputPath string, outputFormat string, filter Filter, checkpoints CheckpointStore, checkpointID string, tokenProvider *domain.TokenProvider, logger *zap.Logger) *TransferReportJob {
+	return &TransferReportJob{
+		database:      database,
+		pageSize:      pageSize,
+		pricesCache:   pricesCache,
+		storage:       storage,
+		outputPath:    outputPath,
+		outputFormat:  outputFormat,
+		filter:        filter,
+		checkpoints:   checkpoints,
+		checkpointID:  checkpointID,
+		tokenProvider: tokenProvider,
+		logger:        logger,
+	}
 }
 
 // Run runs the transfer report job.
 func (j *TransferReportJob) Run(ctx context.Context) error {
 
-	file, err := os.Create(j.outputPath)
-	if err != nil {
-		return err
+	filter := j.filter
+	if j.checkpoints != nil {
+		c, err := j.checkpoints.Get(ctx, j.checkpointID)
+		if err != nil && !errors.Is(err, checkpoint.ErrNotFound) {
+			return fmt.Errorf("failed to get checkpoint: %w", err)
+		}
+		if c != nil {
+			filter.From = c.LastTime.UTC()
+		}
+		if filter.To.IsZero() {
+			filter.To = time.Now().UTC().Truncate(24 * time.Hour)
+		}
+		if !filter.From.Before(filter.To) {
+			j.logger.Info("Report is up to date", zap.Time("from", filter.From), zap.Time("to", filter.To))
+			return nil
+		}
 	}
 
-	writer := csv.NewWriter(file)
+	name := outputName(j.outputPath, filter)
+	j.logger.Info("Generating transfer report", zap.String("output", name),
+		zap.Time("from", filter.From), zap.Time("to", filter.To))
 
-	_ = j.writeHeader(writer)
+	file, err := j.storage.Create(ctx, name)
+	if err != nil {
+		return err
+	}
+	// discard the partial report when the run fails.
+	defer file.Abort()
 
-	defer file.Close()
+	writer, err := NewRecordWriter(j.outputFormat, file)
+	if err != nil {
+		return err
+	}
 
-	//start backfilling
 	page := int64(0)
 	for {
 		j.logger.Info("Processing page", zap.Int64("page", page))
 
-		trxs, err := j.findTransactionsByPage(ctx, page, j.pageSize)
+		trxs, err := j.findTransactionsByPage(ctx, filter, page, j.pageSize)
 		if err != nil {
 			j.logger.Error("Failed to get transactions", zap.Error(err))
-			break
+			return err
 		}
 
-		if len(trxs) == 0 {
-			j.logger.Info("Empty page", zap.Int64("page", page))
-			break
-		}
 		for _, t := range trxs {
-			j.logger.Debug("Processing transaction", zap.String("id", t.ID))
-
-			if t.TokenAddressHexa == "" {
-				j.writeRecord(t, t.Amount, nil, nil, writer)
+			if !filter.matches(t) {
 				continue
 			}
-
-			tokenAddress, err := sdk.StringToAddress(t.TokenAddressHexa)
-			if err != nil {
-				j.logger.Error("Failed to get transactions",
-					zap.String("id", t.ID),
-					zap.String("TokenAddressHexa", t.TokenAddressHexa),
-					zap.Error(err))
+			j.logger.Debug("Processing transaction", zap.String("id", t.ID))
+			record := j.createRecord(t)
+			if record == nil {
 				continue
 			}
-
-			m, ok := j.tokenProvider.GetTokenByAddress(sdk.ChainID(t.TokenChain), tokenAddress.String())
-			if ok {
-				tokenPrice, err := j.pricesCache.GetPriceByTime(m.CoingeckoID, t.Timestamp)
-				if err != nil {
-					continue
-				}
-				if t.Amount == "" {
-					j.writeRecord(t, t.Amount, nil, nil, writer)
-					continue
-				}
-				amount := new(big.Int)
-				amount, ok := amount.SetString(t.Amount, 10)
-				if !ok {
-					j.logger.Error("amount is not a number",
-						zap.String("id", t.ID),
-						zap.String("amount", t.Amount),
-					)
-					j.writeRecord(t, "", nil, nil, writer)
-					continue
-				}
-
-				priceUSD := prices.CalculatePriceUSD(tokenPrice, amount, m.Decimals)
-
-				j.writeRecord(t, t.Amount, m, &priceUSD, writer)
-			} else {
-				j.writeRecord(t, t.Amount, nil, nil, writer)
+			if err := writer.Write(record); err != nil {
+				return err
 			}
-
 		}
-		writer.Flush()
+		if int64(len(trxs)) < j.pageSize {
+			break
+		}
 		page++
 	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := file.Commit(); err != nil {
+		return err
+	}
+
+	if j.checkpoints != nil {
+		if err := j.checkpoints.Save(ctx, j.checkpointID, filter.To); err != nil {
+			return fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+	}
 	return nil
 }
 
-func (j *TransferReportJob) writeRecord(trx transactionResult, fAmount string, m *domain.TokenMetadata, priceUSD *decimal.Decimal, file *csv.Writer) error {
+// createRecord creates the report record of a transaction. It returns nil when the transaction must be skipped.
+func (j *TransferReportJob) createRecord(t transactionResult) *Record {
+
+	if t.TokenAddressHexa == "" {
+		return j.newRecord(t, t.Amount, nil, nil)
+	}
+
+	tokenAddress, err := sdk.StringToAddress(t.TokenAddressHexa)
+	if err != nil {
+		j.logger.Error("Failed to get transactions",
+			zap.String("id", t.ID),
+			zap.String("TokenAddressHexa", t.TokenAddressHexa),
+			zap.Error(err))
+		return nil
+	}
+
+	m, ok := j.tokenProvider.GetTokenByAddress(sdk.ChainID(t.TokenChain), tokenAddress.String())
+	if !ok {
+		return j.newRecord(t, t.Amount, nil, nil)
+	}
+
+	tokenPrice, err := j.pricesCache.GetPriceByTime(m.CoingeckoID, t.Timestamp)
+	if err != nil {
+		return nil
+	}
+	if t.Amount == "" {
+		return j.newRecord(t, t.Amount, nil, nil)
+	}
+	amount := new(big.Int)
+	amount, ok = amount.SetString(t.Amount, 10)
+	if !ok {
+		j.logger.Error("amount is not a number",
+			zap.String("id", t.ID),
+			zap.String("amount", t.Amount),
+		)
+		return j.newRecord(t, "", nil, nil)
+	}
+
+	priceUSD := prices.CalculatePriceUSD(tokenPrice, amount, m.Decimals)
+	return j.newRecord(t, t.Amount, m, &priceUSD)
+}
+
+func (j *TransferReportJob) newRecord(trx transactionResult, fAmount string, m *domain.TokenMetadata, priceUSD *decimal.Decimal) *Record {
 	var notionalUSD, decimals, symbol, coingeckoID, tokenAddress string
 	if m != nil {
 		decimals = fmt.Sprintf("%d", m.Decimals)
@@ -146,46 +229,31 @@ func (j *TransferReportJob) writeRecord(trx transactionResult, fAmount string, m
 		tokenAddress, _ = domain.TranslateEmitterAddress(trx.TokenChain, trx.TokenAddressHexa)
 	}
 
-	var record []string
-	record = append(record, trx.ID)
-	record = append(record, chainIDToCsv(trx.SourceChain))
-	record = append(record, trx.EmitterAddress)
-	record = append(record, trx.Sequence)
-	record = append(record, trx.SourceWallet)
-	record = append(record, chainIDToCsv(trx.DestinationChain))
-	record = append(record, trx.DestinationWallet)
-	record = append(record, chainIDToCsv(trx.TokenChain))
-	record = append(record, tokenAddress)
-	record = append(record, fAmount)
-	record = append(record, decimals)
-	record = append(record, notionalUSD)
-	record = append(record, trx.Fee)
-	record = append(record, coingeckoID)
-	record = append(record, symbol)
-	return file.Write(record)
-}
-
-func (*TransferReportJob) writeHeader(writer *csv.Writer) error {
-	var record []string
-	record = append(record, "vaaId")
-	record = append(record, "sourceChain")
-	record = append(record, "emitterAddress")
-	record = append(record, "sequence")
-	record = append(record, "sourceWallet")
-	record = append(record, "destinationChain")
-	record = append(record, "destinationWallet")
-	record = append(record, "tokenChain")
-	record = append(record, "tokenAddress")
-	record = append(record, "amount")
-	record = append(record, "decimals")
-	record = append(record, "notionalUSD")
-	record = append(record, "fee")
-	record = append(record, "coinGeckoId")
-	record = append(record, "symbol")
-	return writer.Write(record)
+	return &Record{
+		VaaID:             trx.ID,
+		SourceChain:       chainIDToCsv(trx.SourceChain),
+		EmitterAddress:    trx.EmitterAddress,
+		Sequence:          trx.Sequence,
+		SourceWallet:      trx.SourceWallet,
+		DestinationChain:  chainIDToCsv(trx.DestinationChain),
+		DestinationWallet: trx.DestinationWallet,
+		TokenChain:        chainIDToCsv(trx.TokenChain),
+		TokenAddress:      tokenAddress,
+		Amount:            fAmount,
+		Decimals:          decimals,
+		NotionalUSD:       notionalUSD,
+		Fee:               trx.Fee,
+		CoingeckoID:       coingeckoID,
+		Symbol:            symbol,
+		Timestamp:         trx.Timestamp.UTC().Format(time.RFC3339),
+		AppIDs:            strings.Join(trx.AppIds, ","),
+	}
 }
 
-func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pageSize int64) ([]transactionResult, error) {
+// findTransactionsByPage returns a page of the transfers filtered by the fields of the vaas collection.
+// The filters on the joined collections are applied by Filter.matches, so a page can have less
+// transfers than the page size only when it is the last one.
+func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, filter Filter, page, pageSize int64) ([]transactionResult, error) {
 
 	vaas := j.database.Collection("vaas")
 
@@ -194,6 +262,25 @@ func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pa
 	// Build the aggregation pipeline
 	var pipeline mongo.Pipeline
 
+	// filter by the fields of the vaas collection
+	match := bson.D{}
+	timestamp := bson.D{}
+	if !filter.From.IsZero() {
+		timestamp = append(timestamp, bson.E{Key: "$gte", Value: filter.From})
+	}
+	if !filter.To.IsZero() {
+		timestamp = append(timestamp, bson.E{Key: "$lt", Value: filter.To})
+	}
+	if len(timestamp) > 0 {
+		match = append(match, bson.E{Key: "timestamp", Value: timestamp})
+	}
+	if filter.SourceChain != nil {
+		match = append(match, bson.E{Key: "emitterChain", Value: *filter.SourceChain})
+	}
+	if len(match) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: match}})
+	}
+
 	pipeline = append(pipeline, bson.D{
 		{Key: "$sort", Value: bson.D{
 			bson.E{Key: "timestamp", Value: -1},
@@ -201,6 +288,10 @@ func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pa
 		}},
 	})
 
+	// paginate the vaas before the lookups, so every page only joins its own documents.
+	pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: pageSize}})
+
 	pipeline = append(pipeline, bson.D{
 		{Key: "$lookup", Value: bson.D{
 			{Key: "from", Value: "parsedVaa"},
@@ -219,16 +310,6 @@ func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pa
 		}},
 	})
 
-	// Skip initial results
-	pipeline = append(pipeline, bson.D{
-		{Key: "$skip", Value: skip},
-	})
-
-	// Limit size of results
-	pipeline = append(pipeline, bson.D{
-		{Key: "$limit", Value: pageSize},
-	})
-
 	// add nested fields
 	pipeline = append(pipeline, bson.D{
 		{Key: "$addFields", Value: bson.D{
@@ -274,6 +355,32 @@ func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pa
 	return documents, nil
 }
 
+// matches returns whether a transfer matches the filters on the fields of the joined collections.
+func (f Filter) matches(t transactionResult) bool {
+	if f.DestinationChain != nil && t.DestinationChain != *f.DestinationChain {
+		return false
+	}
+	if f.AppID != "" && !containsString(t.AppIds, f.AppID) {
+		return false
+	}
+	if f.TokenChain != nil && t.TokenChain != *f.TokenChain {
+		return false
+	}
+	if f.TokenAddress != "" && t.TokenAddress != f.TokenAddress && t.TokenAddressHexa != f.TokenAddress {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
 func chainIDToCsv(chainID sdk.ChainID) string {
 
 	if chainID.String() == sdk.ChainIDUnset.String() {
@@ -281,3 +388,15 @@ func chainIDToCsv(chainID sdk.ChainID) string {
 	}
 	return fmt.Sprintf("%d", int16(chainID))
 }
+
+// outputName replaces the {from} and {to} placeholders of the output path.
+func outputName(path string, filter Filter) string {
+	from, to := "start", "end"
+	if !filter.From.IsZero() {
+		from = filter.From.UTC().Format("2006-01-02")
+	}
+	if !filter.To.IsZero() {
+		to = filter.To.UTC().Format("2006-01-02")
+	}
+	return strings.NewReplacer("{from}", from, "{to}", to).Replace(path)
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Output formats supported by the transfer report.
+const (
+	FormatCSV     = "csv"
+	FormatJSONL   = "jsonl"
+	FormatParquet = "parquet"
+)
+
+// Record is a single row of the transfer report.
+type Record struct {
+	VaaID             string `json:"vaaId" parquet:"name=vaaId, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SourceChain       string `json:"sourceChain" parquet:"name=sourceChain, type=BYTE_ARRAY, convertedtype=UTF8"`
+	EmitterAddress    string `json:"emitterAddress" parquet:"name=emitterAddress, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Sequence          string `json:"sequence" parquet:"name=sequence, type=BYTE_ARRAY, convertedtype=UTF8"`
+	SourceWallet      string `json:"sourceWallet" parquet:"name=sourceWallet, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DestinationChain  string `json:"destinationChain" parquet:"name=destinationChain, type=BYTE_ARRAY, convertedtype=UTF8"`
+	DestinationWallet string `json:"destinationWallet" parquet:"name=destinationWallet, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TokenChain        string `json:"tokenChain" parquet:"name=tokenChain, type=BYTE_ARRAY, convertedtype=UTF8"`
+	TokenAddress      string `json:"tokenAddress" parquet:"name=tokenAddress, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Amount            string `json:"amount" parquet:"name=amount, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Decimals          string `json:"decimals" parquet:"name=decimals, type=BYTE_ARRAY, convertedtype=UTF8"`
+	NotionalUSD       string `json:"notionalUSD" parquet:"name=notionalUSD, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Fee               string `json:"fee" parquet:"name=fee, type=BYTE_ARRAY, convertedtype=UTF8"`
+	CoingeckoID       string `json:"coinGeckoId" parquet:"name=coinGeckoId, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Symbol            string `json:"symbol" parquet:"name=symbol, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Timestamp         string `json:"timestamp" parquet:"name=timestamp, type=BYTE_ARRAY, convertedtype=UTF8"`
+	AppIDs            string `json:"appIds" parquet:"name=appIds, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// RecordWriter writes report records in a specific format.
+type RecordWriter interface {
+	Write(r *Record) error
+	// Close flushes the pending records. It does not close the underlying writer.
+	Close() error
+}
+
+// NewRecordWriter creates a RecordWriter for the given format.
+func NewRecordWriter(format string, w io.Writer) (RecordWriter, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return newCsvRecordWriter(w)
+	case FormatJSONL:
+		return &jsonlRecordWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return newParquetRecordWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported output format %s", format)
+	}
+}
+
+type csvRecordWriter struct {
+	writer *csv.Writer
+}
+
+func newCsvRecordWriter(w io.Writer) (*csvRecordWriter, error) {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"vaaId", "sourceChain", "emitterAddress", "sequence", "sourceWallet",
+		"destinationChain", "destinationWallet", "tokenChain", "tokenAddress", "amount", "decimals",
+		"notionalUSD", "fee", "coinGeckoId", "symbol", "timestamp", "appIds"})
+	if err != nil {
+		return nil, err
+	}
+	return &csvRecordWriter{writer: writer}, nil
+}
+
+func (c *csvRecordWriter) Write(r *Record) error {
+	return c.writer.Write([]string{r.VaaID, r.SourceChain, r.EmitterAddress, r.Sequence, r.SourceWallet,
+		r.DestinationChain, r.DestinationWallet, r.TokenChain, r.TokenAddress, r.Amount, r.Decimals,
+		r.NotionalUSD, r.Fee, r.CoingeckoID, r.Symbol, r.Timestamp, r.AppIDs})
+}
+
+func (c *csvRecordWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type jsonlRecordWriter struct {
+	encoder *json.Encoder
+}
+
+func (j *jsonlRecordWriter) Write(r *Record) error {
+	return j.encoder.Encode(r)
+}
+
+func (j *jsonlRecordWriter) Close() error {
+	return nil
+}
+
+type parquetRecordWriter struct {
+	writer *writer.ParquetWriter
+}
+
+func newParquetRecordWriter(w io.Writer) (*parquetRecordWriter, error) {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(Record), 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return &parquetRecordWriter{writer: pw}, nil
+}
+
+func (p *parquetRecordWriter) Write(r *Record) error {
+	return p.writer.Write(*r)
+}
+
+func (p *parquetRecordWriter) Close() error {
+	return p.writer.WriteStop()
+}
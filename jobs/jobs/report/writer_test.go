@@ -0,0 +1,80 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRecord = Record{
+	VaaID:            "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1",
+	SourceChain:      "2",
+	DestinationChain: "1",
+	Amount:           "1000",
+	Timestamp:        "2023-05-04T12:00:00Z",
+	AppIDs:           "PORTAL_TOKEN_BRIDGE",
+}
+
+func TestNewRecordWriter_CSV(t *testing.T) {
+
+	var buf bytes.Buffer
+	w, err := NewRecordWriter("csv", &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(&testRecord))
+	assert.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "vaaId,sourceChain,"))
+	assert.True(t, strings.HasPrefix(lines[1], testRecord.VaaID+",2,"))
+}
+
+func TestNewRecordWriter_JSONL(t *testing.T) {
+
+	var buf bytes.Buffer
+	w, err := NewRecordWriter("jsonl", &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(&testRecord))
+	assert.NoError(t, w.Write(&testRecord))
+	assert.NoError(t, w.Close())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	var r Record
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &r))
+	assert.Equal(t, testRecord, r)
+}
+
+func TestNewRecordWriter_Parquet(t *testing.T) {
+
+	var buf bytes.Buffer
+	w, err := NewRecordWriter("parquet", &buf)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Write(&testRecord))
+	assert.NoError(t, w.Close())
+
+	// parquet files start and end with the PAR1 magic number.
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PAR1")))
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("PAR1")))
+}
+
+func TestNewRecordWriter_UnsupportedFormat(t *testing.T) {
+
+	_, err := NewRecordWriter("xml", &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestOutputName(t *testing.T) {
+
+	filter := Filter{
+		From: time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, "reports/transfers-2023-05-03-2023-05-04.csv", outputName("reports/transfers-{from}-{to}.csv", filter))
+	assert.Equal(t, "report.csv", outputName("report.csv", filter))
+	assert.Equal(t, "transfers-start-end.csv", outputName("transfers-{from}-{to}.csv", Filter{}))
+}
@@ -0,0 +1,213 @@
+package rollup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+)
+
+const queryTemplateVaaCount = `
+from(bucket: "%s")
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r["_measurement"] == "vaa_count")
+  |> group()
+  |> count()
+`
+
+const queryTemplateAllMessages = `
+from(bucket: "%s")
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r["_measurement"] == "vaa_count_all_messages")
+  |> filter(fn: (r) => r["_field"] == "count")
+  |> group()
+  |> count()
+`
+
+const queryTemplateTotalTx = `
+from(bucket: "%s")
+  |> range(start: 1970-01-01T00:00:00Z, stop: %s)
+  |> filter(fn: (r) => r["_measurement"] == "vaa_volume_v2")
+  |> filter(fn: (r) => r["_field"] == "volume")
+  |> group()
+  |> %s()
+`
+
+const queryTemplateAssetVolumes = `
+from(bucket: "%s")
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r["_measurement"] == "vaa_volume_v2")
+  |> filter(fn: (r) => r["_field"] == "volume")
+  |> group(columns: ["emitter_chain", "token_address", "token_chain"])
+  |> sum(column: "_value")
+`
+
+const queryTemplateChainActivity = `
+from(bucket: "%s")
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == "vaa_volume_v2" and r._field == "volume")
+  |> group(columns: ["emitter_chain", "destination_chain", "app_id"])
+  |> %s(column: "_value")
+`
+
+// Buckets are the InfluxDB buckets used by the rollups.
+type Buckets struct {
+	Infinite string
+	Days30   string
+	Hours24  string
+}
+
+// chainActivityPeriod is a time span summarized by the chain activity rollup.
+type chainActivityPeriod struct {
+	name  string
+	start func(stop time.Time) time.Time
+}
+
+var chainActivityPeriods = []chainActivityPeriod{
+	{name: "7_days", start: func(stop time.Time) time.Time { return stop.AddDate(0, 0, -7).Truncate(24 * time.Hour) }},
+	{name: "15_days", start: func(stop time.Time) time.Time { return stop.AddDate(0, 0, -15).Truncate(24 * time.Hour) }},
+	{name: "30_days", start: func(stop time.Time) time.Time { return stop.AddDate(0, 0, -30).Truncate(24 * time.Hour) }},
+	{name: "90_days", start: func(stop time.Time) time.Time { return stop.AddDate(0, 0, -90).Truncate(24 * time.Hour) }},
+	{name: "1_year", start: func(stop time.Time) time.Time { return stop.AddDate(-1, 0, 0).Truncate(24 * time.Hour) }},
+	{name: "all_time", start: func(stop time.Time) time.Time { return time.Unix(0, 0).UTC() }},
+}
+
+var chainActivityTags = []string{"emitter_chain", "destination_chain", "app_id"}
+
+var assetVolumesTags = []string{"emitter_chain", "token_address", "token_chain"}
+
+// NewDefinition returns the rollup definition for a job id.
+func NewDefinition(jobID string, b Buckets) (Definition, error) {
+	switch jobID {
+	case jobs.JobIDRollupVaaCount1h:
+		return VaaCount1h(b), nil
+	case jobs.JobIDRollupAllMessages5m:
+		return AllMessages5m(b), nil
+	case jobs.JobIDRollupTotalTx24h:
+		return TotalTx24h(b), nil
+	case jobs.JobIDRollupAssetVolumes24h:
+		return AssetVolumes24h(b), nil
+	case jobs.JobIDRollupChainActivity3h:
+		return ChainActivity3h(b), nil
+	default:
+		return Definition{}, fmt.Errorf("unknown rollup job id %s", jobID)
+	}
+}
+
+// VaaCount1h counts the signed VAAs of each hour into `vaa_count_1h`. The points are written at the
+// end of the hour, as the aggregateWindow of the former flux task did.
+func VaaCount1h(b Buckets) Definition {
+	return Definition{
+		ID:    jobs.JobIDRollupVaaCount1h,
+		Every: time.Hour,
+		Aggregations: func(w Window) []Aggregation {
+			return []Aggregation{{
+				Query:       fmt.Sprintf(queryTemplateVaaCount, b.Days30, formatTime(w.Start), formatTime(w.Stop)),
+				Bucket:      b.Days30,
+				Measurement: "vaa_count_1h",
+				Field:       "count",
+				Time:        w.Stop,
+				Default:     int64(0),
+			}}
+		},
+	}
+}
+
+// AllMessages5m counts all the messages of each 5 minutes into `vaa_count_all_messages_5m`.
+func AllMessages5m(b Buckets) Definition {
+	return Definition{
+		ID:    jobs.JobIDRollupAllMessages5m,
+		Every: 5 * time.Minute,
+		Aggregations: func(w Window) []Aggregation {
+			return []Aggregation{{
+				Query:       fmt.Sprintf(queryTemplateAllMessages, b.Hours24, formatTime(w.Start), formatTime(w.Stop)),
+				Bucket:      b.Hours24,
+				Measurement: "vaa_count_all_messages_5m",
+				Field:       "volume",
+				Time:        w.Start,
+			}}
+		},
+	}
+}
+
+// TotalTx24h computes the all-time transfer count and volume into `total_tx_count_v2` and `total_tx_volume_v2`.
+func TotalTx24h(b Buckets) Definition {
+	return Definition{
+		ID:    jobs.JobIDRollupTotalTx24h,
+		Every: 24 * time.Hour,
+		Aggregations: func(w Window) []Aggregation {
+			return []Aggregation{
+				{
+					Query:       fmt.Sprintf(queryTemplateTotalTx, b.Infinite, formatTime(w.Stop), "count"),
+					Bucket:      b.Days30,
+					Measurement: "total_tx_count_v2",
+					Field:       "value",
+					Time:        w.Stop,
+				},
+				{
+					Query:       fmt.Sprintf(queryTemplateTotalTx, b.Infinite, formatTime(w.Stop), "sum"),
+					Bucket:      b.Days30,
+					Measurement: "total_tx_volume_v2",
+					Field:       "value",
+					Time:        w.Stop,
+				},
+			}
+		},
+	}
+}
+
+// AssetVolumes24h sums the daily volume of each asset into `asset_volumes_24h_v2`.
+func AssetVolumes24h(b Buckets) Definition {
+	return Definition{
+		ID:    jobs.JobIDRollupAssetVolumes24h,
+		Every: 24 * time.Hour,
+		Aggregations: func(w Window) []Aggregation {
+			return []Aggregation{{
+				Query:       fmt.Sprintf(queryTemplateAssetVolumes, b.Infinite, formatTime(w.Start), formatTime(w.Stop)),
+				Tags:        assetVolumesTags,
+				Bucket:      b.Days30,
+				Measurement: "asset_volumes_24h_v2",
+				Field:       "volume",
+				Time:        w.Start,
+			}}
+		},
+	}
+}
+
+// ChainActivity3h computes the transfer count and notional per chain pair and app
+// into the `chain_activity_<period>_3h_v2` measurements.
+func ChainActivity3h(b Buckets) Definition {
+	return Definition{
+		ID:    jobs.JobIDRollupChainActivity3h,
+		Every: 3 * time.Hour,
+		Aggregations: func(w Window) []Aggregation {
+			var aggregations []Aggregation
+			for _, p := range chainActivityPeriods {
+				start := formatTime(p.start(w.Stop))
+				measurement := fmt.Sprintf("chain_activity_%s_3h_v2", p.name)
+				aggregations = append(aggregations,
+					Aggregation{
+						Query:       fmt.Sprintf(queryTemplateChainActivity, b.Infinite, start, formatTime(w.Stop), "count"),
+						Tags:        chainActivityTags,
+						Bucket:      b.Hours24,
+						Measurement: measurement,
+						Field:       "count",
+						Time:        w.Stop,
+					},
+					Aggregation{
+						Query:       fmt.Sprintf(queryTemplateChainActivity, b.Infinite, start, formatTime(w.Stop), "sum"),
+						Tags:        chainActivityTags,
+						Bucket:      b.Hours24,
+						Measurement: measurement,
+						Field:       "notional",
+						Time:        w.Stop,
+					})
+			}
+			return aggregations
+		},
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
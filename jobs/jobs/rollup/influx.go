@@ -0,0 +1,62 @@
+package rollup
+
+import (
+	"context"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// InfluxQuerier executes flux queries against an InfluxDB organization.
+type InfluxQuerier struct {
+	client       influxdb2.Client
+	organization string
+}
+
+// NewInfluxQuerier creates a new InfluxQuerier.
+func NewInfluxQuerier(client influxdb2.Client, organization string) *InfluxQuerier {
+	return &InfluxQuerier{client: client, organization: organization}
+}
+
+// Query executes a flux query and returns one row per record.
+func (q *InfluxQuerier) Query(ctx context.Context, query string, tags []string) ([]Row, error) {
+
+	result, err := q.client.QueryAPI(q.organization).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var rows []Row
+	for result.Next() {
+		record := result.Record()
+		row := Row{Tags: make(map[string]string, len(tags)), Value: record.Value()}
+		for _, t := range tags {
+			if v, ok := record.ValueByKey(t).(string); ok {
+				row.Tags[t] = v
+			}
+		}
+		rows = append(rows, row)
+	}
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	return rows, nil
+}
+
+// InfluxWriter writes points into the buckets of an InfluxDB organization.
+type InfluxWriter struct {
+	client       influxdb2.Client
+	organization string
+}
+
+// NewInfluxWriter creates a new InfluxWriter.
+func NewInfluxWriter(client influxdb2.Client, organization string) *InfluxWriter {
+	return &InfluxWriter{client: client, organization: organization}
+}
+
+// WritePoint writes points into a bucket.
+func (w *InfluxWriter) WritePoint(ctx context.Context, bucket string, points ...*write.Point) error {
+	return w.client.WriteAPIBlocking(w.organization, bucket).WritePoint(ctx, points...)
+}
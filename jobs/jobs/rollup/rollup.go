@@ -0,0 +1,204 @@
+// Package rollup contains the jobs that downsample InfluxDB measurements into
+// the aggregated measurements consumed by the API.
+package rollup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/checkpoint"
+	"go.uber.org/zap"
+)
+
+// Window is a half-open time range [Start, Stop).
+type Window struct {
+	Start time.Time
+	Stop  time.Time
+}
+
+// Aggregation is a single flux query whose rows are written as points of a measurement.
+type Aggregation struct {
+	// Query is the flux query that computes the aggregation.
+	Query string
+	// Tags are the group columns copied from each row into the point tags.
+	Tags []string
+	// Bucket is the destination bucket.
+	Bucket      string
+	Measurement string
+	Field       string
+	// Time is the timestamp of the written points.
+	Time time.Time
+	// Default is written when the query returns no rows. Nil means nothing is written.
+	Default interface{}
+}
+
+// Definition describes a rollup.
+type Definition struct {
+	ID    string
+	Every time.Duration
+	// Aggregations returns the aggregations to compute for a window.
+	Aggregations func(w Window) []Aggregation
+}
+
+// Row is a single value returned by a Querier.
+type Row struct {
+	Tags  map[string]string
+	Value interface{}
+}
+
+// Querier executes flux queries.
+type Querier interface {
+	Query(ctx context.Context, query string, tags []string) ([]Row, error)
+}
+
+// Writer writes points into a bucket.
+type Writer interface {
+	WritePoint(ctx context.Context, bucket string, points ...*write.Point) error
+}
+
+// CheckpointStore stores the end of the last completed window of each rollup.
+type CheckpointStore interface {
+	Get(ctx context.Context, id string) (*checkpoint.Checkpoint, error)
+	Save(ctx context.Context, id string, lastTime time.Time) error
+}
+
+// RollupJob computes a rollup window by window.
+type RollupJob struct {
+	definition  Definition
+	querier     Querier
+	writer      Writer
+	checkpoints CheckpointStore
+	maxWindows  int
+	now         func() time.Time
+	logger      *zap.Logger
+}
+
+// NewRollupJob creates a new rollup job.
+func NewRollupJob(definition Definition, querier Querier, writer Writer, checkpoints CheckpointStore, maxWindows int, logger *zap.Logger) *RollupJob {
+	return &RollupJob{
+		definition:  definition,
+		querier:     querier,
+		writer:      writer,
+		checkpoints: checkpoints,
+		maxWindows:  maxWindows,
+		now:         time.Now,
+		logger:      logger.With(zap.String("rollup", definition.ID)),
+	}
+}
+
+// Run computes every window completed since the last checkpoint.
+//
+// When there is no checkpoint only the last completed window is computed. A run computes at most
+// maxWindows windows, the remaining ones are computed by the following runs.
+func (j *RollupJob) Run(ctx context.Context) error {
+
+	stop := j.now().UTC().Truncate(j.definition.Every)
+	start := stop.Add(-j.definition.Every)
+
+	c, err := j.checkpoints.Get(ctx, j.definition.ID)
+	switch {
+	case errors.Is(err, checkpoint.ErrNotFound):
+		j.logger.Info("no checkpoint found, starting from the last window", zap.Time("start", start))
+	case err != nil:
+		return fmt.Errorf("failed to get checkpoint: %w", err)
+	default:
+		start = c.LastTime.UTC()
+	}
+
+	if j.maxWindows > 0 {
+		limit := start.Add(time.Duration(j.maxWindows) * j.definition.Every)
+		if limit.Before(stop) {
+			stop = limit
+		}
+	}
+
+	return j.process(ctx, start, stop, start)
+}
+
+// Backfill recomputes every window between from and to.
+//
+// Windows are aligned to the rollup period. The checkpoint is only moved forward.
+func (j *RollupJob) Backfill(ctx context.Context, from, to time.Time) error {
+
+	start := from.UTC().Truncate(j.definition.Every)
+	stop := to.UTC().Truncate(j.definition.Every)
+	if !start.Before(stop) {
+		return fmt.Errorf("invalid backfill range [%s, %s)", start, stop)
+	}
+
+	var last time.Time
+	c, err := j.checkpoints.Get(ctx, j.definition.ID)
+	if err != nil && !errors.Is(err, checkpoint.ErrNotFound) {
+		return fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+	if c != nil {
+		last = c.LastTime.UTC()
+	}
+
+	j.logger.Info("backfilling rollup", zap.Time("from", start), zap.Time("to", stop))
+	return j.process(ctx, start, stop, last)
+}
+
+// process computes the windows in [start, stop) and saves the checkpoint when it is after last.
+func (j *RollupJob) process(ctx context.Context, start, stop, last time.Time) error {
+
+	for w := (Window{Start: start, Stop: start.Add(j.definition.Every)}); !w.Stop.After(stop); w = (Window{Start: w.Stop, Stop: w.Stop.Add(j.definition.Every)}) {
+
+		if err := j.processWindow(ctx, w); err != nil {
+			return fmt.Errorf("failed to process window %s: %w", w.Start.Format(time.RFC3339), err)
+		}
+
+		if w.Stop.After(last) {
+			if err := j.checkpoints.Save(ctx, j.definition.ID, w.Stop); err != nil {
+				return fmt.Errorf("failed to save checkpoint: %w", err)
+			}
+			last = w.Stop
+		}
+	}
+
+	return nil
+}
+
+// processWindow computes all the aggregations of a window.
+//
+// Points are written with a deterministic timestamp, so computing a window twice overwrites the previous values.
+func (j *RollupJob) processWindow(ctx context.Context, w Window) error {
+
+	for _, a := range j.definition.Aggregations(w) {
+
+		rows, err := j.querier.Query(ctx, a.Query, a.Tags)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 && a.Default != nil {
+			rows = []Row{{Value: a.Default}}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		points := make([]*write.Point, 0, len(rows))
+		for _, r := range rows {
+			p := influxdb2.NewPointWithMeasurement(a.Measurement).
+				AddField(a.Field, r.Value).
+				SetTime(a.Time)
+			for _, t := range a.Tags {
+				if v, ok := r.Tags[t]; ok {
+					p.AddTag(t, v)
+				}
+			}
+			points = append(points, p)
+		}
+
+		if err := j.writer.WritePoint(ctx, a.Bucket, points...); err != nil {
+			return err
+		}
+	}
+
+	j.logger.Debug("processed window", zap.Time("start", w.Start), zap.Time("stop", w.Stop))
+	return nil
+}
@@ -0,0 +1,172 @@
+package rollup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/checkpoint"
+	"go.uber.org/zap"
+)
+
+type fakeQuerier struct {
+	queries []string
+	rows    []Row
+}
+
+func (q *fakeQuerier) Query(_ context.Context, query string, _ []string) ([]Row, error) {
+	q.queries = append(q.queries, query)
+	return q.rows, nil
+}
+
+type fakeWriter struct {
+	points map[string][]*write.Point
+}
+
+func (w *fakeWriter) WritePoint(_ context.Context, bucket string, points ...*write.Point) error {
+	if w.points == nil {
+		w.points = make(map[string][]*write.Point)
+	}
+	w.points[bucket] = append(w.points[bucket], points...)
+	return nil
+}
+
+type fakeCheckpoints struct {
+	checkpoints map[string]time.Time
+}
+
+func (c *fakeCheckpoints) Get(_ context.Context, id string) (*checkpoint.Checkpoint, error) {
+	t, ok := c.checkpoints[id]
+	if !ok {
+		return nil, checkpoint.ErrNotFound
+	}
+	return &checkpoint.Checkpoint{ID: id, LastTime: t}, nil
+}
+
+func (c *fakeCheckpoints) Save(_ context.Context, id string, lastTime time.Time) error {
+	if c.checkpoints == nil {
+		c.checkpoints = make(map[string]time.Time)
+	}
+	c.checkpoints[id] = lastTime
+	return nil
+}
+
+var testBuckets = Buckets{Infinite: "wormscan", Days30: "wormscan-30days", Hours24: "wormscan-24hours"}
+
+func newTestJob(d Definition, q Querier, w Writer, c CheckpointStore, maxWindows int, now time.Time) *RollupJob {
+	j := NewRollupJob(d, q, w, c, maxWindows, zap.NewNop())
+	j.now = func() time.Time { return now }
+	return j
+}
+
+func TestRollupJob_RunWithoutCheckpoint(t *testing.T) {
+
+	now := time.Date(2023, 5, 4, 12, 25, 48, 0, time.UTC)
+	querier := &fakeQuerier{}
+	writer := &fakeWriter{}
+	checkpoints := &fakeCheckpoints{}
+
+	job := newTestJob(VaaCount1h(testBuckets), querier, writer, checkpoints, 24, now)
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, querier.queries, 1)
+	assert.Contains(t, querier.queries[0], "range(start: 2023-05-04T11:00:00Z, stop: 2023-05-04T12:00:00Z)")
+
+	// an empty hour is written as zero.
+	points := writer.points["wormscan-30days"]
+	assert.Len(t, points, 1)
+	assert.Equal(t, "vaa_count_1h", points[0].Name())
+	// the hours are written at their end, as the aggregateWindow of the former task did.
+	assert.Equal(t, time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC), points[0].Time())
+	assert.Equal(t, "count", points[0].FieldList()[0].Key)
+	assert.Equal(t, int64(0), points[0].FieldList()[0].Value)
+
+	assert.Equal(t, time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC), checkpoints.checkpoints[VaaCount1h(testBuckets).ID])
+}
+
+func TestRollupJob_RunFromCheckpoint(t *testing.T) {
+
+	now := time.Date(2023, 5, 4, 12, 25, 48, 0, time.UTC)
+	querier := &fakeQuerier{rows: []Row{{Value: int64(10)}}}
+	writer := &fakeWriter{}
+	d := VaaCount1h(testBuckets)
+	checkpoints := &fakeCheckpoints{checkpoints: map[string]time.Time{
+		d.ID: time.Date(2023, 5, 4, 7, 0, 0, 0, time.UTC),
+	}}
+
+	job := newTestJob(d, querier, writer, checkpoints, 3, now)
+	err := job.Run(context.Background())
+
+	// only maxWindows windows are processed in a single run.
+	assert.NoError(t, err)
+	assert.Len(t, querier.queries, 3)
+	assert.Len(t, writer.points["wormscan-30days"], 3)
+	assert.Equal(t, time.Date(2023, 5, 4, 10, 0, 0, 0, time.UTC), checkpoints.checkpoints[d.ID])
+
+	err = job.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, querier.queries, 5)
+	assert.Equal(t, time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC), checkpoints.checkpoints[d.ID])
+}
+
+func TestRollupJob_BackfillDoesNotMoveCheckpointBackwards(t *testing.T) {
+
+	now := time.Date(2023, 5, 10, 0, 0, 0, 0, time.UTC)
+	querier := &fakeQuerier{rows: []Row{
+		{Tags: map[string]string{"emitter_chain": "2", "token_chain": "2", "token_address": "0xa0b8"}, Value: uint64(100)},
+		{Tags: map[string]string{"emitter_chain": "1", "token_chain": "1", "token_address": "So111"}, Value: uint64(50)},
+	}}
+	writer := &fakeWriter{}
+	d := AssetVolumes24h(testBuckets)
+	checkpoints := &fakeCheckpoints{checkpoints: map[string]time.Time{
+		d.ID: time.Date(2023, 5, 9, 0, 0, 0, 0, time.UTC),
+	}}
+
+	job := newTestJob(d, querier, writer, checkpoints, 24, now)
+	err := job.Backfill(context.Background(),
+		time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Len(t, querier.queries, 3)
+	points := writer.points["wormscan-30days"]
+	assert.Len(t, points, 6)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), points[0].Time())
+	assert.Len(t, points[0].TagList(), 3)
+	assert.Equal(t, time.Date(2023, 5, 9, 0, 0, 0, 0, time.UTC), checkpoints.checkpoints[d.ID])
+}
+
+func TestRollupJob_BackfillInvalidRange(t *testing.T) {
+
+	job := newTestJob(TotalTx24h(testBuckets), &fakeQuerier{}, &fakeWriter{}, &fakeCheckpoints{}, 24, time.Now())
+	err := job.Backfill(context.Background(),
+		time.Date(2023, 5, 4, 10, 0, 0, 0, time.UTC),
+		time.Date(2023, 5, 4, 20, 0, 0, 0, time.UTC))
+
+	assert.Error(t, err)
+}
+
+func TestChainActivity3h_Aggregations(t *testing.T) {
+
+	w := Window{
+		Start: time.Date(2023, 5, 4, 9, 0, 0, 0, time.UTC),
+		Stop:  time.Date(2023, 5, 4, 12, 0, 0, 0, time.UTC),
+	}
+	aggregations := ChainActivity3h(testBuckets).Aggregations(w)
+
+	assert.Len(t, aggregations, 2*len(chainActivityPeriods))
+	assert.Equal(t, "chain_activity_7_days_3h_v2", aggregations[0].Measurement)
+	assert.Equal(t, "count", aggregations[0].Field)
+	assert.Equal(t, "notional", aggregations[1].Field)
+	assert.Contains(t, aggregations[0].Query, "range(start: 2023-04-27T00:00:00Z, stop: 2023-05-04T12:00:00Z)")
+	assert.Equal(t, "chain_activity_all_time_3h_v2", aggregations[len(aggregations)-1].Measurement)
+	assert.Contains(t, aggregations[len(aggregations)-1].Query, "range(start: 1970-01-01T00:00:00Z")
+	for _, a := range aggregations {
+		assert.Equal(t, "wormscan-24hours", a.Bucket)
+		assert.Equal(t, w.Stop, a.Time)
+	}
+}
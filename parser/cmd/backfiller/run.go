@@ -9,12 +9,14 @@ import (
 	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/http/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/processor"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/relayer"
 	"go.uber.org/zap"
 )
 
@@ -62,8 +64,11 @@ func Run(config *config.BackfillerConfiguration) {
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
 
+	// create a relayer delivery tracker
+	relayTracker := relayer.NewTracker(config.P2pNetwork, relayer.NewRepository(db.Database, logger), logger)
+
 	//create a processor
-	eventProcessor := processor.New(parserVAAAPIClient, parserRepository, alert.NewDummyClient(), metrics.NewDummyMetrics(), tokenProvider, logger)
+	eventProcessor := processor.New(parserVAAAPIClient, parserRepository, relayTracker, lineage.NewRecorder(db.Database, lineage.StageParser, logger), alert.NewDummyClient(), metrics.NewDummyMetrics(), tokenProvider, logger)
 
 	logger.Info("Started wormhole-explorer-parser as backfiller")
 
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dlq"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/cmd/backfiller"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/cmd/service"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
@@ -25,6 +29,7 @@ func execute() error {
 
 	addServiceCommand(root)
 	addBackfiller(root)
+	addDLQCommand(root)
 
 	return root.Execute()
 }
@@ -84,3 +89,52 @@ func addBackfiller(root *cobra.Command) {
 
 	root.AddCommand(backfillerCommand)
 }
+
+func addDLQCommand(root *cobra.Command) {
+	var cfg dlq.Config
+	var chainID int
+
+	dlqCommand := &cobra.Command{
+		Use:   "dlq",
+		Short: "List, inspect, re-drive or purge the messages of the dead-letter queue",
+	}
+	dlqCommand.PersistentFlags().StringVar(&cfg.AwsRegion, "aws-region", "", "AWS region")
+	dlqCommand.PersistentFlags().StringVar(&cfg.AwsEndpoint, "aws-endpoint", "", "AWS endpoint, e.g. a local SQS compatible service")
+	dlqCommand.PersistentFlags().StringVar(&cfg.AwsAccessKeyID, "aws-access-key-id", "", "AWS access key id")
+	dlqCommand.PersistentFlags().StringVar(&cfg.AwsSecretAccessKey, "aws-secret-access-key", "", "AWS secret access key")
+	dlqCommand.PersistentFlags().StringVar(&cfg.DLQSQSUrl, "dlq-sqs-url", "", "dead-letter queue url")
+	dlqCommand.PersistentFlags().StringVar(&cfg.Filter.VaaID, "vaa-id", "", "filter messages by VAA ID")
+	dlqCommand.PersistentFlags().IntVar(&chainID, "chain", -1, "filter messages by chain ID")
+	dlqCommand.PersistentFlags().StringVar(&cfg.Filter.ErrorClass, "error-class", "", "filter messages by error class")
+	dlqCommand.PersistentFlags().IntVar(&cfg.Limit, "limit", 0, "maximum number of messages (default all)")
+	dlqCommand.MarkPersistentFlagRequired("dlq-sqs-url")
+
+	newAction := func(action, short string) *cobra.Command {
+		return &cobra.Command{
+			Use:   action,
+			Short: short,
+			Run: func(_ *cobra.Command, _ []string) {
+				if chainID >= 0 {
+					c := uint16(chainID)
+					cfg.Filter.ChainID = &c
+				}
+				if err := dlq.Run(context.Background(), action, &cfg, os.Stdout); err != nil {
+					log.Fatal(err)
+				}
+			},
+		}
+	}
+
+	inspectCommand := newAction(dlq.ActionInspect, "Print the messages with their body")
+	inspectCommand.Flags().StringVar(&cfg.Filter.MessageID, "message-id", "", "filter messages by SQS message ID")
+	redriveCommand := newAction(dlq.ActionRedrive, "Send the messages back to their queue")
+	redriveCommand.Flags().StringVar(&cfg.TargetSQSUrl, "target-sqs-url", "", "queue url the messages are sent to (default the queue they failed in)")
+
+	dlqCommand.AddCommand(
+		newAction(dlq.ActionList, "List the messages"),
+		inspectCommand,
+		redriveCommand,
+		newAction(dlq.ActionPurge, "Delete the messages"),
+	)
+	root.AddCommand(dlqCommand)
+}
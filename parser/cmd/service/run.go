@@ -12,22 +12,25 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
 	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/consumer"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/http/infrastructure"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/http/vaa"
 	parserAlert "github.com/wormhole-foundation/wormhole-explorer/parser/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
-	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/migration"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/processor"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/relayer"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
@@ -43,6 +46,8 @@ func handleExit() {
 	}
 }
 
+// Run starts the parser with the configuration from the environment and blocks until a
+// termination signal is received.
 func Run() {
 
 	defer handleExit()
@@ -55,6 +60,42 @@ func Run() {
 
 	logger := logger.New("wormhole-explorer-parser", logger.WithLevel(config.LogLevel))
 
+	shutdownTracing, err := tracing.Init(rootCtx, "wormscan-parser", config.Tracing, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	stop := Start(rootCtx, config, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("flushing traces...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Warn("failed to flush traces", zap.Error(err))
+	}
+
+	logger.Info("Finished wormhole-explorer-parser")
+}
+
+// Start starts the parser consumers and http server with the given configuration. The consumers
+// run until rootCtx is cancelled and the returned function releases the remaining resources.
+func Start(rootCtx context.Context, config *config.ServiceConfiguration, logger *zap.Logger) (stop func()) {
+
 	logger.Info("Starting wormhole-explorer-parser ...")
 
 	// setup DB connection
@@ -103,8 +144,11 @@ func Run() {
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
 
+	// create a relayer delivery tracker
+	relayTracker := relayer.NewTracker(config.P2pNetwork, relayer.NewRepository(db.Database, logger), logger)
+
 	//create a processor
-	processor := processor.New(parserVAAAPIClient, repository, alertClient, metrics, tokenProvider, logger)
+	processor := processor.New(parserVAAAPIClient, repository, relayTracker, lineage.NewRecorder(db.Database, lineage.StageParser, logger), alertClient, metrics, tokenProvider, logger)
 
 	// create and start a vaaConsumer
 	vaaConsumer := consumer.New(vaaConsumeFunc, processor.Process, metrics, logger)
@@ -121,26 +165,13 @@ func Run() {
 
 	logger.Info("Started wormhole-explorer-parser")
 
-	// Waiting for signal
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("Terminating with root context cancelled.")
-	case signal := <-sigterm:
-		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
-	}
-
-	logger.Info("root context cancelled, exiting...")
-	rootCtxCancel()
-
-	logger.Info("closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
-
-	logger.Info("Closing Http server ...")
-	server.Stop()
+	return func() {
+		logger.Info("closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
 
-	logger.Info("Finished wormhole-explorer-parser")
+		logger.Info("Closing Http server ...")
+		server.Stop()
+	}
 }
 
 // Creates a new AWS config depending on whether the execution is local (localstack) or not (AWS)
@@ -171,37 +202,65 @@ func newAwsConfig(appCtx context.Context, cfg *config.ServiceConfiguration) (aws
 }
 
 func newVAAConsume(appCtx context.Context, config *config.ServiceConfiguration, metrics metrics.Metrics, logger *zap.Logger) queue.ConsumeFunc {
-	sqsConsumer, err := newSQSConsumer(appCtx, config, config.PipelineSQSUrl)
+	consumer, err := newQueueConsumer(appCtx, config, config.PipelineSQSUrl, config.PipelineTopic)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue consumer", zap.Error(err))
 	}
 
 	filterConsumeFunc := newFilterFunc(config)
-	vaaQueue := queue.NewEventSQS(sqsConsumer, queue.NewVaaConverter(logger), filterConsumeFunc, metrics, logger)
+	vaaQueue := queue.NewEventQueue(consumer, queue.NewVaaConverter(logger), filterConsumeFunc, metrics, logger)
 	return vaaQueue.Consume
 }
 
 func newNotificationConsume(appCtx context.Context, config *config.ServiceConfiguration, metrics metrics.Metrics, logger *zap.Logger) queue.ConsumeFunc {
-	sqsConsumer, err := newSQSConsumer(appCtx, config, config.NotificationsSQSUrl)
+	consumer, err := newQueueConsumer(appCtx, config, config.NotificationsSQSUrl, config.NotificationsTopic)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue consumer", zap.Error(err))
 	}
 
 	filterConsumeFunc := newFilterFunc(config)
-	vaaQueue := queue.NewEventSQS(sqsConsumer, queue.NewNotificationEvent(logger), filterConsumeFunc, metrics, logger)
+	vaaQueue := queue.NewEventQueue(consumer, queue.NewNotificationEvent(logger), filterConsumeFunc, metrics, logger)
 	return vaaQueue.Consume
 }
 
-// Create a new SQS consumer.
-func newSQSConsumer(appCtx context.Context, config *config.ServiceConfiguration, sqsUrl string) (*sqs.Consumer, error) {
-	awsconfig, err := newAwsConfig(appCtx, config)
+// Creates a consumer of the configured message broker. The SQS broker reads the queue sqsUrl
+// and the others the consumer group of the topic.
+func newQueueConsumer(appCtx context.Context, config *config.ServiceConfiguration, sqsUrl, topic string) (broker.Consumer, error) {
+	settings, err := newBrokerSettings(appCtx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return sqs.NewConsumer(awsconfig, sqsUrl,
-		sqs.WithMaxMessages(10),
-		sqs.WithVisibilityTimeout(120))
+	subscription := broker.Subscription{
+		QueueURL:           sqsUrl,
+		Topic:              topic,
+		Group:              config.ConsumerGroup,
+		DeadLetterQueueURL: config.DLQSQSUrl,
+		DeadLetterTopic:    config.DLQTopic,
+		MaxAttempts:        config.DLQMaxAttempts,
+	}
+	return broker.NewConsumer(appCtx, settings, subscription,
+		broker.WithMaxMessages(10),
+		broker.WithVisibilityTimeout(120*time.Second))
+}
+
+// Creates the message broker settings, the AWS config is only loaded for the SQS broker.
+func newBrokerSettings(appCtx context.Context, config *config.ServiceConfiguration) (broker.Settings, error) {
+	settings := broker.Settings{
+		Type:               broker.Type(config.QueueBroker),
+		RedisURI:           config.QueueRedisURI,
+		NatsURL:            config.QueueNatsURL,
+		NatsStreamMaxAge:   time.Duration(config.QueueNatsStreamMaxAgeHours) * time.Hour,
+		NatsStreamMaxBytes: config.QueueNatsStreamMaxBytes,
+	}
+	if settings.Type == broker.TypeSQS {
+		awsConfig, err := newAwsConfig(appCtx, config)
+		if err != nil {
+			return settings, err
+		}
+		settings.AwsConfig = awsConfig
+	}
+	return settings, nil
 }
 
 // Creates a filter depending on whether the execution is local (dummy filter) or not (Pyth filter)
@@ -240,15 +299,17 @@ func newHealthChecks(
 	db *mongo.Database,
 ) ([]health.Check, error) {
 
+	healthChecks := []health.Check{health.Mongo(db)}
+	if config.QueueBroker != string(broker.TypeSQS) {
+		return healthChecks, nil
+	}
+
 	awsConfig, err := newAwsConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
-
-	healthChecks := []health.Check{
+	healthChecks = append(healthChecks,
 		health.SQS(awsConfig, config.PipelineSQSUrl),
-		health.SQS(awsConfig, config.NotificationsSQSUrl),
-		health.Mongo(db),
-	}
+		health.SQS(awsConfig, config.NotificationsSQSUrl))
 	return healthChecks, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 // p2p network constants.
@@ -28,6 +29,8 @@ type ServiceConfiguration struct {
 	AwsRegion               string `env:"AWS_REGION"`
 	PipelineSQSUrl          string `env:"PIPELINE_SQS_URL"`
 	NotificationsSQSUrl     string `env:"NOTIFICATIONS_SQS_URL"`
+	DLQSQSUrl               string `env:"DLQ_SQS_URL"`
+	DLQMaxAttempts          int    `env:"DLQ_MAX_ATTEMPTS,default=5"`
 	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
 	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
 	PprofEnabled            bool   `env:"PPROF_ENABLED,default=false"`
@@ -35,6 +38,22 @@ type ServiceConfiguration struct {
 	AlertEnabled            bool   `env:"ALERT_ENABLED,default=false"`
 	AlertApiKey             string `env:"ALERT_API_KEY"`
 	MetricsEnabled          bool   `env:"METRICS_ENABLED,default=false"`
+	QueueConfiguration
+	Tracing tracing.Config
+}
+
+// QueueConfiguration represents the message broker configuration. The topics are used by all
+// the brokers but SQS, which uses the queue URLs.
+type QueueConfiguration struct {
+	QueueBroker                string `env:"QUEUE_BROKER,default=sqs"`
+	QueueRedisURI              string `env:"QUEUE_REDIS_URI"`
+	QueueNatsURL               string `env:"QUEUE_NATS_URL"`
+	QueueNatsStreamMaxAgeHours int    `env:"QUEUE_NATS_STREAM_MAX_AGE_HOURS,default=168"`
+	QueueNatsStreamMaxBytes    int64  `env:"QUEUE_NATS_STREAM_MAX_BYTES,default=1073741824"`
+	PipelineTopic              string `env:"PIPELINE_TOPIC,default=wormscan.vaas"`
+	NotificationsTopic         string `env:"NOTIFICATIONS_TOPIC,default=wormscan.notifications"`
+	DLQTopic                   string `env:"DLQ_TOPIC,default=wormscan.parser.dlq"`
+	ConsumerGroup              string `env:"CONSUMER_GROUP,default=parser"`
 }
 
 // BackfillerConfiguration represents the application configuration when running as backfiller with default values.
@@ -31,7 +31,7 @@ func (c *Consumer) Start(ctx context.Context) {
 			// check id message is expired.
 			if msg.IsExpired() {
 				c.logger.Warn("Event expired", zap.String("id", event.ID))
-				msg.Failed()
+				msg.Failed(queue.ErrMessageExpired)
 				continue
 			}
 			c.metrics.IncVaaUnexpired(event.ChainID)
@@ -40,13 +40,13 @@ func (c *Consumer) Start(ctx context.Context) {
 				TrackID: event.TrackID,
 				Vaa:     event.Vaa,
 			}
-			_, err := c.process(ctx, params)
+			_, err := c.process(msg.Context(), params)
 			if err != nil {
 				c.logger.Error("Error processing event",
 					zap.String("trackId", event.TrackID),
 					zap.String("id", event.ID),
 					zap.Error(err))
-				msg.Failed()
+				msg.Failed(err)
 				continue
 			} else {
 				c.logger.Debug("Event processed",
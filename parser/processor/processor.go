@@ -11,26 +11,35 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	parserAlert "github.com/wormhole-foundation/wormhole-explorer/parser/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/relayer"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+// errVaaCannotBeParsed is returned when the vaa-payload-parser has no parser for the VAA.
+var errVaaCannotBeParsed = errors.New("vaa cannot be parsed")
+
 type Processor struct {
 	parser        vaaPayloadParser.ParserVAAAPIClient
 	repository    *parser.Repository
+	relays        *relayer.Tracker
+	lineage       *lineage.Recorder
 	alert         alert.AlertClient
 	metrics       metrics.Metrics
 	tokenProvider *domain.TokenProvider
 	logger        *zap.Logger
 }
 
-func New(parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Repository, alert alert.AlertClient, metrics metrics.Metrics, tokenProvider *domain.TokenProvider, logger *zap.Logger) *Processor {
+func New(parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Repository, relays *relayer.Tracker, lineage *lineage.Recorder, alert alert.AlertClient, metrics metrics.Metrics, tokenProvider *domain.TokenProvider, logger *zap.Logger) *Processor {
 	return &Processor{
 		parser:        parser,
 		repository:    repository,
+		relays:        relays,
+		lineage:       lineage,
 		alert:         alert,
 		metrics:       metrics,
 		tokenProvider: tokenProvider,
@@ -39,19 +48,45 @@ func New(parser vaaPayloadParser.ParserVAAAPIClient, repository *parser.Reposito
 }
 
 func (p *Processor) Process(ctx context.Context, params *Params) (*parser.ParsedVaaUpdate, error) {
+	startedAt := time.Now()
+
 	// unmarshal vaa.
 	vaa, err := sdk.Unmarshal(params.Vaa)
 	if err != nil {
 		return nil, err
 	}
 
-	// call vaa-payload-parser api to parse a VAA.
+	vaaParsed, err := p.process(ctx, params, vaa)
+	if errors.Is(err, errVaaCannotBeParsed) {
+		p.lineage.Skip(ctx, vaa.MessageID(), params.TrackID, startedAt, err.Error())
+		return nil, nil
+	}
+	p.lineage.Record(ctx, vaa.MessageID(), params.TrackID, startedAt, err)
+	return vaaParsed, err
+}
+
+func (p *Processor) process(ctx context.Context, params *Params, vaa *sdk.VAA) (*parser.ParsedVaaUpdate, error) {
 	chainID := uint16(vaa.EmitterChain)
 	emitterAddress := vaa.EmitterAddress.String()
 	sequence := fmt.Sprintf("%d", vaa.Sequence)
 
+	// store the delivery requested by the generic relayer VAAs. The error is returned so that the
+	// message is retried, and moved to the dead-letter queue when the retries are exhausted.
+	isRelay, err := p.relays.Track(ctx, vaa)
+	if err != nil {
+		p.logger.Error("Error tracking relayer delivery", zap.Error(err),
+			zap.String("trackId", params.TrackID),
+			zap.Uint16("chainId", chainID),
+			zap.String("address", emitterAddress),
+			zap.String("sequence", sequence))
+		return nil, fmt.Errorf("error tracking relayer delivery: %w", err)
+	}
+	if isRelay {
+		p.logger.Info("relayer delivery was successfully persisted", zap.String("trackId", params.TrackID), zap.String("id", vaa.MessageID()))
+	}
+
 	p.metrics.IncVaaPayloadParserRequestCount(chainID)
-	vaaParseResponse, err := p.parser.ParseVaaWithStandarizedProperties(vaa)
+	vaaParseResponse, err := p.parser.ParseVaaWithStandarizedProperties(ctx, vaa)
 	if err != nil {
 		// split metrics error not found and others errors.
 		if errors.Is(err, vaaPayloadParser.ErrNotFound) {
@@ -81,7 +116,7 @@ func (p *Processor) Process(ctx context.Context, params *Params) (*parser.Parsed
 			zap.Uint16("chainId", chainID),
 			zap.String("address", emitterAddress),
 			zap.String("sequence", sequence))
-		return nil, nil
+		return nil, fmt.Errorf("%w: %v", errVaaCannotBeParsed, err)
 	}
 	p.metrics.IncVaaPayloadParserSuccessCount(chainID)
 	p.metrics.IncVaaParsed(chainID)
@@ -66,10 +66,10 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 
 		switch notification.Event {
 		case events.SignedVaaType:
-			signedVaaEvent, err := events.GetEventData[events.SignedVaa](&notification)
+			signedVaaEvent, err := events.Decode[events.SignedVaa](&notification)
 			if err != nil {
 				log.Error("Error decoding signedVAA from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
-				return nil, nil
+				return nil, err
 			}
 
 			return &Event{
@@ -81,12 +81,13 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				Vaa:            signedVaaEvent.Vaa,
 				Timestamp:      &signedVaaEvent.Timestamp,
 				TxHash:         signedVaaEvent.TxHash,
+				TraceContext:   notification.TraceContext,
 			}, nil
 		case events.LogMessagePublishedMesageType:
-			plm, err := events.GetEventData[events.LogMessagePublished](&notification)
+			plm, err := events.Decode[events.LogMessagePublished](&notification)
 			if err != nil {
 				log.Error("Error decoding publishedLogMessage from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
-				return nil, nil
+				return nil, err
 			}
 
 			vaa, err := events.CreateUnsignedVAA(&plm)
@@ -109,6 +110,7 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				Vaa:            vaaBytes,
 				Timestamp:      &plm.BlockTime,
 				TxHash:         plm.TxHash,
+				TraceContext:   notification.TraceContext,
 			}, nil
 		}
 		return nil, nil
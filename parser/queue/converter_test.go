@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events/eventstest"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
@@ -85,3 +87,38 @@ func TestSqsEvent(t *testing.T) {
 	assert.Equal(t, expectedTimestamp.UTC(), vaa.Timestamp.UTC())
 	assert.Equal(t, uint8(200), vaa.ConsistencyLevel)
 }
+
+func TestNotificationEvent_TraceContext(t *testing.T) {
+	converter := NewNotificationEvent(zap.NewNop())
+	fixture := eventstest.ByEvent(eventstest.Valid(), events.SignedVaaType)[0]
+	var notification events.NotificationEvent
+	assert.NoError(t, json.Unmarshal(fixture.Body, &notification))
+	notification.TraceContext = map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	msg, err := json.Marshal(notification)
+	assert.NoError(t, err)
+
+	event, err := converter(string(msg))
+	assert.NoError(t, err)
+	if assert.NotNil(t, event) {
+		assert.Equal(t, map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, event.TraceContext)
+	}
+}
+
+// TestNotificationEvent_Contract checks the converter against the events shared with the producers.
+func TestNotificationEvent_Contract(t *testing.T) {
+	converter := NewNotificationEvent(zap.NewNop())
+	for _, f := range eventstest.Valid() {
+		event, err := converter(string(f.Body))
+		if assert.NoError(t, err, f.Name) && assert.NotNil(t, event, f.Name) {
+			assert.NotEmpty(t, event.ID, f.Name)
+			assert.NotEmpty(t, event.TrackID, f.Name)
+			_, err := sdk.Unmarshal(event.Vaa)
+			assert.NoError(t, err, f.Name)
+		}
+	}
+	for _, f := range eventstest.Invalid() {
+		event, err := converter(string(f.Body))
+		assert.Error(t, err, f.Name)
+		assert.Nil(t, event, f.Name)
+	}
+}
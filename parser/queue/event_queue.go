@@ -0,0 +1,196 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const tracerName = "github.com/wormhole-foundation/wormhole-explorer/parser/queue"
+
+// EventQueueOption represents an event queue option function.
+type EventQueueOption func(*EventQueue)
+
+// EventQueue represents an event queue consumed from a message broker.
+type EventQueue struct {
+	consumer      broker.Consumer
+	ch            chan ConsumerMessage
+	chSize        int
+	wg            sync.WaitGroup
+	filterConsume FilterConsumeFunc
+	converter     ConverterFunc
+	metrics       metrics.Metrics
+	logger        *zap.Logger
+}
+
+// FilterConsumeFunc filter vaaa func definition.
+type FilterConsumeFunc func(*Event) bool
+
+// ConverterFunc converts a message from a broker message.
+type ConverterFunc func(string) (*Event, error)
+
+// NewEventQueue creates an event queue instance.
+func NewEventQueue(consumer broker.Consumer, converter ConverterFunc, filterConsume FilterConsumeFunc, metrics metrics.Metrics, logger *zap.Logger, opts ...EventQueueOption) *EventQueue {
+	s := &EventQueue{
+		consumer:      consumer,
+		chSize:        10,
+		converter:     converter,
+		filterConsume: filterConsume,
+		metrics:       metrics,
+		logger:        logger}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ch = make(chan ConsumerMessage, s.chSize)
+	return s
+}
+
+// WithChannelSize allows to specify an channel size when setting a value.
+func WithChannelSize(size int) EventQueueOption {
+	return func(d *EventQueue) {
+		d.chSize = size
+	}
+}
+
+// Consume returns the channel with the received messages from the broker.
+func (q *EventQueue) Consume(ctx context.Context) <-chan ConsumerMessage {
+	go func() {
+		for {
+			messages, err := q.consumer.Receive(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				q.logger.Error("Error getting messages from broker", zap.Error(err))
+				continue
+			}
+			expiredAt := time.Now().Add(q.consumer.VisibilityTimeout())
+			for _, msg := range messages {
+
+				// converts message to event
+				event, err := q.converter(string(msg.Body()))
+				if err != nil {
+					q.logger.Error("Error decoding event message", zap.Error(err))
+					q.failed(ctx, msg, broker.Failure{Err: err})
+					continue
+				}
+
+				if event == nil {
+					continue
+				}
+
+				q.metrics.IncVaaConsumedQueue(event.ChainID)
+
+				// filter vaaEvent by p2p net.
+				if q.filterConsume(event) {
+					if err := msg.Done(ctx); err != nil {
+						q.logger.Error("Error deleting message from broker", zap.Error(err))
+					}
+					continue
+				}
+				q.metrics.IncVaaUnfiltered(event.ChainID)
+
+				msgCtx, span := startSpan(ctx, msg, event)
+				q.wg.Add(1)
+				q.ch <- &brokerConsumerMessage{
+					message:   msg,
+					data:      event,
+					wg:        &q.wg,
+					logger:    q.logger,
+					expiredAt: expiredAt,
+					ctx:       msgCtx,
+					span:      span,
+				}
+			}
+			q.wg.Wait()
+		}
+
+	}()
+	return q.ch
+}
+
+// startSpan starts the span of the processing of an event, child of the trace context sent in the
+// message attributes or, when the broker has no message attributes, in the event.
+func startSpan(ctx context.Context, msg broker.Message, event *Event) (context.Context, trace.Span) {
+	attributes := msg.Attributes()
+	if len(attributes) == 0 {
+		attributes = event.TraceContext
+	}
+	return tracing.StartConsumerSpan(ctx, tracerName, "parser.consume", attributes,
+		tracing.VaaIDKey.String(event.ID), tracing.TrackIDKey.String(event.TrackID))
+}
+
+// failed records the failure of a message that could not be decoded.
+func (q *EventQueue) failed(ctx context.Context, msg broker.Message, failure broker.Failure) {
+	moved, err := msg.Failed(ctx, failure)
+	if err != nil {
+		q.logger.Error("Error moving message to dead-letter queue", zap.Error(err))
+	} else if moved {
+		q.logger.Warn("Message moved to dead-letter queue",
+			zap.Int("attempts", msg.Attempts()),
+			zap.Error(failure.Err))
+	}
+}
+
+// Close closes all consumer resources.
+func (q *EventQueue) Close() {
+	close(q.ch)
+	if err := q.consumer.Close(); err != nil {
+		q.logger.Error("Error closing broker consumer", zap.Error(err))
+	}
+}
+
+type brokerConsumerMessage struct {
+	data      *Event
+	wg        *sync.WaitGroup
+	message   broker.Message
+	logger    *zap.Logger
+	expiredAt time.Time
+	ctx       context.Context
+	span      trace.Span
+}
+
+func (m *brokerConsumerMessage) Data() *Event {
+	return m.data
+}
+
+func (m *brokerConsumerMessage) Done() {
+	if err := m.message.Done(m.ctx); err != nil {
+		m.logger.Error("Error deleting message from broker", zap.Error(err))
+	}
+	m.span.End()
+	m.wg.Done()
+}
+
+func (m *brokerConsumerMessage) Failed(err error) {
+	failure := broker.Failure{VaaID: m.data.ID, ChainID: m.data.ChainID, Err: err}
+	moved, dlqErr := m.message.Failed(m.ctx, failure)
+	if dlqErr != nil {
+		m.logger.Error("Error moving message to dead-letter queue",
+			zap.String("vaaId", m.data.ID),
+			zap.Error(dlqErr),
+		)
+	} else if moved {
+		m.logger.Warn("Message moved to dead-letter queue",
+			zap.String("vaaId", m.data.ID),
+			zap.Int("attempts", m.message.Attempts()),
+			zap.Error(err),
+		)
+	}
+	tracing.EndSpan(m.span, err)
+	m.wg.Done()
+}
+
+func (m *brokerConsumerMessage) IsExpired() bool {
+	return m.expiredAt.Before(time.Now())
+}
+
+func (m *brokerConsumerMessage) Context() context.Context {
+	return m.ctx
+}
@@ -2,6 +2,7 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
@@ -20,14 +21,23 @@ type Event struct {
 	Vaa            []byte
 	Timestamp      *time.Time
 	TxHash         string
+	// TraceContext is the trace context sent in the event when the broker has no message attributes.
+	TraceContext map[string]string
 }
 
+// ErrMessageExpired is the failure of a message received after its visibility timeout expired.
+var ErrMessageExpired = errors.New("message expired")
+
 // ConsumerMessage defition.
 type ConsumerMessage interface {
 	Data() *Event
 	Done()
-	Failed()
+	// Failed releases the message to be retried, or moves it to the dead-letter queue
+	// when it reached the maximum number of attempts.
+	Failed(err error)
 	IsExpired() bool
+	// Context returns the context of the processing of the message, with the span of the message.
+	Context() context.Context
 }
 
 // ConsumeFunc is a function to consume Event.
@@ -0,0 +1,267 @@
+// Package relayer tracks the deliveries requested to the generic relayer contracts.
+package relayer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Payload types of the generic relayer VAAs.
+const (
+	PayloadTypeDelivery   uint8 = 1
+	PayloadTypeRedelivery uint8 = 2
+)
+
+// message key types of a delivery instruction.
+const (
+	keyTypeVaa uint8 = 1
+)
+
+// executionInfoEvmV1 is the version of the execution info of the EVM target chains.
+const executionInfoEvmV1 uint8 = 0
+
+// ErrUnknownPayloadType is returned when the payload is not a delivery nor a redelivery instruction.
+var ErrUnknownPayloadType = errors.New("unknown relayer payload type")
+
+// VaaKey identifies a VAA delivered with a delivery instruction.
+type VaaKey struct {
+	ChainID        sdk.ChainID `bson:"chainId" json:"chainId"`
+	EmitterAddress string      `bson:"emitterAddress" json:"emitterAddress"`
+	Sequence       uint64      `bson:"sequence" json:"sequence"`
+}
+
+// ID returns the VAA ID of the key.
+func (k *VaaKey) ID() string {
+	return fmt.Sprintf("%d/%s/%d", k.ChainID, k.EmitterAddress, k.Sequence)
+}
+
+// DeliveryInstruction is the payload of a generic relayer delivery VAA.
+type DeliveryInstruction struct {
+	TargetChainID          sdk.ChainID
+	TargetAddress          sdk.Address
+	Payload                []byte
+	RequestedReceiverValue *big.Int
+	ExtraReceiverValue     *big.Int
+	EncodedExecutionInfo   []byte
+	RefundChainID          sdk.ChainID
+	RefundAddress          sdk.Address
+	RefundDeliveryProvider sdk.Address
+	SourceDeliveryProvider sdk.Address
+	SenderAddress          sdk.Address
+	VaaKeys                []VaaKey
+}
+
+// RedeliveryInstruction is the payload of a generic relayer redelivery VAA, it requests a new delivery
+// of a delivery VAA with new execution parameters.
+type RedeliveryInstruction struct {
+	DeliveryVaaKey            VaaKey
+	TargetChainID             sdk.ChainID
+	NewRequestedReceiverValue *big.Int
+	NewEncodedExecutionInfo   []byte
+	NewSourceDeliveryProvider sdk.Address
+	NewSenderAddress          sdk.Address
+}
+
+// ExecutionInfo are the execution parameters of a delivery on an EVM target chain.
+type ExecutionInfo struct {
+	GasLimit                      *big.Int
+	TargetChainRefundPerGasUnused *big.Int
+}
+
+// PayloadType returns the type of a generic relayer payload.
+func PayloadType(payload []byte) (uint8, error) {
+	if len(payload) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return payload[0], nil
+}
+
+// DecodeDeliveryInstruction decodes the payload of a delivery VAA.
+func DecodeDeliveryInstruction(payload []byte) (*DeliveryInstruction, error) {
+	r := bytes.NewReader(payload)
+	if err := readPayloadType(r, PayloadTypeDelivery); err != nil {
+		return nil, err
+	}
+
+	var d DeliveryInstruction
+	var err error
+	if d.TargetChainID, err = readChainID(r); err != nil {
+		return nil, fmt.Errorf("failed to read target chain: %w", err)
+	}
+	if d.TargetAddress, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read target address: %w", err)
+	}
+	if d.Payload, err = readBytes(r); err != nil {
+		return nil, fmt.Errorf("failed to read payload: %w", err)
+	}
+	if d.RequestedReceiverValue, err = readUint256(r); err != nil {
+		return nil, fmt.Errorf("failed to read requested receiver value: %w", err)
+	}
+	if d.ExtraReceiverValue, err = readUint256(r); err != nil {
+		return nil, fmt.Errorf("failed to read extra receiver value: %w", err)
+	}
+	if d.EncodedExecutionInfo, err = readBytes(r); err != nil {
+		return nil, fmt.Errorf("failed to read execution info: %w", err)
+	}
+	if d.RefundChainID, err = readChainID(r); err != nil {
+		return nil, fmt.Errorf("failed to read refund chain: %w", err)
+	}
+	if d.RefundAddress, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read refund address: %w", err)
+	}
+	if d.RefundDeliveryProvider, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read refund delivery provider: %w", err)
+	}
+	if d.SourceDeliveryProvider, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read source delivery provider: %w", err)
+	}
+	if d.SenderAddress, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read sender address: %w", err)
+	}
+
+	var keys uint8
+	if err := binary.Read(r, binary.BigEndian, &keys); err != nil {
+		return nil, fmt.Errorf("failed to read message keys: %w", err)
+	}
+	for i := uint8(0); i < keys; i++ {
+		var keyType uint8
+		if err := binary.Read(r, binary.BigEndian, &keyType); err != nil {
+			return nil, fmt.Errorf("failed to read message key type: %w", err)
+		}
+		if keyType != keyTypeVaa {
+			// the other message keys (e.g. CCTP messages) are skipped.
+			if _, err := readBytes(r); err != nil {
+				return nil, fmt.Errorf("failed to read message key: %w", err)
+			}
+			continue
+		}
+		key, err := readVaaKey(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read vaa key: %w", err)
+		}
+		d.VaaKeys = append(d.VaaKeys, *key)
+	}
+
+	return &d, nil
+}
+
+// DecodeRedeliveryInstruction decodes the payload of a redelivery VAA.
+func DecodeRedeliveryInstruction(payload []byte) (*RedeliveryInstruction, error) {
+	r := bytes.NewReader(payload)
+	if err := readPayloadType(r, PayloadTypeRedelivery); err != nil {
+		return nil, err
+	}
+
+	var d RedeliveryInstruction
+	key, err := readVaaKey(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read delivery vaa key: %w", err)
+	}
+	d.DeliveryVaaKey = *key
+	if d.TargetChainID, err = readChainID(r); err != nil {
+		return nil, fmt.Errorf("failed to read target chain: %w", err)
+	}
+	if d.NewRequestedReceiverValue, err = readUint256(r); err != nil {
+		return nil, fmt.Errorf("failed to read requested receiver value: %w", err)
+	}
+	if d.NewEncodedExecutionInfo, err = readBytes(r); err != nil {
+		return nil, fmt.Errorf("failed to read execution info: %w", err)
+	}
+	if d.NewSourceDeliveryProvider, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read source delivery provider: %w", err)
+	}
+	if d.NewSenderAddress, err = readAddress(r); err != nil {
+		return nil, fmt.Errorf("failed to read sender address: %w", err)
+	}
+	return &d, nil
+}
+
+// DecodeExecutionInfo decodes the execution info of a delivery on an EVM target chain, which is the ABI
+// encoding of the version, the gas limit and the refund per unused gas unit.
+func DecodeExecutionInfo(data []byte) (*ExecutionInfo, error) {
+	if len(data) != 96 {
+		return nil, fmt.Errorf("invalid execution info length %d", len(data))
+	}
+	version := new(big.Int).SetBytes(data[0:32])
+	if !version.IsUint64() || version.Uint64() != uint64(executionInfoEvmV1) {
+		return nil, fmt.Errorf("unsupported execution info version %s", version)
+	}
+	return &ExecutionInfo{
+		GasLimit:                      new(big.Int).SetBytes(data[32:64]),
+		TargetChainRefundPerGasUnused: new(big.Int).SetBytes(data[64:96]),
+	}, nil
+}
+
+func readPayloadType(r *bytes.Reader, expected uint8) error {
+	payloadType, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read payload type: %w", err)
+	}
+	if payloadType != expected {
+		return fmt.Errorf("%w %d", ErrUnknownPayloadType, payloadType)
+	}
+	return nil
+}
+
+func readChainID(r io.Reader) (sdk.ChainID, error) {
+	var chainID uint16
+	if err := binary.Read(r, binary.BigEndian, &chainID); err != nil {
+		return 0, err
+	}
+	return sdk.ChainID(chainID), nil
+}
+
+func readAddress(r io.Reader) (sdk.Address, error) {
+	var address sdk.Address
+	if _, err := io.ReadFull(r, address[:]); err != nil {
+		return address, err
+	}
+	return address, nil
+}
+
+func readUint256(r io.Reader) (*big.Int, error) {
+	var data [32]byte
+	if _, err := io.ReadFull(r, data[:]); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data[:]), nil
+}
+
+// readBytes reads a byte array prefixed with its length as uint32.
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	if int64(length) > int64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func readVaaKey(r io.Reader) (*VaaKey, error) {
+	chainID, err := readChainID(r)
+	if err != nil {
+		return nil, err
+	}
+	emitter, err := readAddress(r)
+	if err != nil {
+		return nil, err
+	}
+	var sequence uint64
+	if err := binary.Read(r, binary.BigEndian, &sequence); err != nil {
+		return nil, err
+	}
+	return &VaaKey{ChainID: chainID, EmitterAddress: hex.EncodeToString(emitter[:]), Sequence: sequence}, nil
+}
@@ -0,0 +1,121 @@
+package relayer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func writeUint256(buf *bytes.Buffer, v int64) {
+	var data [32]byte
+	big.NewInt(v).FillBytes(data[:])
+	buf.Write(data[:])
+}
+
+func writeBytes(buf *bytes.Buffer, data []byte) {
+	binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	buf.Write(data)
+}
+
+func writeVaaKey(buf *bytes.Buffer, chainID sdk.ChainID, emitter sdk.Address, sequence uint64) {
+	binary.Write(buf, binary.BigEndian, uint16(chainID))
+	buf.Write(emitter[:])
+	binary.Write(buf, binary.BigEndian, sequence)
+}
+
+func newExecutionInfo(gasLimit, refundPerGasUnused int64) []byte {
+	var buf bytes.Buffer
+	writeUint256(&buf, int64(executionInfoEvmV1))
+	writeUint256(&buf, gasLimit)
+	writeUint256(&buf, refundPerGasUnused)
+	return buf.Bytes()
+}
+
+func newDeliveryPayload(executionInfo []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte(PayloadTypeDelivery)
+	binary.Write(&buf, binary.BigEndian, uint16(sdk.ChainIDArbitrum))
+	buf.Write(sdk.Address{31: 0x0a}.Bytes())
+	writeBytes(&buf, []byte{0x01, 0x02, 0x03})
+	writeUint256(&buf, 1000)
+	writeUint256(&buf, 0)
+	writeBytes(&buf, executionInfo)
+	binary.Write(&buf, binary.BigEndian, uint16(sdk.ChainIDArbitrum))
+	buf.Write(sdk.Address{31: 0x0b}.Bytes())
+	buf.Write(sdk.Address{31: 0x0c}.Bytes())
+	buf.Write(sdk.Address{31: 0x0d}.Bytes())
+	buf.Write(sdk.Address{31: 0x0e}.Bytes())
+	// one vaa key and one cctp key.
+	buf.WriteByte(2)
+	buf.WriteByte(keyTypeVaa)
+	writeVaaKey(&buf, sdk.ChainIDEthereum, sdk.Address{31: 0x01}, 42)
+	buf.WriteByte(2)
+	writeBytes(&buf, []byte{0xff, 0xff})
+	return buf.Bytes()
+}
+
+func TestDecodeDeliveryInstruction(t *testing.T) {
+	payload := newDeliveryPayload(newExecutionInfo(250000, 10))
+
+	payloadType, err := PayloadType(payload)
+	require.NoError(t, err)
+	assert.Equal(t, PayloadTypeDelivery, payloadType)
+
+	d, err := DecodeDeliveryInstruction(payload)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.ChainIDArbitrum, d.TargetChainID)
+	assert.Equal(t, sdk.Address{31: 0x0a}, d.TargetAddress)
+	assert.Equal(t, []byte{0x01, 0x02, 0x03}, d.Payload)
+	assert.Equal(t, int64(1000), d.RequestedReceiverValue.Int64())
+	assert.Equal(t, int64(0), d.ExtraReceiverValue.Int64())
+	assert.Equal(t, sdk.ChainIDArbitrum, d.RefundChainID)
+	assert.Equal(t, sdk.Address{31: 0x0b}, d.RefundAddress)
+	assert.Equal(t, sdk.Address{31: 0x0e}, d.SenderAddress)
+	require.Len(t, d.VaaKeys, 1)
+	assert.Equal(t, "2/0000000000000000000000000000000000000000000000000000000000000001/42", d.VaaKeys[0].ID())
+
+	info, err := DecodeExecutionInfo(d.EncodedExecutionInfo)
+	require.NoError(t, err)
+	assert.Equal(t, int64(250000), info.GasLimit.Int64())
+	assert.Equal(t, int64(10), info.TargetChainRefundPerGasUnused.Int64())
+
+	instructions := newInstructions(d)
+	assert.Equal(t, "250000", instructions.GasLimit)
+	assert.Equal(t, "0x3e8", instructions.RequestedReceiverValue.Hex)
+}
+
+func TestDecodeDeliveryInstruction_Invalid(t *testing.T) {
+	payload := newDeliveryPayload(newExecutionInfo(250000, 10))
+
+	_, err := DecodeDeliveryInstruction(payload[:50])
+	assert.Error(t, err)
+
+	_, err = DecodeRedeliveryInstruction(payload)
+	assert.ErrorIs(t, err, ErrUnknownPayloadType)
+
+	_, err = DecodeExecutionInfo([]byte{0x01})
+	assert.Error(t, err)
+}
+
+func TestDecodeRedeliveryInstruction(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteByte(PayloadTypeRedelivery)
+	writeVaaKey(&buf, sdk.ChainIDBSC, sdk.Address{31: 0x02}, 7)
+	binary.Write(&buf, binary.BigEndian, uint16(sdk.ChainIDPolygon))
+	writeUint256(&buf, 5)
+	writeBytes(&buf, newExecutionInfo(500000, 1))
+	buf.Write(sdk.Address{31: 0x03}.Bytes())
+	buf.Write(sdk.Address{31: 0x04}.Bytes())
+
+	d, err := DecodeRedeliveryInstruction(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "4/0000000000000000000000000000000000000000000000000000000000000002/7", d.DeliveryVaaKey.ID())
+	assert.Equal(t, sdk.ChainIDPolygon, d.TargetChainID)
+	assert.Equal(t, int64(5), d.NewRequestedReceiverValue.Int64())
+	assert.Equal(t, sdk.Address{31: 0x04}, d.NewSenderAddress)
+}
@@ -0,0 +1,110 @@
+package relayer
+
+import (
+	"context"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// RelaysCollection is the collection of the generic relayer deliveries.
+const RelaysCollection = "relays"
+
+// BigNumber is the representation of the uint256 values in the relays collection.
+type BigNumber struct {
+	Hex         string `bson:"_hex"`
+	IsBigNumber bool   `bson:"_isBigNumber"`
+}
+
+func newBigNumber(hex string) BigNumber {
+	return BigNumber{Hex: hex, IsBigNumber: true}
+}
+
+// Instructions are the decoded delivery instruction of a relay.
+type Instructions struct {
+	TargetChainID          sdk.ChainID `bson:"targetChainId"`
+	TargetAddress          string      `bson:"targetAddress"`
+	RequestedReceiverValue BigNumber   `bson:"requestedReceiverValue"`
+	ExtraReceiverValue     BigNumber   `bson:"extraReceiverValue"`
+	EncodedExecutionInfo   string      `bson:"encodedExecutionInfo"`
+	// GasLimit is decoded from the execution info, it is empty when the target chain is not an EVM chain.
+	GasLimit               string      `bson:"gasLimit,omitempty"`
+	RefundChainID          sdk.ChainID `bson:"refundChainId"`
+	RefundAddress          string      `bson:"refundAddress"`
+	RefundDeliveryProvider string      `bson:"refundDeliveryProvider"`
+	SourceDeliveryProvider string      `bson:"sourceDeliveryProvider"`
+	SenderAddress          string      `bson:"senderAddress"`
+	VaaKeys                []VaaKey    `bson:"vaaKeys"`
+}
+
+// RelayUpdate is the delivery request of a delivery VAA.
+type RelayUpdate struct {
+	ID             string
+	EmitterChain   sdk.ChainID
+	EmitterAddress string
+	Sequence       string
+	Vaa            string
+	Timestamp      time.Time
+	Instructions   Instructions
+}
+
+// Repository stores the deliveries in the relays collection.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		relays *mongo.Collection
+	}
+}
+
+// NewRepository creates a new relays repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	r := &Repository{db: db, logger: logger.With(zap.String("module", "RelayRepository"))}
+	r.collections.relays = db.Collection(RelaysCollection)
+	return r
+}
+
+// UpsertDelivery saves the delivery request of a delivery VAA. The status is only set when the relay is
+// created, because the delivery on the target chain may have been processed before.
+func (r *Repository) UpsertDelivery(ctx context.Context, relay *RelayUpdate) error {
+	update := bson.M{
+		"$set": bson.M{
+			"data.emitterChain":            relay.EmitterChain,
+			"data.emitterAddress":          relay.EmitterAddress,
+			"data.sequence":                relay.Sequence,
+			"data.vaa":                     relay.Vaa,
+			"data.metadata.emitterChain":   relay.EmitterChain,
+			"data.metadata.emitterAddress": relay.EmitterAddress,
+			"data.metadata.sequence":       relay.Sequence,
+			"data.metadata.rawVaaHex":      relay.Vaa,
+			"data.metadata.payloadType":    PayloadTypeDelivery,
+			"data.metadata.didParse":       true,
+			"data.metadata.instructions":   relay.Instructions,
+		},
+		"$setOnInsert": bson.M{
+			"origin":          domain.RelayOrigin,
+			"data.status":     domain.RelayStatusPending,
+			"data.receivedAt": relay.Timestamp,
+		},
+	}
+	_, err := r.collections.relays.UpdateByID(ctx, relay.ID, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// AddRedelivery links a redelivery VAA to the relay of the delivery VAA it redelivers.
+func (r *Repository) AddRedelivery(ctx context.Context, deliveryID, redeliveryID string) error {
+	update := bson.M{
+		"$addToSet": bson.M{"data.redeliveryIds": redeliveryID},
+		"$setOnInsert": bson.M{
+			"origin":      domain.RelayOrigin,
+			"data.status": domain.RelayStatusPending,
+		},
+	}
+	_, err := r.collections.relays.UpdateByID(ctx, deliveryID, update, options.Update().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,90 @@
+package relayer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Tracker stores the deliveries requested in the generic relayer VAAs. The result of the deliveries
+// is stored by the contract-watcher when the target chain emits the Delivery event.
+type Tracker struct {
+	p2pNetwork string
+	repository *Repository
+	logger     *zap.Logger
+}
+
+// NewTracker creates a new delivery tracker.
+func NewTracker(p2pNetwork string, repository *Repository, logger *zap.Logger) *Tracker {
+	return &Tracker{p2pNetwork: p2pNetwork, repository: repository, logger: logger}
+}
+
+// Track stores the delivery requested by a generic relayer VAA, the other VAAs are ignored.
+// It returns true when the VAA was emitted by a generic relayer contract.
+func (t *Tracker) Track(ctx context.Context, v *sdk.VAA) (bool, error) {
+	if !domain.IsWormholeRelayerEmitter(t.p2pNetwork, v.EmitterAddress) {
+		return false, nil
+	}
+
+	payloadType, err := PayloadType(v.Payload)
+	if err != nil {
+		return true, err
+	}
+
+	switch payloadType {
+	case PayloadTypeDelivery:
+		instruction, err := DecodeDeliveryInstruction(v.Payload)
+		if err != nil {
+			return true, err
+		}
+		data, err := v.Marshal()
+		if err != nil {
+			return true, err
+		}
+		relay := RelayUpdate{
+			ID:             v.MessageID(),
+			EmitterChain:   v.EmitterChain,
+			EmitterAddress: v.EmitterAddress.String(),
+			Sequence:       fmt.Sprintf("%d", v.Sequence),
+			Vaa:            hex.EncodeToString(data),
+			Timestamp:      v.Timestamp,
+			Instructions:   newInstructions(instruction),
+		}
+		return true, t.repository.UpsertDelivery(ctx, &relay)
+	case PayloadTypeRedelivery:
+		instruction, err := DecodeRedeliveryInstruction(v.Payload)
+		if err != nil {
+			return true, err
+		}
+		return true, t.repository.AddRedelivery(ctx, instruction.DeliveryVaaKey.ID(), v.MessageID())
+	default:
+		return true, fmt.Errorf("%w %d", ErrUnknownPayloadType, payloadType)
+	}
+}
+
+func newInstructions(d *DeliveryInstruction) Instructions {
+	i := Instructions{
+		TargetChainID:          d.TargetChainID,
+		TargetAddress:          d.TargetAddress.String(),
+		RequestedReceiverValue: newBigNumber(fmt.Sprintf("0x%x", d.RequestedReceiverValue)),
+		ExtraReceiverValue:     newBigNumber(fmt.Sprintf("0x%x", d.ExtraReceiverValue)),
+		EncodedExecutionInfo:   hex.EncodeToString(d.EncodedExecutionInfo),
+		RefundChainID:          d.RefundChainID,
+		RefundAddress:          d.RefundAddress.String(),
+		RefundDeliveryProvider: d.RefundDeliveryProvider.String(),
+		SourceDeliveryProvider: d.SourceDeliveryProvider.String(),
+		SenderAddress:          d.SenderAddress.String(),
+		VaaKeys:                d.VaaKeys,
+	}
+	if info, err := DecodeExecutionInfo(d.EncodedExecutionInfo); err == nil {
+		i.GasLimit = info.GasLimit.String()
+	}
+	if i.VaaKeys == nil {
+		i.VaaKeys = []VaaKey{}
+	}
+	return i
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/config"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/healthcheck"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/http/infrastructure"
+	pipelineAlert "github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/monitor"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/pipeline"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/topic"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/watcher"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type exitCode int
+
+func handleExit() {
+	if r := recover(); r != nil {
+		if e, ok := r.(exitCode); ok {
+			os.Exit(int(e))
+		}
+		panic(r) // not an Exit, bubble up
+	}
+}
+
+// Run starts the pipeline with the configuration from the environment and blocks until a
+// termination signal is received.
+func Run() {
+
+	defer handleExit()
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+
+	config, err := config.New(rootCtx)
+	if err != nil {
+		log.Fatal("Error creating config", err)
+	}
+
+	logger := logger.New("wormhole-explorer-pipeline", logger.WithLevel(config.LogLevel))
+
+	shutdownTracing, err := tracing.Init(rootCtx, "wormscan-pipeline", config.Tracing, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	stop := Start(rootCtx, config, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("flushing traces...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Warn("failed to flush traces", zap.Error(err))
+	}
+
+	logger.Info("Finished wormhole-explorer-pipeline")
+
+}
+
+// Start starts the pipeline watcher and http server with the given configuration. The watcher
+// runs until rootCtx is cancelled and the returned function releases the remaining resources.
+func Start(rootCtx context.Context, config *config.Configuration, logger *zap.Logger) (stop func()) {
+
+	logger.Info("Starting wormhole-explorer-pipeline ...")
+
+	//setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+	}
+
+	// get alert client.
+	alertClient, err := newAlertClient(config)
+	if err != nil {
+		logger.Fatal("failed to create alert client", zap.Error(err))
+	}
+
+	// get metrics.
+	metrics := newMetrics(config)
+
+	// get publish function.
+	pushFunc, err := newTopicProducer(rootCtx, config, alertClient, lineage.NewRecorder(db.Database, lineage.StagePipeline, logger), metrics, logger)
+	if err != nil {
+		logger.Fatal("failed to create publish function", zap.Error(err))
+	}
+
+	// get health check functions.
+	healthChecks, err := newHealthChecks(rootCtx, config, db.Database)
+	if err != nil {
+		logger.Fatal("failed to create health checks", zap.Error(err))
+	}
+
+	// create a new pipeline repository.
+	repository := pipeline.NewRepository(db.Database, logger)
+
+	// create and start a new tx hash handler.
+	quit := make(chan bool)
+	txHashHandler := pipeline.NewTxHashHandler(repository, pushFunc, alertClient, metrics, logger, quit)
+	go txHashHandler.Run(rootCtx)
+
+	// create a new publisher.
+	publisher := pipeline.NewPublisher(pushFunc, metrics, repository, config.P2pNetwork, txHashHandler, logger)
+	handler := publisher.Publish
+
+	// create and start the chain health monitor.
+	if config.ChainHealthEnabled {
+		chainMonitor := newChainMonitor(config, db.Database, alertClient, metrics, logger)
+		if err := chainMonitor.Start(rootCtx); err != nil {
+			logger.Fatal("failed to start chain health monitor", zap.Error(err))
+		}
+		handler = func(ctx context.Context, e *watcher.Event) {
+			publisher.Publish(ctx, e)
+			chainMonitor.Track(ctx, e)
+		}
+	}
+
+	watcher := watcher.NewWatcher(rootCtx, db.Database, config.MongoDatabase, handler, alertClient, metrics, logger)
+	err = watcher.Start(rootCtx)
+	if err != nil {
+		logger.Fatal("failed to watch MongoDB", zap.Error(err))
+	}
+
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, healthChecks...)
+	server.Start()
+
+	logger.Info("Started wormhole-explorer-pipeline")
+
+	return func() {
+		logger.Info("Closing tx hash handler ...")
+		close(quit)
+
+		logger.Info("closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+
+		logger.Info("Closing Http server ...")
+		server.Stop()
+	}
+}
+
+func newAwsConfig(appCtx context.Context, cfg *config.Configuration) (aws.Config, error) {
+	region := cfg.AwsRegion
+
+	if cfg.AwsAccessKeyID != "" && cfg.AwsSecretAccessKey != "" {
+		credentials := credentials.NewStaticCredentialsProvider(cfg.AwsAccessKeyID, cfg.AwsSecretAccessKey, "")
+		customResolver := aws.EndpointResolverFunc(func(service, region string) (aws.Endpoint, error) {
+			if cfg.AwsEndpoint != "" {
+				return aws.Endpoint{
+					PartitionID:   "aws",
+					URL:           cfg.AwsEndpoint,
+					SigningRegion: region,
+				}, nil
+			}
+
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		})
+
+		awsCfg, err := awsconfig.LoadDefaultConfig(appCtx,
+			awsconfig.WithRegion(region),
+			awsconfig.WithEndpointResolver(customResolver),
+			awsconfig.WithCredentialsProvider(credentials),
+		)
+		return awsCfg, err
+	}
+
+	return awsconfig.LoadDefaultConfig(appCtx, awsconfig.WithRegion(region))
+}
+
+func newTopicProducer(appCtx context.Context, config *config.Configuration, alertClient alert.AlertClient, lineage *lineage.Recorder, metrics metrics.Metrics, logger *zap.Logger) (topic.PushFunc, error) {
+	settings := broker.Settings{
+		Type:               broker.Type(config.QueueBroker),
+		RedisURI:           config.QueueRedisURI,
+		NatsURL:            config.QueueNatsURL,
+		NatsStreamMaxAge:   time.Duration(config.QueueNatsStreamMaxAgeHours) * time.Hour,
+		NatsStreamMaxBytes: config.QueueNatsStreamMaxBytes,
+	}
+	if settings.Type == broker.TypeSQS {
+		awsConfig, err := newAwsConfig(appCtx, config)
+		if err != nil {
+			return nil, err
+		}
+		settings.AwsConfig = awsConfig
+	}
+
+	producer, err := broker.NewProducer(settings, broker.Destination{
+		TopicARN: config.SNSUrl,
+		Topic:    config.PipelineTopic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return topic.NewVAATopic(producer, alertClient, lineage, metrics, logger).Publish, nil
+}
+
+func newHealthChecks(ctx context.Context, config *config.Configuration, db *mongo.Database) ([]healthcheck.Check, error) {
+	if config.QueueBroker != string(broker.TypeSQS) {
+		return []healthcheck.Check{healthcheck.Mongo(db)}, nil
+	}
+
+	awsConfig, err := newAwsConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return []healthcheck.Check{healthcheck.Mongo(db), healthcheck.SNS(awsConfig, config.SNSUrl)}, nil
+}
+
+func newMetrics(cfg *config.Configuration) metrics.Metrics {
+	metricsEnabled := cfg.MetricsEnabled
+	if !metricsEnabled {
+		return metrics.NewDummyMetrics()
+	}
+	return metrics.NewPrometheusMetrics(cfg.Environment)
+}
+
+func newChainMonitor(cfg *config.Configuration, db *mongo.Database, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *monitor.Monitor {
+	monitorConfig := monitor.Config{
+		CheckInterval:        time.Duration(cfg.ChainHealthCheckIntervalSeconds) * time.Second,
+		GracePeriod:          time.Duration(cfg.ChainHealthGracePeriodSeconds) * time.Second,
+		StallFactor:          cfg.ChainHealthStallFactor,
+		MinStall:             time.Duration(cfg.ChainHealthMinStallSeconds) * time.Second,
+		ObservationsLookback: time.Duration(cfg.ChainHealthObservationsLookbackSeconds) * time.Second,
+		AlertCooldown:        time.Duration(cfg.ChainHealthAlertCooldownSeconds) * time.Second,
+	}
+	repository := monitor.NewRepository(db, logger)
+	return monitor.NewMonitor(monitorConfig, repository, alertClient, metrics, logger)
+}
+
+func newAlertClient(cfg *config.Configuration) (alert.AlertClient, error) {
+	if !cfg.AlertEnabled {
+		return alert.NewDummyClient(), nil
+	}
+
+	alertConfig := alert.AlertConfig{
+		Environment: cfg.Environment,
+		ApiKey:      cfg.AlertApiKey,
+		Enabled:     cfg.AlertEnabled,
+	}
+	return alert.NewAlertService(alertConfig, pipelineAlert.LoadAlerts)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 // Configuration represents the application configuration with the default values.
@@ -24,6 +25,31 @@ type Configuration struct {
 	AlertEnabled       bool   `env:"ALERT_ENABLED,default=false"`
 	AlertApiKey        string `env:"ALERT_API_KEY"`
 	MetricsEnabled     bool   `env:"METRICS_ENABLED,default=false"`
+	ChainHealthConfiguration
+	QueueConfiguration
+	Tracing tracing.Config
+}
+
+// QueueConfiguration represents the message broker configuration. The SQS broker publishes
+// to the SNS topic and the others to the pipeline topic.
+type QueueConfiguration struct {
+	QueueBroker                string `env:"QUEUE_BROKER,default=sqs"`
+	QueueRedisURI              string `env:"QUEUE_REDIS_URI"`
+	QueueNatsURL               string `env:"QUEUE_NATS_URL"`
+	QueueNatsStreamMaxAgeHours int    `env:"QUEUE_NATS_STREAM_MAX_AGE_HOURS,default=168"`
+	QueueNatsStreamMaxBytes    int64  `env:"QUEUE_NATS_STREAM_MAX_BYTES,default=1073741824"`
+	PipelineTopic              string `env:"PIPELINE_TOPIC,default=wormscan.vaas"`
+}
+
+// ChainHealthConfiguration represents the chain health monitor configuration.
+type ChainHealthConfiguration struct {
+	ChainHealthEnabled                     bool    `env:"CHAIN_HEALTH_ENABLED,default=true"`
+	ChainHealthCheckIntervalSeconds        int     `env:"CHAIN_HEALTH_CHECK_INTERVAL_SECONDS,default=60"`
+	ChainHealthGracePeriodSeconds          int     `env:"CHAIN_HEALTH_GRACE_PERIOD_SECONDS,default=600"`
+	ChainHealthStallFactor                 float64 `env:"CHAIN_HEALTH_STALL_FACTOR,default=10"`
+	ChainHealthMinStallSeconds             int     `env:"CHAIN_HEALTH_MIN_STALL_SECONDS,default=3600"`
+	ChainHealthObservationsLookbackSeconds int     `env:"CHAIN_HEALTH_OBSERVATIONS_LOOKBACK_SECONDS,default=3600"`
+	ChainHealthAlertCooldownSeconds        int     `env:"CHAIN_HEALTH_ALERT_COOLDOWN_SECONDS,default=21600"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
@@ -11,6 +11,10 @@ const (
 	ErrorDecodeWatcherEvent = "ERROR_DECODE_WATCHER_EVENT"
 	ErrorUpdateVaaTxHash    = "ERROR_UPDATE_VAA_TX_HASH"
 	ErrorPushEventSNS       = "ERROR_PUSH_EVENT_SNS"
+
+	AlertSequenceGap            = "SEQUENCE_GAP"
+	AlertStalledEmitter         = "STALLED_EMITTER"
+	AlertObservationsWithoutVaa = "OBSERVATIONS_WITHOUT_VAA"
 )
 
 func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
@@ -49,5 +53,38 @@ func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
 		Priority:    alert.CRITICAL,
 	}
 
+	// Alert sequence gap.
+	alerts[AlertSequenceGap] = alert.Alert{
+		Alias:       "Sequence gap detected",
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Sequence gap detected"),
+		Description: "VAAs are missing between two sequences of an emitter",
+		Actions:     []string{""},
+		Tags:        []string{cfg.Environment, "pipeline", "monitor", "sequence"},
+		Entity:      "pipeline",
+		Priority:    alert.HIGH,
+	}
+
+	// Alert stalled emitter.
+	alerts[AlertStalledEmitter] = alert.Alert{
+		Alias:       "Emitter stalled",
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Emitter stalled"),
+		Description: "An emitter has not emitted VAAs for much longer than its historical rate",
+		Actions:     []string{""},
+		Tags:        []string{cfg.Environment, "pipeline", "monitor", "emitter"},
+		Entity:      "pipeline",
+		Priority:    alert.MODERATE,
+	}
+
+	// Alert observations without vaa.
+	alerts[AlertObservationsWithoutVaa] = alert.Alert{
+		Alias:       "Observations without vaa",
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Observations without vaa"),
+		Description: "Messages were observed by the guardians but no VAA was stored for them",
+		Actions:     []string{""},
+		Tags:        []string{cfg.Environment, "pipeline", "monitor", "observations"},
+		Entity:      "pipeline",
+		Priority:    alert.MODERATE,
+	}
+
 	return alerts
 }
@@ -20,3 +20,24 @@ func (m *DummyMetrics) IncVaaWithoutTxHash(chainID uint16) {}
 
 // IncVaaWithTxHashFixed increments the vaa received count with tx hash fixed.
 func (m *DummyMetrics) IncVaaWithTxHashFixed(chainID uint16) {}
+
+// IncSequenceGapDetected increments the number of sequence gaps detected.
+func (m *DummyMetrics) IncSequenceGapDetected(chainID uint16) {}
+
+// SetOpenGaps sets the number of open sequence gaps and missing vaas.
+func (m *DummyMetrics) SetOpenGaps(chainID uint16, gaps, missing int64) {}
+
+// ResetOpenGaps resets the open sequence gaps metrics.
+func (m *DummyMetrics) ResetOpenGaps() {}
+
+// SetStalledEmitters sets the number of stalled emitters.
+func (m *DummyMetrics) SetStalledEmitters(chainID uint16, count int64) {}
+
+// ResetStalledEmitters resets the stalled emitters metric.
+func (m *DummyMetrics) ResetStalledEmitters() {}
+
+// SetObservationsWithoutVaa sets the number of observed messages without vaa.
+func (m *DummyMetrics) SetObservationsWithoutVaa(chainID uint16, count int64) {}
+
+// ResetObservationsWithoutVaa resets the observed messages without vaa metric.
+func (m *DummyMetrics) ResetObservationsWithoutVaa() {}
@@ -9,4 +9,12 @@ type Metrics interface {
 
 	IncVaaWithoutTxHash(chainID uint16)
 	IncVaaWithTxHashFixed(chainID uint16)
+
+	IncSequenceGapDetected(chainID uint16)
+	SetOpenGaps(chainID uint16, gaps, missing int64)
+	ResetOpenGaps()
+	SetStalledEmitters(chainID uint16, count int64)
+	ResetStalledEmitters()
+	SetObservationsWithoutVaa(chainID uint16, count int64)
+	ResetObservationsWithoutVaa()
 }
@@ -8,8 +8,12 @@ import (
 
 // PrometheusMetrics is a metrics implementation for Prometheus.
 type PrometheusMetrics struct {
-	vaaReceivedCount *prometheus.CounterVec
-	vaaTxHashCount   *prometheus.CounterVec
+	vaaReceivedCount        *prometheus.CounterVec
+	vaaTxHashCount          *prometheus.CounterVec
+	sequenceGapCount        *prometheus.CounterVec
+	openGaps                *prometheus.GaugeVec
+	stalledEmitters         *prometheus.GaugeVec
+	observationsWithoutVaas *prometheus.GaugeVec
 }
 
 // NewPrometheusMetrics creates a new PrometheusMetrics.
@@ -34,9 +38,46 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 			},
 		}, []string{"chain", "type"})
 
+	constLabels := map[string]string{
+		"environment": environment,
+		"service":     serviceName,
+	}
+
+	sequenceGapCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "sequence_gap_count_by_chain",
+			Help:        "Total number of sequence gaps detected by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain"})
+
+	openGaps := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "open_sequence_gaps_by_chain",
+			Help:        "Current number of open sequence gaps and missing vaas by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain", "type"})
+
+	stalledEmitters := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "stalled_emitters_by_chain",
+			Help:        "Current number of stalled emitters by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain"})
+
+	observationsWithoutVaas := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "observations_without_vaa_by_chain",
+			Help:        "Current number of observed messages without vaa by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain"})
+
 	return &PrometheusMetrics{
-		vaaReceivedCount: vaaReceivedCount,
-		vaaTxHashCount:   vaaTxHashCount,
+		vaaReceivedCount:        vaaReceivedCount,
+		vaaTxHashCount:          vaaTxHashCount,
+		sequenceGapCount:        sequenceGapCount,
+		openGaps:                openGaps,
+		stalledEmitters:         stalledEmitters,
+		observationsWithoutVaas: observationsWithoutVaas,
 	}
 }
 
@@ -63,3 +104,43 @@ func (m *PrometheusMetrics) IncVaaWithTxHashFixed(chainID uint16) {
 	chain := vaa.ChainID(chainID).String()
 	m.vaaTxHashCount.WithLabelValues(chain, "vaa-with-txhash-fixed").Inc()
 }
+
+// IncSequenceGapDetected increments the number of sequence gaps detected.
+func (m *PrometheusMetrics) IncSequenceGapDetected(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.sequenceGapCount.WithLabelValues(chain).Inc()
+}
+
+// SetOpenGaps sets the number of open sequence gaps and missing vaas.
+func (m *PrometheusMetrics) SetOpenGaps(chainID uint16, gaps, missing int64) {
+	chain := vaa.ChainID(chainID).String()
+	m.openGaps.WithLabelValues(chain, "gaps").Set(float64(gaps))
+	m.openGaps.WithLabelValues(chain, "missing-vaas").Set(float64(missing))
+}
+
+// ResetOpenGaps resets the open sequence gaps metrics.
+func (m *PrometheusMetrics) ResetOpenGaps() {
+	m.openGaps.Reset()
+}
+
+// SetStalledEmitters sets the number of stalled emitters.
+func (m *PrometheusMetrics) SetStalledEmitters(chainID uint16, count int64) {
+	chain := vaa.ChainID(chainID).String()
+	m.stalledEmitters.WithLabelValues(chain).Set(float64(count))
+}
+
+// ResetStalledEmitters resets the stalled emitters metric.
+func (m *PrometheusMetrics) ResetStalledEmitters() {
+	m.stalledEmitters.Reset()
+}
+
+// SetObservationsWithoutVaa sets the number of observed messages without vaa.
+func (m *PrometheusMetrics) SetObservationsWithoutVaa(chainID uint16, count int64) {
+	chain := vaa.ChainID(chainID).String()
+	m.observationsWithoutVaas.WithLabelValues(chain).Set(float64(count))
+}
+
+// ResetObservationsWithoutVaa resets the observed messages without vaa metric.
+func (m *PrometheusMetrics) ResetObservationsWithoutVaa() {
+	m.observationsWithoutVaas.Reset()
+}
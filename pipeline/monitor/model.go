@@ -0,0 +1,56 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// leaseID is the identifier of the lease document of the monitor in the monitorLeases collection.
+const leaseID = "chainHealthMonitor"
+
+// EmitterState is the sequence continuity state of an emitter, stored in the emitterSequences collection.
+type EmitterState struct {
+	ID           string      `bson:"_id"`
+	EmitterChain vaa.ChainID `bson:"emitterChain"`
+	EmitterAddr  string      `bson:"emitterAddr"`
+	LastSequence uint64      `bson:"lastSequence"`
+	// LastTimestamp is the timestamp of the VAA with the last sequence.
+	LastTimestamp time.Time `bson:"lastTimestamp"`
+	VaaCount      uint64    `bson:"vaaCount"`
+	// AvgIntervalSeconds is the exponential moving average of the time between two VAAs of the emitter.
+	AvgIntervalSeconds float64   `bson:"avgIntervalSeconds"`
+	Stalled            bool      `bson:"stalled"`
+	UpdatedAt          time.Time `bson:"updatedAt"`
+}
+
+// emitterID returns the identifier of an emitter.
+func emitterID(chainID vaa.ChainID, emitterAddr string) string {
+	return fmt.Sprintf("%d/%s", chainID, emitterAddr)
+}
+
+// governorHeldMessageID returns the message ID of a VAA enqueued by the governor. The governor status
+// reports the emitter address with the 0x prefix.
+func governorHeldMessageID(chainID vaa.ChainID, emitterAddr string, sequence string) string {
+	return fmt.Sprintf("%d/%s/%s", chainID, strings.TrimPrefix(emitterAddr, "0x"), sequence)
+}
+
+// ToMap returns a map representation of the EmitterState.
+func (s *EmitterState) ToMap() map[string]string {
+	return map[string]string{
+		"emitterChain":       s.EmitterChain.String(),
+		"emitterAddr":        s.EmitterAddr,
+		"lastSequence":       fmt.Sprint(s.LastSequence),
+		"lastTimestamp":      s.LastTimestamp.String(),
+		"avgIntervalSeconds": fmt.Sprintf("%.0f", s.AvgIntervalSeconds),
+	}
+}
+
+// chainCount is the result of a count grouped by chain.
+type chainCount struct {
+	ChainID vaa.ChainID `bson:"_id"`
+	Count   int64       `bson:"count"`
+	Missing int64       `bson:"missing"`
+}
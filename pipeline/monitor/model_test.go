@@ -0,0 +1,13 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestGovernorHeldMessageID(t *testing.T) {
+	id := governorHeldMessageID(vaa.ChainIDEthereum, "0x0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585", "42")
+	assert.Equal(t, "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/42", id)
+}
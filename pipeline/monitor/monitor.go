@@ -0,0 +1,317 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	pipelineAlert "github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/watcher"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Config is the chain health monitor configuration.
+type Config struct {
+	// CheckInterval is the time between two checks of gaps, stalled emitters and observations.
+	CheckInterval time.Duration
+	// GracePeriod is the time a gap or an observation without VAA is tolerated before it is reported,
+	// since VAAs are not always inserted in sequence order.
+	GracePeriod time.Duration
+	// StallFactor is the number of average intervals between VAAs after which an emitter is stalled.
+	StallFactor float64
+	// MinStall is the minimum time without VAAs after which an emitter is stalled.
+	MinStall time.Duration
+	// ObservationsLookback is the time window checked for observations without VAA.
+	ObservationsLookback time.Duration
+	// AlertCooldown is the minimum time between two alerts of observations without VAA of the same chain.
+	AlertCooldown time.Duration
+}
+
+// leaseChecks is the number of check intervals the lease of the monitor lasts without being renewed.
+const leaseChecks = 3
+
+// Monitor tracks the sequence continuity of every emitter from the VAAs inserted in the vaas collection
+// and reports sequence gaps, stalled emitters and observations that never reached a VAA.
+// When many replicas run, only the one that holds the lease tracks the emitters and sends the alerts.
+type Monitor struct {
+	cfg         Config
+	repository  *Repository
+	alertClient alert.AlertClient
+	metrics     metrics.Metrics
+	logger      *zap.Logger
+	owner       string
+
+	mu       sync.Mutex
+	leader   bool
+	emitters map[string]*EmitterState
+
+	// observationsAlertedAt is the time of the last alert of observations without VAA by chain.
+	// It is only used by the checks goroutine.
+	observationsAlertedAt map[vaa.ChainID]time.Time
+}
+
+// NewMonitor creates a new chain health monitor.
+func NewMonitor(cfg Config, repository *Repository, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Monitor {
+	return &Monitor{
+		cfg:         cfg,
+		repository:  repository,
+		alertClient: alertClient,
+		metrics:     metrics,
+		logger:      logger.With(zap.String("module", "ChainHealthMonitor")),
+		owner:       leaseOwner(),
+		emitters:    make(map[string]*EmitterState),
+
+		observationsAlertedAt: make(map[vaa.ChainID]time.Time),
+	}
+}
+
+// leaseOwner returns the identifier of the replica in the lease, the pod name when running in kubernetes.
+func leaseOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// Start tries to acquire the lease of the monitor and runs the periodic checks until the context is cancelled.
+func (m *Monitor) Start(ctx context.Context) error {
+	if err := m.repository.CreateIndexes(ctx); err != nil {
+		return err
+	}
+	m.renewLease(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.cfg.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if m.renewLease(ctx) {
+					m.check(ctx)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// renewLease acquires or renews the lease of the monitor and returns whether the replica is the leader.
+// The replica that becomes the leader loads the state of the emitters stored by the previous one, and
+// the replica that loses the lease stops tracking the emitters.
+func (m *Monitor) renewLease(ctx context.Context) bool {
+	acquired, err := m.repository.AcquireLease(ctx, m.owner, time.Now(), leaseChecks*m.cfg.CheckInterval)
+	if err != nil {
+		m.logger.Error("error acquiring monitor lease", zap.String("owner", m.owner), zap.Error(err))
+	}
+
+	m.mu.Lock()
+	wasLeader := m.leader
+	m.mu.Unlock()
+
+	switch {
+	case acquired && !wasLeader:
+		emitters, err := m.repository.FindEmitters(ctx)
+		if err != nil {
+			m.logger.Error("error loading emitters state", zap.Error(err))
+			return false
+		}
+		m.mu.Lock()
+		m.leader = true
+		m.emitters = make(map[string]*EmitterState, len(emitters))
+		for _, e := range emitters {
+			m.emitters[e.ID] = e
+		}
+		m.mu.Unlock()
+		m.logger.Info("acquired monitor lease", zap.String("owner", m.owner), zap.Int("emitters", len(emitters)))
+	case !acquired && wasLeader:
+		m.mu.Lock()
+		m.leader = false
+		m.emitters = make(map[string]*EmitterState)
+		m.mu.Unlock()
+		m.logger.Info("lost monitor lease", zap.String("owner", m.owner))
+	}
+	return acquired
+}
+
+// Track updates the sequence continuity of the emitter of a VAA inserted in the database.
+func (m *Monitor) Track(ctx context.Context, e *watcher.Event) {
+	chainID := vaa.ChainID(e.ChainID)
+	// pyth messages are stored in a capped collection, so their sequences are not tracked.
+	if chainID == vaa.ChainIDPythNet {
+		return
+	}
+	seq, err := strconv.ParseUint(e.Sequence, 10, 64)
+	if err != nil {
+		m.logger.Error("invalid sequence", zap.String("id", e.ID), zap.Error(err))
+		return
+	}
+	timestamp := e.IndexedAt
+	if e.Timestamp != nil {
+		timestamp = *e.Timestamp
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.leader {
+		return
+	}
+
+	now := time.Now()
+	id := emitterID(chainID, e.EmitterAddress)
+	state, ok := m.emitters[id]
+	if !ok {
+		state = &EmitterState{ID: id, EmitterChain: chainID, EmitterAddr: e.EmitterAddress}
+		m.emitters[id] = state
+	}
+
+	gap, advanced := state.advance(seq, timestamp, now)
+	if !advanced {
+		// the VAA arrived after a greater sequence, so it may fill a gap.
+		m.fillGap(ctx, chainID, e.EmitterAddress, seq, now)
+		return
+	}
+	if gap != nil {
+		m.logger.Info("sequence gap detected", zap.String("emitter", id),
+			zap.Uint64("start", gap.StartSequence), zap.Uint64("end", gap.EndSequence))
+		m.metrics.IncSequenceGapDetected(e.ChainID)
+		if err := m.repository.UpsertGap(ctx, gap); err != nil {
+			m.logger.Error("error saving sequence gap", zap.String("id", gap.ID), zap.Error(err))
+		}
+	}
+	if err := m.repository.UpsertEmitter(ctx, state); err != nil {
+		m.logger.Error("error saving emitter state", zap.String("id", id), zap.Error(err))
+	}
+}
+
+func (m *Monitor) fillGap(ctx context.Context, chainID vaa.ChainID, emitterAddr string, seq uint64, now time.Time) {
+	gap, err := m.repository.FindOpenGap(ctx, chainID, emitterAddr, seq)
+	if err != nil {
+		m.logger.Error("error finding sequence gap", zap.String("emitter", emitterID(chainID, emitterAddr)),
+			zap.Uint64("sequence", seq), zap.Error(err))
+		return
+	}
+	if gap == nil {
+		return
+	}
+	split := fill(gap, seq, now)
+	if err := m.repository.UpsertGap(ctx, gap); err != nil {
+		m.logger.Error("error saving sequence gap", zap.String("id", gap.ID), zap.Error(err))
+	}
+	if split != nil {
+		if err := m.repository.UpsertGap(ctx, split); err != nil {
+			m.logger.Error("error saving sequence gap", zap.String("id", split.ID), zap.Error(err))
+		}
+	}
+}
+
+// check reports the gaps older than the grace period, the stalled emitters and the observations without VAA.
+func (m *Monitor) check(ctx context.Context) {
+	now := time.Now()
+	m.checkGaps(ctx, now)
+	m.checkStalledEmitters(ctx, now)
+	m.checkObservations(ctx, now)
+}
+
+func (m *Monitor) checkGaps(ctx context.Context, now time.Time) {
+	detectedBefore := now.Add(-m.cfg.GracePeriod)
+
+	gaps, err := m.repository.FindGapsToAlert(ctx, detectedBefore)
+	if err != nil {
+		m.logger.Error("error finding sequence gaps to alert", zap.Error(err))
+		return
+	}
+	for _, g := range gaps {
+		alertContext := alert.AlertContext{Details: g.ToMap()}
+		m.alertClient.CreateAndSend(ctx, pipelineAlert.AlertSequenceGap, alertContext)
+		if err := m.repository.SetGapAlerted(ctx, g.ID, now); err != nil {
+			m.logger.Error("error updating sequence gap", zap.String("id", g.ID), zap.Error(err))
+		}
+	}
+
+	counts, err := m.repository.CountOpenGapsByChain(ctx, detectedBefore)
+	if err != nil {
+		m.logger.Error("error counting open sequence gaps", zap.Error(err))
+		return
+	}
+	m.metrics.ResetOpenGaps()
+	for _, c := range counts {
+		m.metrics.SetOpenGaps(uint16(c.ChainID), c.Count, c.Missing)
+	}
+}
+
+func (m *Monitor) checkStalledEmitters(ctx context.Context, now time.Time) {
+	// take a snapshot of the new stalled emitters, so that Track is not blocked while alerting.
+	stalledByChain := make(map[vaa.ChainID]int64)
+	var stalled []EmitterState
+	m.mu.Lock()
+	for _, s := range m.emitters {
+		if !s.isStalled(now, m.cfg.StallFactor, m.cfg.MinStall) {
+			continue
+		}
+		stalledByChain[s.EmitterChain]++
+		if s.Stalled {
+			continue
+		}
+		s.Stalled = true
+		s.UpdatedAt = now
+		stalled = append(stalled, *s)
+	}
+	m.mu.Unlock()
+
+	m.metrics.ResetStalledEmitters()
+	for chainID, count := range stalledByChain {
+		m.metrics.SetStalledEmitters(uint16(chainID), count)
+	}
+
+	for i := range stalled {
+		s := &stalled[i]
+		m.logger.Info("emitter stalled", zap.String("emitter", s.ID), zap.Time("lastTimestamp", s.LastTimestamp))
+		alertContext := alert.AlertContext{Details: s.ToMap()}
+		m.alertClient.CreateAndSend(ctx, pipelineAlert.AlertStalledEmitter, alertContext)
+		// the emitter may have emitted a VAA since the snapshot, so the stored state is not replaced.
+		if err := m.repository.SetEmitterStalled(ctx, s.ID, s.LastSequence, now); err != nil {
+			m.logger.Error("error saving emitter state", zap.String("id", s.ID), zap.Error(err))
+		}
+	}
+}
+
+func (m *Monitor) checkObservations(ctx context.Context, now time.Time) {
+	// the VAAs held by the governor are not signed until they are released, so they are not reported.
+	heldIDs, err := m.repository.FindGovernorHeldMessageIDs(ctx)
+	if err != nil {
+		m.logger.Error("error finding vaas held by the governor", zap.Error(err))
+		return
+	}
+	to := now.Add(-m.cfg.GracePeriod)
+	from := to.Add(-m.cfg.ObservationsLookback)
+	counts, err := m.repository.CountObservationsWithoutVaa(ctx, from, to, heldIDs)
+	if err != nil {
+		m.logger.Error("error counting observations without vaa", zap.Error(err))
+		return
+	}
+
+	m.metrics.ResetObservationsWithoutVaa()
+	for _, c := range counts {
+		m.metrics.SetObservationsWithoutVaa(uint16(c.ChainID), c.Count)
+	}
+	for _, c := range counts {
+		if alertedAt, ok := m.observationsAlertedAt[c.ChainID]; ok && now.Sub(alertedAt) < m.cfg.AlertCooldown {
+			continue
+		}
+		details := map[string]string{
+			"emitterChain": c.ChainID.String(),
+			"count":        strconv.FormatInt(c.Count, 10),
+		}
+		m.alertClient.CreateAndSend(ctx, pipelineAlert.AlertObservationsWithoutVaa, alert.AlertContext{Details: details})
+		m.observationsAlertedAt[c.ChainID] = now
+	}
+}
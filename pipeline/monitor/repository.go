@@ -0,0 +1,255 @@
+package monitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository is the chain health monitor data access layer.
+type Repository struct {
+	db          *mongo.Database
+	log         *zap.Logger
+	collections struct {
+		emitterSequences *mongo.Collection
+		sequenceGaps     *mongo.Collection
+		observations     *mongo.Collection
+		governorStatus   *mongo.Collection
+		monitorLeases    *mongo.Collection
+	}
+}
+
+// NewRepository creates a new repository.
+func NewRepository(db *mongo.Database, log *zap.Logger) *Repository {
+	return &Repository{db, log, struct {
+		emitterSequences *mongo.Collection
+		sequenceGaps     *mongo.Collection
+		observations     *mongo.Collection
+		governorStatus   *mongo.Collection
+		monitorLeases    *mongo.Collection
+	}{
+		emitterSequences: db.Collection("emitterSequences"),
+		sequenceGaps:     db.Collection("sequenceGaps"),
+		observations:     db.Collection("observations"),
+		governorStatus:   db.Collection("governorStatus"),
+		monitorLeases:    db.Collection("monitorLeases"),
+	}}
+}
+
+// CreateIndexes creates the indexes used by the monitor queries.
+func (r *Repository) CreateIndexes(ctx context.Context) error {
+	_, err := r.collections.sequenceGaps.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "emitterChain", Value: 1}, {Key: "emitterAddr", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "detectedAt", Value: 1}}},
+	})
+	return err
+}
+
+// FindEmitters returns the state of every emitter.
+func (r *Repository) FindEmitters(ctx context.Context) ([]*EmitterState, error) {
+	cur, err := r.collections.emitterSequences.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var emitters []*EmitterState
+	if err := cur.All(ctx, &emitters); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return emitters, nil
+}
+
+// UpsertEmitter stores the state of an emitter.
+func (r *Repository) UpsertEmitter(ctx context.Context, s *EmitterState) error {
+	update := bson.D{{Key: "$set", Value: s}}
+	_, err := r.collections.emitterSequences.UpdateByID(ctx, s.ID, update, options.Update().SetUpsert(true))
+	return errors.WithStack(err)
+}
+
+// SetEmitterStalled marks an emitter as stalled, unless it emitted a VAA after the given sequence.
+func (r *Repository) SetEmitterStalled(ctx context.Context, id string, lastSequence uint64, updatedAt time.Time) error {
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "lastSequence", Value: lastSequence}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "stalled", Value: true}, {Key: "updatedAt", Value: updatedAt}}}}
+	_, err := r.collections.emitterSequences.UpdateOne(ctx, filter, update)
+	return errors.WithStack(err)
+}
+
+// AcquireLease acquires or renews the lease of the monitor for the given owner until now plus ttl.
+// It returns false when the lease is held by another owner and has not expired.
+func (r *Repository) AcquireLease(ctx context.Context, owner string, now time.Time, ttl time.Duration) (bool, error) {
+	filter := bson.D{
+		{Key: "_id", Value: leaseID},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "owner", Value: owner}},
+			bson.D{{Key: "expiresAt", Value: bson.D{{Key: "$lt", Value: now}}}},
+		}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "owner", Value: owner},
+		{Key: "expiresAt", Value: now.Add(ttl)},
+	}}}
+	_, err := r.collections.monitorLeases.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	// the upsert fails with a duplicate key when the lease exists and is held by another owner.
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// UpsertGap stores a sequence gap.
+func (r *Repository) UpsertGap(ctx context.Context, g *domain.SequenceGap) error {
+	update := bson.D{{Key: "$set", Value: g}}
+	_, err := r.collections.sequenceGaps.UpdateByID(ctx, g.ID, update, options.Update().SetUpsert(true))
+	return errors.WithStack(err)
+}
+
+// FindOpenGap returns the open gap of an emitter that contains the sequence, or nil if there is none.
+func (r *Repository) FindOpenGap(ctx context.Context, chainID vaa.ChainID, emitterAddr string, seq uint64) (*domain.SequenceGap, error) {
+	filter := bson.D{
+		{Key: "emitterChain", Value: chainID},
+		{Key: "emitterAddr", Value: emitterAddr},
+		{Key: "status", Value: domain.GapStatusOpen},
+		{Key: "startSequence", Value: bson.D{{Key: "$lte", Value: seq}}},
+		{Key: "endSequence", Value: bson.D{{Key: "$gte", Value: seq}}},
+	}
+	var gap domain.SequenceGap
+	err := r.collections.sequenceGaps.FindOne(ctx, filter).Decode(&gap)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &gap, nil
+}
+
+// FindGapsToAlert returns the open gaps detected before the given time that have not been alerted.
+func (r *Repository) FindGapsToAlert(ctx context.Context, detectedBefore time.Time) ([]*domain.SequenceGap, error) {
+	filter := bson.D{
+		{Key: "status", Value: domain.GapStatusOpen},
+		{Key: "detectedAt", Value: bson.D{{Key: "$lt", Value: detectedBefore}}},
+		{Key: "alertedAt", Value: bson.D{{Key: "$exists", Value: false}}},
+	}
+	cur, err := r.collections.sequenceGaps.Find(ctx, filter)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var gaps []*domain.SequenceGap
+	if err := cur.All(ctx, &gaps); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return gaps, nil
+}
+
+// SetGapAlerted records the time a gap was alerted.
+func (r *Repository) SetGapAlerted(ctx context.Context, id string, alertedAt time.Time) error {
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "alertedAt", Value: alertedAt}}}}
+	_, err := r.collections.sequenceGaps.UpdateByID(ctx, id, update)
+	return errors.WithStack(err)
+}
+
+// CountOpenGapsByChain counts the open gaps detected before the given time and their missing VAAs, grouped by chain.
+func (r *Repository) CountOpenGapsByChain(ctx context.Context, detectedBefore time.Time) ([]chainCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "status", Value: domain.GapStatusOpen},
+			{Key: "detectedAt", Value: bson.D{{Key: "$lt", Value: detectedBefore}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$emitterChain"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "missing", Value: bson.D{{Key: "$sum", Value: "$missing"}}},
+		}}},
+	}
+	return r.aggregateByChain(ctx, r.collections.sequenceGaps, pipeline)
+}
+
+// FindGovernorHeldMessageIDs returns the message IDs of the VAAs enqueued by the governor of any guardian.
+func (r *Repository) FindGovernorHeldMessageIDs(ctx context.Context) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$parsedStatus.chains"}},
+		{{Key: "$unwind", Value: "$parsedStatus.chains.emitters"}},
+		{{Key: "$unwind", Value: "$parsedStatus.chains.emitters.enqueuedvaas"}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "chainId", Value: "$parsedStatus.chains.chainid"},
+			{Key: "emitterAddr", Value: "$parsedStatus.chains.emitters.emitteraddress"},
+			{Key: "sequence", Value: "$parsedStatus.chains.emitters.enqueuedvaas.sequence"},
+		}}},
+	}
+	cur, err := r.collections.governorStatus.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var rows []struct {
+		ChainID     vaa.ChainID `bson:"chainId"`
+		EmitterAddr string      `bson:"emitterAddr"`
+		Sequence    string      `bson:"sequence"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// every guardian reports the VAAs held by its own governor, so the same VAA appears many times.
+	ids := make([]string, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		id := governorHeldMessageID(row.ChainID, row.EmitterAddr, row.Sequence)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// CountObservationsWithoutVaa counts, grouped by chain, the messages observed between from and to
+// for which there is no VAA, excluding the given message IDs.
+func (r *Repository) CountObservationsWithoutVaa(ctx context.Context, from, to time.Time, excludedIDs []string) ([]chainCount, error) {
+	if excludedIDs == nil {
+		excludedIDs = []string{}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "indexedAt", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+			{Key: "emitterChain", Value: bson.D{{Key: "$ne", Value: vaa.ChainIDPythNet}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$messageId"},
+			{Key: "emitterChain", Value: bson.D{{Key: "$first", Value: "$emitterChain"}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$nin", Value: excludedIDs}}}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: "vaas"},
+			{Key: "localField", Value: "_id"},
+			{Key: "foreignField", Value: "_id"},
+			{Key: "as", Value: "vaas"},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "vaas", Value: bson.D{{Key: "$size", Value: 0}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$emitterChain"},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+	return r.aggregateByChain(ctx, r.collections.observations, pipeline)
+}
+
+func (r *Repository) aggregateByChain(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline) ([]chainCount, error) {
+	cur, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var counts []chainCount
+	if err := cur.All(ctx, &counts); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return counts, nil
+}
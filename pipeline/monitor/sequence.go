@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+)
+
+// intervalWeight is the weight of the last interval in the moving average of the time between VAAs.
+const intervalWeight = 0.1
+
+// minStallSamples is the number of VAAs needed to estimate the rate of an emitter.
+const minStallSamples = 10
+
+// advance updates the state of the emitter with the sequence of a new VAA and returns the gap between
+// the last sequence and the new one, if any. It returns false when the sequence is not greater than the
+// last sequence, e.g. a VAA that arrived late and may fill a gap.
+func (s *EmitterState) advance(seq uint64, timestamp, now time.Time) (*domain.SequenceGap, bool) {
+	if s.VaaCount > 0 && seq <= s.LastSequence {
+		return nil, false
+	}
+
+	var gap *domain.SequenceGap
+	if s.VaaCount > 0 {
+		if seq > s.LastSequence+1 {
+			gap = domain.NewSequenceGap(s.EmitterChain, s.EmitterAddr, s.LastSequence+1, seq-1, now)
+		}
+		interval := timestamp.Sub(s.LastTimestamp).Seconds()
+		if interval < 0 {
+			interval = 0
+		}
+		if s.VaaCount == 1 {
+			s.AvgIntervalSeconds = interval
+		} else {
+			s.AvgIntervalSeconds = intervalWeight*interval + (1-intervalWeight)*s.AvgIntervalSeconds
+		}
+	}
+
+	s.LastSequence = seq
+	s.LastTimestamp = timestamp
+	s.VaaCount++
+	s.Stalled = false
+	s.UpdatedAt = now
+	return gap, true
+}
+
+// isStalled returns true when the emitter has not emitted a VAA for longer than stallFactor times
+// its average interval between VAAs, and at least minStall.
+func (s *EmitterState) isStalled(now time.Time, stallFactor float64, minStall time.Duration) bool {
+	if s.VaaCount < minStallSamples {
+		return false
+	}
+	threshold := time.Duration(stallFactor * s.AvgIntervalSeconds * float64(time.Second))
+	if threshold < minStall {
+		threshold = minStall
+	}
+	return now.Sub(s.LastTimestamp) > threshold
+}
+
+// fill removes a sequence from the gap. It returns the gap that must be inserted when the
+// sequence splits the gap in two.
+func fill(g *domain.SequenceGap, seq uint64, now time.Time) *domain.SequenceGap {
+	if seq < g.StartSequence || seq > g.EndSequence {
+		return nil
+	}
+
+	var split *domain.SequenceGap
+	switch {
+	case g.StartSequence == g.EndSequence:
+		g.Status = domain.GapStatusClosed
+		g.ClosedAt = &now
+	case seq == g.StartSequence:
+		g.StartSequence++
+	case seq == g.EndSequence:
+		g.EndSequence--
+	default:
+		split = domain.NewSequenceGap(g.EmitterChain, g.EmitterAddr, seq+1, g.EndSequence, g.DetectedAt)
+		split.UpdatedAt = now
+		split.AlertedAt = g.AlertedAt
+		g.EndSequence = seq - 1
+	}
+
+	if g.Status == domain.GapStatusClosed {
+		g.Missing = 0
+	} else {
+		g.Missing = g.EndSequence - g.StartSequence + 1
+	}
+	g.UpdatedAt = now
+	return split
+}
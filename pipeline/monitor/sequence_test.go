@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/test-go/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestEmitterState_Advance(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	s := &EmitterState{EmitterChain: vaa.ChainIDEthereum, EmitterAddr: "0001"}
+
+	gap, ok := s.advance(10, now, now)
+	assert.True(t, ok)
+	assert.Nil(t, gap)
+
+	gap, ok = s.advance(11, now.Add(10*time.Second), now)
+	assert.True(t, ok)
+	assert.Nil(t, gap)
+	assert.Equal(t, 10.0, s.AvgIntervalSeconds)
+
+	gap, ok = s.advance(15, now.Add(30*time.Second), now)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12), gap.StartSequence)
+	assert.Equal(t, uint64(14), gap.EndSequence)
+	assert.Equal(t, uint64(3), gap.Missing)
+	assert.Equal(t, "2/0001/12", gap.ID)
+	assert.Equal(t, 11.0, s.AvgIntervalSeconds)
+
+	gap, ok = s.advance(13, now.Add(31*time.Second), now)
+	assert.False(t, ok)
+	assert.Nil(t, gap)
+	assert.Equal(t, uint64(15), s.LastSequence)
+	assert.Equal(t, uint64(3), s.VaaCount)
+}
+
+func TestEmitterState_IsStalled(t *testing.T) {
+	last := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	s := &EmitterState{LastTimestamp: last, VaaCount: minStallSamples, AvgIntervalSeconds: 600}
+
+	assert.False(t, s.isStalled(last.Add(90*time.Minute), 10, time.Hour))
+	assert.True(t, s.isStalled(last.Add(101*time.Minute), 10, time.Hour))
+
+	// the minimum stall duration applies to emitters with a high rate.
+	s.AvgIntervalSeconds = 1
+	assert.False(t, s.isStalled(last.Add(59*time.Minute), 10, time.Hour))
+
+	// emitters without enough samples are never stalled.
+	s.VaaCount = 1
+	assert.False(t, s.isStalled(last.Add(24*time.Hour), 10, time.Hour))
+}
+
+func TestSequenceGap_Fill(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	g := domain.NewSequenceGap(vaa.ChainIDEthereum, "0001", 10, 20, now)
+
+	assert.Nil(t, fill(g, 9, now))
+	assert.Nil(t, fill(g, 10, now))
+	assert.Equal(t, uint64(11), g.StartSequence)
+	assert.Nil(t, fill(g, 20, now))
+	assert.Equal(t, uint64(19), g.EndSequence)
+
+	split := fill(g, 15, now)
+	assert.Equal(t, uint64(11), g.StartSequence)
+	assert.Equal(t, uint64(14), g.EndSequence)
+	assert.Equal(t, uint64(4), g.Missing)
+	assert.Equal(t, uint64(16), split.StartSequence)
+	assert.Equal(t, uint64(19), split.EndSequence)
+	assert.Equal(t, uint64(4), split.Missing)
+	assert.Equal(t, "2/0001/16", split.ID)
+
+	single := domain.NewSequenceGap(vaa.ChainIDEthereum, "0001", 30, 30, now)
+	assert.Nil(t, fill(single, 30, now))
+	assert.Equal(t, domain.GapStatusClosed, single.Status)
+	assert.Equal(t, uint64(0), single.Missing)
+	assert.NotNil(t, single.ClosedAt)
+}
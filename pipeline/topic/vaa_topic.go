@@ -0,0 +1,87 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
+	pipelineAlert "github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const tracerName = "github.com/wormhole-foundation/wormhole-explorer/pipeline/topic"
+
+// Topic represents a VAA topic in a message broker.
+type Topic struct {
+	producer    broker.Producer
+	alertClient alert.AlertClient
+	lineage     *lineage.Recorder
+	metrics     metrics.Metrics
+	logger      *zap.Logger
+}
+
+// NewVAATopic creates a VAA topic instance.
+func NewVAATopic(producer broker.Producer, alertClient alert.AlertClient, lineage *lineage.Recorder, metrics metrics.Metrics, logger *zap.Logger) *Topic {
+	s := &Topic{
+		producer:    producer,
+		alertClient: alertClient,
+		lineage:     lineage,
+		metrics:     metrics,
+		logger:      logger,
+	}
+	return s
+}
+
+// Publish sends the message to the topic.
+func (s *Topic) Publish(ctx context.Context, message *Event) (err error) {
+	startedAt := time.Now()
+
+	// the VAAs are received from a change stream so the trace of fly is not continued, a new trace is
+	// started for each VAA but the pyth VAAs, that are not traced because of their volume.
+	if sdk.ChainID(message.ChainID) != sdk.ChainIDPythNet {
+		var span trace.Span
+		ctx, span = tracing.StartProducerSpan(ctx, tracerName, "pipeline.publish", tracing.VaaIDKey.String(message.ID))
+		defer func() { tracing.EndSpan(span, err) }()
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	groupID := fmt.Sprintf("%d/%s", message.ChainID, message.EmitterAddress)
+	s.logger.Debug("Publishing message", zap.String("groupID", groupID))
+	err = s.producer.Publish(ctx, &broker.OutgoingMessage{
+		GroupID:         groupID,
+		DeduplicationID: message.ID,
+		Body:            body,
+		Attributes:      tracing.Inject(ctx),
+	})
+	if err == nil {
+		s.metrics.IncVaaSendNotification(message.ChainID)
+	} else {
+		// Alert error pushing event.
+		alertContext := alert.AlertContext{
+			Details: map[string]string{
+				"groupID":   groupID,
+				"messageID": message.ID,
+			},
+			Error: err,
+		}
+		s.alertClient.CreateAndSend(ctx, pipelineAlert.ErrorPushEventSNS, alertContext)
+	}
+
+	// pyth messages are not recorded because of their volume.
+	if sdk.ChainID(message.ChainID) != sdk.ChainIDPythNet {
+		s.lineage.Record(ctx, message.ID, "", startedAt, err)
+	}
+	return err
+}
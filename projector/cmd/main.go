@@ -0,0 +1,63 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/cmd/rebuild"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/cmd/service"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/config"
+)
+
+func main() {
+	execute()
+}
+
+func execute() error {
+	root := &cobra.Command{
+		Use: "projector",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				service.Run()
+			}
+		},
+	}
+
+	addServiceCommand(root)
+	addRebuildCommand(root)
+
+	return root.Execute()
+}
+
+func addServiceCommand(root *cobra.Command) {
+	serviceCommand := &cobra.Command{
+		Use:   "service",
+		Short: "Run projector as service",
+		Run: func(_ *cobra.Command, _ []string) {
+			service.Run()
+		},
+	}
+	root.AddCommand(serviceCommand)
+}
+
+func addRebuildCommand(root *cobra.Command) {
+	var cfg config.RebuildConfiguration
+
+	rebuildCommand := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Rebuild the operations of the stored VAAs",
+		Run: func(_ *cobra.Command, _ []string) {
+			rebuild.Run(&cfg)
+		},
+	}
+	rebuildCommand.Flags().StringVar(&cfg.LogLevel, "log-level", "INFO", "log level")
+	rebuildCommand.Flags().StringVar(&cfg.MongoURI, "mongo-uri", "", "Mongo connection")
+	rebuildCommand.Flags().StringVar(&cfg.MongoDatabase, "mongo-database", "", "Mongo database")
+	rebuildCommand.Flags().StringVar(&cfg.StartTime, "start-time", "1970-01-01T00:00:00Z", "minimum VAA timestamp to process")
+	rebuildCommand.Flags().StringVar(&cfg.EndTime, "end-time", "", "maximum VAA timestamp to process (default now)")
+	rebuildCommand.Flags().Int64Var(&cfg.PageSize, "page-size", 100, "number of VAAs processed at a time")
+	rebuildCommand.Flags().StringVar(&cfg.SLAConfig, "sla-config", "", "JSON SLA configuration used to expire the operations")
+
+	rebuildCommand.MarkFlagRequired("mongo-uri")
+	rebuildCommand.MarkFlagRequired("mongo-database")
+
+	root.AddCommand(rebuildCommand)
+}
@@ -0,0 +1,77 @@
+package rebuild
+
+import (
+	"context"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/config"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/lifecycle"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/migration"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/operation"
+	"go.uber.org/zap"
+)
+
+// Run rebuilds the operations of the VAAs stored in the configured time range.
+func Run(config *config.RebuildConfiguration) {
+
+	rootCtx := context.Background()
+
+	logger := logger.New("wormhole-explorer-projector", logger.WithLevel(config.LogLevel))
+
+	logger.Info("Starting wormhole-explorer-projector rebuild ...")
+
+	startTime, err := time.Parse(time.RFC3339, config.StartTime)
+	if err != nil {
+		logger.Fatal("failed to parse start time", zap.Error(err))
+	}
+
+	endTime := time.Now()
+	if config.EndTime != "" {
+		endTime, err = time.Parse(time.RFC3339, config.EndTime)
+		if err != nil {
+			logger.Fatal("Failed to parse end time", zap.Error(err))
+		}
+	}
+
+	if startTime.After(endTime) {
+		logger.Fatal("Start time should be before end time",
+			zap.String("start_time", startTime.Format(time.RFC3339)),
+			zap.String("end_time", endTime.Format(time.RFC3339)))
+	}
+
+	//setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	// run the database migration.
+	err = migration.Run(db.Database)
+	if err != nil {
+		logger.Fatal("error running migration", zap.Error(err))
+	}
+
+	slas, err := lifecycle.ParseConfig(config.SLAConfig)
+	if err != nil {
+		logger.Fatal("failed to parse SLA config", zap.Error(err))
+	}
+
+	repository := operation.NewRepository(db.Database, logger)
+	projector := operation.NewProjector(repository, slas, metrics.NewDummyMetrics(), logger)
+
+	total, err := projector.Rebuild(rootCtx, operation.RebuildQuery{
+		StartTime: startTime,
+		EndTime:   endTime,
+		PageSize:  config.PageSize,
+	})
+	if err != nil {
+		logger.Error("Failed to rebuild operations", zap.Int("operations", total), zap.Error(err))
+		return
+	}
+
+	logger.Info("Finished wormhole-explorer-projector rebuild", zap.Int("operations", total))
+}
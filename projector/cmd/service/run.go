@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/config"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/http/infrastructure"
+	projectorAlert "github.com/wormhole-foundation/wormhole-explorer/projector/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/lifecycle"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/migration"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/operation"
+	"go.uber.org/zap"
+)
+
+type exitCode int
+
+func handleExit() {
+	if r := recover(); r != nil {
+		if e, ok := r.(exitCode); ok {
+			os.Exit(int(e))
+		}
+		panic(r) // not an Exit, bubble up
+	}
+}
+
+// Run starts the projector with the configuration from the environment and blocks until a
+// termination signal is received.
+func Run() {
+
+	defer handleExit()
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+
+	config, err := config.New(rootCtx)
+	if err != nil {
+		log.Fatal("Error creating config", err)
+	}
+
+	logger := logger.New("wormhole-explorer-projector", logger.WithLevel(config.LogLevel))
+
+	stop := Start(rootCtx, config, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("Finished wormhole-explorer-projector")
+}
+
+// Start starts the projector watcher and http server with the given configuration. The watcher
+// runs until rootCtx is cancelled and the returned function releases the remaining resources.
+func Start(rootCtx context.Context, config *config.ServiceConfiguration, logger *zap.Logger) (stop func()) {
+
+	logger.Info("Starting wormhole-explorer-projector ...")
+
+	// setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+	}
+
+	// run the database migration.
+	err = migration.Run(db.Database)
+	if err != nil {
+		logger.Fatal("error running migration", zap.Error(err))
+	}
+
+	// create a metrics
+	metrics := newMetrics(config)
+
+	slas, err := lifecycle.ParseConfig(config.SLAConfig)
+	if err != nil {
+		logger.Fatal("failed to parse SLA config", zap.Error(err))
+	}
+
+	// create a alert client.
+	alertClient, err := newAlertClient(config)
+	if err != nil {
+		logger.Fatal("failed to create alert client", zap.Error(err))
+	}
+
+	// create and start the change watcher.
+	repository := operation.NewRepository(db.Database, logger)
+	projector := operation.NewProjector(repository, slas, metrics, logger)
+	watcher := operation.NewWatcher(db.Database, projector, repository, metrics, logger)
+	if err := watcher.Start(rootCtx); err != nil {
+		logger.Fatal("failed to watch MongoDB", zap.Error(err))
+	}
+
+	// create and start the lifecycle monitor.
+	lifecycleRepository := lifecycle.NewRepository(db.Database, logger)
+	monitor := lifecycle.NewMonitor(time.Duration(config.SLACheckIntervalSeconds)*time.Second, slas,
+		lifecycleRepository, projector, alertClient, metrics, logger)
+	monitor.Start(rootCtx)
+
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, health.Mongo(db.Database), watcher.Check)
+	server.Start()
+
+	logger.Info("Started wormhole-explorer-projector")
+
+	return func() {
+		logger.Info("closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+
+		logger.Info("Closing Http server ...")
+		server.Stop()
+	}
+}
+
+// Creates a metrics depending on whether the execution is local (dummy metrics) or not (Prometheus metrics)
+func newMetrics(cfg *config.ServiceConfiguration) metrics.Metrics {
+	if !cfg.MetricsEnabled {
+		return metrics.NewDummyMetrics()
+	}
+	return metrics.NewPrometheusMetrics(cfg.Environment)
+}
+
+func newAlertClient(cfg *config.ServiceConfiguration) (alert.AlertClient, error) {
+	if !cfg.AlertEnabled {
+		return alert.NewDummyClient(), nil
+	}
+
+	alertConfig := alert.AlertConfig{
+		Environment: cfg.Environment,
+		ApiKey:      cfg.AlertApiKey,
+		Enabled:     cfg.AlertEnabled,
+	}
+	return alert.NewAlertService(alertConfig, projectorAlert.LoadAlerts)
+}
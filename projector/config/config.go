@@ -0,0 +1,47 @@
+package config
+
+import (
+	"context"
+
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// ServiceConfiguration represents the application configuration when running as service with default values.
+type ServiceConfiguration struct {
+	Environment    string `env:"ENVIRONMENT,required"`
+	LogLevel       string `env:"LOG_LEVEL,default=INFO"`
+	Port           string `env:"PORT,default=8000"`
+	MongoURI       string `env:"MONGODB_URI,required"`
+	MongoDatabase  string `env:"MONGODB_DATABASE,required"`
+	PprofEnabled   bool   `env:"PPROF_ENABLED,default=false"`
+	MetricsEnabled bool   `env:"METRICS_ENABLED,default=false"`
+	AlertEnabled   bool   `env:"ALERT_ENABLED,default=false"`
+	AlertApiKey    string `env:"ALERT_API_KEY"`
+	// SLAConfig is the JSON SLA configuration of the operations lifecycle, see lifecycle.Config.
+	SLAConfig               string `env:"SLA_CONFIG"`
+	SLACheckIntervalSeconds int    `env:"SLA_CHECK_INTERVAL_SECONDS,default=60"`
+}
+
+// RebuildConfiguration represents the application configuration when rebuilding the operations of the stored VAAs.
+type RebuildConfiguration struct {
+	LogLevel      string
+	MongoURI      string
+	MongoDatabase string
+	StartTime     string
+	EndTime       string
+	PageSize      int64
+	SLAConfig     string
+}
+
+// New creates a configuration with the values from .env file and environment variables.
+func New(ctx context.Context) (*ServiceConfiguration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration ServiceConfiguration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
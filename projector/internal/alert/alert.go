@@ -0,0 +1,29 @@
+package alert
+
+import (
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+)
+
+// alert key constants definition.
+const (
+	AlertOperationSLAExceeded = "OPERATION_SLA_EXCEEDED"
+)
+
+func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
+	alerts := make(map[string]alert.Alert)
+
+	// Alert operation sla exceeded.
+	alerts[AlertOperationSLAExceeded] = alert.Alert{
+		Alias:       "Operation SLA exceeded",
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Operation SLA exceeded"),
+		Description: "An operation stayed in a state of its lifecycle longer than the SLA of its route",
+		Actions:     []string{""},
+		Tags:        []string{cfg.Environment, "projector", "lifecycle", "sla"},
+		Entity:      "projector",
+		Priority:    alert.MODERATE,
+	}
+
+	return alerts
+}
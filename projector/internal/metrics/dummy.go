@@ -0,0 +1,22 @@
+package metrics
+
+// DummyMetrics is a dummy implementation of Metric interface.
+type DummyMetrics struct {
+}
+
+// NewDummyMetrics returns a new instance of DummyMetrics.
+func NewDummyMetrics() *DummyMetrics {
+	return &DummyMetrics{}
+}
+
+// IncChangeFromMongoStream increments the number of changes received from the mongo stream.
+func (m *DummyMetrics) IncChangeFromMongoStream(collection string) {}
+
+// IncOperationProjected increments the number of operations written.
+func (m *DummyMetrics) IncOperationProjected(chainID uint16) {}
+
+// IncOperationFailed increments the number of operations that could not be written.
+func (m *DummyMetrics) IncOperationFailed() {}
+
+// IncOperationSLAExceeded increments the number of operations that exceeded the SLA of a state.
+func (m *DummyMetrics) IncOperationSLAExceeded(chainID uint16, state string) {}
@@ -0,0 +1,11 @@
+package metrics
+
+const serviceName = "wormscan-projector"
+
+// Metrics is a metrics interface.
+type Metrics interface {
+	IncChangeFromMongoStream(collection string)
+	IncOperationProjected(chainID uint16)
+	IncOperationFailed()
+	IncOperationSLAExceeded(chainID uint16, state string)
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// PrometheusMetrics is a metrics implementation for Prometheus.
+type PrometheusMetrics struct {
+	changeCount      *prometheus.CounterVec
+	operationCount   *prometheus.CounterVec
+	slaExceededCount *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates a new PrometheusMetrics.
+func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+	constLabels := map[string]string{
+		"environment": environment,
+		"service":     serviceName,
+	}
+
+	changeCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "change_count_by_collection",
+			Help:        "Total number of changes received from the mongo stream by collection",
+			ConstLabels: constLabels,
+		}, []string{"collection"})
+
+	operationCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "operation_count_by_chain",
+			Help:        "Total number of operations projected by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain", "type"})
+
+	slaExceededCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "operation_sla_exceeded_count_by_chain",
+			Help:        "Total number of operations that exceeded the SLA of a lifecycle state by chain",
+			ConstLabels: constLabels,
+		}, []string{"chain", "state"})
+
+	return &PrometheusMetrics{
+		changeCount:      changeCount,
+		operationCount:   operationCount,
+		slaExceededCount: slaExceededCount,
+	}
+}
+
+// IncChangeFromMongoStream increments the number of changes received from the mongo stream.
+func (m *PrometheusMetrics) IncChangeFromMongoStream(collection string) {
+	m.changeCount.WithLabelValues(collection).Inc()
+}
+
+// IncOperationProjected increments the number of operations written.
+func (m *PrometheusMetrics) IncOperationProjected(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.operationCount.WithLabelValues(chain, "projected").Inc()
+}
+
+// IncOperationFailed increments the number of operations that could not be written.
+func (m *PrometheusMetrics) IncOperationFailed() {
+	m.operationCount.WithLabelValues("all", "failed").Inc()
+}
+
+// IncOperationSLAExceeded increments the number of operations that exceeded the SLA of a state.
+func (m *PrometheusMetrics) IncOperationSLAExceeded(chainID uint16, state string) {
+	chain := vaa.ChainID(chainID).String()
+	m.slaExceededCount.WithLabelValues(chain, state).Inc()
+}
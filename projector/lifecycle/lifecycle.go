@@ -0,0 +1,162 @@
+// Package lifecycle computes the lifecycle of an operation, the states a VAA goes through from the
+// message published on the emitter chain to its redeem on the destination chain, and monitors the
+// time the operations spend in each state.
+package lifecycle
+
+import (
+	"time"
+)
+
+// State is a state of the lifecycle of an operation. The governor holds the messages after they are
+// observed and before the VAAs are signed, so a held operation moves from governor_held to signed
+// when the governor releases it.
+type State string
+
+const (
+	// StateEmitted indicates that the message was published on the emitter chain.
+	StateEmitted State = "emitted"
+	// StateObserved indicates that the guardians observed the message.
+	StateObserved State = "observed"
+	// StateGovernorHeld indicates that the governor of the guardians delayed the signing of the VAA.
+	StateGovernorHeld State = "governor_held"
+	// StateSigned indicates that the VAA was signed by a quorum of guardians.
+	StateSigned State = "signed"
+	// StateRedeemed indicates that the VAA was redeemed on the destination chain.
+	StateRedeemed State = "redeemed"
+	// StateFailed indicates that the redeem of the VAA failed on the destination chain.
+	StateFailed State = "failed"
+	// StateExpired indicates that the VAA was not redeemed before the expiration of its route.
+	StateExpired State = "expired"
+)
+
+// MonitoredStates are the states the SLAs can limit.
+var MonitoredStates = []State{StateEmitted, StateObserved, StateGovernorHeld, StateSigned}
+
+// IsTerminal returns whether an operation can leave the state. An expired operation is redeemed
+// when the VAA is redeemed late.
+func (s State) IsTerminal() bool {
+	return s == StateRedeemed || s == StateFailed
+}
+
+// Transition is the time an operation entered a state.
+type Transition struct {
+	State State     `bson:"state" json:"state"`
+	At    time.Time `bson:"at" json:"at"`
+}
+
+// Lifecycle is the current state of an operation and the transitions it went through.
+type Lifecycle struct {
+	State State `bson:"state" json:"state"`
+	// StartedAt is the time of the first transition and EnteredAt the time of the last one.
+	StartedAt   time.Time    `bson:"startedAt" json:"startedAt"`
+	EnteredAt   time.Time    `bson:"enteredAt" json:"enteredAt"`
+	Transitions []Transition `bson:"transitions" json:"transitions"`
+	// AlertedState is the last state the operation was reported for exceeding its SLA.
+	AlertedState State `bson:"alertedState,omitempty" json:"-"`
+}
+
+// Facts are the times of the events of an operation found in its source documents, the events that
+// did not happen yet are nil.
+type Facts struct {
+	EmittedAt    *time.Time
+	ObservedAt   *time.Time
+	GovernorHeld bool
+	SignedAt     *time.Time
+	RedeemedAt   *time.Time
+	FailedAt     *time.Time
+}
+
+// Compute returns the lifecycle of an operation from its facts. The transitions are rebuilt from the
+// facts on every call, except the governor hold whose time is not stored by the sources, it is the
+// first time the hold was seen and is kept from the previous lifecycle.
+//
+// An operation that was not redeemed expireAfter after it was emitted is expired, a zero expireAfter
+// disables the expiration.
+func Compute(previous *Lifecycle, f Facts, expireAfter time.Duration, now time.Time) *Lifecycle {
+	var transitions []Transition
+	add := func(state State, at *time.Time) {
+		if at != nil {
+			transitions = append(transitions, Transition{State: state, At: at.UTC()})
+		}
+	}
+
+	add(StateEmitted, f.EmittedAt)
+	add(StateObserved, f.ObservedAt)
+	if heldAt := previousTransition(previous, StateGovernorHeld); heldAt != nil {
+		add(StateGovernorHeld, heldAt)
+	} else if f.GovernorHeld && f.SignedAt == nil {
+		add(StateGovernorHeld, &now)
+	}
+	add(StateSigned, f.SignedAt)
+	add(StateRedeemed, f.RedeemedAt)
+	add(StateFailed, f.FailedAt)
+
+	if !isTerminal(transitions) && expireAfter > 0 {
+		start := firstTime(transitions)
+		if start != nil && now.Sub(*start) > expireAfter {
+			expiredAt := start.Add(expireAfter)
+			add(StateExpired, &expiredAt)
+		}
+	}
+
+	if len(transitions) == 0 {
+		return nil
+	}
+	clampTransitions(transitions)
+
+	last := transitions[len(transitions)-1]
+	l := &Lifecycle{
+		State:       last.State,
+		StartedAt:   transitions[0].At,
+		EnteredAt:   last.At,
+		Transitions: transitions,
+	}
+	if previous != nil && previous.AlertedState == l.State {
+		l.AlertedState = previous.AlertedState
+	}
+	return l
+}
+
+// previousTransition returns the time the previous lifecycle entered a state.
+func previousTransition(previous *Lifecycle, state State) *time.Time {
+	if previous == nil {
+		return nil
+	}
+	for _, t := range previous.Transitions {
+		if t.State == state {
+			at := t.At
+			return &at
+		}
+	}
+	return nil
+}
+
+func isTerminal(transitions []Transition) bool {
+	for _, t := range transitions {
+		if t.State.IsTerminal() {
+			return true
+		}
+	}
+	return false
+}
+
+func firstTime(transitions []Transition) *time.Time {
+	var first *time.Time
+	for i := range transitions {
+		if first == nil || transitions[i].At.Before(*first) {
+			first = &transitions[i].At
+		}
+	}
+	return first
+}
+
+// clampTransitions moves the time of a state that is before the time of the previous state to the
+// time of the previous state. The sources are written by different services with their own clocks,
+// e.g. the VAA can be indexed before the timestamp of the origin transaction of a slow chain.
+func clampTransitions(transitions []Transition) {
+	for i := 1; i < len(transitions); i++ {
+		if transitions[i].At.Before(transitions[i-1].At) {
+			transitions[i].At = transitions[i-1].At
+		}
+	}
+}
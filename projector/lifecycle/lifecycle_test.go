@@ -0,0 +1,117 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(minutes int) *time.Time {
+	t := time.Unix(1690000000, 0).UTC().Add(time.Duration(minutes) * time.Minute)
+	return &t
+}
+
+func TestCompute(t *testing.T) {
+	now := *at(120)
+	tests := []struct {
+		name        string
+		facts       Facts
+		expireAfter time.Duration
+		state       State
+		transitions []State
+	}{
+		{name: "no facts", facts: Facts{}},
+		{name: "emitted", facts: Facts{EmittedAt: at(0)}, state: StateEmitted, transitions: []State{StateEmitted}},
+		{
+			name:        "signed",
+			facts:       Facts{EmittedAt: at(0), ObservedAt: at(1), SignedAt: at(2)},
+			state:       StateSigned,
+			transitions: []State{StateEmitted, StateObserved, StateSigned},
+		},
+		{
+			name:        "held",
+			facts:       Facts{EmittedAt: at(0), ObservedAt: at(1), GovernorHeld: true},
+			state:       StateGovernorHeld,
+			transitions: []State{StateEmitted, StateObserved, StateGovernorHeld},
+		},
+		{
+			name:        "redeemed",
+			facts:       Facts{EmittedAt: at(0), SignedAt: at(2), RedeemedAt: at(10)},
+			expireAfter: time.Minute,
+			state:       StateRedeemed,
+			transitions: []State{StateEmitted, StateSigned, StateRedeemed},
+		},
+		{
+			name:        "failed",
+			facts:       Facts{EmittedAt: at(0), SignedAt: at(2), FailedAt: at(10)},
+			state:       StateFailed,
+			transitions: []State{StateEmitted, StateSigned, StateFailed},
+		},
+		{
+			name:        "expired",
+			facts:       Facts{EmittedAt: at(0), SignedAt: at(2)},
+			expireAfter: time.Hour,
+			state:       StateExpired,
+			transitions: []State{StateEmitted, StateSigned, StateExpired},
+		},
+		{
+			name:        "not expired",
+			facts:       Facts{EmittedAt: at(0), SignedAt: at(2)},
+			expireAfter: 3 * time.Hour,
+			state:       StateSigned,
+			transitions: []State{StateEmitted, StateSigned},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := Compute(nil, tc.facts, tc.expireAfter, now)
+			if tc.transitions == nil {
+				assert.Nil(t, l)
+				return
+			}
+			require.NotNil(t, l)
+			assert.Equal(t, tc.state, l.State)
+			var states []State
+			for _, tr := range l.Transitions {
+				states = append(states, tr.State)
+			}
+			assert.Equal(t, tc.transitions, states)
+			assert.Equal(t, l.Transitions[0].At, l.StartedAt)
+			assert.Equal(t, l.Transitions[len(l.Transitions)-1].At, l.EnteredAt)
+		})
+	}
+}
+
+func TestCompute_Times(t *testing.T) {
+	now := *at(120)
+
+	// the expiration is counted from the first transition.
+	l := Compute(nil, Facts{EmittedAt: at(0), SignedAt: at(2)}, time.Hour, now)
+	assert.Equal(t, *at(60), l.EnteredAt)
+
+	// the VAA indexed before the origin transaction timestamp is signed when it is emitted.
+	l = Compute(nil, Facts{EmittedAt: at(5), SignedAt: at(2)}, 0, now)
+	assert.Equal(t, *at(5), l.EnteredAt)
+
+	// the governor hold is first seen now.
+	l = Compute(nil, Facts{ObservedAt: at(1), GovernorHeld: true}, 0, now)
+	assert.Equal(t, now, l.EnteredAt)
+}
+
+func TestCompute_Previous(t *testing.T) {
+	held := Compute(nil, Facts{ObservedAt: at(1), GovernorHeld: true}, 0, *at(10))
+	held.AlertedState = StateGovernorHeld
+
+	// the alert of the state is kept while the operation stays in it.
+	l := Compute(held, Facts{ObservedAt: at(1), GovernorHeld: true}, 0, *at(20))
+	assert.Equal(t, *at(10), l.EnteredAt)
+	assert.Equal(t, StateGovernorHeld, l.AlertedState)
+
+	// the hold is kept when the governor releases the VAA.
+	l = Compute(l, Facts{ObservedAt: at(1), SignedAt: at(30)}, 0, *at(40))
+	assert.Equal(t, StateSigned, l.State)
+	assert.Equal(t, Transition{State: StateGovernorHeld, At: *at(10)}, l.Transitions[1])
+	assert.Empty(t, l.AlertedState)
+}
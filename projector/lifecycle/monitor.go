@@ -0,0 +1,181 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	projectorAlert "github.com/wormhole-foundation/wormhole-explorer/projector/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// checkBatchSize is the number of operations checked at once.
+const checkBatchSize = 500
+
+// Projector rebuilds the operations of VAAs.
+type Projector interface {
+	Project(ctx context.Context, ids ...string) (int, error)
+}
+
+// Monitor updates the lifecycle of the operations whose state changes without a change in their
+// source documents, the governor holds and the expirations, and reports the operations that stay in
+// a state longer than the SLA of their route.
+type Monitor struct {
+	checkInterval time.Duration
+	slas          *Config
+	repository    *Repository
+	projector     Projector
+	alertClient   alert.AlertClient
+	metrics       metrics.Metrics
+	logger        *zap.Logger
+}
+
+// NewMonitor creates a new lifecycle monitor.
+func NewMonitor(checkInterval time.Duration, slas *Config, repository *Repository, projector Projector,
+	alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Monitor {
+	return &Monitor{
+		checkInterval: checkInterval,
+		slas:          slas,
+		repository:    repository,
+		projector:     projector,
+		alertClient:   alertClient,
+		metrics:       metrics,
+		logger:        logger.With(zap.String("module", "LifecycleMonitor")),
+	}
+}
+
+// Start runs the periodic checks until the context is cancelled.
+func (m *Monitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.check(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+func (m *Monitor) check(ctx context.Context, now time.Time) {
+	m.checkGovernor(ctx)
+	m.checkSLAs(ctx, now)
+}
+
+// checkGovernor projects the operations of the VAAs enqueued by the governor that are not held yet.
+// The governor status is stored by guardian, so its changes can not be mapped to the operations.
+func (m *Monitor) checkGovernor(ctx context.Context) {
+	ids, err := m.repository.FindHeldVaaIDs(ctx)
+	if err != nil {
+		m.logger.Error("error finding vaas held by the governor", zap.Error(err))
+		return
+	}
+	if len(ids) == 0 {
+		return
+	}
+	held, err := m.repository.FindHeldOperationIDs(ctx, ids)
+	if err != nil {
+		m.logger.Error("error finding held operations", zap.Error(err))
+		return
+	}
+	var toProject []string
+	for _, id := range ids {
+		if !held[id] {
+			toProject = append(toProject, id)
+		}
+	}
+	if len(toProject) == 0 {
+		return
+	}
+	if _, err := m.projector.Project(ctx, toProject...); err != nil {
+		m.logger.Error("error projecting held operations", zap.Strings("ids", toProject), zap.Error(err))
+	}
+}
+
+// checkSLAs projects the operations that expired and reports the operations that exceed the SLA of
+// their current state, every operation is reported once per state. The operations are checked in
+// pages of checkBatchSize.
+func (m *Monitor) checkSLAs(ctx context.Context, now time.Time) {
+	query := newCheckQuery(m.slas, now)
+	if query == nil {
+		return
+	}
+	var afterID string
+	for ctx.Err() == nil {
+		operations, err := m.repository.FindOperationsToCheck(ctx, query, afterID, checkBatchSize)
+		if err != nil {
+			m.logger.Error("error finding operations to check", zap.Error(err))
+			return
+		}
+		m.checkBatch(ctx, now, operations)
+		if len(operations) < checkBatchSize {
+			return
+		}
+		afterID = operations[len(operations)-1].ID
+	}
+}
+
+// newCheckQuery returns the query of the operations to check at the given time, nil when the SLAs
+// have neither limits nor expirations.
+func newCheckQuery(slas *Config, now time.Time) *CheckQuery {
+	q := CheckQuery{EnteredBefore: make(map[State]time.Time)}
+	for state, limit := range slas.StateLimits() {
+		q.EnteredBefore[state] = now.Add(-limit)
+	}
+	if expireAfter := slas.MinExpireAfter(); expireAfter > 0 {
+		q.StartedBefore = now.Add(-expireAfter)
+	}
+	if len(q.EnteredBefore) == 0 && q.StartedBefore.IsZero() {
+		return nil
+	}
+	return &q
+}
+
+func (m *Monitor) checkBatch(ctx context.Context, now time.Time, operations []*OperationLifecycle) {
+	var expired []string
+	for _, op := range operations {
+		sla := m.slas.For(op.EmitterChain, op.ToChain)
+		if sla.ExpireAfter > 0 && now.Sub(op.Lifecycle.StartedAt) > time.Duration(sla.ExpireAfter) {
+			expired = append(expired, op.ID)
+			continue
+		}
+
+		state := op.Lifecycle.State
+		limit := sla.Limit(state)
+		if limit == 0 || now.Sub(op.Lifecycle.EnteredAt) <= limit || op.Lifecycle.AlertedState == state {
+			continue
+		}
+		m.logger.Info("operation exceeded its SLA",
+			zap.String("id", op.ID),
+			zap.String("state", string(state)),
+			zap.Time("enteredAt", op.Lifecycle.EnteredAt))
+		m.metrics.IncOperationSLAExceeded(uint16(op.EmitterChain), string(state))
+		alertContext := alert.AlertContext{Details: map[string]string{
+			"id":           op.ID,
+			"emitterChain": op.EmitterChain.String(),
+			"toChain":      op.ToChain.String(),
+			"state":        string(state),
+			"enteredAt":    op.Lifecycle.EnteredAt.Format(time.RFC3339),
+			"sla":          limit.String(),
+		}}
+		// the operation is reported again in the next check if the alert is not sent.
+		if err := m.alertClient.CreateAndSend(ctx, projectorAlert.AlertOperationSLAExceeded, alertContext); err != nil {
+			m.logger.Error("error sending operation SLA alert", zap.String("id", op.ID), zap.Error(err))
+			continue
+		}
+		if err := m.repository.SetAlerted(ctx, op.ID, state); err != nil {
+			m.logger.Error("error updating operation lifecycle", zap.String("id", op.ID), zap.Error(err))
+		}
+	}
+
+	if len(expired) == 0 {
+		return
+	}
+	if _, err := m.projector.Project(ctx, expired...); err != nil {
+		m.logger.Error("error projecting expired operations", zap.Int("operations", len(expired)), zap.Error(err))
+	}
+}
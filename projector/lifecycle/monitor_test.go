@@ -0,0 +1,49 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewCheckQuery(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	cfg, err := ParseConfig(`{"default": {"states": {"emitted": "30m", "governor_held": "25h"}, "expireAfter": "720h"}}`)
+	require.NoError(t, err)
+
+	q := newCheckQuery(cfg, now)
+
+	// signed has no limit, so the signed operations are only checked for their expiration.
+	assert.Equal(t, &CheckQuery{
+		EnteredBefore: map[State]time.Time{
+			StateEmitted:      now.Add(-30 * time.Minute),
+			StateGovernorHeld: now.Add(-25 * time.Hour),
+		},
+		StartedBefore: now.Add(-720 * time.Hour),
+	}, q)
+
+	empty, err := ParseConfig("")
+	require.NoError(t, err)
+	assert.Nil(t, newCheckQuery(empty, now))
+}
+
+func TestNewCheckFilter(t *testing.T) {
+	enteredBefore := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	q := &CheckQuery{EnteredBefore: map[State]time.Time{StateEmitted: enteredBefore}}
+
+	filter := newCheckFilter(q, "2/a/1")
+
+	assert.Equal(t, bson.D{
+		{Key: "$or", Value: bson.A{
+			bson.D{
+				{Key: "lifecycle.state", Value: StateEmitted},
+				{Key: "lifecycle.enteredAt", Value: bson.M{"$lte": enteredBefore}},
+				{Key: "lifecycle.alertedState", Value: bson.M{"$ne": StateEmitted}},
+			},
+		}},
+		{Key: "_id", Value: bson.M{"$gt": "2/a/1"}},
+	}, filter)
+}
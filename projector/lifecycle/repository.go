@@ -0,0 +1,167 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository exposes the lifecycle of the operations and the VAAs held by the governor.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		operations     *mongo.Collection
+		governorStatus *mongo.Collection
+	}
+}
+
+// NewRepository creates a new lifecycle repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	r := &Repository{db: db, logger: logger.With(zap.String("module", "LifecycleRepository"))}
+	r.collections.operations = db.Collection("operations")
+	r.collections.governorStatus = db.Collection("governorStatus")
+	return r
+}
+
+// OperationLifecycle is the lifecycle of an operation with its route.
+type OperationLifecycle struct {
+	ID           string      `bson:"_id"`
+	EmitterChain sdk.ChainID `bson:"emitterChain"`
+	// ToChain is the target chain of the operation, it is unset until the VAA is parsed.
+	ToChain   sdk.ChainID `bson:"toChain"`
+	Lifecycle Lifecycle   `bson:"lifecycle"`
+}
+
+// FindHeldVaaIDs returns the IDs of the VAAs enqueued by the governor of any guardian.
+func (r *Repository) FindHeldVaaIDs(ctx context.Context) ([]string, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$parsedStatus.chains"}},
+		{{Key: "$unwind", Value: "$parsedStatus.chains.emitters"}},
+		{{Key: "$unwind", Value: "$parsedStatus.chains.emitters.enqueuedvaas"}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: bson.D{
+			{Key: "chain", Value: "$parsedStatus.chains.chainid"},
+			{Key: "emitter", Value: "$parsedStatus.chains.emitters.emitteraddress"},
+			{Key: "sequence", Value: "$parsedStatus.chains.emitters.enqueuedvaas.sequence"},
+		}}}}},
+	}
+	cur, err := r.collections.governorStatus.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var rows []struct {
+		ID struct {
+			Chain    uint32 `bson:"chain"`
+			Emitter  string `bson:"emitter"`
+			Sequence string `bson:"sequence"`
+		} `bson:"_id"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		emitter := strings.ToLower(strings.TrimPrefix(row.ID.Emitter, "0x"))
+		ids = append(ids, fmt.Sprintf("%d/%s/%s", row.ID.Chain, emitter, row.ID.Sequence))
+	}
+	return ids, nil
+}
+
+// FindHeldOperationIDs returns the IDs of the given operations whose lifecycle has a governor hold.
+func (r *Repository) FindHeldOperationIDs(ctx context.Context, ids []string) (map[string]bool, error) {
+	filter := bson.D{
+		{Key: "_id", Value: bson.M{"$in": ids}},
+		{Key: "lifecycle.transitions.state", Value: StateGovernorHeld},
+	}
+	cur, err := r.collections.operations.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	held := make(map[string]bool, len(docs))
+	for _, doc := range docs {
+		held[doc.ID] = true
+	}
+	return held, nil
+}
+
+// CheckQuery selects the operations checked by the monitor: the operations that stayed in a state
+// longer than its shortest limit and were not reported for it yet, and the operations that started
+// before the shortest expiration.
+type CheckQuery struct {
+	// EnteredBefore is the time before which the operations entered each limited state.
+	EnteredBefore map[State]time.Time
+	// StartedBefore is the time before which the operations may have expired, zero if they do not expire.
+	StartedBefore time.Time
+}
+
+// FindOperationsToCheck returns a page of the operations selected by the query, sorted by ID. The
+// page starts after the operation afterID, the first page when it is empty.
+func (r *Repository) FindOperationsToCheck(ctx context.Context, q *CheckQuery, afterID string, limit int64) ([]*OperationLifecycle, error) {
+	projection := bson.D{
+		{Key: "emitterChain", Value: 1},
+		{Key: "toChain", Value: "$standardizedProperties.toChain"},
+		{Key: "lifecycle", Value: 1},
+	}
+	opts := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+	cur, err := r.collections.operations.Find(ctx, newCheckFilter(q, afterID), opts)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var operations []*OperationLifecycle
+	if err := cur.All(ctx, &operations); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return operations, nil
+}
+
+func newCheckFilter(q *CheckQuery, afterID string) bson.D {
+	conditions := bson.A{}
+	for _, state := range MonitoredStates {
+		enteredBefore, ok := q.EnteredBefore[state]
+		if !ok {
+			continue
+		}
+		conditions = append(conditions, bson.D{
+			{Key: "lifecycle.state", Value: state},
+			{Key: "lifecycle.enteredAt", Value: bson.M{"$lte": enteredBefore}},
+			{Key: "lifecycle.alertedState", Value: bson.M{"$ne": state}},
+		})
+	}
+	if !q.StartedBefore.IsZero() {
+		conditions = append(conditions, bson.D{
+			{Key: "lifecycle.state", Value: bson.M{"$in": MonitoredStates}},
+			{Key: "lifecycle.startedAt", Value: bson.M{"$lte": q.StartedBefore}},
+		})
+	}
+	filter := bson.D{{Key: "$or", Value: conditions}}
+	if afterID != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.M{"$gt": afterID}})
+	}
+	return filter
+}
+
+// SetAlerted records that an operation was reported for exceeding the SLA of its current state. The
+// update is skipped if the operation left the state in the meantime.
+func (r *Repository) SetAlerted(ctx context.Context, id string, state State) error {
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "lifecycle.state", Value: state}}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "lifecycle.alertedState", Value: state}}}}
+	_, err := r.collections.operations.UpdateOne(ctx, filter, update)
+	return errors.WithStack(err)
+}
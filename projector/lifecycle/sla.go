@@ -0,0 +1,135 @@
+package lifecycle
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Duration is a time.Duration encoded in JSON as a string, e.g. "30m".
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON formats the duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// SLA defines the maximum time the operations of a route can stay in each state and the time after
+// which they expire if they are not redeemed. The states without limit are not monitored.
+type SLA struct {
+	States      map[State]Duration `json:"states"`
+	ExpireAfter Duration           `json:"expireAfter"`
+}
+
+// Limit returns the maximum time an operation can stay in a state, zero if the state is not monitored.
+func (s SLA) Limit(state State) time.Duration {
+	return time.Duration(s.States[state])
+}
+
+// Config defines the SLAs of the routes. The routes are keyed by "<source chain>-<target chain>"
+// with the chain IDs, "*" matches any chain, e.g. "2-*" are the operations emitted on Ethereum.
+// The most specific route of an operation is used, then the default SLA.
+type Config struct {
+	Default SLA            `json:"default"`
+	Routes  map[string]SLA `json:"routes"`
+}
+
+// ParseConfig parses a JSON SLA configuration, an empty string is a configuration without SLAs.
+func ParseConfig(s string) (*Config, error) {
+	var cfg Config
+	if s == "" {
+		return &cfg, nil
+	}
+	if err := json.Unmarshal([]byte(s), &cfg); err != nil {
+		return nil, fmt.Errorf("invalid SLA configuration: %w", err)
+	}
+	slas := map[string]SLA{"default": cfg.Default}
+	for route, sla := range cfg.Routes {
+		slas[route] = sla
+	}
+	for route, sla := range slas {
+		for state := range sla.States {
+			if !isMonitored(state) {
+				return nil, fmt.Errorf("invalid SLA configuration: %s route has an SLA for state %s", route, state)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+// For returns the SLA of the route of an operation. The target chain is zero when it is unknown.
+func (c *Config) For(sourceChain, targetChain sdk.ChainID) SLA {
+	source := strconv.Itoa(int(sourceChain))
+	target := "*"
+	if targetChain != sdk.ChainIDUnset {
+		target = strconv.Itoa(int(targetChain))
+	}
+	for _, route := range []string{source + "-" + target, source + "-*", "*-" + target} {
+		if sla, ok := c.Routes[route]; ok {
+			return sla
+		}
+	}
+	return c.Default
+}
+
+// StateLimits returns the shortest time limit of each state in all the SLAs, the states without
+// limit are not included.
+func (c *Config) StateLimits() map[State]time.Duration {
+	limits := make(map[State]time.Duration)
+	for _, sla := range append([]SLA{c.Default}, routes(c)...) {
+		for state, d := range sla.States {
+			if min, ok := limits[state]; d > 0 && (!ok || time.Duration(d) < min) {
+				limits[state] = time.Duration(d)
+			}
+		}
+	}
+	return limits
+}
+
+// MinExpireAfter returns the shortest expiration of all the SLAs, the operations that started later
+// can not be expired. It returns zero when the operations do not expire.
+func (c *Config) MinExpireAfter() time.Duration {
+	var min time.Duration
+	for _, sla := range append([]SLA{c.Default}, routes(c)...) {
+		if d := time.Duration(sla.ExpireAfter); d > 0 && (min == 0 || d < min) {
+			min = d
+		}
+	}
+	return min
+}
+
+func routes(c *Config) []SLA {
+	slas := make([]SLA, 0, len(c.Routes))
+	for _, sla := range c.Routes {
+		slas = append(slas, sla)
+	}
+	return slas
+}
+
+// isMonitored returns whether an operation can stay too long in a state, the operations do not leave
+// the expired and the terminal states without a new fact.
+func isMonitored(state State) bool {
+	for _, s := range MonitoredStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package lifecycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const testConfig = `{
+	"default": {"states": {"signed": "24h"}},
+	"routes": {
+		"2-4": {"states": {"signed": "30m"}, "expireAfter": "72h"},
+		"2-*": {"states": {"signed": "1h", "governor_held": "25h"}},
+		"*-4": {"states": {"emitted": "10m"}}
+	}
+}`
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(testConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		source, target sdk.ChainID
+		state          State
+		limit          time.Duration
+	}{
+		{source: sdk.ChainIDEthereum, target: sdk.ChainIDBSC, state: StateSigned, limit: 30 * time.Minute},
+		{source: sdk.ChainIDEthereum, target: sdk.ChainIDSolana, state: StateSigned, limit: time.Hour},
+		{source: sdk.ChainIDEthereum, target: sdk.ChainIDUnset, state: StateGovernorHeld, limit: 25 * time.Hour},
+		{source: sdk.ChainIDSolana, target: sdk.ChainIDBSC, state: StateEmitted, limit: 10 * time.Minute},
+		{source: sdk.ChainIDSolana, target: sdk.ChainIDEthereum, state: StateSigned, limit: 24 * time.Hour},
+		{source: sdk.ChainIDSolana, target: sdk.ChainIDEthereum, state: StateObserved, limit: 0},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.limit, cfg.For(tc.source, tc.target).Limit(tc.state), "%s-%s %s", tc.source, tc.target, tc.state)
+	}
+	assert.Equal(t, 72*time.Hour, time.Duration(cfg.For(sdk.ChainIDEthereum, sdk.ChainIDBSC).ExpireAfter))
+	assert.Equal(t, map[State]time.Duration{
+		StateSigned:       30 * time.Minute,
+		StateGovernorHeld: 25 * time.Hour,
+		StateEmitted:      10 * time.Minute,
+	}, cfg.StateLimits())
+	assert.Equal(t, 72*time.Hour, cfg.MinExpireAfter())
+}
+
+func TestParseConfig_Empty(t *testing.T) {
+	cfg, err := ParseConfig("")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.StateLimits())
+	assert.Zero(t, cfg.MinExpireAfter())
+	assert.Zero(t, cfg.For(sdk.ChainIDEthereum, sdk.ChainIDSolana).Limit(StateSigned))
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	for _, s := range []string{
+		`{"default": {"states": {"signed": "1 hour"}}}`,
+		`{"default": {"states": {"redeemed": "1h"}}}`,
+		`{"routes": {"2-*": {"states": {"unknown": "1h"}}}}`,
+	} {
+		_, err := ParseConfig(s)
+		assert.Error(t, err, s)
+	}
+}
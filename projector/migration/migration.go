@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wormhole-foundation/wormhole-explorer/projector/operation"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TODO: move this to migration tool that support mongodb.
+func Run(db *mongo.Database) error {
+	// Created operations collection.
+	err := db.CreateCollection(context.TODO(), operation.OperationsCollection)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create the indexes of the fields the operations are searched, filtered and sorted by.
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "txHash", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "usdValue", Value: -1}}},
+		{Keys: bson.D{{Key: "originTx.timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "originTx.from", Value: 1}}},
+		{Keys: bson.D{{Key: "originTx.nativeTxHash", Value: 1}}},
+		{Keys: bson.D{{Key: "originTx.attribute.value.originTxHash", Value: 1}}},
+		{Keys: bson.D{{Key: "destinationTx.txHash", Value: 1}}},
+		{Keys: bson.D{{Key: "standardizedProperties.appIds", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "standardizedProperties.fromChain", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "standardizedProperties.toChain", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "standardizedProperties.toAddress", Value: 1}}},
+		{Keys: bson.D{{Key: "lifecycle.state", Value: 1}, {Key: "lifecycle.startedAt", Value: 1}}},
+		{Keys: bson.D{{Key: "lifecycle.state", Value: 1}, {Key: "lifecycle.enteredAt", Value: 1}}},
+	}
+	_, err = db.Collection(operation.OperationsCollection).Indexes().CreateMany(context.TODO(), indexes)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create the index of the observations of a message, the lifecycle is observed at the first one.
+	indexObservationsByMessageID := mongo.IndexModel{Keys: bson.D{{Key: "messageId", Value: 1}, {Key: "indexedAt", Value: 1}}}
+	_, err = db.Collection(operation.ObservationsCollection).Indexes().CreateOne(context.TODO(), indexObservationsByMessageID)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func isNotAlreadyExistsError(err error) bool {
+	target := &mongo.CommandError{}
+	isCommandError := errors.As(err, target)
+	if !isCommandError || err.(mongo.CommandError).Code != 48 {
+		return true
+	}
+	return false
+}
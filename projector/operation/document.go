@@ -0,0 +1,317 @@
+// Package operation maintains the `operations` collection, a read model with one denormalized
+// document per VAA built from the documents the other services store about it.
+package operation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/lifecycle"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Status represents the status of an operation.
+type Status string
+
+const (
+	// StatusInProgress indicates that the message was published on the emitter chain but the VAA was not signed yet.
+	// It is the status of the origin transaction stored by the tx-tracker.
+	StatusInProgress = Status(domain.SourceTxStatusInProgress)
+	// StatusPending indicates that the VAA was signed but it was not redeemed on the destination chain yet.
+	StatusPending Status = "pending"
+	// StatusCompleted indicates that the VAA was redeemed on the destination chain.
+	StatusCompleted Status = "completed"
+	// StatusFailed indicates that the redeem transaction on the destination chain failed.
+	StatusFailed Status = "failed"
+)
+
+// Operation models a document in the `operations` collection.
+type Operation struct {
+	ID           string      `bson:"_id"`
+	EmitterChain sdk.ChainID `bson:"emitterChain"`
+	EmitterAddr  string      `bson:"emitterAddr"`
+	Sequence     string      `bson:"sequence"`
+	// TxHash is the hash of the transaction that emitted the VAA.
+	TxHash    string     `bson:"txHash,omitempty"`
+	Timestamp *time.Time `bson:"timestamp"`
+	Status    Status     `bson:"status"`
+	// Vaa is the document of the `vaas` collection, it is empty while the operation is in progress.
+	Vaa bson.Raw `bson:"vaa,omitempty"`
+	// Payload and StandardizedProperties come from the `parsedVaa` collection.
+	Payload                bson.RawValue `bson:"payload,omitempty"`
+	StandardizedProperties bson.RawValue `bson:"standardizedProperties,omitempty"`
+	// Symbol, TokenAmount and UsdAmount come from the `transferPrices` collection.
+	Symbol      string `bson:"symbol,omitempty"`
+	TokenAmount string `bson:"tokenAmount,omitempty"`
+	UsdAmount   string `bson:"usdAmount,omitempty"`
+	// UsdValue is UsdAmount as a number, so that operations can be filtered and sorted by value.
+	UsdValue float64 `bson:"usdValue,omitempty"`
+	// OriginTx and DestinationTx come from the `globalTransactions` collection.
+	OriginTx      bson.RawValue `bson:"originTx,omitempty"`
+	DestinationTx bson.RawValue `bson:"destinationTx,omitempty"`
+	// Lifecycle is computed from all the sources, see the lifecycle package.
+	Lifecycle *lifecycle.Lifecycle `bson:"lifecycle,omitempty"`
+	UpdatedAt time.Time            `bson:"updatedAt"`
+}
+
+// Sources are the documents an operation is built from, keyed by the VAA ID in every collection.
+// The documents that were not stored yet are nil.
+type Sources struct {
+	ID                string
+	Vaa               bson.Raw
+	VaaIdTxHash       bson.Raw
+	ParsedVaa         bson.Raw
+	TransferPrices    bson.Raw
+	GlobalTransaction bson.Raw
+	Relay             bson.Raw
+	// ObservedAt is the time the first observation of the message was stored.
+	ObservedAt *time.Time
+	// GovernorHeld indicates that the VAA is enqueued by the governor of a guardian.
+	GovernorHeld bool
+	// Lifecycle is the lifecycle of the stored operation.
+	Lifecycle *lifecycle.Lifecycle
+}
+
+type vaaFields struct {
+	ID           string      `bson:"_id"`
+	EmitterChain sdk.ChainID `bson:"emitterChain"`
+	EmitterAddr  string      `bson:"emitterAddr"`
+	Sequence     string      `bson:"sequence"`
+	TxHash       string      `bson:"txHash"`
+	Timestamp    *time.Time  `bson:"timestamp"`
+	IndexedAt    *time.Time  `bson:"indexedAt"`
+	UpdatedAt    *time.Time  `bson:"updatedAt"`
+}
+
+type transferPricesFields struct {
+	Symbol      string `bson:"symbol"`
+	TokenAmount string `bson:"tokenAmount"`
+	UsdAmount   string `bson:"usdAmount"`
+}
+
+type inProgressFields struct {
+	OriginTx *struct {
+		Status       string     `bson:"status"`
+		NativeTxHash string     `bson:"nativeTxHash"`
+		Timestamp    *time.Time `bson:"timestamp"`
+	} `bson:"originTx"`
+}
+
+type destinationTxFields struct {
+	Status    string     `bson:"status"`
+	Timestamp *time.Time `bson:"timestamp"`
+}
+
+type relayFields struct {
+	Data struct {
+		Status      domain.RelayStatus `bson:"status"`
+		CompletedAt *time.Time         `bson:"completedAt"`
+		FailedAt    *time.Time         `bson:"failedAt"`
+	} `bson:"data"`
+}
+
+// ErrMissingVaa is returned for the sources of a VAA that was not stored yet and whose message
+// was neither published nor observed.
+var ErrMissingVaa = errors.New("missing vaa document")
+
+// NewOperation builds the operation of a VAA from its source documents. Before the VAA is stored,
+// the operation of a published or observed message is built in progress. The expiration of the
+// lifecycle is the one of the route of the operation in the SLA configuration.
+func NewOperation(s *Sources, slas *lifecycle.Config, now time.Time) (*Operation, error) {
+	var signedAt *time.Time
+	var op *Operation
+	if s.Vaa != nil {
+		var v vaaFields
+		if err := bson.Unmarshal(s.Vaa, &v); err != nil {
+			return nil, fmt.Errorf("failed to decode vaa document: %w", err)
+		}
+
+		op = &Operation{
+			ID:           v.ID,
+			EmitterChain: v.EmitterChain,
+			EmitterAddr:  v.EmitterAddr,
+			Sequence:     v.Sequence,
+			TxHash:       v.TxHash,
+			Timestamp:    v.Timestamp,
+			Status:       StatusPending,
+			Vaa:          s.Vaa,
+			UpdatedAt:    now,
+		}
+		// the VAAs stored before the indexedAt field was added are signed at their last update.
+		signedAt = v.IndexedAt
+		if signedAt == nil {
+			signedAt = v.UpdatedAt
+		}
+		if signedAt == nil {
+			signedAt = v.Timestamp
+		}
+	} else {
+		var err error
+		op, err = newInProgressOperation(s, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the txHash of the vaaIdTxHash collection is the one fixed by the pipeline.
+	if s.VaaIdTxHash != nil {
+		if txHash, ok := s.VaaIdTxHash.Lookup("txHash").StringValueOK(); ok && txHash != "" {
+			op.TxHash = txHash
+		}
+	}
+
+	if s.ParsedVaa != nil {
+		op.Payload = lookupDocument(s.ParsedVaa, "parsedPayload")
+		op.StandardizedProperties = lookupDocument(s.ParsedVaa, "standardizedProperties")
+	}
+
+	if s.TransferPrices != nil {
+		var p transferPricesFields
+		if err := bson.Unmarshal(s.TransferPrices, &p); err != nil {
+			return nil, fmt.Errorf("failed to decode transferPrices document: %w", err)
+		}
+		op.Symbol = p.Symbol
+		op.TokenAmount = p.TokenAmount
+		op.UsdAmount = p.UsdAmount
+		if usdValue, err := strconv.ParseFloat(p.UsdAmount, 64); err == nil {
+			op.UsdValue = usdValue
+		}
+	}
+
+	if s.GlobalTransaction != nil {
+		op.OriginTx = lookupDocument(s.GlobalTransaction, "originTx")
+		op.DestinationTx = lookupDocument(s.GlobalTransaction, "destinationTx")
+		if destinationTx, ok := op.DestinationTx.DocumentOK(); ok {
+			if status, ok := destinationTx.Lookup("status").StringValueOK(); ok {
+				op.Status = destinationTxStatus(status)
+			}
+		}
+	}
+
+	facts, err := newFacts(s, op, signedAt)
+	if err != nil {
+		return nil, err
+	}
+	sla := slas.For(op.EmitterChain, toChain(op.StandardizedProperties))
+	op.Lifecycle = lifecycle.Compute(s.Lifecycle, facts, time.Duration(sla.ExpireAfter), now)
+
+	return op, nil
+}
+
+// newInProgressOperation builds the operation of a message before its VAA is stored, from the
+// origin transaction stored by the tx-tracker or from the observations and the governor status
+// stored by fly.
+func newInProgressOperation(s *Sources, now time.Time) (*Operation, error) {
+	var tx inProgressFields
+	if s.GlobalTransaction != nil {
+		if err := bson.Unmarshal(s.GlobalTransaction, &tx); err != nil {
+			return nil, fmt.Errorf("failed to decode globalTransactions document: %w", err)
+		}
+	}
+	published := tx.OriginTx != nil && tx.OriginTx.Status == string(domain.SourceTxStatusInProgress)
+	if !published && s.ObservedAt == nil && !s.GovernorHeld {
+		return nil, ErrMissingVaa
+	}
+
+	// the VAA ID is the message ID, chain/emitter/sequence.
+	parts := strings.Split(s.ID, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid vaa id %s", s.ID)
+	}
+	chainID, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain of vaa id %s: %w", s.ID, err)
+	}
+
+	op := &Operation{
+		ID:           s.ID,
+		EmitterChain: sdk.ChainID(chainID),
+		EmitterAddr:  parts[1],
+		Sequence:     parts[2],
+		Status:       StatusInProgress,
+		UpdatedAt:    now,
+	}
+	if published {
+		op.TxHash = tx.OriginTx.NativeTxHash
+		op.Timestamp = tx.OriginTx.Timestamp
+	}
+	return op, nil
+}
+
+// newFacts returns the lifecycle facts of an operation. The message is emitted at the timestamp of
+// the VAA, or of the origin transaction before the VAA is stored, and redeemed or failed when the
+// destination transaction or the generic relayer delivery is confirmed or failed.
+func newFacts(s *Sources, op *Operation, signedAt *time.Time) (lifecycle.Facts, error) {
+	facts := lifecycle.Facts{
+		EmittedAt:    op.Timestamp,
+		ObservedAt:   s.ObservedAt,
+		GovernorHeld: s.GovernorHeld,
+		SignedAt:     signedAt,
+	}
+
+	if destinationTx, ok := op.DestinationTx.DocumentOK(); ok {
+		var tx destinationTxFields
+		if err := bson.Unmarshal(destinationTx, &tx); err != nil {
+			return facts, fmt.Errorf("failed to decode destinationTx: %w", err)
+		}
+		switch tx.Status {
+		case domain.DstTxStatusConfirmed:
+			facts.RedeemedAt = tx.Timestamp
+		case domain.DstTxStatusFailedToProcess:
+			facts.FailedAt = tx.Timestamp
+		}
+	}
+
+	if s.Relay != nil && facts.RedeemedAt == nil && facts.FailedAt == nil {
+		var r relayFields
+		if err := bson.Unmarshal(s.Relay, &r); err != nil {
+			return facts, fmt.Errorf("failed to decode relays document: %w", err)
+		}
+		switch r.Data.Status {
+		case domain.RelayStatusDelivered, domain.RelayStatusForwardRequested:
+			facts.RedeemedAt = r.Data.CompletedAt
+		case domain.RelayStatusReceiverFailure, domain.RelayStatusRefunded:
+			facts.FailedAt = r.Data.FailedAt
+		}
+	}
+	return facts, nil
+}
+
+// toChain returns the target chain of the standardized properties, unset if it is unknown.
+func toChain(standardizedProperties bson.RawValue) sdk.ChainID {
+	doc, ok := standardizedProperties.DocumentOK()
+	if !ok {
+		return sdk.ChainIDUnset
+	}
+	if chainID, ok := doc.Lookup("toChain").AsInt64OK(); ok {
+		return sdk.ChainID(chainID)
+	}
+	return sdk.ChainIDUnset
+}
+
+// lookupDocument returns the embedded document of a key, null values are ignored.
+func lookupDocument(doc bson.Raw, key string) bson.RawValue {
+	v := doc.Lookup(key)
+	if _, ok := v.DocumentOK(); !ok {
+		return bson.RawValue{}
+	}
+	return v
+}
+
+// destinationTxStatus maps the status of a destination transaction to the status of the operation.
+// A destination transaction removed by a reorg or in an unknown state leaves the operation pending.
+func destinationTxStatus(status string) Status {
+	switch status {
+	case domain.DstTxStatusConfirmed:
+		return StatusCompleted
+	case domain.DstTxStatusFailedToProcess:
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
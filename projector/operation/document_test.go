@@ -0,0 +1,231 @@
+package operation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/lifecycle"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const testVaaID = "2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1000"
+
+func marshal(t *testing.T, doc interface{}) bson.Raw {
+	data, err := bson.Marshal(doc)
+	require.NoError(t, err)
+	return data
+}
+
+var noSLAs = &lifecycle.Config{}
+
+func newTestSources(t *testing.T) *Sources {
+	timestamp := time.Unix(1690000000, 0).UTC()
+	return &Sources{
+		ID: testVaaID,
+		Vaa: marshal(t, bson.M{
+			"_id":          testVaaID,
+			"emitterChain": 2,
+			"emitterAddr":  "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585",
+			"sequence":     "1000",
+			"txHash":       "vaa-tx-hash",
+			"timestamp":    timestamp,
+		}),
+	}
+}
+
+func TestNewOperation_OnlyVaa(t *testing.T) {
+	now := time.Now()
+	op, err := NewOperation(newTestSources(t), noSLAs, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, testVaaID, op.ID)
+	assert.Equal(t, sdk.ChainIDEthereum, op.EmitterChain)
+	assert.Equal(t, "1000", op.Sequence)
+	assert.Equal(t, "vaa-tx-hash", op.TxHash)
+	assert.Equal(t, StatusPending, op.Status)
+	assert.Equal(t, now, op.UpdatedAt)
+	assert.Equal(t, bson.RawValue{}, op.Payload)
+	assert.Equal(t, bson.RawValue{}, op.OriginTx)
+	assert.Zero(t, op.UsdValue)
+}
+
+func TestNewOperation_AllSources(t *testing.T) {
+	s := newTestSources(t)
+	s.VaaIdTxHash = marshal(t, bson.M{"_id": testVaaID, "txHash": "fixed-tx-hash"})
+	s.ParsedVaa = marshal(t, bson.M{
+		"_id":                    testVaaID,
+		"parsedPayload":          bson.M{"payloadType": 1},
+		"standardizedProperties": nil,
+	})
+	s.TransferPrices = marshal(t, bson.M{"_id": testVaaID, "symbol": "USDC", "tokenAmount": "10", "usdAmount": "10.5"})
+	s.GlobalTransaction = marshal(t, bson.M{
+		"_id":           testVaaID,
+		"originTx":      bson.M{"from": "0xabc"},
+		"destinationTx": bson.M{"status": domain.DstTxStatusConfirmed, "txHash": "0xdef"},
+	})
+
+	op, err := NewOperation(s, noSLAs, time.Now())
+	require.NoError(t, err)
+
+	assert.Equal(t, "fixed-tx-hash", op.TxHash)
+	assert.Equal(t, int32(1), op.Payload.Document().Lookup("payloadType").Int32())
+	assert.Equal(t, bson.RawValue{}, op.StandardizedProperties)
+	assert.Equal(t, "USDC", op.Symbol)
+	assert.Equal(t, "10.5", op.UsdAmount)
+	assert.Equal(t, 10.5, op.UsdValue)
+	assert.Equal(t, "0xabc", op.OriginTx.Document().Lookup("from").StringValue())
+	assert.Equal(t, StatusCompleted, op.Status)
+}
+
+func TestNewOperation_Status(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected Status
+	}{
+		{status: domain.DstTxStatusConfirmed, expected: StatusCompleted},
+		{status: domain.DstTxStatusFailedToProcess, expected: StatusFailed},
+		{status: "unknown", expected: StatusPending},
+	}
+	for _, tc := range tests {
+		t.Run(tc.status, func(t *testing.T) {
+			s := newTestSources(t)
+			s.GlobalTransaction = marshal(t, bson.M{"_id": testVaaID, "destinationTx": bson.M{"status": tc.status}})
+
+			op, err := NewOperation(s, noSLAs, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, op.Status)
+		})
+	}
+}
+
+func TestNewOperation_MissingVaa(t *testing.T) {
+	_, err := NewOperation(&Sources{}, noSLAs, time.Now())
+	assert.ErrorIs(t, err, ErrMissingVaa)
+
+	// the destination transaction stored before the VAA does not build an operation.
+	s := &Sources{GlobalTransaction: marshal(t, bson.M{"_id": testVaaID, "destinationTx": bson.M{"status": domain.DstTxStatusConfirmed}})}
+	_, err = NewOperation(s, noSLAs, time.Now())
+	assert.ErrorIs(t, err, ErrMissingVaa)
+}
+
+func TestNewOperation_InProgress(t *testing.T) {
+	blockTime := time.Unix(1690000000, 0).UTC()
+	s := &Sources{
+		ID:        testVaaID,
+		ParsedVaa: marshal(t, bson.M{"_id": testVaaID, "parsedPayload": bson.M{"payloadType": 1}}),
+		GlobalTransaction: marshal(t, bson.M{
+			"_id": testVaaID,
+			"originTx": bson.M{
+				"status":       domain.SourceTxStatusInProgress,
+				"nativeTxHash": "0xabc",
+				"timestamp":    blockTime,
+				"blockNumber":  "10012893",
+			},
+		}),
+	}
+
+	op, err := NewOperation(s, noSLAs, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, testVaaID, op.ID)
+	assert.Equal(t, sdk.ChainIDEthereum, op.EmitterChain)
+	assert.Equal(t, "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585", op.EmitterAddr)
+	assert.Equal(t, "1000", op.Sequence)
+	assert.Equal(t, "0xabc", op.TxHash)
+	assert.Equal(t, blockTime, op.Timestamp.UTC())
+	assert.Equal(t, StatusInProgress, op.Status)
+	assert.Equal(t, int32(1), op.Payload.Document().Lookup("payloadType").Int32())
+	assert.Equal(t, "10012893", op.OriginTx.Document().Lookup("blockNumber").StringValue())
+
+	doc := marshal(t, op)
+	_, err = doc.LookupErr("vaa")
+	assert.Error(t, err)
+
+	// the operation is pending once the VAA is stored.
+	s.Vaa = newTestSources(t).Vaa
+	op, err = NewOperation(s, noSLAs, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, op.Status)
+	assert.Equal(t, "vaa-tx-hash", op.TxHash)
+}
+
+func TestNewOperation_Lifecycle(t *testing.T) {
+	emittedAt := time.Unix(1690000000, 0).UTC()
+	observedAt := emittedAt.Add(time.Minute)
+	signedAt := emittedAt.Add(2 * time.Minute)
+	redeemedAt := emittedAt.Add(time.Hour)
+
+	s := newTestSources(t)
+	s.Vaa = marshal(t, bson.M{
+		"_id":          testVaaID,
+		"emitterChain": 2,
+		"timestamp":    emittedAt,
+		"indexedAt":    signedAt,
+	})
+	s.ObservedAt = &observedAt
+
+	op, err := NewOperation(s, noSLAs, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.StateSigned, op.Lifecycle.State)
+	assert.Equal(t, signedAt, op.Lifecycle.EnteredAt)
+
+	s.GlobalTransaction = marshal(t, bson.M{
+		"_id":           testVaaID,
+		"destinationTx": bson.M{"status": domain.DstTxStatusConfirmed, "timestamp": redeemedAt},
+	})
+	op, err = NewOperation(s, noSLAs, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []lifecycle.Transition{
+		{State: lifecycle.StateEmitted, At: emittedAt},
+		{State: lifecycle.StateObserved, At: observedAt},
+		{State: lifecycle.StateSigned, At: signedAt},
+		{State: lifecycle.StateRedeemed, At: redeemedAt},
+	}, op.Lifecycle.Transitions)
+	assert.Equal(t, emittedAt, op.Lifecycle.StartedAt)
+}
+
+func TestNewOperation_LifecycleRelay(t *testing.T) {
+	failedAt := time.Unix(1690003600, 0).UTC()
+	s := newTestSources(t)
+	s.Relay = marshal(t, bson.M{
+		"_id":  testVaaID,
+		"data": bson.M{"status": domain.RelayStatusReceiverFailure, "failedAt": failedAt},
+	})
+
+	op, err := NewOperation(s, noSLAs, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.StateFailed, op.Lifecycle.State)
+	assert.Equal(t, failedAt, op.Lifecycle.EnteredAt)
+}
+
+func TestNewOperation_Observed(t *testing.T) {
+	observedAt := time.Unix(1690000000, 0).UTC()
+	now := observedAt.Add(48 * time.Hour)
+	slas, err := lifecycle.ParseConfig(`{"routes": {"2-*": {"expireAfter": "24h"}}}`)
+	require.NoError(t, err)
+
+	op, err := NewOperation(&Sources{ID: testVaaID, ObservedAt: &observedAt}, slas, now)
+	require.NoError(t, err)
+	assert.Equal(t, StatusInProgress, op.Status)
+	assert.Equal(t, "1000", op.Sequence)
+	assert.Equal(t, []lifecycle.Transition{
+		{State: lifecycle.StateObserved, At: observedAt},
+		{State: lifecycle.StateExpired, At: observedAt.Add(24 * time.Hour)},
+	}, op.Lifecycle.Transitions)
+
+	// the governor hold is kept after the VAA is released.
+	op, err = NewOperation(&Sources{ID: testVaaID, ObservedAt: &observedAt, GovernorHeld: true}, noSLAs, observedAt)
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.StateGovernorHeld, op.Lifecycle.State)
+
+	s := newTestSources(t)
+	s.ObservedAt = &observedAt
+	s.Lifecycle = op.Lifecycle
+	op, err = NewOperation(s, noSLAs, now)
+	require.NoError(t, err)
+	assert.Equal(t, lifecycle.StateSigned, op.Lifecycle.State)
+	assert.Equal(t, lifecycle.StateGovernorHeld, op.Lifecycle.Transitions[2].State)
+}
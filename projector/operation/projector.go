@@ -0,0 +1,95 @@
+package operation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/projector/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/lifecycle"
+	"go.uber.org/zap"
+)
+
+// Projector builds the operations of VAAs and stores them.
+type Projector struct {
+	repository *Repository
+	slas       *lifecycle.Config
+	metrics    metrics.Metrics
+	logger     *zap.Logger
+}
+
+// NewProjector creates a new projector.
+func NewProjector(repository *Repository, slas *lifecycle.Config, metrics metrics.Metrics, logger *zap.Logger) *Projector {
+	return &Projector{repository: repository, slas: slas, metrics: metrics, logger: logger}
+}
+
+// Project rebuilds the operations of the given VAA IDs from their current source documents.
+// It returns the number of operations written.
+func (p *Projector) Project(ctx context.Context, ids ...string) (int, error) {
+	sources, err := p.repository.FindSources(ctx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	operations := make([]*Operation, 0, len(sources))
+	for id, s := range sources {
+		op, err := NewOperation(s, p.slas, now)
+		if errors.Is(err, ErrMissingVaa) {
+			// the operation is built when the VAA or its published or observed message arrives.
+			continue
+		}
+		if err != nil {
+			p.metrics.IncOperationFailed()
+			p.logger.Error("Error building operation", zap.String("id", id), zap.Error(err))
+			continue
+		}
+		operations = append(operations, op)
+	}
+
+	if err := p.repository.Upsert(ctx, operations); err != nil {
+		for range operations {
+			p.metrics.IncOperationFailed()
+		}
+		return 0, err
+	}
+	for _, op := range operations {
+		p.metrics.IncOperationProjected(uint16(op.EmitterChain))
+	}
+	return len(operations), nil
+}
+
+// RebuildQuery defines the VAAs whose operations are rebuilt.
+type RebuildQuery struct {
+	StartTime time.Time
+	EndTime   time.Time
+	PageSize  int64
+}
+
+// Rebuild rebuilds the operations of the stored VAAs with a timestamp in the query range, one
+// page at a time. It returns the number of operations written.
+func (p *Projector) Rebuild(ctx context.Context, q RebuildQuery) (int, error) {
+	total := 0
+	page := VaaPageQuery{StartTime: q.StartTime, EndTime: q.EndTime, Limit: q.PageSize}
+	for {
+		ids, err := p.repository.FindVaaIDs(ctx, &page)
+		if err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		count, err := p.Project(ctx, ids...)
+		if err != nil {
+			return total, err
+		}
+		total += count
+		page.After = ids[len(ids)-1]
+
+		p.logger.Info("Rebuilt operations page",
+			zap.Int("operations", count),
+			zap.Int("total", total),
+			zap.String("lastId", page.After))
+	}
+}
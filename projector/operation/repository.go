@@ -0,0 +1,348 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/lifecycle"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Collection names.
+const (
+	OperationsCollection         = "operations"
+	VaasCollection               = "vaas"
+	VaaIdTxHashCollection        = "vaaIdTxHash"
+	ParsedVaaCollection          = "parsedVaa"
+	TransferPricesCollection     = "transferPrices"
+	GlobalTransactionsCollection = "globalTransactions"
+	RelaysCollection             = "relays"
+	ObservationsCollection       = "observations"
+	governorStatusCollection     = "governorStatus"
+	checkpointsCollection        = "projectorCheckpoints"
+)
+
+// SourceCollections are the collections the operations are built from.
+var SourceCollections = []string{
+	VaasCollection,
+	VaaIdTxHashCollection,
+	ParsedVaaCollection,
+	TransferPricesCollection,
+	GlobalTransactionsCollection,
+	RelaysCollection,
+}
+
+// Repository exposes operations over the `operations` collection and its sources.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		operations     *mongo.Collection
+		vaas           *mongo.Collection
+		observations   *mongo.Collection
+		governorStatus *mongo.Collection
+		checkpoints    *mongo.Collection
+	}
+}
+
+// NewRepository creates a new operations repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	r := &Repository{db: db, logger: logger.With(zap.String("module", "OperationRepository"))}
+	r.collections.operations = db.Collection(OperationsCollection)
+	r.collections.vaas = db.Collection(VaasCollection)
+	r.collections.observations = db.Collection(ObservationsCollection)
+	r.collections.governorStatus = db.Collection(governorStatusCollection)
+	r.collections.checkpoints = db.Collection(checkpointsCollection)
+	return r
+}
+
+type sourceDocument struct {
+	ID string `bson:"_id"`
+}
+
+// FindSources returns the source documents of the given VAA IDs. The sources of the VAAs that are
+// not stored yet are returned without the VAA document.
+func (r *Repository) FindSources(ctx context.Context, ids []string) (map[string]*Sources, error) {
+	sources, err := r.findSourceDocuments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.findObservedAt(ctx, ids, sources); err != nil {
+		return nil, err
+	}
+	if err := r.findGovernorHeld(ctx, sources); err != nil {
+		return nil, err
+	}
+	if err := r.findLifecycles(ctx, sources); err != nil {
+		return nil, err
+	}
+	for id, s := range sources {
+		s.ID = id
+	}
+	return sources, nil
+}
+
+// findSourceDocuments returns the documents of the source collections keyed by the VAA ID.
+func (r *Repository) findSourceDocuments(ctx context.Context, ids []string) (map[string]*Sources, error) {
+	sources := make(map[string]*Sources, len(ids))
+	filter := bson.M{"_id": bson.M{"$in": ids}}
+
+	for _, name := range SourceCollections {
+		cur, err := r.db.Collection(name).Find(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find %s documents", name)
+		}
+		for cur.Next(ctx) {
+			var doc sourceDocument
+			if err := cur.Decode(&doc); err != nil {
+				cur.Close(ctx)
+				return nil, errors.Wrapf(err, "failed to decode %s document", name)
+			}
+			raw := make(bson.Raw, len(cur.Current))
+			copy(raw, cur.Current)
+
+			if name == VaasCollection {
+				sources[doc.ID] = &Sources{Vaa: raw}
+				continue
+			}
+			s, ok := sources[doc.ID]
+			if !ok {
+				s = &Sources{}
+				sources[doc.ID] = s
+			}
+			switch name {
+			case VaaIdTxHashCollection:
+				s.VaaIdTxHash = raw
+			case ParsedVaaCollection:
+				s.ParsedVaa = raw
+			case TransferPricesCollection:
+				s.TransferPrices = raw
+			case GlobalTransactionsCollection:
+				s.GlobalTransaction = raw
+			case RelaysCollection:
+				s.Relay = raw
+			}
+		}
+		if err := cur.Err(); err != nil {
+			cur.Close(ctx)
+			return nil, errors.Wrapf(err, "failed to iterate %s documents", name)
+		}
+		cur.Close(ctx)
+	}
+	return sources, nil
+}
+
+// findObservedAt sets the time the first observation of each message was stored.
+func (r *Repository) findObservedAt(ctx context.Context, ids []string, sources map[string]*Sources) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "messageId", Value: bson.M{"$in": ids}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$messageId"},
+			{Key: "observedAt", Value: bson.M{"$min": "$indexedAt"}},
+		}}},
+	}
+	cur, err := r.collections.observations.Aggregate(ctx, pipeline)
+	if err != nil {
+		return errors.Wrap(err, "failed to aggregate observations")
+	}
+	var rows []struct {
+		ID         string     `bson:"_id"`
+		ObservedAt *time.Time `bson:"observedAt"`
+	}
+	if err := cur.All(ctx, &rows); err != nil {
+		return errors.Wrap(err, "failed to decode observations")
+	}
+	for _, row := range rows {
+		s, ok := sources[row.ID]
+		if !ok {
+			s = &Sources{}
+			sources[row.ID] = s
+		}
+		s.ObservedAt = row.ObservedAt
+	}
+	return nil
+}
+
+// findGovernorHeld sets whether the governor of a guardian holds the VAAs that are not stored yet.
+func (r *Repository) findGovernorHeld(ctx context.Context, sources map[string]*Sources) error {
+	var conditions bson.A
+	for id, s := range sources {
+		if s.Vaa != nil {
+			continue
+		}
+		parts := strings.Split(id, "/")
+		if len(parts) != 3 {
+			continue
+		}
+		chainID, err := strconv.ParseUint(parts[0], 10, 16)
+		if err != nil {
+			continue
+		}
+		// the emitter address is stored as published by the guardians.
+		conditions = append(conditions, bson.M{"parsedStatus.chains": bson.M{"$elemMatch": bson.M{
+			"chainid": chainID,
+			"emitters": bson.M{"$elemMatch": bson.M{
+				"emitteraddress":        bson.M{"$in": bson.A{parts[1], "0x" + parts[1]}},
+				"enqueuedvaas.sequence": parts[2],
+			}},
+		}}})
+	}
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	cur, err := r.collections.governorStatus.Find(ctx, bson.M{"$or": conditions})
+	if err != nil {
+		return errors.Wrap(err, "failed to find governorStatus documents")
+	}
+	var docs []struct {
+		ParsedStatus struct {
+			Chains []struct {
+				ChainID  uint32 `bson:"chainid"`
+				Emitters []struct {
+					EmitterAddress string `bson:"emitteraddress"`
+					EnqueuedVaas   []struct {
+						Sequence string `bson:"sequence"`
+					} `bson:"enqueuedvaas"`
+				} `bson:"emitters"`
+			} `bson:"chains"`
+		} `bson:"parsedStatus"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return errors.Wrap(err, "failed to decode governorStatus documents")
+	}
+	for _, doc := range docs {
+		for _, c := range doc.ParsedStatus.Chains {
+			for _, e := range c.Emitters {
+				emitter := strings.ToLower(strings.TrimPrefix(e.EmitterAddress, "0x"))
+				for _, v := range e.EnqueuedVaas {
+					id := fmt.Sprintf("%d/%s/%s", c.ChainID, emitter, v.Sequence)
+					if s, ok := sources[id]; ok {
+						s.GovernorHeld = true
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// findLifecycles sets the lifecycle of the stored operations.
+func (r *Repository) findLifecycles(ctx context.Context, sources map[string]*Sources) error {
+	ids := make([]string, 0, len(sources))
+	for id := range sources {
+		ids = append(ids, id)
+	}
+	opts := options.Find().SetProjection(bson.M{"lifecycle": 1})
+	cur, err := r.collections.operations.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return errors.Wrap(err, "failed to find operations")
+	}
+	var docs []struct {
+		ID        string               `bson:"_id"`
+		Lifecycle *lifecycle.Lifecycle `bson:"lifecycle"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return errors.Wrap(err, "failed to decode operations")
+	}
+	for _, doc := range docs {
+		sources[doc.ID].Lifecycle = doc.Lifecycle
+	}
+	return nil
+}
+
+// IsObserved returns whether the lifecycle of an operation has an observation.
+func (r *Repository) IsObserved(ctx context.Context, id string) (bool, error) {
+	filter := bson.D{
+		{Key: "_id", Value: id},
+		{Key: "lifecycle.transitions.state", Value: lifecycle.StateObserved},
+	}
+	count, err := r.collections.operations.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// Upsert replaces the documents of the operations.
+func (r *Repository) Upsert(ctx context.Context, operations []*Operation) error {
+	if len(operations) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(operations))
+	for _, op := range operations {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": op.ID}).
+			SetReplacement(op).
+			SetUpsert(true))
+	}
+	_, err := r.collections.operations.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// VaaPageQuery defines a page of VAA IDs ordered by ID.
+type VaaPageQuery struct {
+	StartTime time.Time
+	EndTime   time.Time
+	// After is the last ID of the previous page.
+	After string
+	Limit int64
+}
+
+// FindVaaIDs returns a page of the IDs of the VAAs with a timestamp in the query range.
+func (r *Repository) FindVaaIDs(ctx context.Context, q *VaaPageQuery) ([]string, error) {
+	filter := bson.D{
+		{Key: "timestamp", Value: bson.M{"$gte": q.StartTime, "$lte": q.EndTime}},
+	}
+	if q.After != "" {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.M{"$gt": q.After}})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(q.Limit).
+		SetProjection(bson.M{"_id": 1})
+
+	cur, err := r.collections.vaas.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []sourceDocument
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+type checkpoint struct {
+	ID          string    `bson:"_id"`
+	ResumeToken bson.Raw  `bson:"resumeToken"`
+	UpdatedAt   time.Time `bson:"updatedAt"`
+}
+
+// GetResumeToken returns the last change stream token processed by the projector, nil if there is none.
+func (r *Repository) GetResumeToken(ctx context.Context, name string) (bson.Raw, error) {
+	var c checkpoint
+	err := r.collections.checkpoints.FindOne(ctx, bson.M{"_id": name}).Decode(&c)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.ResumeToken, nil
+}
+
+// SaveResumeToken stores the last change stream token processed by the projector.
+func (r *Repository) SaveResumeToken(ctx context.Context, name string, token bson.Raw) error {
+	c := checkpoint{ID: name, ResumeToken: token, UpdatedAt: time.Now()}
+	_, err := r.collections.checkpoints.ReplaceOne(ctx, bson.M{"_id": name}, c, options.Replace().SetUpsert(true))
+	return err
+}
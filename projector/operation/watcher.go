@@ -0,0 +1,226 @@
+package operation
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/projector/internal/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+const (
+	checkpointName = "operations"
+	// reconnectDelay is the time to wait before reconnecting a closed change stream.
+	reconnectDelay = 5 * time.Second
+	// observationsDebounce is the time the observations of a message already projected are ignored.
+	observationsDebounce = time.Minute
+)
+
+// Watcher projects the operations of the VAAs whose source documents change.
+type Watcher struct {
+	db         *mongo.Database
+	projector  *Projector
+	repository *Repository
+	metrics    metrics.Metrics
+	logger     *zap.Logger
+	observed   *recentMessages
+	mu         sync.RWMutex
+	err        error
+}
+
+type changeEvent struct {
+	DocumentKey struct {
+		ID string `bson:"_id"`
+	} `bson:"documentKey"`
+	Namespace struct {
+		Collection string `bson:"coll"`
+	} `bson:"ns"`
+}
+
+// NewWatcher creates a new database change watcher.
+func NewWatcher(db *mongo.Database, projector *Projector, repository *Repository, metrics metrics.Metrics, logger *zap.Logger) *Watcher {
+	return &Watcher{
+		db:         db,
+		projector:  projector,
+		repository: repository,
+		metrics:    metrics,
+		logger:     logger,
+		observed:   newRecentMessages(observationsDebounce),
+	}
+}
+
+// Start watches the changes of the source collections until the context is cancelled. The watcher
+// resumes after the last change projected, so no change is lost while the projector is stopped or
+// while the change stream is reconnected.
+func (w *Watcher) Start(ctx context.Context) error {
+	stream, err := w.watch(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for {
+			err := w.consume(ctx, stream)
+			if ctx.Err() != nil {
+				return
+			}
+			w.setErr(err)
+			w.logger.Error("Change stream closed, reconnecting from the last resume token", zap.Error(err))
+			for stream = nil; stream == nil; {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(reconnectDelay):
+				}
+				if stream, err = w.watch(ctx); err != nil {
+					w.setErr(err)
+					w.logger.Error("Error reconnecting change stream", zap.Error(err))
+				}
+			}
+			w.setErr(nil)
+		}
+	}()
+	return nil
+}
+
+// Check is a health check that fails while the change stream is closed.
+func (w *Watcher) Check(ctx context.Context) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.err != nil {
+		return errors.Wrap(w.err, "change stream closed")
+	}
+	return nil
+}
+
+func (w *Watcher) setErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
+
+// watch opens a change stream on the source collections after the last resume token saved.
+func (w *Watcher) watch(ctx context.Context) (*mongo.ChangeStream, error) {
+	namespaces := bson.A{}
+	for _, name := range SourceCollections {
+		namespaces = append(namespaces, bson.D{{Key: "db", Value: w.db.Name()}, {Key: "coll", Value: name}})
+	}
+	// every guardian stores an observation of a message and updates it when it is received again,
+	// only the new observations can change the operation.
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": bson.A{
+			bson.D{
+				{Key: "operationType", Value: bson.M{"$in": bson.A{"insert", "update", "replace"}}},
+				{Key: "ns", Value: bson.M{"$in": namespaces}},
+			},
+			bson.D{
+				{Key: "operationType", Value: "insert"},
+				{Key: "ns", Value: bson.D{{Key: "db", Value: w.db.Name()}, {Key: "coll", Value: ObservationsCollection}}},
+			},
+		}}}},
+		{{Key: "$project", Value: bson.D{{Key: "documentKey", Value: 1}, {Key: "ns", Value: 1}}}},
+	}
+
+	opts := options.ChangeStream()
+	token, err := w.repository.GetResumeToken(ctx, checkpointName)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		opts.SetResumeAfter(token)
+	}
+	return w.db.Watch(ctx, pipeline, opts)
+}
+
+// consume projects the changes of the stream until it is closed. The resume token is only saved
+// after the change is projected, so a change whose projection fails is received again when the
+// stream is reconnected.
+func (w *Watcher) consume(ctx context.Context, stream *mongo.ChangeStream) error {
+	defer stream.Close(context.Background())
+	for stream.Next(ctx) {
+		var e changeEvent
+		if err := stream.Decode(&e); err != nil {
+			w.logger.Error("Error unmarshalling change event", zap.Error(err))
+		} else {
+			w.metrics.IncChangeFromMongoStream(e.Namespace.Collection)
+			if err := w.project(ctx, &e); err != nil {
+				return errors.Wrapf(err, "error projecting operation %s from %s", e.DocumentKey.ID, e.Namespace.Collection)
+			}
+		}
+		if err := w.repository.SaveResumeToken(ctx, checkpointName, stream.ResumeToken()); err != nil {
+			w.logger.Error("Error saving resume token", zap.Error(err))
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	return errors.New("change stream invalidated")
+}
+
+// project projects the operation of a changed document. Every guardian stores an observation of a
+// message, so the operation is only projected for the first observation and the observations of
+// the messages projected recently are ignored.
+func (w *Watcher) project(ctx context.Context, e *changeEvent) error {
+	id := e.DocumentKey.ID
+	if e.Namespace.Collection == ObservationsCollection {
+		// the observation ID is the message ID followed by the guardian address and the hash.
+		parts := strings.Split(id, "/")
+		if len(parts) < 3 {
+			w.logger.Warn("Invalid observation id", zap.String("id", id))
+			return nil
+		}
+		id = strings.Join(parts[:3], "/")
+		if w.observed.contains(id, time.Now()) {
+			return nil
+		}
+		observed, err := w.repository.IsObserved(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !observed {
+			if _, err := w.projector.Project(ctx, id); err != nil {
+				return err
+			}
+		}
+		w.observed.add(id, time.Now())
+		return nil
+	}
+	_, err := w.projector.Project(ctx, id)
+	return err
+}
+
+// recentMessages remembers the IDs of the messages added in the last ttl. It is only used by the
+// goroutine of the watcher.
+type recentMessages struct {
+	ttl       time.Duration
+	addedAt   map[string]time.Time
+	removedAt time.Time
+}
+
+func newRecentMessages(ttl time.Duration) *recentMessages {
+	return &recentMessages{ttl: ttl, addedAt: make(map[string]time.Time)}
+}
+
+// contains returns true if the message was added in the last ttl.
+func (r *recentMessages) contains(id string, now time.Time) bool {
+	addedAt, ok := r.addedAt[id]
+	return ok && now.Sub(addedAt) <= r.ttl
+}
+
+// add adds a message, the messages older than ttl are removed at most once per ttl.
+func (r *recentMessages) add(id string, now time.Time) {
+	if now.Sub(r.removedAt) > r.ttl {
+		for k, addedAt := range r.addedAt {
+			if now.Sub(addedAt) > r.ttl {
+				delete(r.addedAt, k)
+			}
+		}
+		r.removedAt = now
+	}
+	r.addedAt[id] = now
+}
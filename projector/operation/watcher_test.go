@@ -0,0 +1,23 @@
+package operation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentMessages(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	r := newRecentMessages(time.Minute)
+
+	assert.False(t, r.contains(testVaaID, now))
+	r.add(testVaaID, now)
+	assert.True(t, r.contains(testVaaID, now.Add(time.Minute)))
+	assert.False(t, r.contains(testVaaID, now.Add(2*time.Minute)))
+
+	// the expired messages are removed when a message is added.
+	r.add("1/0000000000000000000000000000000000000000000000000000000000000001/1", now.Add(2*time.Minute))
+	assert.NotContains(t, r.addedAt, testVaaID)
+	assert.Len(t, r.addedAt, 1)
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/config"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/job"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/migration"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// CreateParams are the command-line arguments of a job creation.
+type CreateParams struct {
+	VaaIDs         []string
+	EmitterChain   *int
+	EmitterAddress string
+	From           string
+	To             string
+	AppID          string
+	MissingStage   string
+	Stages         []string
+	RateLimit      int
+}
+
+// Create stores a pending job, it is executed by the running service.
+func Create(cfg *config.CliConfiguration, params *CreateParams) {
+	run(cfg, func(ctx context.Context, repository *job.Repository, logger *zap.Logger) (any, error) {
+		selection, stages, err := params.toSelection()
+		if err != nil {
+			return nil, err
+		}
+		j, err := job.NewJob(*selection, stages, params.RateLimit)
+		if err != nil {
+			return nil, err
+		}
+		if err := repository.Create(ctx, j); err != nil {
+			return nil, err
+		}
+		logger.Info("Job created", zap.String("jobId", j.ID))
+		return j, nil
+	})
+}
+
+// Get prints a job and its progress.
+func Get(cfg *config.CliConfiguration, id string) {
+	run(cfg, func(ctx context.Context, repository *job.Repository, _ *zap.Logger) (any, error) {
+		return repository.FindById(ctx, id)
+	})
+}
+
+// Errors prints the VAAs of a job that could not be processed.
+func Errors(cfg *config.CliConfiguration, id string, limit int64) {
+	run(cfg, func(ctx context.Context, repository *job.Repository, _ *zap.Logger) (any, error) {
+		return repository.FindErrors(ctx, id, 0, limit)
+	})
+}
+
+// Pause pauses a pending or running job.
+func Pause(cfg *config.CliConfiguration, id string) {
+	run(cfg, func(ctx context.Context, repository *job.Repository, _ *zap.Logger) (any, error) {
+		return repository.Pause(ctx, id)
+	})
+}
+
+// Resume resumes a paused job.
+func Resume(cfg *config.CliConfiguration, id string) {
+	run(cfg, func(ctx context.Context, repository *job.Repository, _ *zap.Logger) (any, error) {
+		return repository.Resume(ctx, id)
+	})
+}
+
+// Cancel cancels a job that is not finished.
+func Cancel(cfg *config.CliConfiguration, id string) {
+	run(cfg, func(ctx context.Context, repository *job.Repository, _ *zap.Logger) (any, error) {
+		return repository.Cancel(ctx, id)
+	})
+}
+
+// run connects to the database, executes the command and prints its result as JSON.
+func run(cfg *config.CliConfiguration, command func(context.Context, *job.Repository, *zap.Logger) (any, error)) {
+
+	rootCtx := context.Background()
+
+	logger := logger.New("wormhole-explorer-reprocessor", logger.WithLevel(cfg.LogLevel))
+
+	//setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	// run the database migration.
+	err = migration.Run(db.Database)
+	if err != nil {
+		logger.Fatal("error running migration", zap.Error(err))
+	}
+
+	result, err := command(rootCtx, job.NewRepository(db.Database, logger), logger)
+	if err != nil {
+		logger.Error("Failed to execute command", zap.Error(err))
+		return
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		logger.Error("Failed to print result", zap.Error(err))
+	}
+}
+
+func (p *CreateParams) toSelection() (*job.Selection, []job.Stage, error) {
+	selection := job.Selection{
+		VaaIDs:         p.VaaIDs,
+		EmitterAddress: p.EmitterAddress,
+		AppID:          p.AppID,
+		MissingStage:   job.Stage(p.MissingStage),
+	}
+	if p.EmitterChain != nil {
+		chainID := sdk.ChainID(*p.EmitterChain)
+		selection.EmitterChain = &chainID
+	}
+	if p.From != "" {
+		from, err := time.Parse(time.RFC3339, p.From)
+		if err != nil {
+			return nil, nil, err
+		}
+		selection.From = &from
+	}
+	if p.To != "" {
+		to, err := time.Parse(time.RFC3339, p.To)
+		if err != nil {
+			return nil, nil, err
+		}
+		selection.To = &to
+	}
+
+	stages := make([]job.Stage, 0, len(p.Stages))
+	for _, name := range p.Stages {
+		stage, err := job.ParseStage(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		stages = append(stages, stage)
+	}
+	return &selection, stages, nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/cmd/cli"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/cmd/service"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/config"
+)
+
+func main() {
+	execute()
+}
+
+func execute() error {
+	root := &cobra.Command{
+		Use: "reprocessor",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				service.Run()
+			}
+		},
+	}
+
+	addServiceCommand(root)
+	addJobCommand(root)
+
+	return root.Execute()
+}
+
+func addServiceCommand(root *cobra.Command) {
+	serviceCommand := &cobra.Command{
+		Use:   "service",
+		Short: "Run reprocessor as service",
+		Run: func(_ *cobra.Command, _ []string) {
+			service.Run()
+		},
+	}
+	root.AddCommand(serviceCommand)
+}
+
+func addJobCommand(root *cobra.Command) {
+	var cfg config.CliConfiguration
+
+	jobCommand := &cobra.Command{
+		Use:   "job",
+		Short: "Manage the reprocess jobs",
+	}
+	jobCommand.PersistentFlags().StringVar(&cfg.LogLevel, "log-level", "INFO", "log level")
+	jobCommand.PersistentFlags().StringVar(&cfg.MongoURI, "mongo-uri", "", "Mongo connection")
+	jobCommand.PersistentFlags().StringVar(&cfg.MongoDatabase, "mongo-database", "", "Mongo database")
+	jobCommand.MarkPersistentFlagRequired("mongo-uri")
+	jobCommand.MarkPersistentFlagRequired("mongo-database")
+
+	addJobCreateCommand(jobCommand, &cfg)
+
+	var errorsLimit int64
+	errorsCommand := &cobra.Command{
+		Use:   "errors <job-id>",
+		Short: "Print the VAAs of a job that could not be processed",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			cli.Errors(&cfg, args[0], errorsLimit)
+		},
+	}
+	errorsCommand.Flags().Int64Var(&errorsLimit, "limit", 100, "maximum number of errors printed")
+	jobCommand.AddCommand(errorsCommand)
+
+	commands := []struct {
+		use   string
+		short string
+		run   func(*config.CliConfiguration, string)
+	}{
+		{use: "get <job-id>", short: "Print a job and its progress", run: cli.Get},
+		{use: "pause <job-id>", short: "Pause a pending or running job", run: cli.Pause},
+		{use: "resume <job-id>", short: "Resume a paused job", run: cli.Resume},
+		{use: "cancel <job-id>", short: "Cancel a job that is not finished", run: cli.Cancel},
+	}
+	for _, c := range commands {
+		run := c.run
+		jobCommand.AddCommand(&cobra.Command{
+			Use:   c.use,
+			Short: c.short,
+			Args:  cobra.ExactArgs(1),
+			Run: func(_ *cobra.Command, args []string) {
+				run(&cfg, args[0])
+			},
+		})
+	}
+
+	root.AddCommand(jobCommand)
+}
+
+func addJobCreateCommand(jobCommand *cobra.Command, cfg *config.CliConfiguration) {
+	var params cli.CreateParams
+	var emitterChain int
+
+	createCommand := &cobra.Command{
+		Use:   "create",
+		Short: "Create a job to reprocess the selected VAAs in the given stages",
+		Run: func(cmd *cobra.Command, _ []string) {
+			if cmd.Flags().Changed("emitter-chain") {
+				params.EmitterChain = &emitterChain
+			}
+			cli.Create(cfg, &params)
+		},
+	}
+	createCommand.Flags().StringSliceVar(&params.VaaIDs, "vaa-ids", nil, "comma-separated VAA IDs to reprocess")
+	createCommand.Flags().IntVar(&emitterChain, "emitter-chain", 0, "emitter chain of the VAAs")
+	createCommand.Flags().StringVar(&params.EmitterAddress, "emitter-address", "", "emitter address of the VAAs, requires the emitter chain")
+	createCommand.Flags().StringVar(&params.From, "from", "", "minimum VAA timestamp (RFC3339)")
+	createCommand.Flags().StringVar(&params.To, "to", "", "maximum VAA timestamp, exclusive (RFC3339)")
+	createCommand.Flags().StringVar(&params.AppID, "app-id", "", "app ID of the parsed VAAs")
+	createCommand.Flags().StringVar(&params.MissingStage, "missing-stage", "", "select the VAAs without the document of the stage (parser, tx-tracker or analytics)")
+	createCommand.Flags().StringSliceVar(&params.Stages, "stages", nil, "comma-separated stages to execute (parser, tx-tracker, analytics)")
+	createCommand.Flags().IntVar(&params.RateLimit, "rate-limit", 0, "maximum number of VAAs processed per second, 0 means no limit")
+
+	createCommand.MarkFlagRequired("stages")
+
+	jobCommand.AddCommand(createCommand)
+}
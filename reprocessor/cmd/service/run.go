@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/config"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/http/infrastructure"
+	httpJob "github.com/wormhole-foundation/wormhole-explorer/reprocessor/http/job"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/job"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/migration"
+	"go.uber.org/zap"
+)
+
+type exitCode int
+
+func handleExit() {
+	if r := recover(); r != nil {
+		if e, ok := r.(exitCode); ok {
+			os.Exit(int(e))
+		}
+		panic(r) // not an Exit, bubble up
+	}
+}
+
+// Run starts the reprocessor with the configuration from the environment and blocks until a
+// termination signal is received.
+func Run() {
+
+	defer handleExit()
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+
+	config, err := config.New(rootCtx)
+	if err != nil {
+		log.Fatal("Error creating config", err)
+	}
+
+	logger := logger.New("wormhole-explorer-reprocessor", logger.WithLevel(config.LogLevel))
+
+	stop := Start(rootCtx, config, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("Finished wormhole-explorer-reprocessor")
+}
+
+// Start starts the job runner and http server with the given configuration. The runner runs until
+// rootCtx is cancelled and the returned function releases the remaining resources.
+func Start(rootCtx context.Context, config *config.ServiceConfiguration, logger *zap.Logger) (stop func()) {
+
+	logger.Info("Starting wormhole-explorer-reprocessor ...")
+
+	// setup DB connection
+	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+	}
+
+	// run the database migration.
+	err = migration.Run(db.Database)
+	if err != nil {
+		logger.Fatal("error running migration", zap.Error(err))
+	}
+
+	// create a metrics
+	metrics := newMetrics(config)
+
+	// create and start the job runner.
+	repository := job.NewRepository(db.Database, logger)
+	runner := job.NewRunner(repository, newStageProcessors(config), config.PageSize, config.PollInterval, metrics, logger)
+	if err := runner.Start(rootCtx); err != nil {
+		logger.Fatal("failed to start job runner", zap.Error(err))
+	}
+
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, httpJob.NewController(repository, logger), health.Mongo(db.Database))
+	server.Start()
+
+	logger.Info("Started wormhole-explorer-reprocessor")
+
+	return func() {
+		logger.Info("closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+
+		logger.Info("Closing Http server ...")
+		server.Stop()
+	}
+}
+
+// Creates a metrics depending on whether the execution is local (dummy metrics) or not (Prometheus metrics)
+func newMetrics(cfg *config.ServiceConfiguration) metrics.Metrics {
+	if !cfg.MetricsEnabled {
+		return metrics.NewDummyMetrics()
+	}
+	return metrics.NewPrometheusMetrics(cfg.Environment)
+}
+
+// Creates the processors of the stages whose service URL is configured.
+func newStageProcessors(cfg *config.ServiceConfiguration) map[job.Stage]job.StageProcessor {
+	urls := map[job.Stage]string{
+		job.StageParser:    cfg.ParserURL,
+		job.StageTxTracker: cfg.TxTrackerURL,
+		job.StageAnalytics: cfg.AnalyticsURL,
+	}
+	processors := make(map[job.Stage]job.StageProcessor)
+	for stage, url := range urls {
+		if url != "" {
+			processors[stage] = job.NewHttpStageProcessor(stage, url, cfg.RequestTimeout)
+		}
+	}
+	return processors
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// ServiceConfiguration represents the application configuration when running as service with default values.
+type ServiceConfiguration struct {
+	Environment    string `env:"ENVIRONMENT,required"`
+	LogLevel       string `env:"LOG_LEVEL,default=INFO"`
+	Port           string `env:"PORT,default=8000"`
+	MongoURI       string `env:"MONGODB_URI,required"`
+	MongoDatabase  string `env:"MONGODB_DATABASE,required"`
+	PprofEnabled   bool   `env:"PPROF_ENABLED,default=false"`
+	MetricsEnabled bool   `env:"METRICS_ENABLED,default=false"`
+	// base URLs of the stage services, the jobs with a stage without URL fail.
+	ParserURL      string        `env:"PARSER_URL"`
+	TxTrackerURL   string        `env:"TX_TRACKER_URL"`
+	AnalyticsURL   string        `env:"ANALYTICS_URL"`
+	RequestTimeout time.Duration `env:"REQUEST_TIMEOUT,default=30s"`
+	PageSize       int64         `env:"PAGE_SIZE,default=100"`
+	PollInterval   time.Duration `env:"POLL_INTERVAL,default=10s"`
+}
+
+// CliConfiguration represents the application configuration when managing the jobs from the command line.
+type CliConfiguration struct {
+	LogLevel      string
+	MongoURI      string
+	MongoDatabase string
+}
+
+// New creates a configuration with the values from .env file and environment variables.
+func New(ctx context.Context) (*ServiceConfiguration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration ServiceConfiguration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"github.com/ansrivas/fiberprometheus/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/http/job"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	app    *fiber.App
+	port   string
+	logger *zap.Logger
+}
+
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, jobController *job.Controller, checks ...health.Check) *Server {
+	ctrl := health.NewController(checks, logger)
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	// config use of middlware.
+	prometheus := fiberprometheus.New("wormscan-reprocessor")
+	prometheus.RegisterAt(app, "/metrics")
+	app.Use(prometheus.Middleware)
+
+	if pprofEnabled {
+		app.Use(pprof.New())
+	}
+
+	api := app.Group("/api")
+	api.Get("/health", ctrl.HealthCheck)
+	api.Get("/ready", ctrl.ReadyCheck)
+
+	// admin endpoints of the reprocess jobs.
+	jobs := api.Group("/jobs")
+	jobs.Post("/", jobController.Create)
+	jobs.Get("/", jobController.FindAll)
+	jobs.Get("/:id", jobController.FindById)
+	jobs.Get("/:id/errors", jobController.FindErrors)
+	jobs.Post("/:id/pause", jobController.Pause)
+	jobs.Post("/:id/resume", jobController.Resume)
+	jobs.Post("/:id/cancel", jobController.Cancel)
+
+	return &Server{
+		app:    app,
+		port:   port,
+		logger: logger,
+	}
+}
+
+// Start listen serves HTTP requests from addr.
+func (s *Server) Start() {
+	go func() {
+		s.app.Listen(":" + s.port)
+	}()
+}
+
+// Stop gracefull server.
+func (s *Server) Stop() {
+	_ = s.app.Shutdown()
+}
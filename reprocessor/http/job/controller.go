@@ -0,0 +1,141 @@
+package job
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/job"
+	"go.uber.org/zap"
+)
+
+const maxPageSize = 1000
+
+// Controller exposes the admin endpoints of the reprocess jobs.
+type Controller struct {
+	repository *job.Repository
+	logger     *zap.Logger
+}
+
+// NewController creates a Controller instance.
+func NewController(repository *job.Repository, logger *zap.Logger) *Controller {
+	return &Controller{repository: repository, logger: logger}
+}
+
+// CreateRequest is the body of a job creation request.
+type CreateRequest struct {
+	Selection job.Selection `json:"selection"`
+	Stages    []job.Stage   `json:"stages"`
+	RateLimit int           `json:"rateLimit"`
+}
+
+// Create creates a pending job, it is executed asynchronously by the runner.
+func (c *Controller) Create(ctx *fiber.Ctx) error {
+	var payload CreateRequest
+	if err := ctx.BodyParser(&payload); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	j, err := job.NewJob(payload.Selection, payload.Stages, payload.RateLimit)
+	if err != nil {
+		return toHttpError(err)
+	}
+	if err := c.repository.Create(ctx.Context(), j); err != nil {
+		c.logger.Error("Error creating job", zap.Error(err))
+		return err
+	}
+
+	c.logger.Info("Job created from endpoint", zap.String("jobId", j.ID))
+	return ctx.Status(fiber.StatusCreated).JSON(j)
+}
+
+// FindAll returns the jobs sorted from the newest, optionally filtered by status.
+func (c *Controller) FindAll(ctx *fiber.Ctx) error {
+	skip, limit, err := getPagination(ctx)
+	if err != nil {
+		return err
+	}
+	jobs, err := c.repository.FindAll(ctx.Context(), job.Status(ctx.Query("status")), skip, limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(struct {
+		Jobs []*job.Job `json:"jobs"`
+	}{Jobs: jobs})
+}
+
+// FindById returns a job and its progress.
+func (c *Controller) FindById(ctx *fiber.Ctx) error {
+	j, err := c.repository.FindById(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return toHttpError(err)
+	}
+	return ctx.JSON(j)
+}
+
+// FindErrors returns the VAAs of a job that could not be processed.
+func (c *Controller) FindErrors(ctx *fiber.Ctx) error {
+	skip, limit, err := getPagination(ctx)
+	if err != nil {
+		return err
+	}
+	itemErrors, err := c.repository.FindErrors(ctx.Context(), ctx.Params("id"), skip, limit)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(struct {
+		Errors []*job.ItemError `json:"errors"`
+	}{Errors: itemErrors})
+}
+
+// Pause pauses a pending or running job.
+func (c *Controller) Pause(ctx *fiber.Ctx) error {
+	j, err := c.repository.Pause(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return toHttpError(err)
+	}
+	return ctx.JSON(j)
+}
+
+// Resume resumes a paused job.
+func (c *Controller) Resume(ctx *fiber.Ctx) error {
+	j, err := c.repository.Resume(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return toHttpError(err)
+	}
+	return ctx.JSON(j)
+}
+
+// Cancel cancels a job that is not finished.
+func (c *Controller) Cancel(ctx *fiber.Ctx) error {
+	j, err := c.repository.Cancel(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return toHttpError(err)
+	}
+	return ctx.JSON(j)
+}
+
+func getPagination(ctx *fiber.Ctx) (int64, int64, error) {
+	page, err := strconv.ParseInt(ctx.Query("page", "0"), 10, 64)
+	if err != nil || page < 0 {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "invalid page")
+	}
+	pageSize, err := strconv.ParseInt(ctx.Query("pageSize", "50"), 10, 64)
+	if err != nil || pageSize <= 0 || pageSize > maxPageSize {
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "invalid pageSize")
+	}
+	return page * pageSize, pageSize, nil
+}
+
+func toHttpError(err error) error {
+	switch {
+	case errors.Is(err, job.ErrInvalidJob):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, job.ErrNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case errors.Is(err, job.ErrInvalidTransition):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	default:
+		return err
+	}
+}
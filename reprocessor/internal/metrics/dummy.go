@@ -0,0 +1,19 @@
+package metrics
+
+// DummyMetrics is a dummy implementation of Metric interface.
+type DummyMetrics struct {
+}
+
+// NewDummyMetrics returns a new instance of DummyMetrics.
+func NewDummyMetrics() *DummyMetrics {
+	return &DummyMetrics{}
+}
+
+// IncItemProcessed increments the number of VAAs processed by a stage.
+func (m *DummyMetrics) IncItemProcessed(stage string) {}
+
+// IncItemFailed increments the number of VAAs that failed in a stage.
+func (m *DummyMetrics) IncItemFailed(stage string) {}
+
+// IncJobFinished increments the number of jobs finished by status.
+func (m *DummyMetrics) IncJobFinished(status string) {}
@@ -0,0 +1,10 @@
+package metrics
+
+const serviceName = "wormscan-reprocessor"
+
+// Metrics is a metrics interface.
+type Metrics interface {
+	IncItemProcessed(stage string)
+	IncItemFailed(stage string)
+	IncJobFinished(status string)
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusMetrics is a metrics implementation for Prometheus.
+type PrometheusMetrics struct {
+	itemCount *prometheus.CounterVec
+	jobCount  *prometheus.CounterVec
+}
+
+// NewPrometheusMetrics creates a new PrometheusMetrics.
+func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+	constLabels := map[string]string{
+		"environment": environment,
+		"service":     serviceName,
+	}
+
+	itemCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "reprocess_item_count_by_stage",
+			Help:        "Total number of VAAs reprocessed by stage",
+			ConstLabels: constLabels,
+		}, []string{"stage", "type"})
+
+	jobCount := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "reprocess_job_count_by_status",
+			Help:        "Total number of reprocess jobs finished by status",
+			ConstLabels: constLabels,
+		}, []string{"status"})
+
+	return &PrometheusMetrics{
+		itemCount: itemCount,
+		jobCount:  jobCount,
+	}
+}
+
+// IncItemProcessed increments the number of VAAs processed by a stage.
+func (m *PrometheusMetrics) IncItemProcessed(stage string) {
+	m.itemCount.WithLabelValues(stage, "processed").Inc()
+}
+
+// IncItemFailed increments the number of VAAs that failed in a stage.
+func (m *PrometheusMetrics) IncItemFailed(stage string) {
+	m.itemCount.WithLabelValues(stage, "failed").Inc()
+}
+
+// IncJobFinished increments the number of jobs finished by status.
+func (m *PrometheusMetrics) IncJobFinished(status string) {
+	m.jobCount.WithLabelValues(status).Inc()
+}
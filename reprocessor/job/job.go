@@ -0,0 +1,187 @@
+// Package job defines the reprocess jobs and executes them against the services that process the VAAs.
+package job
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Stage is a service that processes a VAA.
+type Stage string
+
+const (
+	// StageParser parses the payload of the VAA and stores it in the parsedVaa collection.
+	StageParser Stage = "parser"
+	// StageTxTracker searches the origin transaction of the VAA and stores it in the globalTransactions collection.
+	StageTxTracker Stage = "tx-tracker"
+	// StageAnalytics pushes the metrics of the VAA and stores the notional of the transfer in the transferPrices collection.
+	StageAnalytics Stage = "analytics"
+)
+
+// Stages are the stages in the order they are executed. The analytics stage reads the documents stored
+// by the parser, so it must run after it.
+var Stages = []Stage{StageParser, StageTxTracker, StageAnalytics}
+
+// Status is the status of a job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusPaused    Status = "paused"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Errors returned when a job is created or its status changes.
+var (
+	ErrInvalidJob        = errors.New("invalid job")
+	ErrNotFound          = errors.New("job not found")
+	ErrInvalidTransition = errors.New("invalid job status transition")
+)
+
+// Selection describes the VAAs processed by a job. All the criteria set must match.
+type Selection struct {
+	VaaIDs         []string     `bson:"vaaIds,omitempty" json:"vaaIds,omitempty"`
+	EmitterChain   *sdk.ChainID `bson:"emitterChain,omitempty" json:"emitterChain,omitempty"`
+	EmitterAddress string       `bson:"emitterAddress,omitempty" json:"emitterAddress,omitempty"`
+	From           *time.Time   `bson:"from,omitempty" json:"from,omitempty"`
+	To             *time.Time   `bson:"to,omitempty" json:"to,omitempty"`
+	AppID          string       `bson:"appId,omitempty" json:"appId,omitempty"`
+	// MissingStage selects the VAAs that have no document stored by the stage.
+	MissingStage Stage `bson:"missingStage,omitempty" json:"missingStage,omitempty"`
+}
+
+// Progress is the progress of a job.
+type Progress struct {
+	Processed int64 `bson:"processed" json:"processed"`
+	Failed    int64 `bson:"failed" json:"failed"`
+	// Cursor is the ID of the last VAA processed, the job continues after it when it is resumed.
+	Cursor string `bson:"cursor" json:"cursor"`
+}
+
+// Job is a request to reprocess the VAAs of a selection in the target stages.
+type Job struct {
+	ID        string    `bson:"_id" json:"id"`
+	Selection Selection `bson:"selection" json:"selection"`
+	Stages    []Stage   `bson:"stages" json:"stages"`
+	// RateLimit is the maximum number of VAAs processed per second, zero means no limit.
+	RateLimit  int        `bson:"rateLimit" json:"rateLimit"`
+	Status     Status     `bson:"status" json:"status"`
+	Progress   Progress   `bson:"progress" json:"progress"`
+	Error      string     `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt  time.Time  `bson:"createdAt" json:"createdAt"`
+	UpdatedAt  time.Time  `bson:"updatedAt" json:"updatedAt"`
+	StartedAt  *time.Time `bson:"startedAt,omitempty" json:"startedAt,omitempty"`
+	FinishedAt *time.Time `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+}
+
+// ItemError is the error of a VAA that could not be processed by a stage.
+type ItemError struct {
+	ID        string    `bson:"_id" json:"-"`
+	JobID     string    `bson:"jobId" json:"jobId"`
+	VaaID     string    `bson:"vaaId" json:"vaaId"`
+	Stage     Stage     `bson:"stage" json:"stage"`
+	Error     string    `bson:"error" json:"error"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// NewJob validates the selection and the stages and creates a pending job.
+func NewJob(selection Selection, stages []Stage, rateLimit int) (*Job, error) {
+	if err := selection.validate(); err != nil {
+		return nil, err
+	}
+	sorted, err := sortStages(stages)
+	if err != nil {
+		return nil, err
+	}
+	if rateLimit < 0 {
+		return nil, fmt.Errorf("%w: rate limit must not be negative", ErrInvalidJob)
+	}
+
+	selection.EmitterAddress = normalizeAddress(selection.EmitterAddress)
+	now := time.Now()
+	return &Job{
+		ID:        primitive.NewObjectID().Hex(),
+		Selection: selection,
+		Stages:    sorted,
+		RateLimit: rateLimit,
+		Status:    StatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// IsFinished returns true if the job can not be executed anymore.
+func (j *Job) IsFinished() bool {
+	return j.Status == StatusCompleted || j.Status == StatusFailed || j.Status == StatusCancelled
+}
+
+// ParseStage returns the stage with the given name.
+func ParseStage(name string) (Stage, error) {
+	for _, s := range Stages {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown stage %s", ErrInvalidJob, name)
+}
+
+func (s *Selection) validate() error {
+	if len(s.VaaIDs) == 0 && s.EmitterChain == nil && s.EmitterAddress == "" && s.From == nil &&
+		s.To == nil && s.AppID == "" && s.MissingStage == "" {
+		return fmt.Errorf("%w: the selection is empty", ErrInvalidJob)
+	}
+	if s.EmitterAddress != "" && s.EmitterChain == nil {
+		return fmt.Errorf("%w: the emitter address requires the emitter chain", ErrInvalidJob)
+	}
+	if s.From != nil && s.To != nil && !s.From.Before(*s.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidJob)
+	}
+	if s.MissingStage != "" {
+		if _, err := ParseStage(string(s.MissingStage)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortStages removes the duplicated stages and sorts them in execution order.
+func sortStages(stages []Stage) ([]Stage, error) {
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("%w: at least one stage is required", ErrInvalidJob)
+	}
+	set := make(map[Stage]bool, len(stages))
+	for _, s := range stages {
+		if _, err := ParseStage(string(s)); err != nil {
+			return nil, err
+		}
+		set[s] = true
+	}
+	sorted := make([]Stage, 0, len(set))
+	for s := range set {
+		sorted = append(sorted, s)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return stageOrder(sorted[i]) < stageOrder(sorted[j]) })
+	return sorted, nil
+}
+
+func stageOrder(stage Stage) int {
+	for i, s := range Stages {
+		if s == stage {
+			return i
+		}
+	}
+	return len(Stages)
+}
+
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.TrimPrefix(address, "0x"))
+}
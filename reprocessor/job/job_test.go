@@ -0,0 +1,51 @@
+package job
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestNewJob(t *testing.T) {
+	chainID := sdk.ChainIDEthereum
+	selection := Selection{EmitterChain: &chainID, EmitterAddress: "0x0000000000000000000000003EE18B2214AFF97000D974CF647E7C347E8FA585"}
+
+	job, err := NewJob(selection, []Stage{StageAnalytics, StageParser, StageAnalytics}, 10)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, job.ID)
+	assert.Equal(t, StatusPending, job.Status)
+	assert.Equal(t, []Stage{StageParser, StageAnalytics}, job.Stages)
+	assert.Equal(t, "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585", job.Selection.EmitterAddress)
+	assert.Equal(t, 10, job.RateLimit)
+}
+
+func TestNewJob_Invalid(t *testing.T) {
+	chainID := sdk.ChainIDSolana
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		selection Selection
+		stages    []Stage
+		rateLimit int
+	}{
+		{name: "empty selection", selection: Selection{}, stages: []Stage{StageParser}},
+		{name: "no stages", selection: Selection{VaaIDs: []string{"1/a/1"}}},
+		{name: "unknown stage", selection: Selection{VaaIDs: []string{"1/a/1"}}, stages: []Stage{"notional"}},
+		{name: "unknown missing stage", selection: Selection{MissingStage: "notional"}, stages: []Stage{StageParser}},
+		{name: "emitter address without chain", selection: Selection{EmitterAddress: "abc"}, stages: []Stage{StageParser}},
+		{name: "from after to", selection: Selection{EmitterChain: &chainID, From: &from, To: &to}, stages: []Stage{StageParser}},
+		{name: "negative rate limit", selection: Selection{VaaIDs: []string{"1/a/1"}}, stages: []Stage{StageParser}, rateLimit: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJob(tt.selection, tt.stages, tt.rateLimit)
+			assert.True(t, errors.Is(err, ErrInvalidJob), "unexpected error %v", err)
+		})
+	}
+}
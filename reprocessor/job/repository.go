@@ -0,0 +1,204 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository exposes operations over the reprocess jobs and the VAAs they select.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		jobs      *mongo.Collection
+		jobErrors *mongo.Collection
+		vaas      *mongo.Collection
+	}
+}
+
+// NewRepository creates a new jobs repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	r := &Repository{db: db, logger: logger.With(zap.String("module", "JobRepository"))}
+	r.collections.jobs = db.Collection(JobsCollection)
+	r.collections.jobErrors = db.Collection(JobErrorsCollection)
+	r.collections.vaas = db.Collection(VaasCollection)
+	return r
+}
+
+// Create stores a new job.
+func (r *Repository) Create(ctx context.Context, job *Job) error {
+	_, err := r.collections.jobs.InsertOne(ctx, job)
+	return err
+}
+
+// FindById returns the job with the given ID.
+func (r *Repository) FindById(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	err := r.collections.jobs.FindOne(ctx, bson.M{"_id": id}).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find job")
+	}
+	return &job, nil
+}
+
+// FindAll returns the jobs sorted from the newest, optionally filtered by status.
+func (r *Repository) FindAll(ctx context.Context, status Status, skip, limit int64) ([]*Job, error) {
+	filter := bson.D{}
+	if status != "" {
+		filter = append(filter, bson.E{Key: "status", Value: status})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetSkip(skip).SetLimit(limit)
+	cur, err := r.collections.jobs.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find jobs")
+	}
+	jobs := []*Job{}
+	if err := cur.All(ctx, &jobs); err != nil {
+		return nil, errors.Wrap(err, "failed to decode jobs")
+	}
+	return jobs, nil
+}
+
+// FindErrors returns the item errors of a job.
+func (r *Repository) FindErrors(ctx context.Context, jobID string, skip, limit int64) ([]*ItemError, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "vaaId", Value: 1}}).SetSkip(skip).SetLimit(limit)
+	cur, err := r.collections.jobErrors.Find(ctx, bson.M{"jobId": jobID}, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find job errors")
+	}
+	itemErrors := []*ItemError{}
+	if err := cur.All(ctx, &itemErrors); err != nil {
+		return nil, errors.Wrap(err, "failed to decode job errors")
+	}
+	return itemErrors, nil
+}
+
+// UpdateStatus changes the status of a job if its current status is one of the given ones.
+func (r *Repository) UpdateStatus(ctx context.Context, id string, from []Status, to Status) (*Job, error) {
+	filter := bson.M{"_id": id, "status": bson.M{"$in": from}}
+	update := bson.M{"$set": bson.M{"status": to, "updatedAt": time.Now()}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var job Job
+	err := r.collections.jobs.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		if _, err := r.FindById(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidTransition
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update job status")
+	}
+	return &job, nil
+}
+
+// Claim marks the oldest pending job as running and returns it, it returns nil if there is no pending job.
+func (r *Repository) Claim(ctx context.Context) (*Job, error) {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"status": StatusRunning, "updatedAt": now},
+		"$min": bson.M{"startedAt": now},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job Job
+	err := r.collections.jobs.FindOneAndUpdate(ctx, bson.M{"status": StatusPending}, update, opts).Decode(&job)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim job")
+	}
+	return &job, nil
+}
+
+// ReleaseRunning sets back to pending the jobs that were running when the service stopped, so they
+// continue from their cursor.
+func (r *Repository) ReleaseRunning(ctx context.Context) error {
+	_, err := r.collections.jobs.UpdateMany(ctx,
+		bson.M{"status": StatusRunning},
+		bson.M{"$set": bson.M{"status": StatusPending, "updatedAt": time.Now()}})
+	return err
+}
+
+// SaveProgress adds the processed and failed items of a page to the progress of a job and moves its cursor.
+func (r *Repository) SaveProgress(ctx context.Context, id string, cursor string, processed, failed int64) error {
+	update := bson.M{
+		"$set": bson.M{"progress.cursor": cursor, "updatedAt": time.Now()},
+		"$inc": bson.M{"progress.processed": processed, "progress.failed": failed},
+	}
+	_, err := r.collections.jobs.UpdateByID(ctx, id, update)
+	return err
+}
+
+// Finish sets the final status of a running job. The status is not changed if the job was paused or
+// cancelled meanwhile.
+func (r *Repository) Finish(ctx context.Context, id string, status Status, cause error) error {
+	now := time.Now()
+	set := bson.M{"status": status, "updatedAt": now, "finishedAt": now}
+	if cause != nil {
+		set["error"] = cause.Error()
+	}
+	_, err := r.collections.jobs.UpdateOne(ctx, bson.M{"_id": id, "status": StatusRunning}, bson.M{"$set": set})
+	return err
+}
+
+// AddError stores the error of a VAA, it replaces the previous error of the VAA in the same job.
+func (r *Repository) AddError(ctx context.Context, jobID, vaaID string, stage Stage, cause error) error {
+	itemError := ItemError{
+		ID:        jobID + "/" + vaaID,
+		JobID:     jobID,
+		VaaID:     vaaID,
+		Stage:     stage,
+		Error:     cause.Error(),
+		CreatedAt: time.Now(),
+	}
+	_, err := r.collections.jobErrors.ReplaceOne(ctx, bson.M{"_id": itemError.ID}, itemError, options.Replace().SetUpsert(true))
+	return err
+}
+
+// FindVaaIDs returns the IDs of the next page of VAAs of the selection after the cursor.
+func (r *Repository) FindVaaIDs(ctx context.Context, selection *Selection, cursor string, limit int64) ([]string, error) {
+	cur, err := r.collections.vaas.Aggregate(ctx, buildPipeline(selection, cursor, limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find vaas")
+	}
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, errors.Wrap(err, "failed to decode vaas")
+	}
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
+
+// Pause stops a pending or running job, the runner stops it after the page in progress.
+func (r *Repository) Pause(ctx context.Context, id string) (*Job, error) {
+	return r.UpdateStatus(ctx, id, []Status{StatusPending, StatusRunning}, StatusPaused)
+}
+
+// Resume sets a paused job back to pending, it continues after the last VAA processed.
+func (r *Repository) Resume(ctx context.Context, id string) (*Job, error) {
+	return r.UpdateStatus(ctx, id, []Status{StatusPaused}, StatusPending)
+}
+
+// Cancel stops a job that is not finished, a cancelled job can not be resumed.
+func (r *Repository) Cancel(ctx context.Context, id string) (*Job, error) {
+	return r.UpdateStatus(ctx, id, []Status{StatusPending, StatusRunning, StatusPaused}, StatusCancelled)
+}
@@ -0,0 +1,182 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/internal/metrics"
+	"go.uber.org/ratelimit"
+	"go.uber.org/zap"
+)
+
+// store is the part of the repository used by the runner.
+type store interface {
+	FindById(ctx context.Context, id string) (*Job, error)
+	Claim(ctx context.Context) (*Job, error)
+	ReleaseRunning(ctx context.Context) error
+	SaveProgress(ctx context.Context, id string, cursor string, processed, failed int64) error
+	Finish(ctx context.Context, id string, status Status, cause error) error
+	AddError(ctx context.Context, jobID, vaaID string, stage Stage, cause error) error
+	FindVaaIDs(ctx context.Context, selection *Selection, cursor string, limit int64) ([]string, error)
+}
+
+// Runner executes the pending jobs one at a time.
+type Runner struct {
+	repository   store
+	processors   map[Stage]StageProcessor
+	pageSize     int64
+	pollInterval time.Duration
+	metrics      metrics.Metrics
+	logger       *zap.Logger
+}
+
+// NewRunner creates a new job runner. The jobs with a stage without processor fail.
+func NewRunner(repository *Repository, processors map[Stage]StageProcessor, pageSize int64, pollInterval time.Duration, metrics metrics.Metrics, logger *zap.Logger) *Runner {
+	return &Runner{
+		repository:   repository,
+		processors:   processors,
+		pageSize:     pageSize,
+		pollInterval: pollInterval,
+		metrics:      metrics,
+		logger:       logger.With(zap.String("module", "JobRunner")),
+	}
+}
+
+// Start executes the pending jobs until the context is cancelled. The jobs that were running when the
+// service stopped are executed again from their cursor.
+func (r *Runner) Start(ctx context.Context) error {
+	if err := r.repository.ReleaseRunning(ctx); err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+		for {
+			r.runPending(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// runPending executes the pending jobs until there is none left.
+func (r *Runner) runPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := r.repository.Claim(ctx)
+		if err != nil {
+			r.logger.Error("Error claiming job", zap.Error(err))
+			return
+		}
+		if job == nil {
+			return
+		}
+		r.run(ctx, job)
+	}
+}
+
+// run processes the pages of VAAs of a job until the selection is exhausted, the job is paused or
+// cancelled, or the context is cancelled.
+func (r *Runner) run(ctx context.Context, job *Job) {
+	logger := r.logger.With(zap.String("jobId", job.ID))
+	logger.Info("Running job", zap.Any("stages", job.Stages), zap.String("cursor", job.Progress.Cursor))
+
+	for _, stage := range job.Stages {
+		if _, ok := r.processors[stage]; !ok {
+			r.finish(ctx, logger, job, StatusFailed, fmt.Errorf("stage %s is not configured", stage))
+			return
+		}
+	}
+
+	limiter := ratelimit.NewUnlimited()
+	if job.RateLimit > 0 {
+		limiter = ratelimit.New(job.RateLimit, ratelimit.Per(time.Second))
+	}
+
+	cursor := job.Progress.Cursor
+	for {
+		// the status is read on every page to stop the job when it is paused or cancelled.
+		current, err := r.repository.FindById(ctx, job.ID)
+		if err != nil {
+			logger.Error("Error reading job status", zap.Error(err))
+			return
+		}
+		if current.Status != StatusRunning {
+			logger.Info("Job stopped", zap.String("status", string(current.Status)))
+			return
+		}
+
+		ids, err := r.repository.FindVaaIDs(ctx, &job.Selection, cursor, r.pageSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.finish(ctx, logger, job, StatusFailed, err)
+			}
+			return
+		}
+		if len(ids) == 0 {
+			r.finish(ctx, logger, job, StatusCompleted, nil)
+			return
+		}
+
+		var processed, failed int64
+		for _, id := range ids {
+			limiter.Take()
+			if ctx.Err() != nil {
+				break
+			}
+			if !r.process(ctx, logger, job, id) {
+				failed++
+			}
+			processed++
+			cursor = id
+		}
+
+		// the progress is saved with a fresh context so the page processed is not lost on shutdown.
+		if processed > 0 {
+			if err := r.repository.SaveProgress(context.Background(), job.ID, cursor, processed, failed); err != nil {
+				logger.Error("Error saving job progress", zap.Error(err))
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// process executes the stages of the job in order for a VAA. The remaining stages are skipped when a
+// stage fails, because they depend on its result.
+func (r *Runner) process(ctx context.Context, logger *zap.Logger, job *Job, vaaID string) bool {
+	for _, stage := range job.Stages {
+		err := r.processors[stage].Process(ctx, vaaID)
+		if err == nil {
+			r.metrics.IncItemProcessed(string(stage))
+			continue
+		}
+
+		r.metrics.IncItemFailed(string(stage))
+		logger.Debug("Error processing vaa", zap.String("vaaId", vaaID), zap.String("stage", string(stage)), zap.Error(err))
+		if err := r.repository.AddError(ctx, job.ID, vaaID, stage, err); err != nil {
+			logger.Error("Error saving item error", zap.String("vaaId", vaaID), zap.Error(err))
+		}
+		return false
+	}
+	return true
+}
+
+func (r *Runner) finish(ctx context.Context, logger *zap.Logger, job *Job, status Status, cause error) {
+	if err := r.repository.Finish(ctx, job.ID, status, cause); err != nil {
+		logger.Error("Error finishing job", zap.Error(err))
+		return
+	}
+	r.metrics.IncJobFinished(string(status))
+	if cause != nil {
+		logger.Error("Job failed", zap.Error(cause))
+		return
+	}
+	logger.Info("Job finished", zap.String("status", string(status)))
+}
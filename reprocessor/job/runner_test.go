@@ -0,0 +1,152 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/internal/metrics"
+	"go.uber.org/zap"
+)
+
+type fakeStore struct {
+	job       *Job
+	vaaIDs    []string
+	errors    map[string]Stage
+	finished  Status
+	cause     error
+	pauseAt   string
+	pageCalls int
+}
+
+func (s *fakeStore) FindById(_ context.Context, _ string) (*Job, error) {
+	return s.job, nil
+}
+
+func (s *fakeStore) Claim(_ context.Context) (*Job, error) {
+	return nil, nil
+}
+
+func (s *fakeStore) ReleaseRunning(_ context.Context) error {
+	return nil
+}
+
+func (s *fakeStore) SaveProgress(_ context.Context, _ string, cursor string, processed, failed int64) error {
+	s.job.Progress.Cursor = cursor
+	s.job.Progress.Processed += processed
+	s.job.Progress.Failed += failed
+	if cursor == s.pauseAt {
+		s.job.Status = StatusPaused
+	}
+	return nil
+}
+
+func (s *fakeStore) Finish(_ context.Context, _ string, status Status, cause error) error {
+	s.finished = status
+	s.cause = cause
+	return nil
+}
+
+func (s *fakeStore) AddError(_ context.Context, _, vaaID string, stage Stage, _ error) error {
+	s.errors[vaaID] = stage
+	return nil
+}
+
+func (s *fakeStore) FindVaaIDs(_ context.Context, _ *Selection, cursor string, limit int64) ([]string, error) {
+	s.pageCalls++
+	ids := []string{}
+	for _, id := range s.vaaIDs {
+		if id > cursor && int64(len(ids)) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+type fakeProcessor struct {
+	stage    Stage
+	calls    *[]string
+	failures map[string]bool
+}
+
+func (p *fakeProcessor) Process(_ context.Context, vaaID string) error {
+	*p.calls = append(*p.calls, string(p.stage)+":"+vaaID)
+	if p.failures[vaaID] {
+		return errors.New("processing failed")
+	}
+	return nil
+}
+
+func newTestRunner(store *fakeStore, processors map[Stage]StageProcessor) *Runner {
+	return &Runner{
+		repository: store,
+		processors: processors,
+		pageSize:   2,
+		metrics:    metrics.NewDummyMetrics(),
+		logger:     zap.NewNop(),
+	}
+}
+
+func TestRunner_Run(t *testing.T) {
+	var calls []string
+	store := &fakeStore{
+		job:    &Job{ID: "job", Stages: []Stage{StageParser, StageAnalytics}, Status: StatusRunning},
+		vaaIDs: []string{"1/a/1", "1/a/2", "1/a/3"},
+		errors: map[string]Stage{},
+	}
+	runner := newTestRunner(store, map[Stage]StageProcessor{
+		StageParser:    &fakeProcessor{stage: StageParser, calls: &calls, failures: map[string]bool{"1/a/2": true}},
+		StageAnalytics: &fakeProcessor{stage: StageAnalytics, calls: &calls},
+	})
+
+	runner.run(context.Background(), store.job)
+
+	// the analytics stage is skipped for the VAA that failed in the parser.
+	assert.Equal(t, []string{"parser:1/a/1", "analytics:1/a/1", "parser:1/a/2", "parser:1/a/3", "analytics:1/a/3"}, calls)
+	assert.Equal(t, map[string]Stage{"1/a/2": StageParser}, store.errors)
+	assert.Equal(t, Progress{Processed: 3, Failed: 1, Cursor: "1/a/3"}, store.job.Progress)
+	assert.Equal(t, StatusCompleted, store.finished)
+}
+
+func TestRunner_RunPaused(t *testing.T) {
+	var calls []string
+	store := &fakeStore{
+		job:     &Job{ID: "job", Stages: []Stage{StageParser}, Status: StatusRunning},
+		vaaIDs:  []string{"1/a/1", "1/a/2", "1/a/3"},
+		errors:  map[string]Stage{},
+		pauseAt: "1/a/2",
+	}
+	runner := newTestRunner(store, map[Stage]StageProcessor{
+		StageParser: &fakeProcessor{stage: StageParser, calls: &calls},
+	})
+
+	runner.run(context.Background(), store.job)
+
+	assert.Equal(t, []string{"parser:1/a/1", "parser:1/a/2"}, calls)
+	assert.Equal(t, "1/a/2", store.job.Progress.Cursor)
+	assert.Equal(t, Status(""), store.finished)
+
+	// the job continues after the cursor when it is resumed.
+	store.job.Status = StatusRunning
+	runner.run(context.Background(), store.job)
+
+	assert.Equal(t, []string{"parser:1/a/1", "parser:1/a/2", "parser:1/a/3"}, calls)
+	assert.Equal(t, StatusCompleted, store.finished)
+}
+
+func TestRunner_RunStageNotConfigured(t *testing.T) {
+	store := &fakeStore{
+		job:    &Job{ID: "job", Stages: []Stage{StageParser, StageTxTracker}, Status: StatusRunning},
+		errors: map[string]Stage{},
+	}
+	runner := newTestRunner(store, map[Stage]StageProcessor{
+		StageParser: &fakeProcessor{stage: StageParser, calls: &[]string{}},
+	})
+
+	runner.run(context.Background(), store.job)
+
+	assert.Equal(t, StatusFailed, store.finished)
+	assert.EqualError(t, store.cause, "stage tx-tracker is not configured")
+	assert.Equal(t, 0, store.pageCalls)
+}
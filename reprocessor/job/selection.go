@@ -0,0 +1,98 @@
+package job
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Collection names.
+const (
+	JobsCollection               = "reprocessJobs"
+	JobErrorsCollection          = "reprocessJobErrors"
+	VaasCollection               = "vaas"
+	ParsedVaaCollection          = "parsedVaa"
+	GlobalTransactionsCollection = "globalTransactions"
+	TransferPricesCollection     = "transferPrices"
+)
+
+// stageOutput is the document a stage stores for a VAA. A VAA is missing the stage when the
+// collection has no document with the VAA ID containing the field.
+type stageOutput struct {
+	collection string
+	field      string
+}
+
+var stageOutputs = map[Stage]stageOutput{
+	StageParser: {collection: ParsedVaaCollection, field: "_id"},
+	// the globalTransactions documents are also created by the contract-watcher with the destination tx only.
+	StageTxTracker: {collection: GlobalTransactionsCollection, field: "originTx"},
+	// only the token transfers with a known token price have a transferPrices document.
+	StageAnalytics: {collection: TransferPricesCollection, field: "_id"},
+}
+
+// buildPipeline returns the aggregation over the vaas collection that returns the IDs of the next
+// page of VAAs of the selection after the cursor.
+func buildPipeline(s *Selection, cursor string, limit int64) mongo.Pipeline {
+	match := bson.D{}
+	ids := bson.D{}
+	if len(s.VaaIDs) > 0 {
+		ids = append(ids, bson.E{Key: "$in", Value: s.VaaIDs})
+	}
+	if cursor != "" {
+		ids = append(ids, bson.E{Key: "$gt", Value: cursor})
+	}
+	if len(ids) > 0 {
+		match = append(match, bson.E{Key: "_id", Value: ids})
+	}
+	if s.EmitterChain != nil {
+		match = append(match, bson.E{Key: "emitterChain", Value: *s.EmitterChain})
+	}
+	if s.EmitterAddress != "" {
+		match = append(match, bson.E{Key: "emitterAddr", Value: s.EmitterAddress})
+	}
+	timestamp := bson.D{}
+	if s.From != nil {
+		timestamp = append(timestamp, bson.E{Key: "$gte", Value: *s.From})
+	}
+	if s.To != nil {
+		timestamp = append(timestamp, bson.E{Key: "$lt", Value: *s.To})
+	}
+	if len(timestamp) > 0 {
+		match = append(match, bson.E{Key: "timestamp", Value: timestamp})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	// the lookups run after the sort, so the ids are read in order from the _id index.
+	if s.AppID != "" {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.D{
+				{Key: "from", Value: ParsedVaaCollection},
+				{Key: "localField", Value: "_id"},
+				{Key: "foreignField", Value: "_id"},
+				{Key: "as", Value: "parsedVaa"},
+			}}},
+			bson.D{{Key: "$match", Value: bson.D{{Key: "parsedVaa.appIds", Value: s.AppID}}}},
+		)
+	}
+	if output, ok := stageOutputs[s.MissingStage]; ok {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.D{
+				{Key: "from", Value: output.collection},
+				{Key: "localField", Value: "_id"},
+				{Key: "foreignField", Value: "_id"},
+				{Key: "as", Value: "stageOutput"},
+			}}},
+			bson.D{{Key: "$match", Value: bson.D{{Key: "stageOutput." + output.field, Value: bson.D{{Key: "$exists", Value: false}}}}}},
+		)
+	}
+
+	pipeline = append(pipeline,
+		bson.D{{Key: "$limit", Value: limit}},
+		bson.D{{Key: "$project", Value: bson.D{{Key: "_id", Value: 1}}}},
+	)
+	return pipeline
+}
@@ -0,0 +1,56 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildPipeline(t *testing.T) {
+	chainID := sdk.ChainIDEthereum
+	from := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	selection := Selection{
+		VaaIDs:       []string{"2/a/1", "2/a/2"},
+		EmitterChain: &chainID,
+		From:         &from,
+	}
+
+	pipeline := buildPipeline(&selection, "2/a/1", 50)
+
+	assert.Len(t, pipeline, 4)
+	assert.Equal(t, bson.D{{Key: "$match", Value: bson.D{
+		{Key: "_id", Value: bson.D{{Key: "$in", Value: selection.VaaIDs}, {Key: "$gt", Value: "2/a/1"}}},
+		{Key: "emitterChain", Value: chainID},
+		{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: from}}},
+	}}}, pipeline[0])
+	assert.Equal(t, bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}}, pipeline[1])
+	assert.Equal(t, bson.D{{Key: "$limit", Value: int64(50)}}, pipeline[2])
+}
+
+func TestBuildPipeline_MissingStage(t *testing.T) {
+	tests := []struct {
+		stage      Stage
+		collection string
+		field      string
+	}{
+		{stage: StageParser, collection: ParsedVaaCollection, field: "stageOutput._id"},
+		{stage: StageTxTracker, collection: GlobalTransactionsCollection, field: "stageOutput.originTx"},
+		{stage: StageAnalytics, collection: TransferPricesCollection, field: "stageOutput._id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.stage), func(t *testing.T) {
+			pipeline := buildPipeline(&Selection{MissingStage: tt.stage, AppID: "PORTAL_TOKEN_BRIDGE"}, "", 10)
+
+			assert.Len(t, pipeline, 8)
+			assert.Equal(t, bson.D{{Key: "$match", Value: bson.D{}}}, pipeline[0])
+			assert.Equal(t, bson.D{{Key: "$match", Value: bson.D{{Key: "parsedVaa.appIds", Value: "PORTAL_TOKEN_BRIDGE"}}}}, pipeline[3])
+			lookup := pipeline[4][0].Value.(bson.D)
+			assert.Equal(t, bson.E{Key: "from", Value: tt.collection}, lookup[0])
+			assert.Equal(t, bson.D{{Key: "$match", Value: bson.D{{Key: tt.field, Value: bson.D{{Key: "$exists", Value: false}}}}}}, pipeline[5])
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StageProcessor processes a VAA in a stage.
+type StageProcessor interface {
+	Process(ctx context.Context, vaaID string) error
+}
+
+// stagePaths are the paths of the single-VAA endpoint of each stage service.
+var stagePaths = map[Stage]string{
+	StageParser:    "/api/vaa/parse",
+	StageTxTracker: "/api/vaa/process",
+	StageAnalytics: "/api/vaa/metrics",
+}
+
+// HttpStageProcessor processes the VAAs calling the single-VAA endpoint of a stage service.
+type HttpStageProcessor struct {
+	url    string
+	client *http.Client
+}
+
+// NewHttpStageProcessor creates a processor for the stage service running at the given base URL.
+func NewHttpStageProcessor(stage Stage, baseURL string, timeout time.Duration) *HttpStageProcessor {
+	return &HttpStageProcessor{
+		url:    strings.TrimSuffix(baseURL, "/") + stagePaths[stage],
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Process posts the VAA ID to the stage endpoint.
+func (p *HttpStageProcessor) Process(ctx context.Context, vaaID string) error {
+	body, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: vaaID})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("status code %d: %s", res.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
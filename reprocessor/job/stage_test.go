@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpStageProcessor_Process(t *testing.T) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		if body == `{"id":"1/a/2"}` {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("vaa not found"))
+		}
+	}))
+	defer server.Close()
+
+	processor := NewHttpStageProcessor(StageTxTracker, server.URL+"/", time.Second)
+
+	err := processor.Process(context.Background(), "1/a/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/vaa/process", path)
+	assert.Equal(t, `{"id":"1/a/1"}`, body)
+
+	err = processor.Process(context.Background(), "1/a/2")
+	assert.EqualError(t, err, "status code 500: vaa not found")
+}
@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wormhole-foundation/wormhole-explorer/reprocessor/job"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TODO: move this to migration tool that support mongodb.
+func Run(db *mongo.Database) error {
+	// Created reprocessJobs collection.
+	err := db.CreateCollection(context.TODO(), job.JobsCollection)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// Created reprocessJobErrors collection.
+	err = db.CreateCollection(context.TODO(), job.JobErrorsCollection)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in reprocessJobs to claim the oldest pending job and list the jobs by status.
+	indexJobsByStatus := mongo.IndexModel{Keys: bson.D{{Key: "status", Value: 1}, {Key: "createdAt", Value: 1}}}
+	_, err = db.Collection(job.JobsCollection).Indexes().CreateOne(context.TODO(), indexJobsByStatus)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in reprocessJobErrors to list the errors of a job.
+	indexErrorsByJob := mongo.IndexModel{Keys: bson.D{{Key: "jobId", Value: 1}, {Key: "vaaId", Value: 1}}}
+	_, err = db.Collection(job.JobErrorsCollection).Indexes().CreateOne(context.TODO(), indexErrorsByJob)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func isNotAlreadyExistsError(err error) bool {
+	target := &mongo.CommandError{}
+	isCommandError := errors.As(err, target)
+	if !isCommandError || err.(mongo.CommandError).Code != 48 {
+		return true
+	}
+	return false
+}
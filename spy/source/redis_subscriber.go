@@ -58,7 +58,7 @@ func (r *RedisSubscriber) subscribe(ctx context.Context) {
 
 			switch notification.Event {
 			case events.SignedVaaType:
-				signedVaa, err := events.GetEventData[events.SignedVaa](&notification)
+				signedVaa, err := events.Decode[events.SignedVaa](&notification)
 				if err != nil {
 					r.logger.Error("Error decoding signedVAA from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
 					continue
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 // timestampFromHex converts a hex timestamp into a `time.Time` value.
@@ -47,7 +48,7 @@ func httpGet(ctx context.Context, rateLimiter *time.Ticker, url string) ([]byte,
 	}
 
 	// Send it
-	var client http.Client
+	client := http.Client{Transport: tracing.NewTransport(nil)}
 	response, err := client.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query url: %w", err)
@@ -86,7 +87,7 @@ func httpPost(ctx context.Context, rateLimiter *time.Ticker, url string, body an
 	request.Header.Set("Content-Type", "application/json")
 
 	// Send it
-	var client http.Client
+	client := http.Client{Transport: tracing.NewTransport(nil)}
 	response, err := client.Do(request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query url: %w", err)
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/dlq"
+)
+
+const usage = `Usage: ./%s <list|inspect|redrive|purge> [flags]
+
+List, inspect, re-drive or purge the messages of the tx-tracker dead-letter queue.
+
+`
+
+func main() {
+
+	// validate commandline arguments
+	if len(os.Args) < 2 {
+		log.Fatalf(usage, os.Args[0])
+	}
+	action := os.Args[1]
+
+	var cfg dlq.Config
+	var chainID int
+
+	flags := flag.NewFlagSet(action, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), usage, os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&cfg.AwsRegion, "aws-region", "", "AWS region")
+	flags.StringVar(&cfg.AwsEndpoint, "aws-endpoint", "", "AWS endpoint, e.g. a local SQS compatible service")
+	flags.StringVar(&cfg.AwsAccessKeyID, "aws-access-key-id", "", "AWS access key id")
+	flags.StringVar(&cfg.AwsSecretAccessKey, "aws-secret-access-key", "", "AWS secret access key")
+	flags.StringVar(&cfg.DLQSQSUrl, "dlq-sqs-url", "", "dead-letter queue url")
+	flags.StringVar(&cfg.TargetSQSUrl, "target-sqs-url", "", "queue url the messages are re-driven to (default the queue they failed in)")
+	flags.StringVar(&cfg.Filter.MessageID, "message-id", "", "filter messages by SQS message ID")
+	flags.StringVar(&cfg.Filter.VaaID, "vaa-id", "", "filter messages by VAA ID")
+	flags.IntVar(&chainID, "chain", -1, "filter messages by chain ID")
+	flags.StringVar(&cfg.Filter.ErrorClass, "error-class", "", "filter messages by error class")
+	flags.IntVar(&cfg.Limit, "limit", 0, "maximum number of messages (default all)")
+	_ = flags.Parse(os.Args[2:])
+
+	if cfg.DLQSQSUrl == "" {
+		log.Fatal("the -dlq-sqs-url flag is required")
+	}
+	if chainID >= 0 {
+		c := uint16(chainID)
+		cfg.Filter.ChainID = &c
+	}
+
+	switch action {
+	case dlq.ActionList, dlq.ActionInspect, dlq.ActionRedrive, dlq.ActionPurge:
+	default:
+		log.Fatalf(usage, os.Args[0])
+	}
+	if err := dlq.Run(context.Background(), action, &cfg, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,7 @@
+package main
+
+import "github.com/wormhole-foundation/wormhole-explorer/txtracker/cmd/service"
+
+func main() {
+	service.Run()
+}
@@ -1,4 +1,4 @@
-package main
+package service
 
 import (
 	"context"
@@ -13,10 +13,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"go.mongodb.org/mongo-driver/mongo"
 
-	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/broker"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/chains"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/config"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/consumer"
@@ -27,7 +29,9 @@ import (
 	"go.uber.org/zap"
 )
 
-func main() {
+// Run starts the tx-tracker with the configuration from the environment and blocks until a
+// termination signal is received.
+func Run() {
 	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
 
 	// load config
@@ -36,29 +40,69 @@ func main() {
 		log.Fatal("Error loading config: ", err)
 	}
 
-	// initialize metrics
-	metrics := newMetrics(cfg)
-
 	// build logger
 	logger := logger.New("wormhole-explorer-tx-tracker", logger.WithLevel(cfg.LogLevel))
 
+	// initialize tracing
+	shutdownTracing, err := tracing.Init(rootCtx, "wormscan-tx-tracker", cfg.Tracing(), logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+
+	stop := Start(rootCtx, cfg, logger)
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	// graceful shutdown
+	logger.Info("Cancelling root context...")
+	rootCtxCancel()
+
+	stop()
+
+	logger.Info("Flushing traces...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Warn("Failed to flush traces", zap.Error(err))
+	}
+
+	logger.Info("Terminated wormhole-explorer-tx-tracker")
+}
+
+// Start starts the consumers and the http server of the tx-tracker with the given configuration.
+// The consumers run until rootCtx is cancelled and the returned function releases the remaining
+// resources.
+func Start(rootCtx context.Context, cfg *config.ServiceSettings, logger *zap.Logger) (stop func()) {
+
 	logger.Info("Starting wormhole-explorer-tx-tracker ...")
 
+	// initialize metrics
+	metrics := newMetrics(cfg)
+
 	// initialize rate limiters
 	chains.Initialize(&cfg.RpcProviderSettings)
 
 	// initialize the database client
 	db, err := dbutil.Connect(rootCtx, logger, cfg.MongodbUri, cfg.MongodbDatabase, false)
 	if err != nil {
-		log.Fatal("Failed to initialize MongoDB client: ", err)
+		logger.Fatal("Failed to initialize MongoDB client", zap.Error(err))
 	}
 
 	// create repositories
 	repository := consumer.NewRepository(logger, db.Database)
 	vaaRepository := vaa.NewRepository(db.Database, logger)
+	lineageRecorder := lineage.NewRecorder(db.Database, lineage.StageTxTracker, logger)
 
 	// create controller
-	vaaController := vaa.NewController(vaaRepository, repository, &cfg.RpcProviderSettings, cfg.P2pNetwork, logger)
+	vaaController := vaa.NewController(vaaRepository, repository, lineageRecorder, &cfg.RpcProviderSettings, cfg.P2pNetwork, logger)
 
 	// start serving /health and /ready endpoints
 	healthChecks, err := makeHealthChecks(rootCtx, cfg, db.Database)
@@ -70,37 +114,23 @@ func main() {
 
 	// create and start a pipeline consumer.
 	vaaConsumeFunc := newVAAConsumeFunc(rootCtx, cfg, metrics, logger)
-	vaaConsumer := consumer.New(vaaConsumeFunc, &cfg.RpcProviderSettings, rootCtx, logger, repository, metrics, cfg.P2pNetwork)
+	vaaConsumer := consumer.New(vaaConsumeFunc, &cfg.RpcProviderSettings, rootCtx, logger, repository, lineageRecorder, metrics, cfg.P2pNetwork)
 	vaaConsumer.Start(rootCtx)
 
 	// create and start a notification consumer.
 	notificationConsumeFunc := newNotificationConsumeFunc(rootCtx, cfg, metrics, logger)
-	notificationConsumer := consumer.New(notificationConsumeFunc, &cfg.RpcProviderSettings, rootCtx, logger, repository, metrics, cfg.P2pNetwork)
+	notificationConsumer := consumer.New(notificationConsumeFunc, &cfg.RpcProviderSettings, rootCtx, logger, repository, lineageRecorder, metrics, cfg.P2pNetwork)
 	notificationConsumer.Start(rootCtx)
 
 	logger.Info("Started wormhole-explorer-tx-tracker")
 
-	// Waiting for signal
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("Terminating with root context cancelled.")
-	case signal := <-sigterm:
-		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
-	}
-
-	// graceful shutdown
-	logger.Info("Cancelling root context...")
-	rootCtxCancel()
-
-	logger.Info("Closing Http server...")
-	server.Stop()
+	return func() {
+		logger.Info("Closing Http server...")
+		server.Stop()
 
-	logger.Info("Closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
-
-	logger.Info("Terminated wormhole-explorer-tx-tracker")
+		logger.Info("Closing MongoDB connection...")
+		db.DisconnectWithTimeout(10 * time.Second)
+	}
 }
 
 func newVAAConsumeFunc(
@@ -110,12 +140,12 @@ func newVAAConsumeFunc(
 	logger *zap.Logger,
 ) queue.ConsumeFunc {
 
-	sqsConsumer, err := newSqsConsumer(ctx, cfg, cfg.PipelineSqsUrl)
+	consumer, err := newQueueConsumer(ctx, cfg, cfg.PipelineSqsUrl, cfg.PipelineTopic)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue consumer", zap.Error(err))
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewVaaConverter(logger), metrics, logger)
+	vaaQueue := queue.NewEventQueue(consumer, queue.NewVaaConverter(logger), metrics, logger)
 	return vaaQueue.Consume
 }
 
@@ -126,29 +156,49 @@ func newNotificationConsumeFunc(
 	logger *zap.Logger,
 ) queue.ConsumeFunc {
 
-	sqsConsumer, err := newSqsConsumer(ctx, cfg, cfg.NotificationsSqsUrl)
+	consumer, err := newQueueConsumer(ctx, cfg, cfg.NotificationsSqsUrl, cfg.NotificationsTopic)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue consumer", zap.Error(err))
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewNotificationEvent(logger), metrics, logger)
+	vaaQueue := queue.NewEventQueue(consumer, queue.NewNotificationEvent(logger), metrics, logger)
 	return vaaQueue.Consume
 }
 
-func newSqsConsumer(ctx context.Context, cfg *config.ServiceSettings, sqsUrl string) (*sqs.Consumer, error) {
+// newQueueConsumer creates a consumer of the configured message broker. The SQS broker reads
+// the queue sqsUrl and the others the consumer group of the topic.
+func newQueueConsumer(ctx context.Context, cfg *config.ServiceSettings, sqsUrl, topic string) (broker.Consumer, error) {
 
-	awsconfig, err := newAwsConfig(ctx, cfg)
-	if err != nil {
-		return nil, err
+	settings := broker.Settings{
+		Type:               broker.Type(cfg.QueueBroker),
+		RedisURI:           cfg.QueueRedisUri,
+		NatsURL:            cfg.QueueNatsUrl,
+		NatsStreamMaxAge:   time.Duration(cfg.QueueNatsStreamMaxAgeHours) * time.Hour,
+		NatsStreamMaxBytes: cfg.QueueNatsStreamMaxBytes,
+	}
+	if settings.Type == broker.TypeSQS {
+		awsconfig, err := newAwsConfig(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		settings.AwsConfig = awsconfig
 	}
 
-	consumer, err := sqs.NewConsumer(
-		awsconfig,
-		sqsUrl,
-		sqs.WithMaxMessages(10),
-		sqs.WithVisibilityTimeout(4*60),
+	subscription := broker.Subscription{
+		QueueURL:           sqsUrl,
+		Topic:              topic,
+		Group:              cfg.ConsumerGroup,
+		DeadLetterQueueURL: cfg.DlqSqsUrl,
+		DeadLetterTopic:    cfg.DlqTopic,
+		MaxAttempts:        cfg.DlqMaxAttempts,
+	}
+	return broker.NewConsumer(
+		ctx,
+		settings,
+		subscription,
+		broker.WithMaxMessages(10),
+		broker.WithVisibilityTimeout(4*time.Minute),
 	)
-	return consumer, err
 }
 
 func newAwsConfig(ctx context.Context, cfg *config.ServiceSettings) (aws.Config, error) {
@@ -188,16 +238,21 @@ func makeHealthChecks(
 	db *mongo.Database,
 ) ([]health.Check, error) {
 
+	plugins := []health.Check{
+		health.Mongo(db),
+	}
+	if config.QueueBroker != string(broker.TypeSQS) {
+		return plugins, nil
+	}
+
 	awsConfig, err := newAwsConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
-
-	plugins := []health.Check{
+	plugins = append(plugins,
 		health.SQS(awsConfig, config.PipelineSqsUrl),
 		health.SQS(awsConfig, config.NotificationsSqsUrl),
-		health.Mongo(db),
-	}
+	)
 
 	return plugins, nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/tracing"
 )
 
 type BackfillingStrategy string
@@ -44,17 +45,54 @@ type ServiceSettings struct {
 	MetricsEnabled bool   `split_words:"true" default:"false"`
 	P2pNetwork     string `split_words:"true" required:"true"`
 	AwsSettings
+	QueueSettings
 	MongodbSettings
 	RpcProviderSettings
+	TracingSettings
 }
 
+// TracingSettings configures the exporter of the traces, see the tracing package of the common module.
+type TracingSettings struct {
+	TracingExporter     string  `split_words:"true" default:"none"`
+	TracingOtlpEndpoint string  `split_words:"true" default:"localhost:4318"`
+	TracingOtlpInsecure bool    `split_words:"true" default:"true"`
+	TracingSampleRatio  float64 `split_words:"true" default:"1"`
+}
+
+// Tracing returns the tracing configuration of the service.
+func (s *TracingSettings) Tracing() tracing.Config {
+	return tracing.Config{
+		Exporter:     s.TracingExporter,
+		OTLPEndpoint: s.TracingOtlpEndpoint,
+		OTLPInsecure: s.TracingOtlpInsecure,
+		SampleRatio:  s.TracingSampleRatio,
+	}
+}
+
+// AwsSettings are required when the queue broker is SQS.
 type AwsSettings struct {
 	AwsEndpoint         string `split_words:"true" required:"false"`
 	AwsAccessKeyID      string `split_words:"true" required:"false"`
 	AwsSecretAccessKey  string `split_words:"true" required:"false"`
-	AwsRegion           string `split_words:"true" required:"true"`
-	PipelineSqsUrl      string `split_words:"true" required:"true"`
-	NotificationsSqsUrl string `split_words:"true" required:"true"`
+	AwsRegion           string `split_words:"true" required:"false"`
+	PipelineSqsUrl      string `split_words:"true" required:"false"`
+	NotificationsSqsUrl string `split_words:"true" required:"false"`
+	DlqSqsUrl           string `split_words:"true" required:"false"`
+	DlqMaxAttempts      int    `split_words:"true" default:"5"`
+}
+
+// QueueSettings represents the message broker settings. The topics are used by all the
+// brokers but SQS, which uses the queue URLs.
+type QueueSettings struct {
+	QueueBroker                string `split_words:"true" default:"sqs"`
+	QueueRedisUri              string `split_words:"true" required:"false"`
+	QueueNatsUrl               string `split_words:"true" required:"false"`
+	QueueNatsStreamMaxAgeHours int    `split_words:"true" default:"168"`
+	QueueNatsStreamMaxBytes    int64  `split_words:"true" default:"1073741824"`
+	PipelineTopic              string `split_words:"true" default:"wormscan.vaas"`
+	NotificationsTopic         string `split_words:"true" default:"wormscan.notifications"`
+	DlqTopic                   string `split_words:"true" default:"wormscan.tx-tracker.dlq"`
+	ConsumerGroup              string `split_words:"true" default:"tx-tracker"`
 }
 
 type MongodbSettings struct {
@@ -3,7 +3,9 @@ package consumer
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/chains"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/config"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/internal/metrics"
@@ -18,6 +20,7 @@ type Consumer struct {
 	rpcProviderSettings *config.RpcProviderSettings
 	logger              *zap.Logger
 	repository          *Repository
+	lineage             *lineage.Recorder
 	metrics             metrics.Metrics
 	p2pNetwork          string
 }
@@ -29,6 +32,7 @@ func New(
 	ctx context.Context,
 	logger *zap.Logger,
 	repository *Repository,
+	lineage *lineage.Recorder,
 	metrics metrics.Metrics,
 	p2pNetwork string,
 ) *Consumer {
@@ -38,6 +42,7 @@ func New(
 		rpcProviderSettings: rpcProviderSettings,
 		logger:              logger,
 		repository:          repository,
+		lineage:             lineage,
 		metrics:             metrics,
 		p2pNetwork:          p2pNetwork,
 	}
@@ -56,24 +61,31 @@ func (c *Consumer) producerLoop(ctx context.Context) {
 
 	for msg := range ch {
 		c.logger.Debug("Received message", zap.String("vaaId", msg.Data().ID))
-		c.process(ctx, msg)
+		c.process(msg.Context(), msg)
 	}
 }
 
 func (c *Consumer) process(ctx context.Context, msg queue.ConsumerMessage) {
 
-	defer msg.Done()
-
 	event := msg.Data()
+	startedAt := time.Now()
 
 	// Do not process messages from PythNet
 	if event.ChainID == sdk.ChainIDPythNet {
 		c.logger.Debug("Skipping expired PythNet message", zap.String("trackId", event.TrackID), zap.String("vaaId", event.ID))
+		msg.Done()
 		return
 	}
 
 	c.metrics.IncVaaUnfiltered(uint16(event.ChainID))
 
+	// Store the origin transaction of a message published before its VAA, it is processed
+	// from the RPC nodes when the VAA arrives.
+	if event.PublishedMessage != nil {
+		c.processPublishedMessage(ctx, msg)
+		return
+	}
+
 	// Process the VAA
 	p := ProcessSourceTxParams{
 		TrackID:   event.TrackID,
@@ -93,6 +105,7 @@ func (c *Consumer) process(ctx context.Context, msg queue.ConsumerMessage) {
 			zap.String("trackId", event.TrackID),
 			zap.String("vaaId", event.ID),
 		)
+		c.lineage.Skip(ctx, event.ID, event.TrackID, startedAt, err.Error())
 	} else if errors.Is(err, ErrAlreadyProcessed) {
 		c.logger.Warn("Message already processed - skipping",
 			zap.String("trackId", event.TrackID),
@@ -104,11 +117,56 @@ func (c *Consumer) process(ctx context.Context, msg queue.ConsumerMessage) {
 			zap.String("vaaId", event.ID),
 			zap.Error(err),
 		)
+		c.lineage.Record(ctx, event.ID, event.TrackID, startedAt, err)
+		// the message is retried and moved to the dead-letter queue after the maximum number of attempts.
+		msg.Failed(err)
+		return
 	} else {
 		c.logger.Info("Transaction processed successfully",
 			zap.String("trackId", event.TrackID),
 			zap.String("id", event.ID),
 		)
 		c.metrics.IncOriginTxInserted(uint16(event.ChainID))
+		c.lineage.Record(ctx, event.ID, event.TrackID, startedAt, nil)
+	}
+	msg.Done()
+}
+
+func (c *Consumer) processPublishedMessage(ctx context.Context, msg queue.ConsumerMessage) {
+
+	event := msg.Data()
+	startedAt := time.Now()
+	p := UpsertPublishedMessageParams{
+		VaaId:       event.ID,
+		TxHash:      event.TxHash,
+		BlockHeight: event.PublishedMessage.BlockHeight,
+		BlockTime:   event.PublishedMessage.BlockTime,
+		Sender:      event.PublishedMessage.Sender,
+	}
+	err := c.repository.UpsertPublishedMessage(ctx, &p)
+
+	if errors.Is(err, ErrAlreadyProcessed) {
+		c.logger.Debug("Published message already processed from its VAA - skipping",
+			zap.String("trackId", event.TrackID),
+			zap.String("vaaId", event.ID),
+		)
+		c.lineage.Skip(ctx, event.ID, event.TrackID, startedAt, "published message already processed from its VAA")
+	} else if err != nil {
+		c.logger.Error("Failed to store published message",
+			zap.String("trackId", event.TrackID),
+			zap.String("vaaId", event.ID),
+			zap.Error(err),
+		)
+		c.lineage.Record(ctx, event.ID, event.TrackID, startedAt, err)
+		msg.Failed(err)
+		return
+	} else {
+		c.logger.Info("Published message stored successfully",
+			zap.String("trackId", event.TrackID),
+			zap.String("vaaId", event.ID),
+		)
+		c.metrics.IncOriginTxInProgress(uint16(event.ChainID))
+		c.lineage.RecordWithReason(ctx, event.ID, event.TrackID, startedAt, "origin transaction stored from the published message, waiting for the VAA", nil)
 	}
+	msg.Done()
 }
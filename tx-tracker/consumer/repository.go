@@ -47,6 +47,7 @@ type UpsertDocumentParams struct {
 
 func (r *Repository) UpsertDocument(ctx context.Context, params *UpsertDocumentParams) error {
 
+	// the origin transaction replaces the one stored from the published message, if any.
 	fields := bson.D{
 		{Key: "status", Value: params.TxStatus},
 	}
@@ -84,12 +85,66 @@ func (r *Repository) UpsertDocument(ctx context.Context, params *UpsertDocumentP
 	return nil
 }
 
+// UpsertPublishedMessageParams is a struct that contains the parameters for the UpsertPublishedMessage method.
+type UpsertPublishedMessageParams struct {
+	VaaId       string
+	TxHash      string
+	BlockHeight string
+	BlockTime   time.Time
+	Sender      string
+}
+
+// UpsertPublishedMessage stores the origin transaction of a message published before its VAA is
+// signed, with the status in progress. The origin transaction already processed from the VAA is
+// not modified.
+func (r *Repository) UpsertPublishedMessage(ctx context.Context, params *UpsertPublishedMessageParams) error {
+
+	filter := bson.D{
+		{Key: "_id", Value: params.VaaId},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "originTx", Value: bson.D{{Key: "$exists", Value: false}}}},
+			bson.D{{Key: "originTx.status", Value: domain.SourceTxStatusInProgress}},
+		}},
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "originTx", Value: bson.D{
+				{Key: "status", Value: domain.SourceTxStatusInProgress},
+				{Key: "nativeTxHash", Value: params.TxHash},
+				{Key: "timestamp", Value: params.BlockTime},
+				{Key: "blockNumber", Value: params.BlockHeight},
+				{Key: "sender", Value: params.Sender},
+			}},
+		}},
+	}
+
+	opts := options.Update().SetUpsert(true)
+
+	_, err := r.globalTransactions.UpdateOne(ctx, filter, update, opts)
+	// the filter does not match the origin transaction processed from the VAA, so the upsert
+	// tries to insert a document with the same id.
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyProcessed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upsert published message: %w", err)
+	}
+
+	return nil
+}
+
 // AlreadyProcessed returns true if the given VAA ID has already been processed.
+//
+// The origin transactions stored from a published message are not processed until the VAA arrives.
 func (r *Repository) AlreadyProcessed(ctx context.Context, vaaId string) (bool, error) {
 
 	result := r.
 		globalTransactions.
-		FindOne(ctx, bson.D{{"_id", vaaId}})
+		FindOne(ctx, bson.D{
+			{"_id", vaaId},
+			{"originTx.status", bson.M{"$ne": domain.SourceTxStatusInProgress}},
+		})
 
 	var tx GlobalTransaction
 	err := result.Decode(&tx)
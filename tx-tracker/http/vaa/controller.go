@@ -1,9 +1,14 @@
 package vaa
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/common/lineage"
+	"github.com/wormhole-foundation/wormhole-explorer/txtracker/chains"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/config"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/consumer"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
@@ -15,13 +20,14 @@ type Controller struct {
 	logger              *zap.Logger
 	vaaRepository       *Repository
 	repository          *consumer.Repository
+	lineage             *lineage.Recorder
 	rpcProviderSettings *config.RpcProviderSettings
 	p2pNetwork          string
 }
 
 // NewController creates a Controller instance.
-func NewController(vaaRepository *Repository, repository *consumer.Repository, rpcProviderSettings *config.RpcProviderSettings, p2pNetwork string, logger *zap.Logger) *Controller {
-	return &Controller{vaaRepository: vaaRepository, repository: repository, rpcProviderSettings: rpcProviderSettings, p2pNetwork: p2pNetwork, logger: logger}
+func NewController(vaaRepository *Repository, repository *consumer.Repository, lineage *lineage.Recorder, rpcProviderSettings *config.RpcProviderSettings, p2pNetwork string, logger *zap.Logger) *Controller {
+	return &Controller{vaaRepository: vaaRepository, repository: repository, lineage: lineage, rpcProviderSettings: rpcProviderSettings, p2pNetwork: p2pNetwork, logger: logger}
 }
 
 func (c *Controller) Process(ctx *fiber.Ctx) error {
@@ -34,6 +40,7 @@ func (c *Controller) Process(ctx *fiber.Ctx) error {
 	}
 
 	c.logger.Info("Processing VAA from endpoint", zap.String("id", payload.ID))
+	startedAt := time.Now()
 
 	v, err := c.vaaRepository.FindById(ctx.Context(), payload.ID)
 	if err != nil {
@@ -46,6 +53,7 @@ func (c *Controller) Process(ctx *fiber.Ctx) error {
 	}
 
 	p := &consumer.ProcessSourceTxParams{
+		TrackID:   fmt.Sprintf("controller-%s", payload.ID),
 		Timestamp: &vaa.Timestamp,
 		VaaId:     vaa.MessageID(),
 		ChainId:   vaa.EmitterChain,
@@ -56,6 +64,11 @@ func (c *Controller) Process(ctx *fiber.Ctx) error {
 	}
 
 	result, err := consumer.ProcessSourceTx(ctx.Context(), c.logger, c.rpcProviderSettings, c.repository, p, c.p2pNetwork)
+	if errors.Is(err, chains.ErrChainNotSupported) {
+		c.lineage.Skip(ctx.Context(), p.VaaId, p.TrackID, startedAt, err.Error())
+	} else {
+		c.lineage.Record(ctx.Context(), p.VaaId, p.TrackID, startedAt, err)
+	}
 	if err != nil {
 		return err
 	}
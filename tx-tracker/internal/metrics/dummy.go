@@ -16,3 +16,6 @@ func (d *DummyMetrics) IncVaaUnfiltered(chainID uint16) {}
 
 // IncOriginTxInserted is a dummy implementation of IncOriginTxInserted.
 func (d *DummyMetrics) IncOriginTxInserted(chainID uint16) {}
+
+// IncOriginTxInProgress is a dummy implementation of IncOriginTxInProgress.
+func (d *DummyMetrics) IncOriginTxInProgress(chainID uint16) {}
@@ -6,4 +6,5 @@ type Metrics interface {
 	IncVaaConsumedQueue(chainID uint16)
 	IncVaaUnfiltered(chainID uint16)
 	IncOriginTxInserted(chainID uint16)
+	IncOriginTxInProgress(chainID uint16)
 }
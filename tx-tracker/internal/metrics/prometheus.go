@@ -44,3 +44,9 @@ func (m *PrometheusMetrics) IncOriginTxInserted(chainID uint16) {
 	chain := vaa.ChainID(chainID).String()
 	m.vaaTxTrackerCount.WithLabelValues(chain, "origin_tx_inserted").Inc()
 }
+
+// IncOriginTxInProgress increments the number of origin tx stored from a published message before its VAA.
+func (m *PrometheusMetrics) IncOriginTxInProgress(chainID uint16) {
+	chain := vaa.ChainID(chainID).String()
+	m.vaaTxTrackerCount.WithLabelValues(chain, "origin_tx_in_progress").Inc()
+}
@@ -66,10 +66,10 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 
 		switch notification.Event {
 		case events.SignedVaaType:
-			signedVaa, err := events.GetEventData[events.SignedVaa](&notification)
+			signedVaa, err := events.Decode[events.SignedVaa](&notification)
 			if err != nil {
 				log.Error("Error decoding signedVAA from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
-				return nil, nil
+				return nil, err
 			}
 
 			return &Event{
@@ -80,12 +80,13 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				Sequence:       strconv.FormatUint(signedVaa.Sequence, 10),
 				Timestamp:      &signedVaa.Timestamp,
 				TxHash:         signedVaa.TxHash,
+				TraceContext:   notification.TraceContext,
 			}, nil
 		case events.LogMessagePublishedMesageType:
-			plm, err := events.GetEventData[events.LogMessagePublished](&notification)
+			plm, err := events.Decode[events.LogMessagePublished](&notification)
 			if err != nil {
 				log.Error("Error decoding publishedLogMessage from notification event", zap.String("trackId", notification.TrackID), zap.Error(err))
-				return nil, nil
+				return nil, err
 			}
 
 			vaa, err := events.CreateUnsignedVAA(&plm)
@@ -102,6 +103,12 @@ func NewNotificationEvent(log *zap.Logger) ConverterFunc {
 				Sequence:       strconv.FormatUint(plm.Attributes.Sequence, 10),
 				Timestamp:      &plm.BlockTime,
 				TxHash:         plm.TxHash,
+				PublishedMessage: &PublishedMessage{
+					BlockHeight: plm.BlockHeight,
+					BlockTime:   plm.BlockTime,
+					Sender:      plm.Attributes.Sender,
+				},
+				TraceContext: notification.TraceContext,
 			}, nil
 		}
 		return nil, nil
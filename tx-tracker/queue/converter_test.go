@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events/eventstest"
+	"go.uber.org/zap"
+)
+
+// TestNotificationEvent_Contract checks the converter against the events shared with the producers.
+func TestNotificationEvent_Contract(t *testing.T) {
+	converter := NewNotificationEvent(zap.NewNop())
+	for _, f := range eventstest.Valid() {
+		event, err := converter(string(f.Body))
+		if assert.NoError(t, err, f.Name) && assert.NotNil(t, event, f.Name) {
+			assert.NotEmpty(t, event.ID, f.Name)
+			assert.NotEmpty(t, event.TrackID, f.Name)
+			assert.NotEmpty(t, event.Sequence, f.Name)
+		}
+	}
+	for _, f := range eventstest.Invalid() {
+		event, err := converter(string(f.Body))
+		assert.Error(t, err, f.Name)
+		assert.Nil(t, event, f.Name)
+	}
+}
+
+func TestNotificationEvent_PublishedMessage(t *testing.T) {
+	converter := NewNotificationEvent(zap.NewNop())
+
+	fixture := eventstest.ByEvent(eventstest.Valid(), events.LogMessagePublishedMesageType)[0]
+	event, err := converter(string(fixture.Body))
+	assert.NoError(t, err)
+	if assert.NotNil(t, event) && assert.NotNil(t, event.PublishedMessage) {
+		assert.Equal(t, "2/00000000000000000000000028d8f1be96f97c1387e94a53e00eccfb4e75175a/3418", event.ID)
+		assert.Equal(t, "0xb6b7af602aa098fbd8c88da2c2e4a316eef22f0ee621c5ca7616992c3fd9d3fe", event.TxHash)
+		assert.Equal(t, "10012893", event.PublishedMessage.BlockHeight)
+		assert.Equal(t, time.Date(2023, 11, 9, 10, 41, 24, 0, time.UTC), event.PublishedMessage.BlockTime.UTC())
+		assert.Equal(t, "0x28D8F1Be96f97C1387e94A53e00eCcFb4E75175a", event.PublishedMessage.Sender)
+	}
+
+	// the VAAs are processed from the RPC nodes.
+	fixture = eventstest.ByEvent(eventstest.Valid(), events.SignedVaaType)[0]
+	event, err = converter(string(fixture.Body))
+	assert.NoError(t, err)
+	if assert.NotNil(t, event) {
+		assert.Nil(t, event.PublishedMessage)
+	}
+}
@@ -2,16 +2,12 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
-type sqsEvent struct {
-	MessageID string `json:"MessageId"`
-	Message   string `json:"Message"`
-}
-
 // Event represents a event data to be handle.
 type Event struct {
 	TrackID        string
@@ -21,14 +17,33 @@ type Event struct {
 	Sequence       string
 	Timestamp      *time.Time
 	TxHash         string
+	// PublishedMessage is the data of a LogMessagePublished event, it is nil for the VAAs.
+	PublishedMessage *PublishedMessage
+	// TraceContext is the trace context sent in the event when the broker has no message attributes.
+	TraceContext map[string]string
 }
 
+// PublishedMessage is a message published on the emitter chain before its VAA is signed.
+type PublishedMessage struct {
+	BlockHeight string
+	BlockTime   time.Time
+	// Sender is the address that called the core contract to publish the message.
+	Sender string
+}
+
+// ErrMessageExpired is the failure of a message received after its visibility timeout expired.
+var ErrMessageExpired = errors.New("message expired")
+
 // ConsumerMessage defition.
 type ConsumerMessage interface {
 	Data() *Event
 	Done()
-	Failed()
+	// Failed releases the message to be retried, or moves it to the dead-letter queue
+	// when it reached the maximum number of attempts.
+	Failed(err error)
 	IsExpired() bool
+	// Context returns the context of the processing of the message, with the span of the message.
+	Context() context.Context
 }
 
 // ConsumeFunc is a function to consume Event.